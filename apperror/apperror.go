@@ -0,0 +1,190 @@
+package apperror
+
+import (
+	"errors"
+	"healthmatefood-api/constants"
+	"net/http"
+	"strings"
+)
+
+/* Kind ประเภทของ error ที่ใช้เลือก status ตอบกลับ handler ไม่ต้องเทียบข้อความเอง */
+type Kind string
+
+const (
+	KindInternal      Kind = "INTERNAL"
+	KindValidation    Kind = "VALIDATION"
+	KindUnauthorized  Kind = "UNAUTHORIZED"
+	KindNotFound      Kind = "NOT_FOUND"
+	KindConflict      Kind = "CONFLICT"
+	KindUnprocessable Kind = "UNPROCESSABLE"
+	KindRateLimited   Kind = "RATE_LIMITED"
+	KindUpstream      Kind = "UPSTREAM"
+)
+
+func (k Kind) HTTPStatus() int {
+	switch k {
+	case KindValidation:
+		return http.StatusBadRequest
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindUnprocessable:
+		return http.StatusUnprocessableEntity
+	case KindRateLimited:
+		return http.StatusTooManyRequests
+	case KindUpstream:
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+/* ProblemType type ของ problem+json ตาม kind เช่น KindNotFound เป็น /problems/not-found */
+func (k Kind) ProblemType() string {
+	return "/problems/" + strings.ToLower(strings.ReplaceAll(string(k), "_", "-"))
+}
+
+/* Typed error ที่บอก kind และ status ของตัวเองได้ นอกจาก *Error แล้ว UpstreamError ของ agent ก็ใช้ interface นี้เพื่อตอบ status ตาม provider */
+type Typed interface {
+	error
+	ErrorKind() Kind
+	HTTPStatus() int
+}
+
+/* Error error ของ domain Message คือข้อความใน constants ที่ใช้แปลภาษา Detail คือส่วนที่ต่อท้าย เช่นชื่อ field หรือค่าที่ผิด Fields คือ error ราย field ของ request */
+type Error struct {
+	Kind    Kind
+	Message string
+	Detail  string
+	Fields  []constants.FieldError
+	/* Extensions member เพิ่มเติมของ problem+json เช่น reset_at ของ RateLimited */
+	Extensions map[string]interface{}
+	Err        error
+}
+
+func New(kind Kind, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+func Validation(message string) *Error {
+	return New(KindValidation, message)
+}
+
+func Unauthorized(message string) *Error {
+	return New(KindUnauthorized, message)
+}
+
+func NotFound(message string) *Error {
+	return New(KindNotFound, message)
+}
+
+func Conflict(message string) *Error {
+	return New(KindConflict, message)
+}
+
+func Unprocessable(message string) *Error {
+	return New(KindUnprocessable, message)
+}
+
+func RateLimited(message string) *Error {
+	return New(KindRateLimited, message)
+}
+
+func Upstream(message string) *Error {
+	return New(KindUpstream, message)
+}
+
+func (e *Error) Error() string {
+	message := e.Message
+	if e.Detail != "" {
+		message += ": " + e.Detail
+	}
+	if e.Err != nil {
+		message += ": " + e.Err.Error()
+	}
+	return message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) ErrorKind() Kind {
+	return e.Kind
+}
+
+func (e *Error) HTTPStatus() int {
+	return e.Kind.HTTPStatus()
+}
+
+/* Is ใช้กับ errors.Is เทียบ kind และข้อความ target ที่ไม่มีข้อความ (เช่น ErrNotFound) เทียบเฉพาะ kind */
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return t.Kind == e.Kind && (t.Message == "" || t.Message == e.Message)
+}
+
+/* WithDetail คืน error ใหม่ที่มีรายละเอียดต่อท้าย ไม่แก้ error เดิมที่อาจเป็น sentinel */
+func (e *Error) WithDetail(detail string) *Error {
+	copied := *e
+	copied.Detail = detail
+	return &copied
+}
+
+/* WithFields คืน error ใหม่ที่มี error ราย field */
+func (e *Error) WithFields(fields []constants.FieldError) *Error {
+	copied := *e
+	copied.Fields = fields
+	return &copied
+}
+
+/* WithExtension คืน error ใหม่ที่มี member เพิ่มเติมใน problem+json */
+func (e *Error) WithExtension(key string, val interface{}) *Error {
+	copied := *e
+	copied.Extensions = make(map[string]interface{}, len(e.Extensions)+1)
+	for k, v := range e.Extensions {
+		copied.Extensions[k] = v
+	}
+	copied.Extensions[key] = val
+	return &copied
+}
+
+/* Wrap คืน error ใหม่ที่เก็บสาเหตุไว้ ยังใช้ errors.Is และ errors.As กับสาเหตุได้ */
+func (e *Error) Wrap(err error) *Error {
+	copied := *e
+	copied.Err = err
+	return &copied
+}
+
+/* sentinel ตาม kind ใช้กับ errors.Is เช่น errors.Is(err, apperror.ErrNotFound) */
+var (
+	ErrValidation    = &Error{Kind: KindValidation}
+	ErrUnauthorized  = &Error{Kind: KindUnauthorized}
+	ErrNotFound      = &Error{Kind: KindNotFound}
+	ErrConflict      = &Error{Kind: KindConflict}
+	ErrUnprocessable = &Error{Kind: KindUnprocessable}
+	ErrRateLimited   = &Error{Kind: KindRateLimited}
+	ErrUpstream      = &Error{Kind: KindUpstream}
+)
+
+/* As คืน typed error ตัวแรกใน chain ของ err */
+func As(err error) (Typed, bool) {
+	var typed Typed
+	if errors.As(err, &typed) {
+		return typed, true
+	}
+	return nil, false
+}
+
+/* KindOf kind ของ err error ที่ไม่ได้ระบุ kind ถือเป็น KindInternal */
+func KindOf(err error) Kind {
+	if typed, ok := As(err); ok {
+		return typed.ErrorKind()
+	}
+	return KindInternal
+}
@@ -0,0 +1,39 @@
+package apperror
+
+import "healthmatefood-api/constants"
+
+/* Fields รวบรวม error ของทุก field ใน request แล้วตอบกลับครั้งเดียว แทนการหยุดที่ field แรก */
+type Fields []constants.FieldError
+
+func (f *Fields) Add(field string, message string) {
+	*f = append(*f, constants.FieldError{Field: field, Message: message})
+}
+
+/* Missing field ที่ต้องส่งแต่ไม่มีใน body */
+func (f *Fields) Missing(field string) {
+	f.Add(field, constants.ERROR_FIELD_WAS_MISSING)
+}
+
+/* Check เพิ่ม error ของ field เมื่อ err ไม่เป็น nil คืน true เมื่อ field ผ่าน */
+func (f *Fields) Check(field string, err error) bool {
+	if err == nil {
+		return true
+	}
+	f.Add(field, err.Error())
+	return false
+}
+
+/* Err คืน Validation error ที่มีทุก field หรือ nil เมื่อไม่มี error */
+func (f Fields) Err() error {
+	if len(f) == 0 {
+		return nil
+	}
+	return Validation(constants.ERROR_REQUEST_IS_INVALID).WithFields(f)
+}
+
+/* Field error ของ field เดียว ใช้กับ validator ที่ตรวจค่าเดียว เช่น path params */
+func Field(field string, message string) error {
+	fields := Fields{}
+	fields.Add(field, message)
+	return fields.Err()
+}
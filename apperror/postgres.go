@@ -0,0 +1,76 @@
+package apperror
+
+import (
+	stdsql "database/sql"
+	"errors"
+	"healthmatefood-api/constants"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+)
+
+/* SQLSTATE ที่แปลงเป็น error ของ domain */
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgCheckViolation      = "23514"
+	pgNotNullViolation    = "23502"
+	pgInvalidTextValue    = "22P02"
+)
+
+/* constraints error ของแต่ละ constraint ใน migrations ที่ผู้ใช้ทำให้ละเมิดได้จาก request */
+var constraints = map[string]*Error{
+	"users_username_unique":                   Conflict(constants.ERROR_USERNAME_WAS_DUPLICATED),
+	"users_email_unique":                      Conflict(constants.ERROR_EMAIL_WAS_DUPLICATED),
+	"user_food_preferences_unique":            Conflict(constants.ERROR_FOOD_PREFERENCE_WAS_DUPLICATED),
+	"prompts_name_version_unique":             Conflict(constants.ERROR_PROMPT_VERSION_WAS_DUPLICATED),
+	"user_info_language_check":                Validation(constants.ERROR_LANGUAGE_IS_INVALID),
+	"recipe_ingredients_food_id_fkey":         NotFound(constants.ERROR_FOOD_NOT_FOUND),
+	"activity_logs_activity_id_fkey":          NotFound(constants.ERROR_ACTIVITY_NOT_FOUND),
+	"grocery_lists_meal_plan_id_fkey":         NotFound(constants.ERROR_MEAL_PLAN_NOT_FOUND),
+	"user_food_preferences_user_info_id_fkey": NotFound(constants.ERROR_USER_INFO_NOT_FOUND),
+	"ai_quotas_role_id_fkey":                  NotFound(constants.ERROR_ROLES_NOT_FOUND),
+}
+
+/* FromDB แปลง error จาก database เป็น error ของ domain ไม่พบแถวเป็น NotFound ด้วย notFound (ว่างไว้ถ้าไม่ใช่การค้นหา) constraint ที่รู้จักแปลงตามชื่อ ที่เหลือแปลงตาม SQLSTATE error อื่นคืนตามเดิม */
+func FromDB(err error, notFound string) error {
+	if err == nil {
+		return nil
+	}
+	if notFound != "" && errors.Is(err, stdsql.ErrNoRows) {
+		return NotFound(notFound)
+	}
+	code, constraint, column, ok := pgError(err)
+	if !ok {
+		return err
+	}
+	if constraintErr, ok := constraints[constraint]; ok {
+		return constraintErr
+	}
+	switch code {
+	case pgUniqueViolation:
+		return Conflict(constants.ERROR_RECORD_WAS_DUPLICATED).WithDetail(constraint)
+	case pgForeignKeyViolation:
+		return Conflict(constants.ERROR_RECORD_REFERENCE_IS_INVALID).WithDetail(constraint)
+	case pgCheckViolation, pgNotNullViolation, pgInvalidTextValue:
+		detail := constraint
+		if detail == "" {
+			detail = column
+		}
+		return Validation(constants.ERROR_VALUE_IS_INVALID).WithDetail(detail)
+	}
+	return err
+}
+
+/* pgError อ่าน SQLSTATE ชื่อ constraint และ column จาก error ของ lib/pq ที่ psql.NewPsqlWithTracingConnection ใช้ หรือของ pgx */
+func pgError(err error) (string, string, string, bool) {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return string(pqErr.Code), pqErr.Constraint, pqErr.Column, true
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code, pgErr.ConstraintName, pgErr.ColumnName, true
+	}
+	return "", "", "", false
+}
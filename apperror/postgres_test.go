@@ -0,0 +1,41 @@
+package apperror
+
+import (
+	stdsql "database/sql"
+	"errors"
+	"fmt"
+	"healthmatefood-api/constants"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromDB(t *testing.T) {
+	plain := errors.New("connection refused")
+	for name, tc := range map[string]struct {
+		err      error
+		notFound string
+		expected error
+	}{
+		"nil":                       {err: nil, expected: nil},
+		"no_rows":                   {err: stdsql.ErrNoRows, notFound: constants.ERROR_USER_NOT_FOUND, expected: NotFound(constants.ERROR_USER_NOT_FOUND)},
+		"no_rows_without_not_found": {err: stdsql.ErrNoRows, expected: stdsql.ErrNoRows},
+		"plain":                     {err: plain, expected: plain},
+		"pq_constraint":             {err: &pq.Error{Code: "23505", Constraint: "users_username_unique"}, expected: Conflict(constants.ERROR_USERNAME_WAS_DUPLICATED)},
+		"pq_wrapped":                {err: fmt.Errorf("exec failed: %w", &pq.Error{Code: "23505", Constraint: "users_email_unique"}), expected: Conflict(constants.ERROR_EMAIL_WAS_DUPLICATED)},
+		"pq_unique":                 {err: &pq.Error{Code: "23505", Constraint: "other_unique"}, expected: Conflict(constants.ERROR_RECORD_WAS_DUPLICATED).WithDetail("other_unique")},
+		"pq_foreign_key":            {err: &pq.Error{Code: "23503", Constraint: "other_fkey"}, expected: Conflict(constants.ERROR_RECORD_REFERENCE_IS_INVALID).WithDetail("other_fkey")},
+		"pq_check":                  {err: &pq.Error{Code: "23514", Constraint: "other_check"}, expected: Validation(constants.ERROR_VALUE_IS_INVALID).WithDetail("other_check")},
+		"pq_not_null":               {err: &pq.Error{Code: "23502", Column: "name"}, expected: Validation(constants.ERROR_VALUE_IS_INVALID).WithDetail("name")},
+		"pgx_constraint":            {err: &pgconn.PgError{Code: "23505", ConstraintName: "users_username_unique"}, expected: Conflict(constants.ERROR_USERNAME_WAS_DUPLICATED)},
+		"pgx_foreign_key":           {err: &pgconn.PgError{Code: "23503", ConstraintName: "recipe_ingredients_food_id_fkey"}, expected: NotFound(constants.ERROR_FOOD_NOT_FOUND)},
+		"pgx_check":                 {err: &pgconn.PgError{Code: "23514", ConstraintName: "other_check"}, expected: Validation(constants.ERROR_VALUE_IS_INVALID).WithDetail("other_check")},
+		"pq_other_code":             {err: &pq.Error{Code: "40001"}, expected: &pq.Error{Code: "40001"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, FromDB(tc.err, tc.notFound))
+		})
+	}
+}
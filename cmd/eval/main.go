@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/config"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/database"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/prompt"
+	"log"
+	"os"
+	"sync"
+
+	agent_ai_repository "healthmatefood-api/service/agent-ai/repository"
+	prompt_repository "healthmatefood-api/service/prompt/repository"
+	prompt_usecase "healthmatefood-api/service/prompt/usecase"
+
+	"github.com/gofrs/uuid"
+)
+
+/*
+eval รัน suite ของโปรไฟล์ผู้ใช้ผ่าน agent แล้วเขียนรายงานที่ diff ระหว่างเวอร์ชันของ prompt หรือโมเดลได้
+
+	go run ./cmd/eval                                   ใช้คำตอบที่บันทึกไว้ใน suite กับ prompt ตั้งต้น
+	go run ./cmd/eval -env .env                         เรียก provider ตาม AGENT_PROVIDER
+	go run ./cmd/eval -env .env -db -out report.txt     ใช้ prompt เวอร์ชันที่ active ใน database
+
+exit code เป็น 1 เมื่อมี case ที่ไม่ผ่าน
+*/
+func main() {
+	suitePath := flag.String("suite", "evaluation/meal_plan_suite.json", "path of the evaluation suite")
+	envPath := flag.String("env", "", "env file of the agent provider, empty uses the recorded responses in the suite")
+	useDB := flag.Bool("db", false, "render the active prompt versions from the database, requires -env")
+	format := flag.String("format", "text", "report format: text or json")
+	out := flag.String("out", "", "write the report to this file instead of stdout")
+	flag.Parse()
+
+	ctx := context.Background()
+	content, err := os.ReadFile(*suitePath)
+	if err != nil {
+		log.Fatalf("read suite failed: %v", err)
+	}
+	suite, err := models.DecodeEvalSuite(content)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var llm agent_ai_repository.LLMProvider
+	var promptRepo prompt.IPromptRepository = defaultPromptRepository{}
+	if *envPath != "" {
+		cfg := config.LoadConfig(*envPath)
+		llm = agent_ai_repository.NewLLMProvider(cfg.Agent())
+		if *useDB {
+			psqlDB := database.DBConnect(ctx, cfg.Db(), nil)
+			defer psqlDB.Close()
+			promptRepo = prompt_repository.NewPromptRepository(psqlDB)
+		}
+	} else if *useDB {
+		log.Fatal("-db requires -env")
+	}
+
+	report := agent_ai_repository.EvaluateSuite(ctx, llm, prompt_usecase.NewPromptUsecase(promptRepo), suite)
+	var output []byte
+	switch *format {
+	case "json":
+		output, err = json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			log.Fatalf("encode report failed: %v", err)
+		}
+		output = append(output, '\n')
+	case "text":
+		output = []byte(report.Text())
+	default:
+		log.Fatalf("unknown format %s", *format)
+	}
+
+	if *out != "" {
+		if err := os.WriteFile(*out, output, 0o644); err != nil {
+			log.Fatalf("write report failed: %v", err)
+		}
+	} else {
+		os.Stdout.Write(output)
+	}
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+/* defaultPromptRepository ไม่มี prompt ใน database ทำให้ render ด้วย prompt ตั้งต้นที่ฝังใน binary */
+type defaultPromptRepository struct{}
+
+func (defaultPromptRepository) FetchAllPrompts(ctx context.Context, args *sync.Map) ([]*models.Prompt, error) {
+	return []*models.Prompt{}, nil
+}
+
+func (defaultPromptRepository) FetchOnePromptById(ctx context.Context, id *uuid.UUID) (*models.Prompt, error) {
+	return nil, apperror.NotFound(constants.ERROR_PROMPT_NOT_FOUND)
+}
+
+func (defaultPromptRepository) FetchActivePromptByName(ctx context.Context, name string) (*models.Prompt, error) {
+	return nil, apperror.NotFound(constants.ERROR_PROMPT_NOT_FOUND)
+}
+
+func (defaultPromptRepository) InsertPrompt(ctx context.Context, prompt *models.Prompt) error {
+	return errors.New("prompts are read only during evaluation")
+}
+
+func (defaultPromptRepository) ActivatePrompt(ctx context.Context, prompt *models.Prompt) error {
+	return errors.New("prompts are read only during evaluation")
+}
@@ -120,19 +120,216 @@ func LoadConfig(path string) Iconfig {
 			}(),
 		},
 		agent: &agent{
+			agentProvider: func() string {
+				provider := envMap["AGENT_PROVIDER"]
+				switch provider {
+				case "":
+					return AGENT_PROVIDER_DIGITAL_OCEAN
+				case AGENT_PROVIDER_DIGITAL_OCEAN, AGENT_PROVIDER_OPENAI, AGENT_PROVIDER_OLLAMA, AGENT_PROVIDER_FAKE:
+					return provider
+				}
+				log.Fatalf("Load Agent Provider Failed: unknown provider %q", provider)
+				return ""
+			}(),
 			agentAccessKey: envMap["AGENT_ACCESS_KEY"],
 			agentEndpoint:  envMap["AGENT_ENDPOINT"],
+			agentModel:     envMap["AGENT_MODEL"],
+			agentVisionModel: func() string {
+				if envMap["AGENT_VISION_MODEL"] == "" {
+					return envMap["AGENT_MODEL"]
+				}
+				return envMap["AGENT_VISION_MODEL"]
+			}(),
+			agentTimeout: func() time.Duration {
+				if envMap["AGENT_TIMEOUT"] == "" {
+					return 60 * time.Second
+				}
+				t, err := strconv.Atoi(envMap["AGENT_TIMEOUT"])
+				if err != nil {
+					log.Fatalf("Load Agent Timeout Failed: %v", err)
+				}
+				return time.Duration(t) * time.Second
+			}(),
+			agentMaxRetries: func() int {
+				if envMap["AGENT_MAX_RETRIES"] == "" {
+					return 2
+				}
+				retries, err := strconv.Atoi(envMap["AGENT_MAX_RETRIES"])
+				if err != nil {
+					log.Fatalf("Load Agent Max Retries Failed: %v", err)
+				}
+				return retries
+			}(),
+			agentBreakerThreshold: func() int {
+				if envMap["AGENT_BREAKER_THRESHOLD"] == "" {
+					return 5
+				}
+				threshold, err := strconv.Atoi(envMap["AGENT_BREAKER_THRESHOLD"])
+				if err != nil {
+					log.Fatalf("Load Agent Breaker Threshold Failed: %v", err)
+				}
+				return threshold
+			}(),
+			agentBreakerCooldown: func() time.Duration {
+				if envMap["AGENT_BREAKER_COOLDOWN"] == "" {
+					return 30 * time.Second
+				}
+				t, err := strconv.Atoi(envMap["AGENT_BREAKER_COOLDOWN"])
+				if err != nil {
+					log.Fatalf("Load Agent Breaker Cooldown Failed: %v", err)
+				}
+				return time.Duration(t) * time.Second
+			}(),
+			agentCache: func() string {
+				cache := envMap["AGENT_CACHE"]
+				switch cache {
+				case "":
+					return AGENT_CACHE_MEMORY
+				case AGENT_CACHE_NONE, AGENT_CACHE_MEMORY, AGENT_CACHE_POSTGRES:
+					return cache
+				}
+				log.Fatalf("Load Agent Cache Failed: unknown cache %q", cache)
+				return ""
+			}(),
+			agentCacheTTL: func() time.Duration {
+				if envMap["AGENT_CACHE_TTL"] == "" {
+					return 24 * time.Hour
+				}
+				t, err := strconv.Atoi(envMap["AGENT_CACHE_TTL"])
+				if err != nil {
+					log.Fatalf("Load Agent Cache TTL Failed: %v", err)
+				}
+				return time.Duration(t) * time.Second
+			}(),
+			agentCacheMaxEntries: func() int {
+				if envMap["AGENT_CACHE_MAX_ENTRIES"] == "" {
+					return 1000
+				}
+				entries, err := strconv.Atoi(envMap["AGENT_CACHE_MAX_ENTRIES"])
+				if err != nil {
+					log.Fatalf("Load Agent Cache Max Entries Failed: %v", err)
+				}
+				return entries
+			}(),
+			agentMaxToolIterations: func() int {
+				if envMap["AGENT_MAX_TOOL_ITERATIONS"] == "" {
+					return 4
+				}
+				iterations, err := strconv.Atoi(envMap["AGENT_MAX_TOOL_ITERATIONS"])
+				if err != nil {
+					log.Fatalf("Load Agent Max Tool Iterations Failed: %v", err)
+				}
+				return iterations
+			}(),
+		},
+		job: &job{
+			workers: func() int {
+				if envMap["JOB_WORKERS"] == "" {
+					return 2
+				}
+				workers, err := strconv.Atoi(envMap["JOB_WORKERS"])
+				if err != nil {
+					log.Fatalf("Load Job Workers Failed: %v", err)
+				}
+				return workers
+			}(),
+			pollInterval: func() time.Duration {
+				if envMap["JOB_POLL_INTERVAL"] == "" {
+					return 2 * time.Second
+				}
+				t, err := strconv.Atoi(envMap["JOB_POLL_INTERVAL"])
+				if err != nil {
+					log.Fatalf("Load Job Poll Interval Failed: %v", err)
+				}
+				return time.Duration(t) * time.Second
+			}(),
+			maxAttempts: func() int {
+				if envMap["JOB_MAX_ATTEMPTS"] == "" {
+					return 3
+				}
+				attempts, err := strconv.Atoi(envMap["JOB_MAX_ATTEMPTS"])
+				if err != nil {
+					log.Fatalf("Load Job Max Attempts Failed: %v", err)
+				}
+				return attempts
+			}(),
+			timeout: func() time.Duration {
+				if envMap["JOB_TIMEOUT"] == "" {
+					return 5 * time.Minute
+				}
+				t, err := strconv.Atoi(envMap["JOB_TIMEOUT"])
+				if err != nil {
+					log.Fatalf("Load Job Timeout Failed: %v", err)
+				}
+				return time.Duration(t) * time.Second
+			}(),
+		},
+		knowledge: &knowledge{
+			embeddingModel: envMap["KNOWLEDGE_EMBEDDING_MODEL"],
+			vectorStore: func() string {
+				store := envMap["KNOWLEDGE_VECTOR_STORE"]
+				switch store {
+				case "":
+					return KNOWLEDGE_VECTOR_STORE_GO
+				case KNOWLEDGE_VECTOR_STORE_GO, KNOWLEDGE_VECTOR_STORE_PGVECTOR:
+					return store
+				}
+				log.Fatalf("Load Knowledge Vector Store Failed: unknown store %q", store)
+				return ""
+			}(),
+			topK: func() int {
+				if envMap["KNOWLEDGE_TOP_K"] == "" {
+					return 4
+				}
+				topK, err := strconv.Atoi(envMap["KNOWLEDGE_TOP_K"])
+				if err != nil {
+					log.Fatalf("Load Knowledge Top K Failed: %v", err)
+				}
+				return topK
+			}(),
+			minScore: func() float64 {
+				if envMap["KNOWLEDGE_MIN_SCORE"] == "" {
+					return 0.3
+				}
+				score, err := strconv.ParseFloat(envMap["KNOWLEDGE_MIN_SCORE"], 64)
+				if err != nil {
+					log.Fatalf("Load Knowledge Min Score Failed: %v", err)
+				}
+				return score
+			}(),
+			chunkSize: func() int {
+				if envMap["KNOWLEDGE_CHUNK_SIZE"] == "" {
+					return 800
+				}
+				size, err := strconv.Atoi(envMap["KNOWLEDGE_CHUNK_SIZE"])
+				if err != nil {
+					log.Fatalf("Load Knowledge Chunk Size Failed: %v", err)
+				}
+				return size
+			}(),
+			chunkOverlap: func() int {
+				if envMap["KNOWLEDGE_CHUNK_OVERLAP"] == "" {
+					return 100
+				}
+				overlap, err := strconv.Atoi(envMap["KNOWLEDGE_CHUNK_OVERLAP"])
+				if err != nil {
+					log.Fatalf("Load Knowledge Chunk Overlap Failed: %v", err)
+				}
+				return overlap
+			}(),
 		},
 	}
 }
 
 // Struct
 type config struct {
-	app   *app
-	db    *db
-	jwt   *jwt
-	gRPC  *gRPC
-	agent *agent
+	app       *app
+	db        *db
+	jwt       *jwt
+	gRPC      *gRPC
+	agent     *agent
+	job       *job
+	knowledge *knowledge
 }
 
 // Port Interface
@@ -142,6 +339,8 @@ type Iconfig interface {
 	Jwt() IJwtConfig
 	GRPC() IgRPCConfig
 	Agent() IAgentConfig
+	Job() IJobConfig
+	Knowledge() IKnowledgeConfig
 }
 
 func (c *config) App() IAppConfig {
@@ -303,14 +502,61 @@ func (c *config) Agent() IAgentConfig {
 	return c.agent
 }
 
+/* provider ของ LLM ที่เลือกได้ผ่าน AGENT_PROVIDER */
+const (
+	AGENT_PROVIDER_DIGITAL_OCEAN = "digitalocean"
+	AGENT_PROVIDER_OPENAI        = "openai"
+	AGENT_PROVIDER_OLLAMA        = "ollama"
+	AGENT_PROVIDER_FAKE          = "fake"
+)
+
+/* ที่เก็บ cache ของคำตอบ AI ที่เลือกได้ผ่าน AGENT_CACHE */
+const (
+	AGENT_CACHE_NONE     = "none"
+	AGENT_CACHE_MEMORY   = "memory"
+	AGENT_CACHE_POSTGRES = "postgres"
+)
+
 type IAgentConfig interface {
+	AgentProvider() string
 	AgentAccessKey() string
 	AgentEndpoint() string
+	AgentModel() string
+	AgentVisionModel() string
+	AgentTimeout() time.Duration
+	AgentMaxRetries() int
+	AgentBreakerThreshold() int
+	AgentBreakerCooldown() time.Duration
+	AgentCache() string
+	AgentCacheTTL() time.Duration
+	AgentCacheMaxEntries() int
+	AgentMaxToolIterations() int
 }
 
 type agent struct {
+	agentProvider  string
 	agentAccessKey string
 	agentEndpoint  string
+	agentModel     string
+	/* โมเดลที่อ่านรูปภาพได้ ใช้วิเคราะห์รูปอาหาร ถ้าไม่ตั้งใช้ AGENT_MODEL */
+	agentVisionModel string
+	/* เวลาสูงสุดต่อการเรียกหนึ่งครั้ง (รวม stream) */
+	agentTimeout time.Duration
+	/* จำนวนครั้งที่ลองใหม่เมื่อได้ 429, 5xx หรือเชื่อมต่อไม่ได้ */
+	agentMaxRetries int
+	/* ล้มเหลวติดกันกี่ครั้งจึงหยุดเรียกชั่วคราว และหยุดนานเท่าไร */
+	agentBreakerThreshold int
+	agentBreakerCooldown  time.Duration
+	/* cache คำตอบแผนอาหาร อายุของแต่ละรายการ และจำนวนรายการสูงสุด */
+	agentCache           string
+	agentCacheTTL        time.Duration
+	agentCacheMaxEntries int
+	/* จำนวนรอบสูงสุดที่ให้โมเดลเรียก tool ในแชท 0 คือปิด tool */
+	agentMaxToolIterations int
+}
+
+func (a *agent) AgentProvider() string {
+	return a.agentProvider
 }
 
 func (a *agent) AgentAccessKey() string {
@@ -320,3 +566,135 @@ func (a *agent) AgentAccessKey() string {
 func (a *agent) AgentEndpoint() string {
 	return a.agentEndpoint
 }
+
+func (a *agent) AgentModel() string {
+	return a.agentModel
+}
+
+func (a *agent) AgentVisionModel() string {
+	return a.agentVisionModel
+}
+
+func (a *agent) AgentTimeout() time.Duration {
+	return a.agentTimeout
+}
+
+func (a *agent) AgentMaxRetries() int {
+	return a.agentMaxRetries
+}
+
+func (a *agent) AgentBreakerThreshold() int {
+	return a.agentBreakerThreshold
+}
+
+func (a *agent) AgentBreakerCooldown() time.Duration {
+	return a.agentBreakerCooldown
+}
+
+func (a *agent) AgentCache() string {
+	return a.agentCache
+}
+
+func (a *agent) AgentCacheTTL() time.Duration {
+	return a.agentCacheTTL
+}
+
+func (a *agent) AgentCacheMaxEntries() int {
+	return a.agentCacheMaxEntries
+}
+
+func (a *agent) AgentMaxToolIterations() int {
+	return a.agentMaxToolIterations
+}
+
+func (c *config) Job() IJobConfig {
+	return c.job
+}
+
+type IJobConfig interface {
+	Workers() int
+	PollInterval() time.Duration
+	MaxAttempts() int
+	Timeout() time.Duration
+}
+
+type job struct {
+	/* จำนวน worker ที่ดึงงานพร้อมกันต่อ instance และระยะห่างระหว่างการดึงเมื่อไม่มีงาน */
+	workers      int
+	pollInterval time.Duration
+	/* จำนวนครั้งที่ทำงานได้ก่อนย้ายเป็น DEAD */
+	maxAttempts int
+	/* เวลาสูงสุดต่องาน และเวลารองานที่ค้างตอนปิดโปรแกรม */
+	timeout time.Duration
+}
+
+func (j *job) Workers() int {
+	return j.workers
+}
+
+func (j *job) PollInterval() time.Duration {
+	return j.pollInterval
+}
+
+func (j *job) MaxAttempts() int {
+	return j.maxAttempts
+}
+
+func (j *job) Timeout() time.Duration {
+	return j.timeout
+}
+
+func (c *config) Knowledge() IKnowledgeConfig {
+	return c.knowledge
+}
+
+/* ที่ค้นหา vector ของ knowledge base ที่เลือกได้ผ่าน KNOWLEDGE_VECTOR_STORE pgvector ต้องติดตั้ง extension vector ใน database ก่อน */
+const (
+	KNOWLEDGE_VECTOR_STORE_GO       = "go"
+	KNOWLEDGE_VECTOR_STORE_PGVECTOR = "pgvector"
+)
+
+type IKnowledgeConfig interface {
+	EmbeddingModel() string
+	VectorStore() string
+	TopK() int
+	MinScore() float64
+	ChunkSize() int
+	ChunkOverlap() int
+}
+
+type knowledge struct {
+	/* โมเดล embedding ของ provider เดียวกับ AGENT_PROVIDER ถ้าไม่ตั้งใช้ค่าตั้งต้นของ provider */
+	embeddingModel string
+	vectorStore    string
+	/* จำนวนส่วนของเอกสารที่แนบไปกับคำถาม และคะแนนความใกล้เคียงต่ำสุด (cosine) */
+	topK     int
+	minScore float64
+	/* ขนาดของแต่ละส่วน (ตัวอักษร) และจำนวนตัวอักษรที่ซ้อนกับส่วนก่อนหน้า */
+	chunkSize    int
+	chunkOverlap int
+}
+
+func (k *knowledge) EmbeddingModel() string {
+	return k.embeddingModel
+}
+
+func (k *knowledge) VectorStore() string {
+	return k.vectorStore
+}
+
+func (k *knowledge) TopK() int {
+	return k.topK
+}
+
+func (k *knowledge) MinScore() float64 {
+	return k.minScore
+}
+
+func (k *knowledge) ChunkSize() int {
+	return k.chunkSize
+}
+
+func (k *knowledge) ChunkOverlap() int {
+	return k.chunkOverlap
+}
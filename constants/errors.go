@@ -1,21 +1,102 @@
 package constants
 
-const (
-	ERROR_USER_NOT_FOUND           = "user not found"
-	ERROR_USERNAME_WAS_DUPLICATED  = "username was duplicated"
-	ERROR_EMAIL_WAS_DUPLICATED     = "email was duplicated"
-	ERROR_EMAIL_PATTERN_IS_INVALID = "email pattern is invalid"
-	ERROR_PASSWORD_IS_INVALID      = "password is invalid"
-	ERROR_OAUTH_NOT_FOUND          = "oauth not found"
-	ERROR_ROLES_NOT_FOUND          = "roles not found"
-)
+import "encoding/json"
 
 const (
-	POSTGRES_ERROR_USERNAME_WAS_DUPLICATED = "pq: duplicate key value violates unique constraint \"users_username_unique\""
-	POSTGRES_ERROR_EMAIL_WAS_DUPLICATED    = "pq: duplicate key value violates unique constraint \"users_email_unique\""
+	ERROR_USER_NOT_FOUND                 = "user not found"
+	ERROR_USERNAME_WAS_DUPLICATED        = "username was duplicated"
+	ERROR_EMAIL_WAS_DUPLICATED           = "email was duplicated"
+	ERROR_EMAIL_PATTERN_IS_INVALID       = "email pattern is invalid"
+	ERROR_PASSWORD_IS_INVALID            = "password is invalid"
+	ERROR_OAUTH_NOT_FOUND                = "oauth not found"
+	ERROR_ROLES_NOT_FOUND                = "roles not found"
+	ERROR_USER_INFO_NOT_FOUND            = "user info not found"
+	ERROR_FOOD_NOT_FOUND                 = "food not found"
+	ERROR_FOOD_DIARY_NOT_FOUND           = "food diary not found"
+	ERROR_MEAL_TYPE_IS_INVALID           = "meal type is invalid"
+	ERROR_UNIT_IS_INVALID                = "unit is invalid"
+	ERROR_DATE_PATTERN_IS_INVALID        = "date pattern is invalid"
+	ERROR_RECIPE_NOT_FOUND               = "recipe not found"
+	ERROR_RECIPE_HAS_NO_INGREDIENT       = "recipe has no ingredient"
+	ERROR_FILE_TYPE_IS_INVALID           = "file type is invalid"
+	ERROR_ACTIVITY_NOT_FOUND             = "activity not found"
+	ERROR_ACTIVITY_LOG_NOT_FOUND         = "activity log not found"
+	ERROR_INTENSITY_IS_INVALID           = "intensity is invalid"
+	ERROR_WATER_LOG_NOT_FOUND            = "water log not found"
+	ERROR_MEAL_PLAN_IS_INVALID           = "meal plan is invalid"
+	ERROR_MEAL_PLAN_NOT_FOUND            = "meal plan not found"
+	ERROR_ACTIVE_MEAL_PLAN_NOT_FOUND     = "active meal plan not found"
+	ERROR_CONVERSATION_NOT_FOUND         = "conversation not found"
+	ERROR_AGENT_UPSTREAM_FAILED          = "agent upstream failed"
+	ERROR_AGENT_UNAVAILABLE              = "agent is unavailable"
+	ERROR_AGENT_TIMEOUT                  = "agent timed out"
+	ERROR_AI_QUOTA_NOT_FOUND             = "ai quota not found"
+	ERROR_AI_QUOTA_EXCEEDED              = "ai quota exceeded"
+	ERROR_PROMPT_NOT_FOUND               = "prompt not found"
+	ERROR_PROMPT_NAME_IS_INVALID         = "prompt name is invalid"
+	ERROR_PROMPT_TEMPLATE_IS_INVALID     = "prompt template is invalid"
+	ERROR_JOB_NOT_FOUND                  = "job not found"
+	ERROR_JOB_NOT_COMPLETED              = "job is not completed"
+	ERROR_JOB_CANNOT_RETRY               = "only dead job can be retried"
+	ERROR_JOB_TIMED_OUT                  = "job timed out"
+	ERROR_JOB_LEASE_LOST                 = "job was claimed by another worker"
+	ERROR_MEAL_PHOTO_IS_INVALID          = "meal photo analysis is invalid"
+	ERROR_KNOWLEDGE_DOCUMENT_NOT_FOUND   = "knowledge document not found"
+	ERROR_KNOWLEDGE_DOCUMENT_IS_EMPTY    = "knowledge document has no content"
+	ERROR_MEAL_PLAN_MEAL_NOT_FOUND       = "meal plan meal not found"
+	ERROR_MEAL_PLAN_ITEM_NOT_FOUND       = "meal plan item not found"
+	ERROR_MEAL_SWAP_IS_INVALID           = "meal swap is invalid"
+	ERROR_GROCERY_LIST_NOT_FOUND         = "grocery list not found"
+	ERROR_GROCERY_ITEM_NOT_FOUND         = "grocery item not found"
+	ERROR_GROCERY_LIST_IS_EMPTY          = "no meal in the selected date range"
+	ERROR_LANGUAGE_IS_INVALID            = "language is invalid"
+	ERROR_FOOD_PREFERENCE_WAS_DUPLICATED = "food preference was duplicated"
+	ERROR_PROMPT_VERSION_WAS_DUPLICATED  = "prompt version was duplicated"
+	ERROR_RECORD_WAS_DUPLICATED          = "record was duplicated"
+	ERROR_RECORD_REFERENCE_IS_INVALID    = "referenced record does not exist or is still in use"
+	ERROR_VALUE_IS_INVALID               = "value is invalid"
+	ERROR_INTERNAL_SERVER                = "internal server error"
+	ERROR_REQUEST_IS_INVALID             = "request is invalid"
+	ERROR_BODY_WAS_MISSING               = "body was missing"
+	ERROR_FIELD_WAS_MISSING              = "was missing on body"
 )
 
+/* ErrorResponse error ตามรูปแบบ RFC 7807 ตอบด้วย Content-Type application/problem+json */
 type ErrorResponse struct {
-	Message string `json:"message" example:"Invalid email format"`
-	Code    int    `json:"code" example:"400"`
+	Type      string       `json:"type" example:"/problems/validation"`
+	Title     string       `json:"title" example:"Bad Request"`
+	Status    int          `json:"status" example:"400"`
+	Detail    string       `json:"detail" example:"request is invalid"`
+	Instance  string       `json:"instance" example:"/v1/user/sign-up"`
+	RequestId string       `json:"request_id" example:"0b6f3c1e-5d1a-4f0a-9c3e-2a7b8d9e0f11"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	/* Extensions member เพิ่มเติมตาม type เช่น reset_at ของ ai quota เขียนไว้ระดับเดียวกับ member มาตรฐาน */
+	Extensions map[string]interface{} `json:"-"`
+}
+
+/* MarshalJSON ใส่ Extensions ไว้ระดับบนสุดของ problem โดยไม่ทับ member มาตรฐาน */
+func (r ErrorResponse) MarshalJSON() ([]byte, error) {
+	type problem ErrorResponse
+	bt, err := json.Marshal(problem(r))
+	if err != nil || len(r.Extensions) == 0 {
+		return bt, err
+	}
+	members := make(map[string]interface{}, len(r.Extensions))
+	for key, val := range r.Extensions {
+		members[key] = val
+	}
+	standard := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(bt, &standard); err != nil {
+		return nil, err
+	}
+	for key, val := range standard {
+		members[key] = val
+	}
+	return json.Marshal(members)
+}
+
+/* FieldError error ของ field เดียวใน request */
+type FieldError struct {
+	Field   string `json:"field" example:"email"`
+	Message string `json:"message" example:"was missing on body"`
 }
@@ -3,9 +3,11 @@ package constants
 const (
 	REF_TYPE_USER    = "USER"
 	REF_TYPE_PRODUCT = "PRODUCT"
+	REF_TYPE_RECIPE  = "RECIPE"
 )
 
 const (
 	PRODUCT_IMAGE_DESTINETION = "images/product"
 	USER_IMAGE_DESTINETION    = "images/user"
+	RECIPE_IMAGE_DESTINETION  = "images/recipe"
 )
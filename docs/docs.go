@@ -15,169 +15,4913 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/v1/activity/list": {
+            "get": {
+                "description": "Get list activities with MET by intensity",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "activities"
+                ],
+                "summary": "FetchAllActivities",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example: วิ่ง",
+                        "name": "search_word",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/activity/log/{user_id}": {
+            "get": {
+                "description": "Get activity logs of user on a date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "activities"
+                ],
+                "summary": "FetchAllActivityLogs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: 2025-03-01 (default today)",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Log an activity; calories burned = MET x weight (kg) x duration (hours)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "activities"
+                ],
+                "summary": "CreateActivityLog",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "activity id from /v1/activity/list",
+                        "name": "activity_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "duration in minutes",
+                        "name": "duration_minutes",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "MODERATE",
+                        "description": "LIGHT, MODERATE or VIGOROUS",
+                        "name": "intensity",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: 2025-03-01 (default today)",
+                        "name": "performed_at",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "note",
+                        "name": "note",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "intensity is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "activity not found, user info not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/activity/log/{user_id}/{log_id}": {
+            "put": {
+                "description": "Edit an activity log; calories burned is recalculated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "activities"
+                ],
+                "summary": "UpdateActivityLog",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "activity log id",
+                        "name": "log_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "activity log not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an activity log",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "activities"
+                ],
+                "summary": "DeleteActivityLog",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "activity log id",
+                        "name": "log_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "activity log not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/agent-ai/meals": {
+            "post": {
+                "description": "Generate a typed meal plan (days, meals, items, portion, kcal and macros) from user info sent in the body and save it for the signed-in user, plan.cache tells whether the plan came from the response cache",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "agent-ai"
+                ],
+                "summary": "GenerateMealsPlan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "MALE or FEMALE",
+                        "name": "gender",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "weight (kg)",
+                        "name": "weight",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "height (cm)",
+                        "name": "height",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "SEDENTARY, LIGHT, MODERATE, ACTIVE or VERY_ACTIVE",
+                        "name": "active_level",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: 1995-03-01 00:00:00",
+                        "name": "dob",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 3,
+                        "description": "number of days (1-7)",
+                        "name": "days",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: Thai, Japanese",
+                        "name": "cuisine",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "food budget per day (THB)",
+                        "name": "budget",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "skip the cached plan and generate a new one",
+                        "name": "fresh",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "ai quota exceeded, problem has reset_at and quota members and Retry-After is set",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "meal plan is invalid or agent upstream failed",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "agent is unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "agent timed out",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/agent-ai/meals/me": {
+            "post": {
+                "description": "Generate and save a meal plan for the signed-in user from the stored user info, diseases, food preferences and current weight",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "agent-ai"
+                ],
+                "summary": "GenerateMyMealsPlan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 3,
+                        "description": "number of days (1-7)",
+                        "name": "days",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: Thai, Japanese",
+                        "name": "cuisine",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "food budget per day (THB)",
+                        "name": "budget",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "skip the cached plan and generate a new one",
+                        "name": "fresh",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "user info not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "meal plan is invalid or agent upstream failed",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "agent is unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "agent timed out",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/agent-ai/meals/me/stream": {
+            "post": {
+                "description": "Same as GenerateMyMealsPlan but answers with Server-Sent Events: token events while the model is writing, reset when the plan is regenerated, then done with the plan or error",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/event-stream",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "agent-ai"
+                ],
+                "summary": "StreamMyMealsPlan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 3,
+                        "description": "number of days (1-7)",
+                        "name": "days",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: Thai, Japanese",
+                        "name": "cuisine",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "food budget per day (THB)",
+                        "name": "budget",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "skip the cached plan and generate a new one",
+                        "name": "fresh",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "user info not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/agent-ai/meals/me/{plan_id}/swap": {
+            "post": {
+                "description": "Replace one meal, or one item when item_id is sent, in a saved meal plan of the signed-in user with an alternative that keeps the day within the calorie and macro target, respects food preferences, allergies and disease rules, and is recorded in the swap history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "agent-ai"
+                ],
+                "summary": "SwapMyMealPlanMeal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "meal plan id",
+                        "name": "plan_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "meal id in the plan",
+                        "name": "meal_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "item id in the meal, swap only this item",
+                        "name": "item_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "why the user wants another dish, example: ไม่ชอบปลา",
+                        "name": "reason",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "meal plan, meal or item not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "ai quota exceeded, problem has reset_at and quota members and Retry-After is set",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "meal swap is invalid or agent upstream failed",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "agent is unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "agent timed out",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/agent-ai/meals/photo": {
+            "post": {
+                "description": "Identify dishes in a meal photo with estimated portion, kcal and macros using a vision model, nothing is saved until the result is confirmed with /v1/diary/{user_id}/photo",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "agent-ai"
+                ],
+                "summary": "AnalyzeMyMealPhoto",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "meal photo (jpeg, png or webp, max 5 MB)",
+                        "name": "images",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "image is missing, too large or not supported",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "ai quota exceeded, problem has reset_at and quota members and Retry-After is set",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "meal photo analysis is invalid or agent upstream failed",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "agent is unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "agent timed out",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/agent-ai/meals/stream": {
+            "post": {
+                "description": "Same as GenerateMealsPlan but answers with Server-Sent Events: token events while the model is writing, reset when the plan is regenerated, then done with the plan or error",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/event-stream",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "agent-ai"
+                ],
+                "summary": "StreamMealsPlan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "MALE or FEMALE",
+                        "name": "gender",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "weight (kg)",
+                        "name": "weight",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "height (cm)",
+                        "name": "height",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "SEDENTARY, LIGHT, MODERATE, ACTIVE or VERY_ACTIVE",
+                        "name": "active_level",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: 1995-03-01 00:00:00",
+                        "name": "dob",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 3,
+                        "description": "number of days (1-7)",
+                        "name": "days",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: Thai, Japanese",
+                        "name": "cuisine",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "food budget per day (THB)",
+                        "name": "budget",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "skip the cached plan and generate a new one",
+                        "name": "fresh",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "ai quota exceeded, problem has reset_at and quota members and Retry-After is set",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/ai-usage/me": {
+            "get": {
+                "description": "Get token usage of signed-in user for today and this month against the quota of their role, null limit means unlimited",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "ai-usage"
+                ],
+                "summary": "FetchMyQuota",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/ai-usage/quotas": {
+            "get": {
+                "description": "Admin only, daily and monthly token quotas of every role, null limit means unlimited",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "ai-usage"
+                ],
+                "summary": "FetchAllQuotas",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/ai-usage/quotas/{role_id}": {
+            "put": {
+                "description": "Admin only, set daily and monthly token quotas of a role, send null for unlimited",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "ai-usage"
+                ],
+                "summary": "UpdateQuota",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "role id",
+                        "name": "role_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "tokens per day, null for unlimited",
+                        "name": "daily_token_limit",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "tokens per month, null for unlimited",
+                        "name": "monthly_token_limit",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "roles not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/ai-usage/report/daily": {
+            "get": {
+                "description": "Admin only, token usage grouped by day, endpoint and model",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "ai-usage"
+                ],
+                "summary": "FetchDailyReport",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: 2025-01-01, default 30 days ago",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: 2025-01-31, default today",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by user id",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/ai-usage/report/users": {
+            "get": {
+                "description": "Admin only, token usage grouped by user, highest usage first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "ai-usage"
+                ],
+                "summary": "FetchUserReport",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: 2025-01-01, default 30 days ago",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: 2025-01-31, default today",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter by user id",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/chat": {
+            "get": {
+                "description": "Get conversations of signed-in user, latest activity first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "FetchAllConversations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Start a new nutrition chat, when message is given the assistant replies in the same call",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "StartConversation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "conversation title, default from the first message",
+                        "name": "title",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "first message",
+                        "name": "message",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "agent upstream failed",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "agent is unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "agent timed out",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/chat/{conversation_id}": {
+            "get": {
+                "description": "Get a conversation with its message history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "FetchOneConversationById",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "conversation id",
+                        "name": "conversation_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "conversation not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/chat/{conversation_id}/messages": {
+            "post": {
+                "description": "Send a message in a conversation and get the assistant reply",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "SendMessage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "conversation id",
+                        "name": "conversation_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "message",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "conversation not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "agent upstream failed",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "agent is unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "agent timed out",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/chat/{conversation_id}/messages/stream": {
+            "post": {
+                "description": "Same as SendMessage but answers with Server-Sent Events: token events while the assistant is writing, tool events when it looks up foods or the diary, then done with the saved reply or error",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/event-stream",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "StreamMessage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "conversation id",
+                        "name": "conversation_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "message",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "conversation not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/diary/{user_id}": {
+            "get": {
+                "description": "Get food diary entries of user on a date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "diaries"
+                ],
+                "summary": "FetchAllFoodDiaries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: 2025-03-01 (default today)",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "BREAKFAST, LUNCH, DINNER or SNACK",
+                        "name": "meal_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Log a food item into a meal slot; send food_id or recipe_id with quantity, or a free-text name with nutrition",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "diaries"
+                ],
+                "summary": "CreateFoodDiary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "BREAKFAST, LUNCH, DINNER or SNACK",
+                        "name": "meal_type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "food id from /v1/food/list",
+                        "name": "food_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "recipe id from /v1/recipe/list (quantity is servings)",
+                        "name": "recipe_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "quantity of unit",
+                        "name": "quantity",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "g, ml, tbsp, cup, piece or serving",
+                        "name": "unit",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "free-text food name",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "free-text calories (kcal)",
+                        "name": "calories",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "free-text protein (g)",
+                        "name": "protein",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "free-text carbohydrate (g)",
+                        "name": "carbohydrate",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "free-text fat (g)",
+                        "name": "fat",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: 2025-03-01 (default today)",
+                        "name": "eaten_at",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "note",
+                        "name": "note",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "meal type is invalid, unit is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "food not found, recipe not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/diary/{user_id}/photo": {
+            "post": {
+                "description": "Log the dishes from /v1/agent-ai/meals/photo after the user confirmed them, dishes can be edited or removed before sending, each dish is saved as one serving with its portion in note",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "diaries"
+                ],
+                "summary": "CreateFoodDiariesFromPhoto",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "must be the signed-in user, example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "BREAKFAST, LUNCH, DINNER or SNACK",
+                        "name": "meal_type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: 2025-03-01 (default today)",
+                        "name": "eaten_at",
+                        "in": "formData"
+                    },
+                    {
+                        "description": "confirmed dishes from analysis.dishes",
+                        "name": "dishes",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.MealPhotoDish"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "meal type is invalid, meal photo analysis is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "user_id is not the signed-in user",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/diary/{user_id}/plan-comparison": {
+            "get": {
+                "description": "Compare what was eaten on a date against the active meal plan, per meal type; difference is eaten minus planned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "diaries"
+                ],
+                "summary": "FetchPlanComparison",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: 2025-03-01 (default today)",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "active meal plan not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/diary/{user_id}/summary": {
+            "get": {
+                "description": "Compare consumed energy and macros of a day against user calories limit; calories burned from activity logs is added back to remaining",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "diaries"
+                ],
+                "summary": "FetchDailySummary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: 2025-03-01 (default today)",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "user info not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/diary/{user_id}/{diary_id}": {
+            "put": {
+                "description": "Edit a food diary entry",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "diaries"
+                ],
+                "summary": "UpdateFoodDiary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "food diary id",
+                        "name": "diary_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "food diary not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a food diary entry",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "diaries"
+                ],
+                "summary": "DeleteFoodDiary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "food diary id",
+                        "name": "diary_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "food diary not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/food/list": {
+            "get": {
+                "description": "Get list foods with nutrition per 100 g",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "FetchAllFoods",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example: ข้าว",
+                        "name": "search_word",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "example: 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "example: 10",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/food/{food_id}": {
+            "get": {
+                "description": "Get one food",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "FetchOneFoodById",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:0b3f1c55-2a5e-4f0b-9a43-6f1f5b7b6a01",
+                        "name": "food_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/grocery-list/{user_id}": {
+            "get": {
+                "description": "Get grocery lists of user grouped by aisle category, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "grocery-list"
+                ],
+                "summary": "FetchAllGroceryLists",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only lists of this meal plan",
+                        "name": "meal_plan_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Aggregate ingredients of a meal plan in the date range, merge duplicates with unit normalisation and group by aisle category. Without dates the whole plan is used",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "grocery-list"
+                ],
+                "summary": "CreateGroceryList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "meal plan id",
+                        "name": "meal_plan_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "example:2025-01-01",
+                        "name": "start_date",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "example:2025-01-07",
+                        "name": "end_date",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "meal plan not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "no meal in the selected date range",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/grocery-list/{user_id}/{list_id}": {
+            "get": {
+                "description": "Get a grocery list with items grouped by aisle category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "grocery-list"
+                ],
+                "summary": "FetchOneGroceryListById",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "grocery list id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "grocery list not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a grocery list with its items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "grocery-list"
+                ],
+                "summary": "DeleteGroceryList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "grocery list id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "grocery list not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/grocery-list/{user_id}/{list_id}/export": {
+            "get": {
+                "description": "Export a grocery list for sharing as plain text (default) or as a JSON file",
+                "produces": [
+                    "text/plain",
+                    "application/problem+json",
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "grocery-list"
+                ],
+                "summary": "ExportGroceryList",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "grocery list id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "text or json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "grocery list not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/grocery-list/{user_id}/{list_id}/items/{item_id}": {
+            "put": {
+                "description": "Tick an item of a grocery list as bought, or untick it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "grocery-list"
+                ],
+                "summary": "CheckGroceryItem",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "grocery list id",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "grocery item id",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "bought or not",
+                        "name": "is_checked",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "grocery list or item not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/guardrails/rules": {
+            "get": {
+                "description": "Nutrient caps per disease that every generated meal plan is checked against (sodium, potassium, purine in mg, sugar and protein in g), scope MEAL or DAY",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "guardrail"
+                ],
+                "summary": "FetchAllDiseaseRules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only rules of this disease",
+                        "name": "disease_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/jobs": {
+            "get": {
+                "description": "Admin only, queued jobs newest first, status DEAD lists jobs that used up every attempt",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "FetchAllJobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "PENDING, RUNNING, SUCCEEDED or DEAD",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "owner of the job",
+                        "name": "user_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/jobs/meal-plans": {
+            "post": {
+                "description": "Queue meal plan generation for the signed-in user and return right away, poll /v1/jobs/{job_id} until status is SUCCEEDED then read /v1/jobs/{job_id}/result",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "EnqueueMealPlanJob",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 3,
+                        "description": "number of days (1-7)",
+                        "name": "days",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: Thai, Japanese",
+                        "name": "cuisine",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "food budget per day (THB)",
+                        "name": "budget",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "skip the cached plan and generate a new one",
+                        "name": "fresh",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "ai quota exceeded, problem has reset_at and quota members and Retry-After is set",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/jobs/{job_id}": {
+            "get": {
+                "description": "Get status of a job, only the owner or an admin can see it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "FetchOneJobById",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "job id",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/jobs/{job_id}/result": {
+            "get": {
+                "description": "Get the meal plan created by a job, answers 409 until the job has SUCCEEDED",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "FetchJobResult",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "job id",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "job is not completed",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/jobs/{job_id}/retry": {
+            "post": {
+                "description": "Admin only, put a DEAD job back in the queue with a fresh set of attempts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "job"
+                ],
+                "summary": "RetryJob",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "job id",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "job not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "only dead job can be retried",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/knowledge/documents": {
+            "get": {
+                "description": "Admin only, nutrition guideline documents in the knowledge base, newest first (without content)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "knowledge"
+                ],
+                "summary": "FetchAllKnowledgeDocuments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "search in title or source",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Admin only, add a clinical nutrition guideline, the text is split into chunks and embedded for retrieval by chat and meal plans. Send content as text or upload one .txt/.md file (max 2 MB) in files",
+                "consumes": [
+                    "multipart/form-data",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "knowledge"
+                ],
+                "summary": "CreateKnowledgeDocument",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "document title, shown in citations",
+                        "name": "title",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "where the guideline comes from, example: Thai Dietetic Association 2024",
+                        "name": "source",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "document text, required when no file is uploaded",
+                        "name": "content",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "document file (.txt, .md)",
+                        "name": "files",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "document is invalid or has no content",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/knowledge/documents/{document_id}": {
+            "get": {
+                "description": "Admin only, get a knowledge document with its content",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "knowledge"
+                ],
+                "summary": "FetchOneKnowledgeDocumentById",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "document id",
+                        "name": "document_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "knowledge document not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Admin only, remove a document and its chunks from the knowledge base",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "knowledge"
+                ],
+                "summary": "DeleteKnowledgeDocument",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "document id",
+                        "name": "document_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "knowledge document not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/knowledge/search": {
+            "get": {
+                "description": "Admin only, preview which chunks the agent would retrieve for a question, with similarity scores",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "knowledge"
+                ],
+                "summary": "SearchKnowledge",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "question, example: โซเดียมสำหรับผู้ป่วยความดันสูง",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "q was missing",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/meal-plan/{user_id}": {
+            "get": {
+                "description": "Get saved meal plans of user, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "meal-plan"
+                ],
+                "summary": "FetchAllMealPlans",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only the active plan",
+                        "name": "is_active",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/meal-plan/{user_id}/{plan_id}": {
+            "get": {
+                "description": "Get a saved meal plan with days, meals and items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "meal-plan"
+                ],
+                "summary": "FetchOneMealPlanById",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "meal plan id",
+                        "name": "plan_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "meal plan not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a meal plan with its days, meals and items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "meal-plan"
+                ],
+                "summary": "DeleteMealPlan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "meal plan id",
+                        "name": "plan_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "meal plan not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/meal-plan/{user_id}/{plan_id}/active": {
+            "put": {
+                "description": "Mark a meal plan as the active one, the previous active plan of user is deactivated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "meal-plan"
+                ],
+                "summary": "ActivateMealPlan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "meal plan id",
+                        "name": "plan_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "meal plan not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/meal-plan/{user_id}/{plan_id}/swaps": {
+            "get": {
+                "description": "Get the swap history of a meal plan with the meal before and after each swap, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "meal-plan"
+                ],
+                "summary": "FetchAllMealPlanSwaps",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "meal plan id",
+                        "name": "plan_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "only swaps of this meal",
+                        "name": "meal_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "meal plan not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/prompts": {
+            "get": {
+                "description": "Admin only, every stored prompt version, latest version first for each name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "prompt"
+                ],
+                "summary": "FetchAllPrompts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "prompt name, example: user_info",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only active versions",
+                        "name": "is_active",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Admin only, save a new version of a prompt, the template must render against user info (text/template with join)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "prompt"
+                ],
+                "summary": "CreatePrompt",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "prompt name, see /v1/prompts/defaults",
+                        "name": "name",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "template, example: อายุ {{.Age}} ปี",
+                        "name": "content",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "activate this version right away",
+                        "name": "is_active",
+                        "in": "body",
+                        "schema": {
+                            "type": "boolean"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "prompt name or template is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/prompts/defaults": {
+            "get": {
+                "description": "Admin only, prompts embedded in the binary, used when a name has no active version",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "prompt"
+                ],
+                "summary": "FetchAllDefaultPrompts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/prompts/{prompt_id}": {
+            "get": {
+                "description": "Admin only, get a prompt version",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "prompt"
+                ],
+                "summary": "FetchOnePromptById",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "prompt id",
+                        "name": "prompt_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "prompt not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/prompts/{prompt_id}/activate": {
+            "put": {
+                "description": "Admin only, make this version the one used by the agent, the previous active version of the same name is deactivated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "prompt"
+                ],
+                "summary": "ActivatePrompt",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "prompt id",
+                        "name": "prompt_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "prompt not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/recipe": {
+            "post": {
+                "description": "Create a recipe; nutrition per serving is computed from ingredients (units: g, ml, tbsp, cup, piece)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "CreateRecipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "owner user id",
+                        "name": "user_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "recipe name",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "recipe description",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "number of servings",
+                        "name": "servings",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "food id",
+                        "name": "ingredients[0][food_id]",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "quantity of unit",
+                        "name": "ingredients[0][quantity]",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "g, ml, tbsp, cup or piece",
+                        "name": "ingredients[0][unit]",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cooking step",
+                        "name": "steps[0]",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "recipe images",
+                        "name": "images",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "unit is invalid, recipe has no ingredient, file type is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "food not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/recipe/list": {
+            "get": {
+                "description": "Get list recipes with nutrition per serving",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "FetchAllRecipes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example: 98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "example: กะเพรา",
+                        "name": "search_word",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/recipe/{recipe_id}": {
+            "get": {
+                "description": "Get one recipe with ingredients, steps and images",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "FetchOneRecipeById",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "recipe id",
+                        "name": "recipe_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "recipe not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a recipe; sending ingredients or steps replaces the whole list",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "UpdateRecipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "recipe id",
+                        "name": "recipe_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "more recipe images",
+                        "name": "images",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "recipe not found, food not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a recipe with its ingredients, steps and images",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "DeleteRecipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "recipe id",
+                        "name": "recipe_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "recipe not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/user/admin": {
             "post": {
-                "description": "Sign-up admin to system with email and password",
+                "description": "Sign-up admin to system with email and password",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "SignUpAdmin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Username user",
+                        "name": "username",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Email user",
+                        "name": "email",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Password user",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "User profile image",
+                        "name": "files",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response\" example({\"message\":\"successful\",\"user_id\":\"uuid-123\",\"username\":\"john_doe\"})",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid email format",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Duplicate username or duplicate email",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Password hashing error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/info": {
+            "post": {
+                "description": "create user info data",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "CreateUserInfo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username user",
+                        "name": "user_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 25,
+                        "description": "age user",
+                        "name": "age",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "\"male\"",
+                        "description": "gender user",
+                        "name": "gender",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "default": 1.8,
+                        "description": "height user",
+                        "name": "height",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "default": 80,
+                        "description": "weight user",
+                        "name": "weight",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "default": 80,
+                        "description": "target weight user",
+                        "name": "target_weight",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "\"active\"",
+                        "description": "active level user",
+                        "name": "active_level",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "\"th\"",
+                        "description": "th or en, default is the request Accept-Language",
+                        "name": "language",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response\" example({\"message\":\"successful\",\"user_id\":\"uuid-123\",\"username\":\"john_doe\"})",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid email format, duplicate username, or duplicate email",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Password hashing error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/info/{user_id}/preferences": {
+            "put": {
+                "description": "Replace liked, disliked and allergic foods of user, used when generating meal plans",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "UpdateFoodPreferences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "liked foods",
+                        "name": "likes",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "disliked foods",
+                        "name": "dislikes",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "allergic foods",
+                        "name": "allergies",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "user info not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/list": {
+            "get": {
+                "description": "Get list users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "FetchAllUsers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example: john doe",
+                        "name": "search_word",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "example: 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "example: 10",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/refresh-passport": {
+            "get": {
+                "description": "Refresh user passport",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "RefreshUserPassport",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "refresh_token",
+                        "name": "refresh_token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/sign-in": {
+            "post": {
+                "description": "Sign-in to system with email and password",
                 "consumes": [
                     "multipart/form-data"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
                     "users"
                 ],
-                "summary": "SignUpAdmin",
+                "summary": "SignIn",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Username user",
+                        "description": "Email user",
+                        "name": "email",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Password user",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "email pattern is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "password is invalid",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "user not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/sign-up": {
+            "post": {
+                "description": "Sign-up to system with email and password",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "SignUp",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "username user",
                         "name": "username",
                         "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Email user",
+                        "description": "email user",
                         "name": "email",
                         "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Password user",
-                        "name": "password",
-                        "in": "formData",
+                        "description": "password user",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "user profile image",
+                        "name": "files",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response\" example({\"message\":\"successful\",\"user_id\":\"uuid-123\",\"username\":\"john_doe\"})",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid email format",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Duplicate username or duplicate email",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Password hashing error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/user/{user_id}": {
+            "get": {
+                "description": "Get One users",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "FetchOneUserById",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:257d3552-c186-4c23-aa5d-1ea53f453e2a",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/water/{user_id}": {
+            "get": {
+                "description": "Get water logs of user on a date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/problem+json"
+                ],
+                "tags": [
+                    "water"
+                ],
+                "summary": "FetchAllWaterLogs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "User profile image",
-                        "name": "files",
-                        "in": "formData"
+                        "type": "string",
+                        "description": "example: 2025-03-01 (default today)",
+                        "name": "date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response\" example({\"message\":\"successful\",\"user_id\":\"uuid-123\",\"username\":\"john_doe\"})",
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid email format, duplicate username, or duplicate email",
-                        "schema": {
-                            "$ref": "#/definitions/constants.ErrorResponse"
-                        }
-                    },
-                    "422": {
-                        "description": "Password hashing error",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/constants.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/constants.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/user/info": {
+            },
             "post": {
-                "description": "create user info data",
+                "description": "Record a drink in ml",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
-                    "users"
+                    "water"
                 ],
-                "summary": "CreateUserInfo",
+                "summary": "CreateWaterLog",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "username user",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
                         "name": "user_id",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 25,
-                        "description": "age user",
-                        "name": "age",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "default": "\"male\"",
-                        "description": "gender user",
-                        "name": "gender",
-                        "in": "formData",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "number",
-                        "default": 1.8,
-                        "description": "height user",
-                        "name": "height",
+                        "description": "amount in ml",
+                        "name": "amount_ml",
                         "in": "formData",
                         "required": true
                     },
                     {
-                        "type": "number",
-                        "default": 80,
-                        "description": "weight user",
-                        "name": "weight",
-                        "in": "formData",
-                        "required": true
+                        "type": "string",
+                        "default": "WATER",
+                        "description": "example: WATER, TEA, MILK",
+                        "name": "drink_type",
+                        "in": "formData"
                     },
                     {
-                        "type": "number",
-                        "default": 80,
-                        "description": "target weight user",
-                        "name": "target_weight",
-                        "in": "formData",
-                        "required": true
+                        "type": "string",
+                        "description": "example: 2025-03-01 (default today)",
+                        "name": "drank_at",
+                        "in": "formData"
                     },
                     {
                         "type": "string",
-                        "default": "\"active\"",
-                        "description": "active level user",
-                        "name": "active_level",
-                        "in": "formData",
-                        "required": true
+                        "description": "note",
+                        "name": "note",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response\" example({\"message\":\"successful\",\"user_id\":\"uuid-123\",\"username\":\"john_doe\"})",
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid email format, duplicate username, or duplicate email",
-                        "schema": {
-                            "$ref": "#/definitions/constants.ErrorResponse"
-                        }
-                    },
-                    "422": {
-                        "description": "Password hashing error",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/constants.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/constants.ErrorResponse"
                         }
@@ -185,36 +4929,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/user/list": {
+        "/v1/water/{user_id}/summary": {
             "get": {
-                "description": "Get list users",
+                "description": "Compare water drunk on a date with the target from user weight and activity level",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
-                    "users"
+                    "water"
                 ],
-                "summary": "FetchAllUsers",
+                "summary": "FetchDailyWaterSummary",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "example: john doe",
-                        "name": "search_word",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "example: 1",
-                        "name": "page",
-                        "in": "query"
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "example: 10",
-                        "name": "per_page",
+                        "type": "string",
+                        "description": "example: 2025-03-01 (default today)",
+                        "name": "date",
                         "in": "query"
                     }
                 ],
@@ -226,43 +4966,16 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/constants.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/v1/user/refresh-passport": {
-            "get": {
-                "description": "Refresh user passport",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "users"
-                ],
-                "summary": "RefreshUserPassport",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "refresh_token",
-                        "name": "refresh_token",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "user info not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/constants.ErrorResponse"
                         }
                     },
                     "500": {
@@ -274,33 +4987,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/user/sign-in": {
-            "post": {
-                "description": "Sign-in to system with email and password",
+        "/v1/water/{user_id}/summary/weekly": {
+            "get": {
+                "description": "Get water progress of the 7 days ending on a date",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
-                    "users"
+                    "water"
                 ],
-                "summary": "SignIn",
+                "summary": "FetchWeeklyWaterSummary",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Email user",
-                        "name": "email",
-                        "in": "formData",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Password user",
-                        "name": "password",
-                        "in": "formData",
-                        "required": true
+                        "description": "last day of the week, example: 2025-03-07 (default today)",
+                        "name": "date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -312,19 +5025,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "password is invalid",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/constants.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "user not found",
+                        "description": "user info not found",
                         "schema": {
                             "$ref": "#/definitions/constants.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/constants.ErrorResponse"
                         }
@@ -332,97 +5045,91 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/user/sign-up": {
-            "post": {
-                "description": "Sign-up to system with email and password",
+        "/v1/water/{user_id}/{log_id}": {
+            "put": {
+                "description": "Edit a water log",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
-                    "users"
+                    "water"
                 ],
-                "summary": "SignUp",
+                "summary": "UpdateWaterLog",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "username user",
-                        "name": "username",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "email user",
-                        "name": "email",
-                        "in": "formData",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
+                        "name": "user_id",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "password user",
-                        "name": "password",
-                        "in": "formData",
+                        "description": "water log id",
+                        "name": "log_id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "user profile image",
-                        "name": "files",
-                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful response\" example({\"message\":\"successful\",\"user_id\":\"uuid-123\",\"username\":\"john_doe\"})",
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Invalid email format, duplicate username, or duplicate email",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/constants.ErrorResponse"
                         }
                     },
-                    "422": {
-                        "description": "Password hashing error",
+                    "404": {
+                        "description": "water log not found",
                         "schema": {
                             "$ref": "#/definitions/constants.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/constants.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/user/{user_id}": {
-            "get": {
-                "description": "Get One users",
+            },
+            "delete": {
+                "description": "Delete a water log",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/problem+json"
                 ],
                 "tags": [
-                    "users"
+                    "water"
                 ],
-                "summary": "FetchOneUserById",
+                "summary": "DeleteWaterLog",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "example:257d3552-c186-4c23-aa5d-1ea53f453e2a",
+                        "description": "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f",
                         "name": "user_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "water log id",
+                        "name": "log_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -433,6 +5140,12 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
+                    "404": {
+                        "description": "water log not found",
+                        "schema": {
+                            "$ref": "#/definitions/constants.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -447,13 +5160,74 @@ const docTemplate = `{
         "constants.ErrorResponse": {
             "type": "object",
             "properties": {
-                "code": {
+                "detail": {
+                    "type": "string",
+                    "example": "request is invalid"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/constants.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/v1/user/sign-up"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "0b6f3c1e-5d1a-4f0a-9c3e-2a7b8d9e0f11"
+                },
+                "status": {
                     "type": "integer",
                     "example": 400
                 },
+                "title": {
+                    "type": "string",
+                    "example": "Bad Request"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "/problems/validation"
+                }
+            }
+        },
+        "constants.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "email"
+                },
                 "message": {
                     "type": "string",
-                    "example": "Invalid email format"
+                    "example": "was missing on body"
+                }
+            }
+        },
+        "models.MealPhotoDish": {
+            "type": "object",
+            "properties": {
+                "calories": {
+                    "type": "number"
+                },
+                "carbohydrate": {
+                    "type": "number"
+                },
+                "confidence": {
+                    "type": "number"
+                },
+                "fat": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "portion": {
+                    "type": "string"
+                },
+                "protein": {
+                    "type": "number"
                 }
             }
         }
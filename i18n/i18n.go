@@ -0,0 +1,49 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"healthmatefood-api/models"
+	"log"
+	"strings"
+)
+
+/* catalogue ข้อความ error แยกตามภาษา key คือข้อความภาษาอังกฤษใน constants และ validator ชื่อไฟล์คือรหัสภาษา */
+//go:embed *.json
+var files embed.FS
+
+var catalogues = loadCatalogues()
+
+func loadCatalogues() map[models.Language]map[string]string {
+	catalogues := make(map[models.Language]map[string]string)
+	for _, language := range models.Languages {
+		content, err := files.ReadFile(string(language) + ".json")
+		if err != nil {
+			log.Fatalf("message catalogue %s not found: %v", language, err)
+		}
+		messages := make(map[string]string)
+		if err := json.Unmarshal(content, &messages); err != nil {
+			log.Fatalf("message catalogue %s is invalid: %v", language, err)
+		}
+		catalogues[language] = messages
+	}
+	return catalogues
+}
+
+/* Translate แปลข้อความ error เป็นภาษาที่ระบุ ข้อความที่ต่อกันด้วย ": " (เช่น "meal_plan_id: was missing on body") แปลทีละส่วน ส่วนที่ไม่มีใน catalogue เช่นชื่อ field คงไว้ตามเดิม */
+func Translate(language models.Language, message string) string {
+	messages, ok := catalogues[language]
+	if !ok {
+		return message
+	}
+	if translated, ok := messages[message]; ok {
+		return translated
+	}
+	parts := strings.Split(message, ": ")
+	for index, part := range parts {
+		if translated, ok := messages[part]; ok {
+			parts[index] = translated
+		}
+	}
+	return strings.Join(parts, ": ")
+}
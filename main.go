@@ -19,10 +19,63 @@ import (
 	user_usecase "healthmatefood-api/service/user/usecase"
 	user_validator "healthmatefood-api/service/user/validator"
 
+	activity_handler "healthmatefood-api/service/activity/http"
+	activity_repository "healthmatefood-api/service/activity/repository"
+	activity_usecase "healthmatefood-api/service/activity/usecase"
+	activity_validator "healthmatefood-api/service/activity/validator"
 	agent_ai_handler "healthmatefood-api/service/agent-ai/http"
 	agetn_ai_repository "healthmatefood-api/service/agent-ai/repository"
 	agent_ai_usecase "healthmatefood-api/service/agent-ai/usecase"
+	agent_ai_validator "healthmatefood-api/service/agent-ai/validator"
+	aiusage_handler "healthmatefood-api/service/aiusage/http"
+	aiusage_repository "healthmatefood-api/service/aiusage/repository"
+	aiusage_usecase "healthmatefood-api/service/aiusage/usecase"
+	aiusage_validator "healthmatefood-api/service/aiusage/validator"
+	chat_handler "healthmatefood-api/service/chat/http"
+	chat_repository "healthmatefood-api/service/chat/repository"
+	chat_usecase "healthmatefood-api/service/chat/usecase"
+	chat_validator "healthmatefood-api/service/chat/validator"
+	diary_handler "healthmatefood-api/service/diary/http"
+	diary_repository "healthmatefood-api/service/diary/repository"
+	diary_usecase "healthmatefood-api/service/diary/usecase"
+	diary_validator "healthmatefood-api/service/diary/validator"
 	file_usecase "healthmatefood-api/service/file/usecase"
+	food_handler "healthmatefood-api/service/food/http"
+	food_repository "healthmatefood-api/service/food/repository"
+	food_usecase "healthmatefood-api/service/food/usecase"
+	grocery_handler "healthmatefood-api/service/grocery/http"
+	grocery_repository "healthmatefood-api/service/grocery/repository"
+	grocery_usecase "healthmatefood-api/service/grocery/usecase"
+	grocery_validator "healthmatefood-api/service/grocery/validator"
+	guardrail_handler "healthmatefood-api/service/guardrail/http"
+	guardrail_repository "healthmatefood-api/service/guardrail/repository"
+	guardrail_usecase "healthmatefood-api/service/guardrail/usecase"
+	guardrail_validator "healthmatefood-api/service/guardrail/validator"
+	job_handler "healthmatefood-api/service/job/http"
+	job_repository "healthmatefood-api/service/job/repository"
+	job_usecase "healthmatefood-api/service/job/usecase"
+	job_validator "healthmatefood-api/service/job/validator"
+	job_worker "healthmatefood-api/service/job/worker"
+	knowledge_handler "healthmatefood-api/service/knowledge/http"
+	knowledge_repository "healthmatefood-api/service/knowledge/repository"
+	knowledge_usecase "healthmatefood-api/service/knowledge/usecase"
+	knowledge_validator "healthmatefood-api/service/knowledge/validator"
+	mealplan_handler "healthmatefood-api/service/mealplan/http"
+	mealplan_repository "healthmatefood-api/service/mealplan/repository"
+	mealplan_usecase "healthmatefood-api/service/mealplan/usecase"
+	mealplan_validator "healthmatefood-api/service/mealplan/validator"
+	prompt_handler "healthmatefood-api/service/prompt/http"
+	prompt_repository "healthmatefood-api/service/prompt/repository"
+	prompt_usecase "healthmatefood-api/service/prompt/usecase"
+	prompt_validator "healthmatefood-api/service/prompt/validator"
+	recipe_handler "healthmatefood-api/service/recipe/http"
+	recipe_repository "healthmatefood-api/service/recipe/repository"
+	recipe_usecase "healthmatefood-api/service/recipe/usecase"
+	recipe_validator "healthmatefood-api/service/recipe/validator"
+	water_handler "healthmatefood-api/service/water/http"
+	water_repository "healthmatefood-api/service/water/repository"
+	water_usecase "healthmatefood-api/service/water/usecase"
+	water_validator "healthmatefood-api/service/water/validator"
 
 	_ "healthmatefood-api/docs"
 
@@ -56,20 +109,76 @@ func main() {
 
 	/* Init Repository */
 	userRepo := user_repository.NewUserRepository(psqlDB)
-	agentAIRepo := agetn_ai_repository.NewAgentAIRepository(cfg.Agent())
+	promptRepo := prompt_repository.NewPromptRepository(psqlDB)
+	responseCache := agetn_ai_repository.NewResponseCache(cfg.Agent(), psqlDB)
 	authRepo := auth_repository.NewAuthRepository(cfg.Jwt(), psqlDB)
+	foodRepo := food_repository.NewFoodRepository(psqlDB)
+	diaryRepo := diary_repository.NewDiaryRepository(psqlDB)
+	recipeRepo := recipe_repository.NewRecipeRepository(psqlDB)
+	activityRepo := activity_repository.NewActivityRepository(psqlDB)
+	waterRepo := water_repository.NewWaterRepository(psqlDB)
+	mealPlanRepo := mealplan_repository.NewMealPlanRepository(psqlDB)
+	groceryRepo := grocery_repository.NewGroceryRepository(psqlDB)
+	chatRepo := chat_repository.NewChatRepository(psqlDB)
+	aiUsageRepo := aiusage_repository.NewAIUsageRepository(psqlDB)
+	jobRepo := job_repository.NewJobRepository(psqlDB)
+	knowledgeRepo := knowledge_repository.NewKnowledgeRepository(psqlDB)
+	guardrailRepo := guardrail_repository.NewGuardrailRepository(psqlDB)
 
 	/* Init Usecase */
 	fileUs := file_usecase.NewFileUsecase(cfg)
 	userUs := user_usecase.NewUserUsecase(cfg, userRepo, fileUs, authRepo)
-	agentAIUs := agent_ai_usecase.NewAgentAIUsecase(agentAIRepo)
+	foodUs := food_usecase.NewFoodUsecase(foodRepo)
+	recipeUs := recipe_usecase.NewRecipeUsecase(cfg, recipeRepo, foodRepo, fileUs)
+	activityUs := activity_usecase.NewActivityUsecase(activityRepo, userUs)
+	waterUs := water_usecase.NewWaterUsecase(waterRepo, userUs)
+	diaryUs := diary_usecase.NewDiaryUsecase(diaryRepo, foodRepo, recipeRepo, activityRepo, mealPlanRepo, userUs)
+	/* agent repository render prompt ผ่าน prompt usecase เรียก tool ผ่าน food, diary, user usecase ค้น knowledge base และตรวจแผนตามกฎโรคประจำตัว จึงต้องสร้างทีหลัง */
+	promptUs := prompt_usecase.NewPromptUsecase(promptRepo)
+	agentToolUs := agent_ai_usecase.NewAgentToolUsecase(foodUs, diaryUs, userUs)
+	embedder := agetn_ai_repository.NewEmbedder(cfg.Agent(), cfg.Knowledge())
+	knowledgeUs := knowledge_usecase.NewKnowledgeUsecase(cfg.Knowledge(), knowledgeRepo, embedder)
+	guardrailUs := guardrail_usecase.NewGuardrailUsecase(guardrailRepo)
+	agentAIRepo := agetn_ai_repository.NewAgentAIRepository(cfg.Agent(), promptUs, responseCache, agentToolUs, knowledgeUs, guardrailUs)
+	agentAIUs := agent_ai_usecase.NewAgentAIUsecase(agentAIRepo, mealPlanRepo)
+	mealPlanUs := mealplan_usecase.NewMealPlanUsecase(mealPlanRepo)
+	groceryUs := grocery_usecase.NewGroceryUsecase(groceryRepo, mealPlanRepo, recipeRepo)
+	chatUs := chat_usecase.NewChatUsecase(chatRepo, agentAIRepo, userUs)
+	aiUsageUs := aiusage_usecase.NewAIUsageUsecase(aiUsageRepo)
+	jobUs := job_usecase.NewJobUsecase(cfg.Job(), jobRepo, agentAIUs, userUs, mealPlanRepo, aiUsageUs)
 
 	/* Init Handler */
 	userHand := user_handler.NewUserHandler(userUs)
 	agentAIHandler := agent_ai_handler.NewAgentAIHandler(agentAIUs, userUs)
+	foodHand := food_handler.NewFoodHandler(foodUs)
+	diaryHand := diary_handler.NewDiaryHandler(diaryUs)
+	recipeHand := recipe_handler.NewRecipeHandler(recipeUs)
+	activityHand := activity_handler.NewActivityHandler(activityUs)
+	waterHand := water_handler.NewWaterHandler(waterUs)
+	mealPlanHand := mealplan_handler.NewMealPlanHandler(mealPlanUs)
+	groceryHand := grocery_handler.NewGroceryHandler(groceryUs)
+	chatHand := chat_handler.NewChatHandler(chatUs)
+	aiUsageHand := aiusage_handler.NewAIUsageHandler(aiUsageUs)
+	promptHand := prompt_handler.NewPromptHandler(promptUs)
+	jobHand := job_handler.NewJobHandler(jobUs)
+	knowledgeHand := knowledge_handler.NewKnowledgeHandler(knowledgeUs)
+	guardrailHand := guardrail_handler.NewGuardrailHandler(guardrailUs)
 
 	/* Init Validate */
 	userValidate := user_validator.Validation{}
+	agentAIValidate := agent_ai_validator.Validation{}
+	diaryValidate := diary_validator.Validation{}
+	recipeValidate := recipe_validator.Validation{}
+	activityValidate := activity_validator.Validation{}
+	waterValidate := water_validator.Validation{}
+	mealPlanValidate := mealplan_validator.Validation{}
+	groceryValidate := grocery_validator.Validation{}
+	chatValidate := chat_validator.Validation{}
+	aiUsageValidate := aiusage_validator.Validation{}
+	promptValidate := prompt_validator.Validation{}
+	jobValidate := job_validator.Validation{}
+	knowledgeValidate := knowledge_validator.Validation{}
+	guardrailValidate := guardrail_validator.Validation{}
 
 	/* Init Fiber Server */
 	app := fiber.New(fiber.Config{
@@ -79,14 +188,12 @@ func main() {
 		WriteTimeout: cfg.App().WriteTimeOut(),
 		JSONEncoder:  json.Marshal,
 		JSONDecoder:  json.Unmarshal,
+		ErrorHandler: middleware.ErrorHandler,
 	})
 	/* Init Middleware */
 	middlewareInf := middleware.InitMiddleware(cfg, authRepo)
 	/* Setup Middleware */
-	app.Use(middlewareInf.SetTracer())
-	app.Use(middlewareInf.Cors())
-	app.Use(middlewareInf.Logger())
-	app.Use(middlewareInf.InputForm())
+	middleware.Setup(app, middlewareInf)
 	app.Get("/", func(c *fiber.Ctx) error {
 		return c.SendString("Hello, World!")
 	})
@@ -97,7 +204,24 @@ func main() {
 	router := app.Group("/v1")
 	r := route.NewRoute(router)
 	r.RegisterUser(userHand, userValidate)
-	r.RegisterAgentAI(agentAIHandler)
+	r.RegisterAgentAI(agentAIHandler, agentAIValidate, aiUsageHand, middlewareInf)
+	r.RegisterFood(foodHand)
+	r.RegisterDiary(diaryHand, diaryValidate, middlewareInf)
+	r.RegisterRecipe(recipeHand, recipeValidate)
+	r.RegisterActivity(activityHand, activityValidate)
+	r.RegisterWater(waterHand, waterValidate)
+	r.RegisterMealPlan(mealPlanHand, mealPlanValidate)
+	r.RegisterGrocery(groceryHand, groceryValidate)
+	r.RegisterChat(chatHand, chatValidate, aiUsageHand, middlewareInf)
+	r.RegisterAIUsage(aiUsageHand, aiUsageValidate, middlewareInf)
+	r.RegisterPrompt(promptHand, promptValidate, middlewareInf)
+	r.RegisterJob(jobHand, jobValidate, aiUsageHand, middlewareInf)
+	r.RegisterKnowledge(knowledgeHand, knowledgeValidate, middlewareInf)
+	r.RegisterGuardrail(guardrailHand, guardrailValidate, middlewareInf)
+
+	/* Start Job Worker */
+	jobWorker := job_worker.NewJobWorker(cfg.Job(), jobUs)
+	jobWorker.Start(ctx)
 
 	/* Graceful Shutdown */
 	c := make(chan os.Signal, 1)
@@ -114,6 +238,12 @@ func main() {
 	if err := app.Listen(cfg.App().Url()); err != nil {
 		logrus.Fatal(err)
 	}
+
+	/* รองานที่ worker กำลังทำให้เสร็จก่อนปิด งานที่ไม่ทันจะกลับเข้าคิว */
+	log.Println("Draining job workers...")
+	if err := jobWorker.Shutdown(cfg.Job().Timeout()); err != nil {
+		logrus.Error(err)
+	}
 }
 
 func startGRPCServer(cfg config.Iconfig, server *grpc.Server) {
@@ -2,8 +2,13 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"healthmatefood-api/apperror"
 	"healthmatefood-api/config"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/i18n"
+	"healthmatefood-api/models"
 	"healthmatefood-api/service/auth"
 	"net/http"
 	"strings"
@@ -11,6 +16,8 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/gofiber/fiber/v2/utils"
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
 	"github.com/opentracing/opentracing-go/log"
@@ -18,10 +25,14 @@ import (
 )
 
 type GoMiddlewareInf interface {
+	RequestId() fiber.Handler
 	SetTracer() fiber.Handler
 	Cors() fiber.Handler
 	Logger() fiber.Handler
 	InputForm() fiber.Handler
+	Localize() fiber.Handler
+	JwtAuth() fiber.Handler
+	Authorize(roleIds ...int64) fiber.Handler
 }
 
 type GoMiddleware struct {
@@ -39,6 +50,24 @@ func InitMiddleware(cfg config.Iconfig, authRepo auth.IAuthRepository) GoMiddlew
 	}
 }
 
+/* Setup ติดตั้ง middleware ที่ใช้กับทุก route ตามลำดับ RequestId ต้องมาก่อนเพื่อให้ทุก error มี request id */
+func Setup(app fiber.Router, m GoMiddlewareInf) {
+	app.Use(m.RequestId())
+	app.Use(m.SetTracer())
+	app.Use(m.Cors())
+	app.Use(m.Logger())
+	app.Use(m.Localize())
+	app.Use(m.InputForm())
+}
+
+/* MIMEApplicationProblemJSON Content-Type ของ error ตาม RFC 7807 */
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+/* RequestId ใช้ X-Request-ID ที่ client ส่งมา หรือสร้างใหม่ ตอบกลับใน header และใส่ใน error ทุกครั้ง */
+func (m GoMiddleware) RequestId() fiber.Handler {
+	return requestid.New()
+}
+
 func (m GoMiddleware) SetTracer() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var span opentracing.Span
@@ -58,6 +87,7 @@ func (m GoMiddleware) SetTracer() fiber.Handler {
 			return fiber.NewError(http.StatusInternalServerError, err.Error())
 		}
 		defer span.Finish()
+		span.SetTag("request_id", c.GetRespHeader(fiber.HeaderXRequestID))
 
 		c.SetUserContext(ctx)
 		// Proceed to the next handler
@@ -73,7 +103,8 @@ func (m GoMiddleware) SetTracer() fiber.Handler {
 			span.SetTag("http.status_code", c.Response().StatusCode())
 		}
 
-		return nil
+		/* คืน err ต่อให้ ErrorHandler ของ fiber ตอบ problem+json */
+		return err
 	}
 }
 
@@ -106,6 +137,94 @@ func (m GoMiddleware) JwtAuth() fiber.Handler {
 	}
 }
 
+/* Localize เลือกภาษาจาก header Accept-Language เก็บใน context ให้ agent ตอบเป็นภาษานั้น และให้ ErrorHandler แปลข้อความ error ที่ตอบกลับ ไม่ระบุภาษาที่รองรับจะตอบ error ตามเดิม */
+func (m GoMiddleware) Localize() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Vary(fiber.HeaderAcceptLanguage)
+		if language, ok := models.NegotiateLanguage(c.Get(fiber.HeaderAcceptLanguage)); ok {
+			c.SetUserContext(models.ContextWithLanguage(c.UserContext(), language))
+		}
+		return c.Next()
+	}
+}
+
+/* ErrorStatus status และข้อความที่ตอบ client ของ err ตาม kind ของ apperror หรือ fiber.Error ที่เหลือได้ 500 และ ok เป็น false ให้ผู้เรียกเขียน log เอง */
+func ErrorStatus(err error) (int, string, bool) {
+	if typed, ok := apperror.As(err); ok {
+		return typed.HTTPStatus(), typed.Error(), true
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code, fiberErr.Message, true
+	}
+	return http.StatusInternalServerError, constants.ERROR_INTERNAL_SERVER, false
+}
+
+/* NewProblem สร้าง problem+json ของ err status และ type ตาม kind ของ apperror error ที่ไม่มี kind เป็น 500 โดยไม่ส่งรายละเอียดภายในให้ client แต่เขียน log พร้อม request id แทน แปลข้อความตามภาษาใน ctx */
+func NewProblem(ctx context.Context, instance string, requestId string, err error) constants.ErrorResponse {
+	status, detail, known := ErrorStatus(err)
+	if !known {
+		logrus.Errorf("[%s] %s: %v", requestId, instance, err)
+	}
+	problem := constants.ErrorResponse{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  instance,
+		RequestId: requestId,
+	}
+	if typed, ok := apperror.As(err); ok {
+		problem.Type = typed.ErrorKind().ProblemType()
+	}
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		problem.Errors = appErr.Fields
+		problem.Extensions = appErr.Extensions
+	}
+	if language, ok := models.LanguageFromContext(ctx); ok {
+		problem.Detail = i18n.Translate(language, problem.Detail)
+		fields := make([]constants.FieldError, len(problem.Errors))
+		for index, field := range problem.Errors {
+			fields[index] = constants.FieldError{Field: field.Field, Message: i18n.Translate(language, field.Message)}
+		}
+		problem.Errors = fields
+	}
+	return problem
+}
+
+/* NewProblemFunc คัดลอก path และ request id ของ c ไว้ก่อน ใช้สร้าง problem หลัง handler คืนค่าแล้วได้ เช่นใน stream SSE ที่ c ถูกนำไปใช้กับ request อื่น */
+func NewProblemFunc(c *fiber.Ctx) func(ctx context.Context, err error) constants.ErrorResponse {
+	instance := utils.CopyString(c.OriginalURL())
+	requestId := utils.CopyString(c.GetRespHeader(fiber.HeaderXRequestID))
+	return func(ctx context.Context, err error) constants.ErrorResponse {
+		return NewProblem(ctx, instance, requestId, err)
+	}
+}
+
+/* ErrorHandler ใช้เป็น ErrorHandler ของ fiber ตอบทุก error เป็น problem+json (constants.ErrorResponse) */
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	problem := NewProblem(c.UserContext(), c.OriginalURL(), c.GetRespHeader(fiber.HeaderXRequestID), err)
+	if err := c.Status(problem.Status).JSON(problem); err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, MIMEApplicationProblemJSON)
+	return nil
+}
+
+/* Authorize ใช้ต่อจาก JwtAuth อนุญาตเฉพาะ role ที่ระบุ */
+func (m GoMiddleware) Authorize(roleIds ...int64) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roleId, _ := c.Locals("role_id").(int64)
+		for _, id := range roleIds {
+			if roleId == id {
+				return c.Next()
+			}
+		}
+		return fiber.NewError(http.StatusForbidden, "no permission to access")
+	}
+}
+
 func (m GoMiddleware) Logger() fiber.Handler {
 	return logger.New(logger.Config{
 		Format:     "👽 ${time} [${ip}] ${status} - ${method} ${path}\n",
@@ -127,11 +246,13 @@ func (m GoMiddleware) setLogByFiber(span opentracing.Span, c *fiber.Ctx) {
 	)
 }
 
+/* setError ใช้ status ตาม ErrorStatus เพราะ ErrorHandler ยังไม่ได้ตั้ง status ของ response ตอนที่ span ถูกปิด */
 func (m GoMiddleware) setError(span opentracing.Span, c *fiber.Ctx, err error) {
-	isError := err != nil && c.Response().StatusCode() >= http.StatusBadRequest
+	status, _, _ := ErrorStatus(err)
+	isError := status >= http.StatusBadRequest
 	span.SetTag("error", isError)
+	span.SetTag("http.status_code", status)
 	if isError {
-		span.SetTag("http.status_code", c.Response().StatusCode())
 		span.LogFields(log.Message(err.Error()))
 	}
 }
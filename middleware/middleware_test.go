@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"encoding/json"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetTracer(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(InitMiddleware(nil, nil).SetTracer())
+	app.Get("/v1/meal-plan/:plan_id", func(c *fiber.Ctx) error {
+		return apperror.NotFound(constants.ERROR_MEAL_PLAN_NOT_FOUND)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/meal-plan/1", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, MIMEApplicationProblemJSON, resp.Header.Get(fiber.HeaderContentType))
+	body := constants.ErrorResponse{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, constants.ErrorResponse{
+		Type:     "/problems/not-found",
+		Title:    "Not Found",
+		Status:   http.StatusNotFound,
+		Detail:   constants.ERROR_MEAL_PLAN_NOT_FOUND,
+		Instance: "/v1/meal-plan/1",
+	}, body)
+}
+
+func TestSetup(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	Setup(app, InitMiddleware(nil, nil))
+	app.Post("/v1/water/:user_id", func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		fields := apperror.Fields{}
+		for _, key := range []string{"amount", "date"} {
+			if _, ok := params[key]; !ok {
+				fields.Missing(key)
+			}
+		}
+		return fields.Err()
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/water/1", strings.NewReader(`{"amount":250}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "th")
+	req.Header.Set(fiber.HeaderXRequestID, "req-1")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, MIMEApplicationProblemJSON, resp.Header.Get(fiber.HeaderContentType))
+	assert.Equal(t, "req-1", resp.Header.Get(fiber.HeaderXRequestID))
+	body := constants.ErrorResponse{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, constants.ErrorResponse{
+		Type:      "/problems/validation",
+		Title:     "Bad Request",
+		Status:    http.StatusBadRequest,
+		Detail:    "คำขอไม่ถูกต้อง",
+		Instance:  "/v1/water/1",
+		RequestId: "req-1",
+		Errors:    []constants.FieldError{{Field: "date", Message: "ไม่พบใน body"}},
+	}, body)
+}
@@ -0,0 +1,138 @@
+package models
+
+import (
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+type Intensity string
+
+const (
+	IntensityLight    Intensity = "LIGHT"
+	IntensityModerate Intensity = "MODERATE"
+	IntensityVigorous Intensity = "VIGOROUS"
+)
+
+var Intensities = []Intensity{IntensityLight, IntensityModerate, IntensityVigorous}
+
+/* Activity ค่า MET ของกิจกรรมแยกตามความหนัก */
+type Activity struct {
+	TableName   struct{}          `json:"-" db:"activities" pk:"Id"`
+	Id          *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	Code        string            `json:"code" db:"code" type:"string"`
+	Name        string            `json:"name" db:"name" type:"string"`
+	METLight    float64           `json:"met_light" db:"met_light" type:"float64"`
+	METModerate float64           `json:"met_moderate" db:"met_moderate" type:"float64"`
+	METVigorous float64           `json:"met_vigorous" db:"met_vigorous" type:"float64"`
+	CreatedAt   *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt   *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+}
+
+func (a *Activity) GetMET(intensity Intensity) float64 {
+	switch intensity {
+	case IntensityLight:
+		return a.METLight
+	case IntensityVigorous:
+		return a.METVigorous
+	default:
+		return a.METModerate
+	}
+}
+
+type ActivityLog struct {
+	TableName       struct{}          `json:"-" db:"activity_logs" pk:"Id"`
+	Id              *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	UserId          *uuid.UUID        `json:"user_id" db:"user_id" type:"uuid"`
+	ActivityId      *uuid.UUID        `json:"activity_id" db:"activity_id" type:"uuid"`
+	Name            string            `json:"name" db:"name" type:"string"`
+	Intensity       Intensity         `json:"intensity" db:"intensity" type:"string"`
+	DurationMinutes float64           `json:"duration_minutes" db:"duration_minutes" type:"float64"`
+	MET             float64           `json:"met" db:"met" type:"float64"`
+	Weight          float64           `json:"weight" db:"weight" type:"float64"`
+	CaloriesBurned  float64           `json:"calories_burned" db:"calories_burned" type:"float64"`
+	Note            string            `json:"note" db:"note" type:"string"`
+	PerformedAt     *helper.Date      `json:"performed_at" db:"performed_at" type:"date"`
+	CreatedAt       *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt       *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+
+	Activity *Activity `json:"activity" db:"-" fk:"fk_field1:ActivityId, fk_field2:Id"`
+}
+
+func NewActivityLogWithParams(params map[string]interface{}, ptr *ActivityLog) *ActivityLog {
+	if ptr == nil {
+		ptr = new(ActivityLog)
+	}
+	for key, val := range params {
+		switch key {
+		case "activity_id":
+			activityId := uuid.FromStringOrNil(cast.ToString(val))
+			ptr.ActivityId = &activityId
+		case "intensity":
+			ptr.Intensity = Intensity(cast.ToString(val))
+		case "duration_minutes":
+			ptr.DurationMinutes = cast.ToFloat64(val)
+		case "note":
+			ptr.Note = cast.ToString(val)
+		case "performed_at":
+			if val != nil {
+				if reflect.TypeOf(val).Kind() == reflect.String {
+					date := helper.NewDateFromString(val.(string))
+					ptr.PerformedAt = &date
+				} else if reflect.TypeOf(val).String() == "time.Time" {
+					date := helper.NewDateFromTime(val.(time.Time))
+					ptr.PerformedAt = &date
+				}
+			}
+		}
+	}
+
+	return ptr
+}
+
+func (a *ActivityLog) NewID() {
+	id := uuid.Must(uuid.NewV4())
+	a.Id = &id
+}
+
+func (a *ActivityLog) SetCreatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	a.CreatedAt = &ti
+}
+
+func (a *ActivityLog) SetUpdatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	a.UpdatedAt = &ti
+}
+
+func (a *ActivityLog) SetPerformedAtIfEmpty() {
+	if a.PerformedAt == nil {
+		date := helper.NewDateFromTime(time.Now())
+		a.PerformedAt = &date
+	}
+}
+
+func (a *ActivityLog) IsIntensity() bool {
+	for index := range Intensities {
+		if Intensities[index] == a.Intensity {
+			return true
+		}
+	}
+	return false
+}
+
+/* SetCaloriesBurned คำนวณพลังงานที่ใช้ไป = MET x น้ำหนัก (kg) x ระยะเวลา (ชั่วโมง) */
+func (a *ActivityLog) SetCaloriesBurned(activity *Activity, weight float64) {
+	if a.Intensity == "" {
+		a.Intensity = IntensityModerate
+	}
+	a.Name = activity.Name
+	a.MET = activity.GetMET(a.Intensity)
+	a.Weight = weight
+	a.CaloriesBurned = math.Round(a.MET*weight*(a.DurationMinutes/60)*100) / 100
+	a.Activity = activity
+}
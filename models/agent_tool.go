@@ -0,0 +1,79 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+/* ชื่อ tool ที่โมเดลเรียกได้ระหว่างแชท */
+const (
+	AgentToolSearchFoods       = "search_foods"
+	AgentToolDiaryTotals       = "get_diary_totals"
+	AgentToolRemainingCalories = "get_remaining_calories"
+	AgentToolUserDiseases      = "get_user_diseases"
+)
+
+/* AgentTool คำอธิบาย tool ที่ส่งให้โมเดล Parameters เป็น JSON schema ของ arguments */
+type AgentTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Parameters  map[string]interface{} `json:"parameters"`
+}
+
+/* AgentToolCall คำขอเรียก tool จากโมเดล Arguments เป็น JSON string ตามที่โมเดลส่งมา */
+type AgentToolCall struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+/* DecodeArguments แปลง arguments เป็น map ถ้าโมเดลไม่ส่งมาถือว่าเป็น object ว่าง */
+func (a *AgentToolCall) DecodeArguments() (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	if a.Arguments == "" {
+		return args, nil
+	}
+	if err := json.Unmarshal([]byte(a.Arguments), &args); err != nil {
+		return nil, fmt.Errorf("%s: invalid arguments: %v", a.Name, err)
+	}
+	return args, nil
+}
+
+/* dateToolParameters arguments ของ tool ที่อ่านข้อมูลรายวัน */
+var dateToolParameters = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"date": map[string]interface{}{"type": "string", "description": "วันที่รูปแบบ YYYY-MM-DD ถ้าไม่ระบุคือวันนี้"},
+	},
+}
+
+/* AgentTools tool ทั้งหมดที่ agent ใช้ตอบคำถามเรื่องโภชนาการด้วยข้อมูลจริงแทนการเดา */
+var AgentTools = []*AgentTool{
+	{
+		Name:        AgentToolSearchFoods,
+		Description: "ค้นหาอาหารในฐานข้อมูลตามชื่อ ได้พลังงานและสารอาหารต่อหนึ่งหน่วยบริโภค",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"query": map[string]interface{}{"type": "string", "description": "ชื่ออาหารหรือบางส่วนของชื่อ"},
+				"limit": map[string]interface{}{"type": "integer", "minimum": 1, "maximum": 10},
+			},
+			"required": []string{"query"},
+		},
+	},
+	{
+		Name:        AgentToolDiaryTotals,
+		Description: "ผลรวมพลังงานและสารอาหารที่ผู้ใช้บันทึกในไดอารี่ของวันที่ระบุ แยกตามมื้อ",
+		Parameters:  dateToolParameters,
+	},
+	{
+		Name:        AgentToolRemainingCalories,
+		Description: "พลังงานที่ผู้ใช้ยังกินได้ในวันที่ระบุ คิดจากเป้าหมาย ที่กินไปแล้ว และที่เผาผลาญจากการออกกำลังกาย",
+		Parameters:  dateToolParameters,
+	},
+	{
+		Name:        AgentToolUserDiseases,
+		Description: "โรคประจำตัวที่ผู้ใช้บันทึกไว้",
+		Parameters:  map[string]interface{}{"type": "object", "properties": map[string]interface{}{}},
+	},
+}
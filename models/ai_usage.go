@@ -0,0 +1,222 @@
+package models
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+type AIUsage struct {
+	TableName        struct{}          `json:"-" db:"ai_usages" pk:"Id"`
+	Id               *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	UserId           *uuid.UUID        `json:"user_id" db:"user_id" type:"uuid"`
+	Endpoint         string            `json:"endpoint" db:"endpoint" type:"string"`
+	Model            string            `json:"model" db:"model" type:"string"`
+	PromptTokens     int               `json:"prompt_tokens" db:"prompt_tokens" type:"int32"`
+	CompletionTokens int               `json:"completion_tokens" db:"completion_tokens" type:"int32"`
+	TotalTokens      int               `json:"total_tokens" db:"total_tokens" type:"int32"`
+	CreatedAt        *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+}
+
+func (a *AIUsage) NewID() {
+	id := uuid.Must(uuid.NewV4())
+	a.Id = &id
+}
+
+func (a *AIUsage) SetCreatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	a.CreatedAt = &ti
+}
+
+/* AIUsageMeter สะสม token ของทุกครั้งที่เรียก LLM ภายใน request เดียว แยกตามโมเดล แล้วส่งให้ onDone ตอนจบ request */
+type AIUsageMeter struct {
+	mu       sync.Mutex
+	once     sync.Once
+	userId   *uuid.UUID
+	endpoint string
+	usages   []*AIUsage
+	deferred bool
+	onDone   func(usages []*AIUsage)
+}
+
+func NewAIUsageMeter(userId *uuid.UUID, endpoint string, onDone func(usages []*AIUsage)) *AIUsageMeter {
+	return &AIUsageMeter{
+		userId:   userId,
+		endpoint: endpoint,
+		onDone:   onDone,
+	}
+}
+
+func (m *AIUsageMeter) Add(model string, promptTokens int, completionTokens int, totalTokens int) {
+	if totalTokens == 0 {
+		totalTokens = promptTokens + completionTokens
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, usage := range m.usages {
+		if usage.Model == model {
+			usage.PromptTokens += promptTokens
+			usage.CompletionTokens += completionTokens
+			usage.TotalTokens += totalTokens
+			return
+		}
+	}
+	m.usages = append(m.usages, &AIUsage{
+		UserId:           m.userId,
+		Endpoint:         m.endpoint,
+		Model:            model,
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		TotalTokens:      totalTokens,
+	})
+}
+
+func (m *AIUsageMeter) Usages() []*AIUsage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*AIUsage(nil), m.usages...)
+}
+
+/* Defer ใช้กับคำตอบแบบ stream ที่ยังเรียก LLM ต่อหลัง handler คืนค่าแล้ว ผู้ที่ stream ต้องเรียก Done เอง */
+func (m *AIUsageMeter) Defer() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deferred = true
+}
+
+func (m *AIUsageMeter) Deferred() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.deferred
+}
+
+func (m *AIUsageMeter) Done() {
+	m.once.Do(func() {
+		if usages := m.Usages(); len(usages) > 0 && m.onDone != nil {
+			m.onDone(usages)
+		}
+	})
+}
+
+type aiUsageMeterKey struct{}
+
+func ContextWithAIUsageMeter(ctx context.Context, meter *AIUsageMeter) context.Context {
+	return context.WithValue(ctx, aiUsageMeterKey{}, meter)
+}
+
+func AIUsageMeterFromContext(ctx context.Context) *AIUsageMeter {
+	meter, _ := ctx.Value(aiUsageMeterKey{}).(*AIUsageMeter)
+	return meter
+}
+
+/* AIQuota โควตา token ต่อ role ค่า nil คือไม่จำกัด */
+type AIQuota struct {
+	TableName         struct{}          `json:"-" db:"ai_quotas" pk:"RoleId"`
+	RoleId            int64             `json:"role_id" db:"role_id" type:"int64"`
+	Role              string            `json:"role" db:"-"`
+	DailyTokenLimit   *int              `json:"daily_token_limit" db:"daily_token_limit" type:"int32"`
+	MonthlyTokenLimit *int              `json:"monthly_token_limit" db:"monthly_token_limit" type:"int32"`
+	UpdatedAt         *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+}
+
+func NewAIQuotaWithParams(params map[string]interface{}, ptr *AIQuota) *AIQuota {
+	if ptr == nil {
+		ptr = new(AIQuota)
+	}
+	for key, val := range params {
+		switch key {
+		case "daily_token_limit":
+			ptr.DailyTokenLimit = tokenLimit(val)
+		case "monthly_token_limit":
+			ptr.MonthlyTokenLimit = tokenLimit(val)
+		}
+	}
+
+	return ptr
+}
+
+/* tokenLimit ค่าว่างหรือ null หมายถึงไม่จำกัด */
+func tokenLimit(val interface{}) *int {
+	if val == nil || val == "" {
+		return nil
+	}
+	limit := cast.ToInt(val)
+	return &limit
+}
+
+func (a *AIQuota) SetUpdatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	a.UpdatedAt = &ti
+}
+
+/* AIQuotaStatus การใช้ token ของผู้ใช้เทียบกับโควตาของ role */
+type AIQuotaStatus struct {
+	UserId            *uuid.UUID        `json:"user_id"`
+	RoleId            int64             `json:"role_id"`
+	DailyUsed         int               `json:"daily_used"`
+	DailyTokenLimit   *int              `json:"daily_token_limit"`
+	DailyResetAt      *helper.Timestamp `json:"daily_reset_at"`
+	MonthlyUsed       int               `json:"monthly_used"`
+	MonthlyTokenLimit *int              `json:"monthly_token_limit"`
+	MonthlyResetAt    *helper.Timestamp `json:"monthly_reset_at"`
+	Exceeded          bool              `json:"exceeded"`
+	ResetAt           *helper.Timestamp `json:"reset_at,omitempty"`
+}
+
+/* NewAIQuotaStatus โควตารายวันรีเซ็ตตอนเที่ยงคืน รายเดือนรีเซ็ตวันที่ 1 ถ้าเกินทั้งสองแบบ reset_at คือเวลาที่ช้ากว่า */
+func NewAIQuotaStatus(userId *uuid.UUID, quota *AIQuota, dailyUsed int, monthlyUsed int, now time.Time) *AIQuotaStatus {
+	dayStart, monthStart := QuotaPeriodStarts(now)
+	dailyReset := helper.NewTimestampFromTime(dayStart.AddDate(0, 0, 1))
+	monthlyReset := helper.NewTimestampFromTime(monthStart.AddDate(0, 1, 0))
+	status := &AIQuotaStatus{
+		UserId:         userId,
+		RoleId:         quota.RoleId,
+		DailyUsed:      dailyUsed,
+		DailyResetAt:   &dailyReset,
+		MonthlyUsed:    monthlyUsed,
+		MonthlyResetAt: &monthlyReset,
+	}
+	status.DailyTokenLimit = quota.DailyTokenLimit
+	status.MonthlyTokenLimit = quota.MonthlyTokenLimit
+
+	if quota.DailyTokenLimit != nil && dailyUsed >= *quota.DailyTokenLimit {
+		status.Exceeded = true
+		status.ResetAt = &dailyReset
+	}
+	if quota.MonthlyTokenLimit != nil && monthlyUsed >= *quota.MonthlyTokenLimit {
+		status.Exceeded = true
+		status.ResetAt = &monthlyReset
+	}
+	return status
+}
+
+/* QuotaPeriodStarts เวลาเริ่มของวันและเดือนปัจจุบันตาม timezone ของ now */
+func QuotaPeriodStarts(now time.Time) (time.Time, time.Time) {
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	return dayStart, monthStart
+}
+
+type AIUsageDailyReport struct {
+	Date             *helper.Date `json:"date" db:"date" type:"date"`
+	Endpoint         string       `json:"endpoint" db:"endpoint" type:"string"`
+	Model            string       `json:"model" db:"model" type:"string"`
+	Requests         int          `json:"requests" db:"requests" type:"int32"`
+	PromptTokens     int          `json:"prompt_tokens" db:"prompt_tokens" type:"int32"`
+	CompletionTokens int          `json:"completion_tokens" db:"completion_tokens" type:"int32"`
+	TotalTokens      int          `json:"total_tokens" db:"total_tokens" type:"int32"`
+}
+
+type AIUsageUserReport struct {
+	UserId           *uuid.UUID `json:"user_id" db:"user_id" type:"uuid"`
+	Username         string     `json:"username" db:"username" type:"string"`
+	Email            string     `json:"email" db:"email" type:"string"`
+	Requests         int        `json:"requests" db:"requests" type:"int32"`
+	PromptTokens     int        `json:"prompt_tokens" db:"prompt_tokens" type:"int32"`
+	CompletionTokens int        `json:"completion_tokens" db:"completion_tokens" type:"int32"`
+	TotalTokens      int        `json:"total_tokens" db:"total_tokens" type:"int32"`
+}
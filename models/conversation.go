@@ -0,0 +1,125 @@
+package models
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+type ChatRole string
+
+const (
+	ChatRoleUser      ChatRole = "USER"
+	ChatRoleAssistant ChatRole = "ASSISTANT"
+)
+
+const (
+	DEFAULT_CONVERSATION_TITLE    = "บทสนทนาใหม่"
+	MAX_CONVERSATION_TITLE_LENGTH = 50
+)
+
+type Conversation struct {
+	TableName       struct{}               `json:"-" db:"conversations" pk:"Id"`
+	Id              *uuid.UUID             `json:"id" db:"id" type:"uuid"`
+	UserId          *uuid.UUID             `json:"user_id" db:"user_id" type:"uuid"`
+	Title           string                 `json:"title" db:"title" type:"string"`
+	Summary         string                 `json:"summary" db:"summary" type:"string"`
+	SummarizedCount int                    `json:"summarized_count" db:"summarized_count" type:"int"`
+	Messages        []*ConversationMessage `json:"messages,omitempty" db:"-"`
+	CreatedAt       *helper.Timestamp      `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt       *helper.Timestamp      `json:"updated_at" db:"updated_at" type:"timestamp"`
+}
+
+func NewConversationWithParams(params map[string]interface{}, ptr *Conversation) *Conversation {
+	if ptr == nil {
+		ptr = new(Conversation)
+	}
+	for key, val := range params {
+		switch key {
+		case "title":
+			ptr.Title = strings.TrimSpace(cast.ToString(val))
+		}
+	}
+
+	return ptr
+}
+
+func (c *Conversation) NewID() {
+	id := uuid.Must(uuid.NewV4())
+	c.Id = &id
+}
+
+func (c *Conversation) SetCreatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	c.CreatedAt = &ti
+}
+
+func (c *Conversation) SetUpdatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	c.UpdatedAt = &ti
+}
+
+/* SetTitleIfEmpty ตั้งชื่อบทสนทนาจากข้อความแรก ตัดให้ไม่เกิน MAX_CONVERSATION_TITLE_LENGTH ตัวอักษร */
+func (c *Conversation) SetTitleIfEmpty(firstMessage string) {
+	if c.Title != "" {
+		return
+	}
+	title := strings.Join(strings.Fields(firstMessage), " ")
+	if title == "" {
+		c.Title = DEFAULT_CONVERSATION_TITLE
+		return
+	}
+	if utf8.RuneCountInString(title) > MAX_CONVERSATION_TITLE_LENGTH {
+		title = string([]rune(title)[:MAX_CONVERSATION_TITLE_LENGTH]) + "…"
+	}
+	c.Title = title
+}
+
+/* SplitHistory แบ่งข้อความที่ยังไม่ถูกสรุปเป็นส่วนที่ต้องสรุปเพิ่มกับส่วนที่ส่งให้โมเดลตรง ๆ จะสรุปเมื่อเกิน threshold และเก็บ window ข้อความล่าสุดไว้เสมอ */
+func (c *Conversation) SplitHistory(messages []*ConversationMessage, window int, threshold int) ([]*ConversationMessage, []*ConversationMessage) {
+	start := c.SummarizedCount
+	if start > len(messages) {
+		start = len(messages)
+	}
+	unsummarized := messages[start:]
+	if len(unsummarized) <= threshold || len(unsummarized) <= window {
+		return nil, unsummarized
+	}
+	cut := len(unsummarized) - window
+	return unsummarized[:cut], unsummarized[cut:]
+}
+
+type ConversationMessage struct {
+	TableName      struct{}             `json:"-" db:"conversation_messages" pk:"Id"`
+	Id             *uuid.UUID           `json:"id" db:"id" type:"uuid"`
+	ConversationId *uuid.UUID           `json:"conversation_id" db:"conversation_id" type:"uuid"`
+	Role           ChatRole             `json:"role" db:"role" type:"string"`
+	Content        string               `json:"content" db:"content" type:"string"`
+	Citations      []*KnowledgeCitation `json:"citations,omitempty" db:"citations"`
+	CreatedAt      *helper.Timestamp    `json:"created_at" db:"created_at" type:"timestamp"`
+}
+
+func NewConversationMessage(conversationId *uuid.UUID, role ChatRole, content string) *ConversationMessage {
+	message := &ConversationMessage{
+		ConversationId: conversationId,
+		Role:           role,
+		Content:        content,
+	}
+	message.NewID()
+	message.SetCreatedAt()
+	return message
+}
+
+func (m *ConversationMessage) NewID() {
+	id := uuid.Must(uuid.NewV4())
+	m.Id = &id
+}
+
+func (m *ConversationMessage) SetCreatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	m.CreatedAt = &ti
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+)
+
+type Disease struct {
+	TableName   struct{}          `json:"-" db:"diseases" pk:"Id"`
+	Id          *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	Name        string            `json:"name" db:"name" type:"string"`
+	Description string            `json:"description" db:"description" type:"string"`
+	CreatedAt   *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt   *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+}
@@ -0,0 +1,185 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+/* ค่าความคลาดเคลื่อนของพลังงานต่อวันเทียบกับ CaloriesLimit เมื่อ case ไม่ได้กำหนด */
+const EVAL_CALORIES_TOLERANCE = 0.1
+
+/* ชื่อ assertion ในรายงานการประเมิน */
+const (
+	EvalCheckValidJSON    = "valid_json"
+	EvalCheckCalories     = "calories"
+	EvalCheckAllergens    = "no_allergens"
+	EvalCheckDiseaseRules = "disease_rules"
+)
+
+/* EvalSuite ชุดโปรไฟล์ผู้ใช้ที่บันทึกไว้ ใช้ประเมิน prompt และโมเดลแบบ offline ก่อนเปลี่ยนจริง */
+type EvalSuite struct {
+	Name  string      `json:"name"`
+	Cases []*EvalCase `json:"cases"`
+}
+
+/* EvalCase โปรไฟล์หนึ่งคนพร้อมกฎโรคประจำตัวที่ใช้ตรวจ Responses คือคำตอบของโมเดลที่บันทึกไว้ ใช้เมื่อรันแบบ fixture ตามลำดับการเรียก */
+type EvalCase struct {
+	Name              string          `json:"name"`
+	UserInfo          *UserInfo       `json:"user_info"`
+	Option            *MealPlanOption `json:"option"`
+	Rules             []*DiseaseRule  `json:"rules"`
+	CaloriesTolerance float64         `json:"calories_tolerance"`
+	Responses         []string        `json:"responses"`
+}
+
+/* DecodeEvalSuite อ่าน suite จาก JSON ชื่อ case ต้องไม่ซ้ำเพื่อให้เทียบรายงานระหว่างรอบได้ */
+func DecodeEvalSuite(content []byte) (*EvalSuite, error) {
+	suite := new(EvalSuite)
+	if err := json.Unmarshal(content, suite); err != nil {
+		return nil, fmt.Errorf("invalid suite: %v", err)
+	}
+	if len(suite.Cases) == 0 {
+		return nil, errors.New("invalid suite: cases must not be empty")
+	}
+	names := make(map[string]bool)
+	for index, evalCase := range suite.Cases {
+		if evalCase == nil || strings.TrimSpace(evalCase.Name) == "" {
+			return nil, fmt.Errorf("invalid suite: cases[%d].name must not be empty", index)
+		}
+		if names[evalCase.Name] {
+			return nil, fmt.Errorf("invalid suite: case %s is duplicated", evalCase.Name)
+		}
+		names[evalCase.Name] = true
+		if evalCase.UserInfo == nil {
+			return nil, fmt.Errorf("invalid suite: case %s has no user_info", evalCase.Name)
+		}
+		if evalCase.Option == nil {
+			evalCase.Option = &MealPlanOption{}
+		}
+		if evalCase.Option.Days <= 0 {
+			evalCase.Option.Days = DEFAULT_MEAL_PLAN_DAYS
+		}
+		if evalCase.CaloriesTolerance <= 0 {
+			evalCase.CaloriesTolerance = EVAL_CALORIES_TOLERANCE
+		}
+	}
+	return suite, nil
+}
+
+type EvalCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail,omitempty"`
+}
+
+/* EvalResult ผลของ case หนึ่ง Attempts คือจำนวนครั้งที่เรียกโมเดลรวมรอบที่ให้แก้คำตอบ */
+type EvalResult struct {
+	Case          string       `json:"case"`
+	Passed        bool         `json:"passed"`
+	Attempts      int          `json:"attempts"`
+	Model         string       `json:"model,omitempty"`
+	PromptVersion string       `json:"prompt_version,omitempty"`
+	Error         string       `json:"error,omitempty"`
+	Checks        []*EvalCheck `json:"checks"`
+}
+
+/* EvaluateMealPlan ตรวจแผนที่ได้จาก agent ตาม assertion ของ case ถ้าสร้างแผนไม่สำเร็จถือว่าไม่ผ่าน valid_json และไม่ตรวจข้ออื่น */
+func EvaluateMealPlan(evalCase *EvalCase, plan *MealPlan, err error) *EvalResult {
+	result := &EvalResult{Case: evalCase.Name, Checks: make([]*EvalCheck, 0)}
+	if err != nil {
+		result.Error = err.Error()
+		result.Checks = append(result.Checks, &EvalCheck{Name: EvalCheckValidJSON, Detail: err.Error()})
+		return result
+	}
+	result.Model = plan.Model
+	result.PromptVersion = plan.PromptVersion
+	result.Checks = append(result.Checks, &EvalCheck{Name: EvalCheckValidJSON, Passed: true})
+
+	plan.CalculateTotals()
+	userInfo := evalCase.UserInfo
+	for _, day := range plan.Days {
+		check := &EvalCheck{Name: fmt.Sprintf("%s day %d", EvalCheckCalories, day.Day), Passed: true}
+		if userInfo.CaloriesLimit > 0 {
+			tolerance := userInfo.CaloriesLimit * evalCase.CaloriesTolerance
+			check.Passed = math.Abs(day.Total.Calories-userInfo.CaloriesLimit) <= tolerance
+			check.Detail = fmt.Sprintf("%.0f kcal, target %.0f ± %.0f", day.Total.Calories, userInfo.CaloriesLimit, tolerance)
+		}
+		result.Checks = append(result.Checks, check)
+	}
+
+	allergens := make([]string, 0)
+	for _, day := range plan.Days {
+		for _, meal := range day.Meals {
+			for _, item := range meal.Items {
+				for _, allergy := range userInfo.GetFoodPreferences(FoodPreferenceAllergy) {
+					if containsFold(item.Name, allergy) || containsFold(meal.Name, allergy) {
+						allergens = append(allergens, fmt.Sprintf("day %d %s: %s", day.Day, item.Name, allergy))
+					}
+				}
+			}
+		}
+	}
+	result.Checks = append(result.Checks, &EvalCheck{Name: EvalCheckAllergens, Passed: len(allergens) == 0, Detail: strings.Join(allergens, "; ")})
+
+	violations := CheckMealPlan(plan, evalCase.Rules, userInfo.Weight)
+	details := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		details = append(details, violation.String())
+	}
+	result.Checks = append(result.Checks, &EvalCheck{Name: EvalCheckDiseaseRules, Passed: len(violations) == 0, Detail: strings.Join(details, "; ")})
+
+	result.Passed = true
+	for _, check := range result.Checks {
+		result.Passed = result.Passed && check.Passed
+	}
+	return result
+}
+
+/* EvalReport รายงานของทั้ง suite ไม่มีเวลาหรือค่าที่เปลี่ยนทุกรอบ จึง diff รายงานระหว่าง prompt หรือโมเดลคนละเวอร์ชันได้ตรง ๆ */
+type EvalReport struct {
+	Suite    string        `json:"suite"`
+	Provider string        `json:"provider"`
+	Passed   int           `json:"passed"`
+	Failed   int           `json:"failed"`
+	Results  []*EvalResult `json:"results"`
+}
+
+func NewEvalReport(suite *EvalSuite, provider string, results []*EvalResult) *EvalReport {
+	report := &EvalReport{Suite: suite.Name, Provider: provider, Results: results}
+	for _, result := range results {
+		if result.Passed {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+	}
+	return report
+}
+
+/* Text รายงานแบบหนึ่งบรรทัดต่อหนึ่ง assertion เรียงตามลำดับ case ใน suite */
+func (r *EvalReport) Text() string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "suite: %s\nprovider: %s\n", r.Suite, r.Provider)
+	for _, result := range r.Results {
+		fmt.Fprintf(&text, "\n%s %s attempts=%d model=%s prompt=%s\n", evalStatus(result.Passed), result.Case, result.Attempts, result.Model, result.PromptVersion)
+		for _, check := range result.Checks {
+			fmt.Fprintf(&text, "  %s %s", evalStatus(check.Passed), check.Name)
+			if check.Detail != "" {
+				fmt.Fprintf(&text, ": %s", check.Detail)
+			}
+			text.WriteString("\n")
+		}
+	}
+	fmt.Fprintf(&text, "\npassed %d, failed %d\n", r.Passed, r.Failed)
+	return text.String()
+}
+
+func evalStatus(passed bool) string {
+	if passed {
+		return "PASS"
+	}
+	return "FAIL"
+}
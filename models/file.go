@@ -7,6 +7,7 @@ import (
 	"mime/multipart"
 
 	"cloud.google.com/go/storage"
+	"github.com/gofrs/uuid"
 )
 
 type FileReq struct {
@@ -24,14 +25,18 @@ type FileResp struct {
 type FilesResp []*FileResp
 
 func (f FilesResp) GetImagesFromFilesResp(user *User) []*Image {
+	return f.GetImagesWithRef(user.Id, constants.REF_TYPE_USER)
+}
+
+func (f FilesResp) GetImagesWithRef(refId *uuid.UUID, refType string) []*Image {
 	images := make([]*Image, 0)
 	for index := range f {
 		image := new(Image)
 		image.NewUUID()
 		image.FileName = f[index].FileName
 		image.URL = f[index].Url
-		image.RefId = user.Id
-		image.RefType = constants.REF_TYPE_USER
+		image.RefId = refId
+		image.RefType = refType
 		image.SetCreatedAt()
 		image.SetUpdatedAt()
 		images = append(images, image)
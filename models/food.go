@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+)
+
+/* Food ค่าโภชนาการของอาหารคิดต่อ 100 กรัม */
+type Food struct {
+	TableName    struct{}          `json:"-" db:"foods" pk:"Id"`
+	Id           *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	Name         string            `json:"name" db:"name" type:"string"`
+	ServingSize  float64           `json:"serving_size" db:"serving_size" type:"float64"`
+	ServingUnit  string            `json:"serving_unit" db:"serving_unit" type:"string"`
+	Calories     float64           `json:"calories" db:"calories" type:"float64"`
+	Protein      float64           `json:"protein" db:"protein" type:"float64"`
+	Carbohydrate float64           `json:"carbohydrate" db:"carbohydrate" type:"float64"`
+	Fat          float64           `json:"fat" db:"fat" type:"float64"`
+	CreatedAt    *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt    *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+}
+
+func (f *Food) NewID() {
+	id := uuid.Must(uuid.NewV4())
+	f.Id = &id
+}
+
+func (f *Food) SetCreatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	f.CreatedAt = &ti
+}
+
+func (f *Food) SetUpdatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	f.UpdatedAt = &ti
+}
+
+/* GetNutrition คำนวณค่าโภชนาการตามน้ำหนักอาหารเป็นกรัม */
+func (f *Food) GetNutrition(grams float64) *Nutrition {
+	ratio := grams / 100
+	return &Nutrition{
+		Calories:     f.Calories * ratio,
+		Protein:      f.Protein * ratio,
+		Carbohydrate: f.Carbohydrate * ratio,
+		Fat:          f.Fat * ratio,
+	}
+}
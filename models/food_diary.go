@@ -0,0 +1,277 @@
+package models
+
+import (
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+type MealType string
+
+const (
+	MealTypeBreakfast MealType = "BREAKFAST"
+	MealTypeLunch     MealType = "LUNCH"
+	MealTypeDinner    MealType = "DINNER"
+	MealTypeSnack     MealType = "SNACK"
+)
+
+var MealTypes = []MealType{MealTypeBreakfast, MealTypeLunch, MealTypeDinner, MealTypeSnack}
+
+/* สัดส่วนพลังงานจากสารอาหารหลัก (คาร์โบไฮเดรต 50%, โปรตีน 20%, ไขมัน 30%) */
+const (
+	CARBOHYDRATE_ENERGY_RATIO = 0.5
+	PROTEIN_ENERGY_RATIO      = 0.2
+	FAT_ENERGY_RATIO          = 0.3
+)
+
+type Nutrition struct {
+	Calories     float64 `json:"calories"`
+	Protein      float64 `json:"protein"`
+	Carbohydrate float64 `json:"carbohydrate"`
+	Fat          float64 `json:"fat"`
+}
+
+func (n *Nutrition) Add(i *Nutrition) {
+	n.Calories += i.Calories
+	n.Protein += i.Protein
+	n.Carbohydrate += i.Carbohydrate
+	n.Fat += i.Fat
+}
+
+func (n *Nutrition) Sub(i *Nutrition) *Nutrition {
+	return &Nutrition{
+		Calories:     n.Calories - i.Calories,
+		Protein:      n.Protein - i.Protein,
+		Carbohydrate: n.Carbohydrate - i.Carbohydrate,
+		Fat:          n.Fat - i.Fat,
+	}
+}
+
+func (n *Nutrition) Round() {
+	n.Calories = math.Round(n.Calories*100) / 100
+	n.Protein = math.Round(n.Protein*100) / 100
+	n.Carbohydrate = math.Round(n.Carbohydrate*100) / 100
+	n.Fat = math.Round(n.Fat*100) / 100
+}
+
+/* NewNutritionTarget แบ่งพลังงานต่อวันเป็นกรัมของสารอาหารหลัก */
+func NewNutritionTarget(calories float64) *Nutrition {
+	target := &Nutrition{
+		Calories:     calories,
+		Protein:      calories * PROTEIN_ENERGY_RATIO / 4,
+		Carbohydrate: calories * CARBOHYDRATE_ENERGY_RATIO / 4,
+		Fat:          calories * FAT_ENERGY_RATIO / 9,
+	}
+	target.Round()
+	return target
+}
+
+type FoodDiary struct {
+	TableName    struct{}          `json:"-" db:"food_diaries" pk:"Id"`
+	Id           *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	UserId       *uuid.UUID        `json:"user_id" db:"user_id" type:"uuid"`
+	FoodId       *uuid.UUID        `json:"food_id" db:"food_id" type:"uuid"`
+	RecipeId     *uuid.UUID        `json:"recipe_id" db:"recipe_id" type:"uuid"`
+	Name         string            `json:"name" db:"name" type:"string"`
+	MealType     MealType          `json:"meal_type" db:"meal_type" type:"string"`
+	Quantity     float64           `json:"quantity" db:"quantity" type:"float64"`
+	Unit         string            `json:"unit" db:"unit" type:"string"`
+	Calories     float64           `json:"calories" db:"calories" type:"float64"`
+	Protein      float64           `json:"protein" db:"protein" type:"float64"`
+	Carbohydrate float64           `json:"carbohydrate" db:"carbohydrate" type:"float64"`
+	Fat          float64           `json:"fat" db:"fat" type:"float64"`
+	Note         string            `json:"note" db:"note" type:"string"`
+	EatenAt      *helper.Date      `json:"eaten_at" db:"eaten_at" type:"date"`
+	CreatedAt    *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt    *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+
+	Food *Food `json:"food" db:"-" fk:"fk_field1:FoodId, fk_field2:Id"`
+}
+
+func NewFoodDiaryWithParams(params map[string]interface{}, ptr *FoodDiary) *FoodDiary {
+	if ptr == nil {
+		ptr = new(FoodDiary)
+	}
+	for key, val := range params {
+		switch key {
+		case "food_id":
+			if val == nil || cast.ToString(val) == "" {
+				ptr.FoodId = nil
+				continue
+			}
+			foodId := uuid.FromStringOrNil(cast.ToString(val))
+			ptr.FoodId = &foodId
+			ptr.RecipeId = nil
+		case "recipe_id":
+			if val == nil || cast.ToString(val) == "" {
+				ptr.RecipeId = nil
+				continue
+			}
+			recipeId := uuid.FromStringOrNil(cast.ToString(val))
+			ptr.RecipeId = &recipeId
+			ptr.FoodId = nil
+			ptr.Food = nil
+		case "name":
+			ptr.Name = cast.ToString(val)
+		case "meal_type":
+			ptr.MealType = MealType(cast.ToString(val))
+		case "quantity":
+			ptr.Quantity = cast.ToFloat64(val)
+		case "unit":
+			ptr.Unit = cast.ToString(val)
+		case "calories":
+			ptr.Calories = cast.ToFloat64(val)
+		case "protein":
+			ptr.Protein = cast.ToFloat64(val)
+		case "carbohydrate":
+			ptr.Carbohydrate = cast.ToFloat64(val)
+		case "fat":
+			ptr.Fat = cast.ToFloat64(val)
+		case "note":
+			ptr.Note = cast.ToString(val)
+		case "eaten_at":
+			if val != nil {
+				if reflect.TypeOf(val).Kind() == reflect.String {
+					date := helper.NewDateFromString(val.(string))
+					ptr.EatenAt = &date
+				} else if reflect.TypeOf(val).String() == "time.Time" {
+					date := helper.NewDateFromTime(val.(time.Time))
+					ptr.EatenAt = &date
+				}
+			}
+		}
+	}
+
+	return ptr
+}
+
+func (d *FoodDiary) NewID() {
+	id := uuid.Must(uuid.NewV4())
+	d.Id = &id
+}
+
+func (d *FoodDiary) SetCreatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	d.CreatedAt = &ti
+}
+
+func (d *FoodDiary) SetUpdatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	d.UpdatedAt = &ti
+}
+
+func (d *FoodDiary) SetEatenAtIfEmpty() {
+	if d.EatenAt == nil {
+		date := helper.NewDateFromTime(time.Now())
+		d.EatenAt = &date
+	}
+}
+
+func (d *FoodDiary) IsMealType() bool {
+	for index := range MealTypes {
+		if MealTypes[index] == d.MealType {
+			return true
+		}
+	}
+	return false
+}
+
+/* SetNutritionFromFood คำนวณค่าโภชนาการของรายการจากข้อมูลอาหารในระบบ */
+func (d *FoodDiary) SetNutritionFromFood(food *Food) {
+	if d.Unit == "" {
+		d.Unit = UNIT_GRAM
+	}
+	if d.Name == "" {
+		d.Name = food.Name
+	}
+	nutrition := food.GetNutrition(ConvertToGrams(d.Quantity, d.Unit, food))
+	nutrition.Round()
+	d.SetNutrition(nutrition)
+	d.Food = food
+}
+
+/* SetNutritionFromRecipe คำนวณค่าโภชนาการจากจำนวนที่เสิร์ฟของสูตรอาหาร */
+func (d *FoodDiary) SetNutritionFromRecipe(recipe *Recipe) {
+	d.Unit = UNIT_SERVING
+	if d.Name == "" {
+		d.Name = recipe.Name
+	}
+	nutrition := &Nutrition{
+		Calories:     recipe.Calories * d.Quantity,
+		Protein:      recipe.Protein * d.Quantity,
+		Carbohydrate: recipe.Carbohydrate * d.Quantity,
+		Fat:          recipe.Fat * d.Quantity,
+	}
+	nutrition.Round()
+	d.SetNutrition(nutrition)
+}
+
+func (d *FoodDiary) SetNutrition(n *Nutrition) {
+	d.Calories = n.Calories
+	d.Protein = n.Protein
+	d.Carbohydrate = n.Carbohydrate
+	d.Fat = n.Fat
+}
+
+func (d *FoodDiary) GetNutrition() *Nutrition {
+	return &Nutrition{
+		Calories:     d.Calories,
+		Protein:      d.Protein,
+		Carbohydrate: d.Carbohydrate,
+		Fat:          d.Fat,
+	}
+}
+
+type DailySummary struct {
+	UserId         *uuid.UUID              `json:"user_id"`
+	Date           *helper.Date            `json:"date"`
+	CaloriesLimit  float64                 `json:"calories_limit"`
+	Target         *Nutrition              `json:"target"`
+	Consumed       *Nutrition              `json:"consumed"`
+	CaloriesBurned float64                 `json:"calories_burned"`
+	Remaining      *Nutrition              `json:"remaining"`
+	Meals          map[MealType]*Nutrition `json:"meals"`
+	Entries        []*FoodDiary            `json:"entries"`
+	Activities     []*ActivityLog          `json:"activities"`
+}
+
+/* NewDailySummary รวมค่าโภชนาการของรายการอาหารทั้งวันเทียบกับพลังงานที่ควรได้รับ พลังงานจากการออกกำลังกายจะบวกคืนให้ในส่วนที่เหลือ */
+func NewDailySummary(userId *uuid.UUID, date *helper.Date, caloriesLimit float64, entries []*FoodDiary, activities []*ActivityLog) *DailySummary {
+	summary := &DailySummary{
+		UserId:        userId,
+		Date:          date,
+		CaloriesLimit: math.Round(caloriesLimit*100) / 100,
+		Target:        NewNutritionTarget(caloriesLimit),
+		Consumed:      new(Nutrition),
+		Meals:         make(map[MealType]*Nutrition),
+		Entries:       entries,
+		Activities:    activities,
+	}
+	for index := range MealTypes {
+		summary.Meals[MealTypes[index]] = new(Nutrition)
+	}
+	for index := range entries {
+		nutrition := entries[index].GetNutrition()
+		summary.Consumed.Add(nutrition)
+		if meal, ok := summary.Meals[entries[index].MealType]; ok {
+			meal.Add(nutrition)
+		}
+	}
+	summary.Consumed.Round()
+	for index := range summary.Meals {
+		summary.Meals[index].Round()
+	}
+	for index := range activities {
+		summary.CaloriesBurned += activities[index].CaloriesBurned
+	}
+	summary.CaloriesBurned = math.Round(summary.CaloriesBurned*100) / 100
+	summary.Remaining = summary.Target.Sub(summary.Consumed)
+	summary.Remaining.Calories += summary.CaloriesBurned
+	summary.Remaining.Round()
+
+	return summary
+}
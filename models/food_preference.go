@@ -0,0 +1,80 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+type FoodPreferenceType string
+
+const (
+	FoodPreferenceLike    FoodPreferenceType = "LIKE"
+	FoodPreferenceDislike FoodPreferenceType = "DISLIKE"
+	FoodPreferenceAllergy FoodPreferenceType = "ALLERGY"
+)
+
+/* key ของ params ที่รับรายการชื่ออาหารแต่ละประเภท */
+var FoodPreferenceParams = map[string]FoodPreferenceType{
+	"likes":     FoodPreferenceLike,
+	"dislikes":  FoodPreferenceDislike,
+	"allergies": FoodPreferenceAllergy,
+}
+
+type FoodPreference struct {
+	TableName      struct{}           `json:"-" db:"user_food_preferences" pk:"Id"`
+	Id             *uuid.UUID         `json:"id" db:"id" type:"uuid"`
+	UserInfoId     *uuid.UUID         `json:"user_info_id" db:"user_info_id" type:"uuid"`
+	Name           string             `json:"name" db:"name" type:"string"`
+	PreferenceType FoodPreferenceType `json:"preference_type" db:"preference_type" type:"string"`
+	CreatedAt      *helper.Timestamp  `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt      *helper.Timestamp  `json:"updated_at" db:"updated_at" type:"timestamp"`
+}
+
+/* NewFoodPreferencesWithParams แปลง likes, dislikes, allergies เป็นรายการ ตัดช่องว่างและชื่อซ้ำในประเภทเดียวกันออก */
+func NewFoodPreferencesWithParams(params map[string]interface{}, userInfoId *uuid.UUID) []*FoodPreference {
+	preferences := make([]*FoodPreference, 0)
+	for key, preferenceType := range FoodPreferenceParams {
+		val, ok := params[key]
+		if !ok {
+			continue
+		}
+		seen := map[string]bool{}
+		for _, name := range cast.ToStringSlice(val) {
+			name = strings.TrimSpace(name)
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			preference := &FoodPreference{
+				UserInfoId:     userInfoId,
+				Name:           name,
+				PreferenceType: preferenceType,
+			}
+			preference.NewID()
+			preference.SetCreatedAt()
+			preference.SetUpdatedAt()
+			preferences = append(preferences, preference)
+		}
+	}
+
+	return preferences
+}
+
+func (f *FoodPreference) NewID() {
+	id := uuid.Must(uuid.NewV4())
+	f.Id = &id
+}
+
+func (f *FoodPreference) SetCreatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	f.CreatedAt = &ti
+}
+
+func (f *FoodPreference) SetUpdatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	f.UpdatedAt = &ti
+}
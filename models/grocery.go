@@ -0,0 +1,349 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+/* GroceryCategory หมวดในร้านที่ใช้จัดกลุ่มรายการซื้อของ */
+type GroceryCategory string
+
+const (
+	GroceryCategoryProduce    GroceryCategory = "PRODUCE"
+	GroceryCategoryMeat       GroceryCategory = "MEAT"
+	GroceryCategorySeafood    GroceryCategory = "SEAFOOD"
+	GroceryCategoryDairyEggs  GroceryCategory = "DAIRY_EGGS"
+	GroceryCategoryGrains     GroceryCategory = "GRAINS"
+	GroceryCategoryCondiments GroceryCategory = "CONDIMENTS"
+	GroceryCategoryBeverages  GroceryCategory = "BEVERAGES"
+	GroceryCategoryOther      GroceryCategory = "OTHER"
+)
+
+/* GroceryCategories เรียงตามลำดับการเดินในร้าน */
+var GroceryCategories = []GroceryCategory{
+	GroceryCategoryProduce,
+	GroceryCategoryMeat,
+	GroceryCategorySeafood,
+	GroceryCategoryDairyEggs,
+	GroceryCategoryGrains,
+	GroceryCategoryCondiments,
+	GroceryCategoryBeverages,
+	GroceryCategoryOther,
+}
+
+var groceryCategoryLabels = map[GroceryCategory]string{
+	GroceryCategoryProduce:    "ผักและผลไม้",
+	GroceryCategoryMeat:       "เนื้อสัตว์",
+	GroceryCategorySeafood:    "อาหารทะเล",
+	GroceryCategoryDairyEggs:  "นมและไข่",
+	GroceryCategoryGrains:     "ข้าว แป้ง และเส้น",
+	GroceryCategoryCondiments: "เครื่องปรุง",
+	GroceryCategoryBeverages:  "เครื่องดื่ม",
+	GroceryCategoryOther:      "อื่นๆ",
+}
+
+func (g GroceryCategory) Label() string {
+	if label, ok := groceryCategoryLabels[g]; ok {
+		return label
+	}
+	return groceryCategoryLabels[GroceryCategoryOther]
+}
+
+/* groceryCategoryKeywords ตรวจตามลำดับ เครื่องปรุงมาก่อนเพราะชื่ออย่างน้ำปลาหรือน้ำมันหอยมีคำของหมวดอื่นปนอยู่ */
+var groceryCategoryKeywords = []struct {
+	category GroceryCategory
+	keywords []string
+}{
+	{GroceryCategoryCondiments, []string{"น้ำปลา", "ซีอิ๊ว", "ซอส", "น้ำมัน", "น้ำตาล", "เกลือ", "พริกไทย", "น้ำส้มสายชู", "กะปิ", "ผงปรุง", "ซุปก้อน", "เต้าเจี้ยว", "sauce", "oil", "sugar", "salt", "pepper", "vinegar"}},
+	{GroceryCategoryBeverages, []string{"น้ำเปล่า", "น้ำผลไม้", "กาแฟ", "ชาเขียว", "ชาดำ", "water", "juice", "coffee", "tea"}},
+	{GroceryCategorySeafood, []string{"กุ้ง", "ปลา", "หมึก", "หอย", "ปู", "shrimp", "prawn", "fish", "salmon", "tuna", "squid", "crab", "shellfish"}},
+	{GroceryCategoryMeat, []string{"ไก่", "หมู", "เนื้อ", "เป็ด", "ไส้กรอก", "แฮม", "เบคอน", "chicken", "pork", "beef", "duck", "sausage", "ham", "bacon"}},
+	{GroceryCategoryDairyEggs, []string{"ไข่", "นม", "โยเกิร์ต", "ชีส", "เนย", "egg", "milk", "yogurt", "cheese", "butter"}},
+	{GroceryCategoryGrains, []string{"ข้าว", "เส้น", "ก๋วยเตี๋ยว", "บะหมี่", "ขนมปัง", "แป้ง", "โอ๊ต", "rice", "noodle", "pasta", "bread", "flour", "oat"}},
+	{GroceryCategoryProduce, []string{"ผัก", "คะน้า", "กะหล่ำ", "แครอท", "มะเขือ", "กะเพรา", "โหระพา", "หอมแดง", "หอมใหญ่", "ต้นหอม", "กระเทียม", "พริก", "มะนาว", "ขิง", "ข่า", "ตะไคร้", "เห็ด", "แตงกวา", "ถั่วงอก", "ผลไม้", "กล้วย", "ส้ม", "แอปเปิ้ล", "มะละกอ", "แตงโม", "สับปะรด", "vegetable", "broccoli", "carrot", "tomato", "onion", "garlic", "lettuce", "spinach", "mushroom", "cucumber", "fruit", "banana", "apple", "orange"}},
+}
+
+/* CategorizeGrocery หาหมวดจากคำในชื่อ ไม่ตรงหมวดใดเป็น OTHER */
+func CategorizeGrocery(name string) GroceryCategory {
+	name = strings.ToLower(name)
+	for _, group := range groceryCategoryKeywords {
+		for _, keyword := range group.keywords {
+			if strings.Contains(name, keyword) {
+				return group.category
+			}
+		}
+	}
+	return GroceryCategoryOther
+}
+
+/* groceryUnits หน่วยที่แปลงเป็นหน่วยกลางได้ น้ำหนักเป็นกรัม ปริมาตรเป็นมิลลิลิตร หน่วยอื่นถือเป็นหน่วยนับ */
+var groceryUnits = map[string]struct {
+	unit   string
+	factor float64
+}{
+	"g":          {UNIT_GRAM, 1},
+	"gram":       {UNIT_GRAM, 1},
+	"grams":      {UNIT_GRAM, 1},
+	"กรัม":       {UNIT_GRAM, 1},
+	"kg":         {UNIT_GRAM, 1000},
+	"กก.":        {UNIT_GRAM, 1000},
+	"กิโลกรัม":   {UNIT_GRAM, 1000},
+	"ml":         {UNIT_MILLILITER, 1},
+	"cc":         {UNIT_MILLILITER, 1},
+	"มล.":        {UNIT_MILLILITER, 1},
+	"มิลลิลิตร":  {UNIT_MILLILITER, 1},
+	"l":          {UNIT_MILLILITER, 1000},
+	"ลิตร":       {UNIT_MILLILITER, 1000},
+	"tbsp":       {UNIT_MILLILITER, 15},
+	"ช้อนโต๊ะ":   {UNIT_MILLILITER, 15},
+	"tsp":        {UNIT_MILLILITER, 5},
+	"ช้อนชา":     {UNIT_MILLILITER, 5},
+	"cup":        {UNIT_MILLILITER, 240},
+	"ถ้วยตวง":    {UNIT_MILLILITER, 240},
+	UNIT_SERVING: {UNIT_SERVING, 1},
+}
+
+/* NormalizeGroceryUnit แปลงปริมาณเป็นหน่วยกลาง หน่วยนับคืนตามเดิม */
+func NormalizeGroceryUnit(quantity float64, unit string) (float64, string) {
+	unit = strings.ToLower(strings.TrimSpace(unit))
+	if normalized, ok := groceryUnits[unit]; ok {
+		return quantity * normalized.factor, normalized.unit
+	}
+	if unit == "" {
+		return quantity, UNIT_SERVING
+	}
+	return quantity, unit
+}
+
+func isMetricUnit(unit string) bool {
+	return unit == UNIT_GRAM || unit == UNIT_MILLILITER
+}
+
+var portionPattern = regexp.MustCompile(`([0-9]+(?:\.[0-9]+)?)\s*([^\s0-9()]*)`)
+
+/* ParsePortion อ่านปริมาณจาก portion ของแผนอาหาร เช่น "1 ตัว (200 g)" ใช้ค่าหน่วยน้ำหนักหรือปริมาตรก่อนหน่วยนับ ไม่มีตัวเลขถือเป็น 1 ที่เสิร์ฟ */
+func ParsePortion(portion string) (float64, string) {
+	matches := portionPattern.FindAllStringSubmatch(portion, -1)
+	if len(matches) == 0 {
+		return 1, UNIT_SERVING
+	}
+	for _, match := range matches {
+		if quantity, unit := NormalizeGroceryUnit(cast.ToFloat64(match[1]), match[2]); isMetricUnit(unit) {
+			return quantity, unit
+		}
+	}
+	return NormalizeGroceryUnit(cast.ToFloat64(matches[0][1]), matches[0][2])
+}
+
+/* GroceryListOption แผนและช่วงวันที่ต้องการซื้อของ ไม่ระบุวันใช้ทั้งแผน */
+type GroceryListOption struct {
+	MealPlanId *uuid.UUID   `json:"meal_plan_id"`
+	StartDate  *helper.Date `json:"start_date"`
+	EndDate    *helper.Date `json:"end_date"`
+}
+
+func NewGroceryListOptionWithParams(params map[string]interface{}) *GroceryListOption {
+	option := new(GroceryListOption)
+	for key, val := range params {
+		switch key {
+		case "meal_plan_id":
+			if id, err := uuid.FromString(cast.ToString(val)); err == nil {
+				option.MealPlanId = &id
+			}
+		case "start_date":
+			if date := cast.ToString(val); date != "" {
+				startDate := helper.NewDateFromString(date)
+				option.StartDate = &startDate
+			}
+		case "end_date":
+			if date := cast.ToString(val); date != "" {
+				endDate := helper.NewDateFromString(date)
+				option.EndDate = &endDate
+			}
+		}
+	}
+
+	return option
+}
+
+type GroceryList struct {
+	TableName  struct{}          `json:"-" db:"grocery_lists" pk:"Id"`
+	Id         *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	UserId     *uuid.UUID        `json:"user_id" db:"user_id" type:"uuid"`
+	MealPlanId *uuid.UUID        `json:"meal_plan_id" db:"meal_plan_id" type:"uuid"`
+	StartDate  *helper.Date      `json:"start_date" db:"start_date" type:"date"`
+	EndDate    *helper.Date      `json:"end_date" db:"end_date" type:"date"`
+	Items      []*GroceryItem    `json:"items" db:"-"`
+	Groups     []*GroceryGroup   `json:"groups,omitempty" db:"-"`
+	CreatedAt  *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt  *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+}
+
+/* GroceryItem วัตถุดิบหนึ่งรายการหลังรวมชื่อและหน่วยที่ซ้ำกันแล้ว */
+type GroceryItem struct {
+	TableName     struct{}        `json:"-" db:"grocery_list_items" pk:"Id"`
+	Id            *uuid.UUID      `json:"id" db:"id" type:"uuid"`
+	GroceryListId *uuid.UUID      `json:"grocery_list_id" db:"grocery_list_id" type:"uuid"`
+	Name          string          `json:"name" db:"name" type:"string"`
+	Quantity      float64         `json:"quantity" db:"quantity" type:"float64"`
+	Unit          string          `json:"unit" db:"unit" type:"string"`
+	Category      GroceryCategory `json:"category" db:"category" type:"string"`
+	IsChecked     bool            `json:"is_checked" db:"is_checked" type:"bool"`
+}
+
+type GroceryGroup struct {
+	Category GroceryCategory `json:"category"`
+	Label    string          `json:"label"`
+	Items    []*GroceryItem  `json:"items"`
+}
+
+/* NewGroceryList รวมวัตถุดิบของทุกมื้อในช่วงวันที่ รายการที่ชื่อตรงกับสูตรอาหารใน recipes (key เป็นชื่อตัวพิมพ์เล็ก) แตกเป็นวัตถุดิบของสูตรตามสัดส่วน portion ที่เหลือใช้ชื่อรายการตามแผน */
+func NewGroceryList(plan *MealPlan, startDate, endDate *helper.Date, recipes map[string]*Recipe) *GroceryList {
+	list := &GroceryList{
+		UserId:     plan.UserId,
+		MealPlanId: plan.Id,
+		StartDate:  startDate,
+		EndDate:    endDate,
+		Items:      make([]*GroceryItem, 0),
+	}
+	merged := make(map[string]*GroceryItem)
+	add := func(name string, quantity float64, unit string) {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name) + "|" + unit
+		if item, ok := merged[key]; ok {
+			item.Quantity += quantity
+			return
+		}
+		item := &GroceryItem{Name: name, Quantity: quantity, Unit: unit, Category: CategorizeGrocery(name)}
+		merged[key] = item
+		list.Items = append(list.Items, item)
+	}
+
+	for _, day := range plan.Days {
+		if day.Date == nil || day.Date.String() < startDate.String() || day.Date.String() > endDate.String() {
+			continue
+		}
+		for _, meal := range day.Meals {
+			for _, mealItem := range meal.Items {
+				quantity, unit := ParsePortion(mealItem.Portion)
+				recipe, ok := recipes[strings.ToLower(strings.TrimSpace(mealItem.Name))]
+				if !ok || len(recipe.Ingredients) == 0 {
+					add(mealItem.Name, quantity, unit)
+					continue
+				}
+				scale := recipeScale(recipe, quantity, unit)
+				for _, ingredient := range recipe.Ingredients {
+					if ingredient.Food == nil {
+						continue
+					}
+					ingredientQuantity, ingredientUnit := ingredient.Quantity, ingredient.Unit
+					if ingredientUnit == UNIT_SERVING {
+						ingredientQuantity, ingredientUnit = ingredient.Grams, UNIT_GRAM
+					}
+					ingredientQuantity, ingredientUnit = NormalizeGroceryUnit(ingredientQuantity, ingredientUnit)
+					add(ingredient.Food.Name, ingredientQuantity*scale, ingredientUnit)
+				}
+			}
+		}
+	}
+
+	for _, item := range list.Items {
+		if isMetricUnit(item.Unit) {
+			item.Quantity = math.Round(item.Quantity*10) / 10
+		} else {
+			/* หน่วยนับซื้อเป็นจำนวนเต็ม */
+			item.Quantity = math.Ceil(item.Quantity - 1e-9)
+		}
+	}
+	list.Sort()
+	return list
+}
+
+/* recipeScale จำนวนที่เสิร์ฟของสูตรที่ต้องใช้ portion เป็นน้ำหนักเทียบกับน้ำหนักต่อที่เสิร์ฟของสูตร หน่วยนับถือว่าเป็นจำนวนที่เสิร์ฟ */
+func recipeScale(recipe *Recipe, quantity float64, unit string) float64 {
+	servings := float64(max(recipe.Servings, 1))
+	if !isMetricUnit(unit) {
+		return quantity / servings
+	}
+	var grams float64
+	for _, ingredient := range recipe.Ingredients {
+		grams += ingredient.Grams
+	}
+	if grams <= 0 {
+		return 1 / servings
+	}
+	return quantity / grams
+}
+
+/* Sort เรียงตามหมวดในร้านแล้วตามชื่อ */
+func (g *GroceryList) Sort() {
+	slices.SortStableFunc(g.Items, func(a, b *GroceryItem) int {
+		if diff := slices.Index(GroceryCategories, a.Category) - slices.Index(GroceryCategories, b.Category); diff != 0 {
+			return diff
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+/* Group จัดรายการตามหมวด ข้ามหมวดที่ไม่มีรายการ */
+func (g *GroceryList) Group() {
+	g.Sort()
+	g.Groups = make([]*GroceryGroup, 0)
+	for _, category := range GroceryCategories {
+		group := &GroceryGroup{Category: category, Label: category.Label(), Items: make([]*GroceryItem, 0)}
+		for _, item := range g.Items {
+			if item.Category == category {
+				group.Items = append(group.Items, item)
+			}
+		}
+		if len(group.Items) > 0 {
+			g.Groups = append(g.Groups, group)
+		}
+	}
+}
+
+/* Text รายการซื้อของเป็นข้อความสำหรับแชร์ รายการที่ซื้อแล้วมีเครื่องหมาย [x] */
+func (g *GroceryList) Text() string {
+	g.Group()
+	var text strings.Builder
+	fmt.Fprintf(&text, "รายการซื้อของ %s ถึง %s\n", g.StartDate.String(), g.EndDate.String())
+	for _, group := range g.Groups {
+		fmt.Fprintf(&text, "\n%s\n", group.Label)
+		for _, item := range group.Items {
+			check := " "
+			if item.IsChecked {
+				check = "x"
+			}
+			fmt.Fprintf(&text, "[%s] %s %s %s\n", check, item.Name, cast.ToString(item.Quantity), item.Unit)
+		}
+	}
+	return text.String()
+}
+
+func (g *GroceryList) NewID() {
+	id := uuid.Must(uuid.NewV4())
+	g.Id = &id
+	for _, item := range g.Items {
+		itemId := uuid.Must(uuid.NewV4())
+		item.Id = &itemId
+		item.GroceryListId = g.Id
+	}
+}
+
+func (g *GroceryList) SetCreatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	g.CreatedAt = &ti
+}
+
+func (g *GroceryList) SetUpdatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	g.UpdatedAt = &ti
+}
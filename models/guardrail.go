@@ -0,0 +1,197 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+)
+
+/* Nutrient สารอาหารที่มีข้อจำกัดตามโรคประจำตัว */
+type Nutrient string
+
+const (
+	NutrientSodium    Nutrient = "SODIUM"
+	NutrientPotassium Nutrient = "POTASSIUM"
+	NutrientPurine    Nutrient = "PURINE"
+	NutrientSugar     Nutrient = "SUGAR"
+	NutrientProtein   Nutrient = "PROTEIN"
+)
+
+var Nutrients = []Nutrient{NutrientSodium, NutrientPotassium, NutrientPurine, NutrientSugar, NutrientProtein}
+
+/* Key ชื่อ field ของสารอาหารใน MealPlanItem */
+func (n Nutrient) Key() string {
+	return strings.ToLower(string(n))
+}
+
+func (n Nutrient) Unit() string {
+	if n == NutrientSugar || n == NutrientProtein {
+		return "g"
+	}
+	return "mg"
+}
+
+/* RuleScope ช่วงที่ใช้รวมปริมาณสารอาหารก่อนเทียบกับเพดาน */
+type RuleScope string
+
+const (
+	RuleScopeMeal RuleScope = "MEAL"
+	RuleScopeDay  RuleScope = "DAY"
+)
+
+/* ข้อความที่แนบกับแผนอาหารของผู้ใช้ที่มีโรคประจำตัว */
+const GuardrailDisclaimer = "แผนอาหารนี้สร้างโดย AI และตรวจตามเกณฑ์ทั่วไปของโรคประจำตัวเท่านั้น ค่าสารอาหารเป็นค่าประมาณ ไม่ใช่คำแนะนำทางการแพทย์ ควรปรึกษาแพทย์หรือนักกำหนดอาหารก่อนปรับการกิน"
+
+/* DiseaseRule เพดานสารอาหารของโรคหนึ่งโรค PerKgBodyWeight คือ MaxValue ต่อน้ำหนักตัว 1 กิโลกรัม */
+type DiseaseRule struct {
+	TableName       struct{}          `json:"-" db:"disease_rules" pk:"Id"`
+	Id              *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	DiseaseId       *uuid.UUID        `json:"disease_id" db:"disease_id" type:"uuid"`
+	DiseaseName     string            `json:"disease_name" db:"-"`
+	Nutrient        Nutrient          `json:"nutrient" db:"nutrient" type:"string"`
+	Scope           RuleScope         `json:"scope" db:"scope" type:"string"`
+	MaxValue        float64           `json:"max_value" db:"max_value" type:"float64"`
+	PerKgBodyWeight bool              `json:"per_kg_body_weight" db:"per_kg_body_weight" type:"bool"`
+	Description     string            `json:"description" db:"description" type:"string"`
+	IsActive        bool              `json:"is_active" db:"is_active" type:"bool"`
+	CreatedAt       *helper.Timestamp `json:"created_at,omitempty" db:"created_at" type:"timestamp"`
+	UpdatedAt       *helper.Timestamp `json:"updated_at,omitempty" db:"updated_at" type:"timestamp"`
+}
+
+/* Limit เพดานจริงของผู้ใช้ กฎต่อน้ำหนักตัวที่ไม่รู้น้ำหนักจะใช้ไม่ได้ */
+func (d *DiseaseRule) Limit(weight float64) (float64, bool) {
+	if !d.PerKgBodyWeight {
+		return d.MaxValue, true
+	}
+	if weight <= 0 {
+		return 0, false
+	}
+	return math.Round(d.MaxValue*weight*10) / 10, true
+}
+
+/* GetNutrient ปริมาณสารอาหารของรายการ nil คือโมเดลไม่ได้ระบุ */
+func (i *MealPlanItem) GetNutrient(nutrient Nutrient) *float64 {
+	switch nutrient {
+	case NutrientSodium:
+		return i.Sodium
+	case NutrientPotassium:
+		return i.Potassium
+	case NutrientPurine:
+		return i.Purine
+	case NutrientSugar:
+		return i.Sugar
+	case NutrientProtein:
+		return &i.Protein
+	}
+	return nil
+}
+
+/* GuardrailViolation มื้อหรือวันที่เกินเพดาน Missing คือรายการ ItemName ไม่ได้ระบุปริมาณจึงตรวจไม่ได้ */
+type GuardrailViolation struct {
+	Day         int       `json:"day"`
+	MealType    MealType  `json:"meal_type,omitempty"`
+	MealName    string    `json:"meal_name,omitempty"`
+	ItemName    string    `json:"item_name,omitempty"`
+	Nutrient    Nutrient  `json:"nutrient"`
+	Scope       RuleScope `json:"scope,omitempty"`
+	Value       float64   `json:"value"`
+	Limit       float64   `json:"limit"`
+	Missing     bool      `json:"missing,omitempty"`
+	Description string    `json:"description,omitempty"`
+}
+
+/* String ข้อความที่ส่งกลับไปให้โมเดลแก้ */
+func (g *GuardrailViolation) String() string {
+	if g.Missing {
+		return fmt.Sprintf("day %d %s %q item %q: %s is missing, report it in %s", g.Day, g.MealType, g.MealName, g.ItemName, g.Nutrient.Key(), g.Nutrient.Unit())
+	}
+	if g.Scope == RuleScopeDay {
+		return fmt.Sprintf("day %d: total %s %.1f %s exceeds %.1f %s per day (%s)", g.Day, g.Nutrient.Key(), g.Value, g.Nutrient.Unit(), g.Limit, g.Nutrient.Unit(), g.Description)
+	}
+	return fmt.Sprintf("day %d %s %q: %s %.1f %s exceeds %.1f %s per meal (%s)", g.Day, g.MealType, g.MealName, g.Nutrient.Key(), g.Value, g.Nutrient.Unit(), g.Limit, g.Nutrient.Unit(), g.Description)
+}
+
+/* MealPlanSafety ผลการตรวจแผนตามโรคประจำตัว Violations คือที่ยังเกินหลังให้โมเดลแก้ครบ Revisions ครั้ง */
+type MealPlanSafety struct {
+	Disclaimer string                `json:"disclaimer"`
+	Rules      []*DiseaseRule        `json:"rules"`
+	Violations []*GuardrailViolation `json:"violations"`
+	Revisions  int                   `json:"revisions"`
+}
+
+func NewMealPlanSafety(rules []*DiseaseRule, violations []*GuardrailViolation, revisions int) *MealPlanSafety {
+	if violations == nil {
+		violations = make([]*GuardrailViolation, 0)
+	}
+	return &MealPlanSafety{
+		Disclaimer: GuardrailDisclaimer,
+		Rules:      rules,
+		Violations: violations,
+		Revisions:  revisions,
+	}
+}
+
+/* SafetyJSON ผลการตรวจในรูป JSON สำหรับคอลัมน์ jsonb ไม่มีคืน nil */
+func SafetyJSON(safety *MealPlanSafety) interface{} {
+	if safety == nil {
+		return nil
+	}
+	data, err := json.Marshal(safety)
+	if err != nil {
+		return nil
+	}
+	return string(data)
+}
+
+/* CheckMealPlan ตรวจแผนกับกฎของโรคประจำตัว รวมปริมาณต่อมื้อและต่อวันเทียบกับเพดาน รายการที่ไม่ระบุสารอาหารที่มีกฎถือว่าไม่ผ่าน */
+func CheckMealPlan(plan *MealPlan, rules []*DiseaseRule, weight float64) []*GuardrailViolation {
+	violations := make([]*GuardrailViolation, 0)
+	checked := make(map[Nutrient]bool)
+	for _, rule := range rules {
+		checked[rule.Nutrient] = true
+	}
+
+	for _, day := range plan.Days {
+		dayTotals := make(map[Nutrient]float64)
+		for _, meal := range day.Meals {
+			mealTotals := make(map[Nutrient]float64)
+			for _, item := range meal.Items {
+				for _, nutrient := range Nutrients {
+					if !checked[nutrient] {
+						continue
+					}
+					value := item.GetNutrient(nutrient)
+					if value == nil {
+						violations = append(violations, &GuardrailViolation{Day: day.Day, MealType: meal.MealType, MealName: meal.Name, ItemName: item.Name, Nutrient: nutrient, Missing: true})
+						continue
+					}
+					mealTotals[nutrient] += *value
+				}
+			}
+			for _, rule := range rules {
+				if rule.Scope != RuleScopeMeal {
+					continue
+				}
+				if limit, ok := rule.Limit(weight); ok && mealTotals[rule.Nutrient] > limit {
+					violations = append(violations, &GuardrailViolation{Day: day.Day, MealType: meal.MealType, MealName: meal.Name, Nutrient: rule.Nutrient, Scope: rule.Scope, Value: math.Round(mealTotals[rule.Nutrient]*10) / 10, Limit: limit, Description: rule.Description})
+				}
+			}
+			for nutrient, value := range mealTotals {
+				dayTotals[nutrient] += value
+			}
+		}
+		for _, rule := range rules {
+			if rule.Scope != RuleScopeDay {
+				continue
+			}
+			if limit, ok := rule.Limit(weight); ok && dayTotals[rule.Nutrient] > limit {
+				violations = append(violations, &GuardrailViolation{Day: day.Day, Nutrient: rule.Nutrient, Scope: rule.Scope, Value: math.Round(dayTotals[rule.Nutrient]*10) / 10, Limit: limit, Description: rule.Description})
+			}
+		}
+	}
+	return violations
+}
@@ -0,0 +1,169 @@
+package models
+
+import (
+	"encoding/json"
+	"healthmatefood-api/apperror"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+)
+
+type JobType string
+
+const (
+	JobTypeMealPlan JobType = "MEAL_PLAN"
+)
+
+type JobStatus string
+
+const (
+	JobStatusPending   JobStatus = "PENDING"
+	JobStatusRunning   JobStatus = "RUNNING"
+	JobStatusSucceeded JobStatus = "SUCCEEDED"
+	JobStatusDead      JobStatus = "DEAD"
+)
+
+/* ระยะรอก่อนลองใหม่ เพิ่มตามกำลังสองของจำนวนครั้งที่ทำไปแล้ว และไม่เกิน MaxJobRetryDelay */
+const (
+	JobRetryBaseDelay = 10 * time.Second
+	MaxJobRetryDelay  = 10 * time.Minute
+)
+
+/* Job งานเบื้องหลังในคิว worker หยิบงานที่ PENDING และถึงเวลา run_at แล้ว ล้มเหลวครบ max_attempts จะเป็น DEAD */
+type Job struct {
+	TableName   struct{}          `json:"-" db:"jobs" pk:"Id"`
+	Id          *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	UserId      *uuid.UUID        `json:"user_id" db:"user_id" type:"uuid"`
+	Type        JobType           `json:"type" db:"type" type:"string"`
+	Status      JobStatus         `json:"status" db:"status" type:"string"`
+	Payload     json.RawMessage   `json:"payload" db:"payload" type:"json"`
+	Attempts    int               `json:"attempts" db:"attempts" type:"int32"`
+	MaxAttempts int               `json:"max_attempts" db:"max_attempts" type:"int32"`
+	LastError   string            `json:"last_error,omitempty" db:"last_error" type:"string"`
+	ResultId    *uuid.UUID        `json:"result_id,omitempty" db:"result_id" type:"uuid"`
+	RunAt       *helper.Timestamp `json:"run_at" db:"run_at" type:"timestamp"`
+	LockedAt    *helper.Timestamp `json:"locked_at,omitempty" db:"locked_at" type:"timestamp"`
+	LockedBy    string            `json:"locked_by,omitempty" db:"locked_by" type:"string"`
+	CompletedAt *helper.Timestamp `json:"completed_at,omitempty" db:"completed_at" type:"timestamp"`
+	CreatedAt   *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt   *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+}
+
+/* NewMealPlanJob งานสร้างแผนอาหารของผู้ใช้ เก็บ option ไว้ใน payload */
+func NewMealPlanJob(userId *uuid.UUID, option *MealPlanOption, maxAttempts int) (*Job, error) {
+	payload, err := json.Marshal(option)
+	if err != nil {
+		return nil, err
+	}
+	job := &Job{
+		UserId:      userId,
+		Type:        JobTypeMealPlan,
+		Status:      JobStatusPending,
+		Payload:     payload,
+		MaxAttempts: max(maxAttempts, 1),
+	}
+	job.NewID()
+	job.SetCreatedAt()
+	job.SetUpdatedAt()
+	job.RunAt = job.CreatedAt
+	return job, nil
+}
+
+func (j *Job) NewID() {
+	id := uuid.Must(uuid.NewV4())
+	j.Id = &id
+}
+
+func (j *Job) SetCreatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	j.CreatedAt = &ti
+}
+
+func (j *Job) SetUpdatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	j.UpdatedAt = &ti
+}
+
+func (j *Job) MealPlanOption() (*MealPlanOption, error) {
+	option := &MealPlanOption{Days: DEFAULT_MEAL_PLAN_DAYS}
+	if len(j.Payload) == 0 {
+		return option, nil
+	}
+	if err := json.Unmarshal(j.Payload, option); err != nil {
+		return nil, err
+	}
+	return option, nil
+}
+
+func (j *Job) Succeed(resultId *uuid.UUID) {
+	ti := helper.NewTimestampFromTime(time.Now())
+	j.Status = JobStatusSucceeded
+	j.ResultId = resultId
+	j.LastError = ""
+	j.CompletedAt = &ti
+	j.unlock()
+}
+
+/* Fail ตั้งเวลาลองใหม่ตาม JobRetryDelay หรือย้ายเป็น DEAD เมื่อทำครบ max_attempts แล้ว error ที่ลองใหม่ก็ไม่สำเร็จ เช่นไม่พบข้อมูลหรือข้อมูลไม่ถูกต้อง ย้ายเป็น DEAD ทันที */
+func (j *Job) Fail(err error) {
+	j.LastError = err.Error()
+	if j.Attempts >= j.MaxAttempts || !jobRetryable(err) {
+		ti := helper.NewTimestampFromTime(time.Now())
+		j.Status = JobStatusDead
+		j.CompletedAt = &ti
+	} else {
+		ti := helper.NewTimestampFromTime(time.Now().Add(JobRetryDelay(j.Attempts)))
+		j.Status = JobStatusPending
+		j.RunAt = &ti
+	}
+	j.unlock()
+}
+
+/* Release คืนงานเข้าคิวโดยไม่นับครั้งที่ถูกขัดจังหวะ ใช้ตอนปิดโปรแกรมระหว่างทำงาน */
+func (j *Job) Release() {
+	j.Status = JobStatusPending
+	j.Attempts = max(j.Attempts-1, 0)
+	j.unlock()
+}
+
+/* Postpone คืนงานเข้าคิวให้ทำที่เวลา runAt โดยไม่นับครั้ง ใช้เมื่อผู้ใช้ใช้ AI เกินโควตาแล้วรอรอบรีเซ็ต */
+func (j *Job) Postpone(runAt *helper.Timestamp) {
+	j.Status = JobStatusPending
+	j.Attempts = max(j.Attempts-1, 0)
+	j.RunAt = runAt
+	j.unlock()
+}
+
+/* Requeue ให้งานที่ DEAD กลับเข้าคิวพร้อมจำนวนครั้งใหม่ */
+func (j *Job) Requeue() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	j.Status = JobStatusPending
+	j.Attempts = 0
+	j.RunAt = &ti
+	j.CompletedAt = nil
+	j.unlock()
+}
+
+func (j *Job) unlock() {
+	j.LockedAt = nil
+	j.LockedBy = ""
+	j.SetUpdatedAt()
+}
+
+func jobRetryable(err error) bool {
+	switch apperror.KindOf(err) {
+	case apperror.KindNotFound, apperror.KindValidation:
+		return false
+	}
+	return true
+}
+
+func JobRetryDelay(attempts int) time.Duration {
+	attempts = max(attempts, 1)
+	delay := time.Duration(attempts*attempts) * JobRetryBaseDelay
+	if delay > MaxJobRetryDelay {
+		return MaxJobRetryDelay
+	}
+	return delay
+}
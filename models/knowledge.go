@@ -0,0 +1,244 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+/* ขนาดไฟล์เอกสารแนวทางสูงสุดที่อัปโหลดได้ (2 MB) */
+const MAX_KNOWLEDGE_DOCUMENT_SIZE = 2 * 1024 * 1024
+
+/* นามสกุลไฟล์เอกสารที่รับ เป็นข้อความล้วน */
+var KnowledgeDocumentExtensions = []string{".txt", ".md"}
+
+/* KnowledgeDocument เอกสารแนวทางโภชนาการทางคลินิกที่ admin อัปโหลด ถูกแบ่งเป็น chunk สำหรับค้นหา */
+type KnowledgeDocument struct {
+	TableName      struct{}          `json:"-" db:"knowledge_documents" pk:"Id"`
+	Id             *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	Title          string            `json:"title" db:"title" type:"string"`
+	Source         string            `json:"source" db:"source" type:"string"`
+	Content        string            `json:"content,omitempty" db:"content" type:"string"`
+	ChunkCount     int               `json:"chunk_count" db:"chunk_count" type:"int32"`
+	EmbeddingModel string            `json:"embedding_model" db:"embedding_model" type:"string"`
+	CreatedBy      *uuid.UUID        `json:"created_by" db:"created_by" type:"uuid"`
+	CreatedAt      *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt      *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+}
+
+func NewKnowledgeDocumentWithParams(params map[string]interface{}, ptr *KnowledgeDocument) *KnowledgeDocument {
+	if ptr == nil {
+		ptr = new(KnowledgeDocument)
+	}
+	for key, val := range params {
+		switch key {
+		case "title":
+			ptr.Title = strings.TrimSpace(cast.ToString(val))
+		case "source":
+			ptr.Source = strings.TrimSpace(cast.ToString(val))
+		case "content":
+			ptr.Content = cast.ToString(val)
+		}
+	}
+
+	return ptr
+}
+
+func (k *KnowledgeDocument) NewID() {
+	id := uuid.Must(uuid.NewV4())
+	k.Id = &id
+}
+
+func (k *KnowledgeDocument) SetCreatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	k.CreatedAt = &ti
+}
+
+func (k *KnowledgeDocument) SetUpdatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	k.UpdatedAt = &ti
+}
+
+/* KnowledgeChunk ส่วนหนึ่งของเอกสารพร้อม embedding Score คือความใกล้เคียงกับคำถามตอนค้นหา */
+type KnowledgeChunk struct {
+	TableName      struct{}   `json:"-" db:"knowledge_chunks" pk:"Id"`
+	Id             *uuid.UUID `json:"id" db:"id" type:"uuid"`
+	DocumentId     *uuid.UUID `json:"document_id" db:"document_id" type:"uuid"`
+	ChunkIndex     int        `json:"chunk_index" db:"chunk_index" type:"int32"`
+	Content        string     `json:"content" db:"content" type:"string"`
+	Embedding      []float32  `json:"embedding,omitempty" db:"embedding"`
+	EmbeddingModel string     `json:"embedding_model,omitempty" db:"embedding_model" type:"string"`
+	Title          string     `json:"title,omitempty" db:"-"`
+	Source         string     `json:"source,omitempty" db:"-"`
+	Score          float64    `json:"score,omitempty" db:"-"`
+}
+
+func NewKnowledgeChunk(documentId *uuid.UUID, index int, content string, embedding []float32, embeddingModel string) *KnowledgeChunk {
+	id := uuid.Must(uuid.NewV4())
+	return &KnowledgeChunk{
+		Id:             &id,
+		DocumentId:     documentId,
+		ChunkIndex:     index,
+		Content:        content,
+		Embedding:      embedding,
+		EmbeddingModel: embeddingModel,
+	}
+}
+
+/* EmbeddingArray embedding ในรูป array ของ Postgres ({0.1,0.2}) */
+func (k *KnowledgeChunk) EmbeddingArray() string {
+	return "{" + joinVector(k.Embedding) + "}"
+}
+
+/* VectorLiteral embedding ในรูปของ pgvector ([0.1,0.2]) */
+func VectorLiteral(embedding []float32) string {
+	return "[" + joinVector(embedding) + "]"
+}
+
+func joinVector(embedding []float32) string {
+	values := make([]string, 0, len(embedding))
+	for _, val := range embedding {
+		values = append(values, strconv.FormatFloat(float64(val), 'g', -1, 32))
+	}
+	return strings.Join(values, ",")
+}
+
+/* KnowledgeCitation แหล่งอ้างอิงที่แนบไปกับคำตอบ Index ตรงกับเลข [n] ในข้อความ */
+type KnowledgeCitation struct {
+	Index      int        `json:"index"`
+	DocumentId *uuid.UUID `json:"document_id"`
+	Title      string     `json:"title"`
+	Source     string     `json:"source,omitempty"`
+	ChunkIndex int        `json:"chunk_index"`
+	Excerpt    string     `json:"excerpt"`
+	Score      float64    `json:"score"`
+}
+
+/* CitationsJSON แหล่งอ้างอิงในรูป JSON สำหรับเก็บลงคอลัมน์ jsonb ไม่มีคือ [] */
+func CitationsJSON(citations []*KnowledgeCitation) string {
+	if len(citations) == 0 {
+		return "[]"
+	}
+	data, err := json.Marshal(citations)
+	if err != nil {
+		return "[]"
+	}
+	return string(data)
+}
+
+/* จำนวนตัวอักษรของ excerpt ในแหล่งอ้างอิง */
+const knowledgeExcerptLength = 200
+
+/* NewKnowledgeCitations แปลง chunk ที่ค้นได้ตามลำดับเป็นแหล่งอ้างอิง [1], [2], ... */
+func NewKnowledgeCitations(chunks []*KnowledgeChunk) []*KnowledgeCitation {
+	citations := make([]*KnowledgeCitation, 0, len(chunks))
+	for index, chunk := range chunks {
+		excerpt := chunk.Content
+		if utf8.RuneCountInString(excerpt) > knowledgeExcerptLength {
+			excerpt = string([]rune(excerpt)[:knowledgeExcerptLength]) + "…"
+		}
+		citations = append(citations, &KnowledgeCitation{
+			Index:      index + 1,
+			DocumentId: chunk.DocumentId,
+			Title:      chunk.Title,
+			Source:     chunk.Source,
+			ChunkIndex: chunk.ChunkIndex,
+			Excerpt:    excerpt,
+			Score:      math.Round(chunk.Score*1000) / 1000,
+		})
+	}
+	return citations
+}
+
+/* KnowledgeContext ข้อความแนวทางที่แนบไปกับ prompt พร้อมเลขอ้างอิง */
+func KnowledgeContext(chunks []*KnowledgeChunk) string {
+	var context strings.Builder
+	context.WriteString("แนวทางโภชนาการทางคลินิกจากฐานความรู้ของระบบ ให้ยึดตามแนวทางนี้เมื่อเกี่ยวข้อง และอ้างอิงด้วยเลข [n] ท้ายประโยคที่ใช้ข้อมูลนั้น:")
+	for index, chunk := range chunks {
+		fmt.Fprintf(&context, "\n\n[%d] %s\n%s", index+1, chunk.Title, chunk.Content)
+	}
+	return context.String()
+}
+
+/* ChunkKnowledgeText แบ่งเอกสารตามย่อหน้าให้แต่ละส่วนยาวไม่เกิน size ตัวอักษร ย่อหน้าที่ยาวเกินจะถูกตัดโดยซ้อนกัน overlap ตัวอักษร */
+func ChunkKnowledgeText(content string, size, overlap int) []string {
+	if size <= 0 {
+		return nil
+	}
+	if overlap < 0 || overlap >= size {
+		overlap = 0
+	}
+
+	chunks := make([]string, 0)
+	var current []rune
+	flush := func() {
+		if text := strings.TrimSpace(string(current)); text != "" {
+			chunks = append(chunks, text)
+		}
+		current = nil
+	}
+	for _, paragraph := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n\n") {
+		runes := []rune(strings.TrimSpace(paragraph))
+		if len(runes) == 0 {
+			continue
+		}
+		if len(current) > 0 && len(current)+2+len(runes) > size {
+			flush()
+		}
+		for len(runes) > size {
+			current = runes[:size]
+			flush()
+			runes = runes[size-overlap:]
+		}
+		if len(current) > 0 {
+			current = append(current, '\n', '\n')
+		}
+		current = append(current, runes...)
+	}
+	flush()
+	return chunks
+}
+
+/* CosineSimilarity ความใกล้เคียงของ vector สองตัว คืน 0 เมื่อขนาดไม่เท่ากันหรือเป็น vector ศูนย์ */
+func CosineSimilarity(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+/* RankKnowledgeChunks ให้คะแนน chunk เทียบกับ embedding ของคำถาม คืนเฉพาะที่คะแนนถึง minScore เรียงจากมากไปน้อยไม่เกิน limit */
+func RankKnowledgeChunks(chunks []*KnowledgeChunk, query []float32, limit int, minScore float64) []*KnowledgeChunk {
+	ranked := make([]*KnowledgeChunk, 0, len(chunks))
+	for _, chunk := range chunks {
+		chunk.Score = CosineSimilarity(chunk.Embedding, query)
+		if chunk.Score >= minScore {
+			ranked = append(ranked, chunk)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+type Language string
+
+const (
+	LanguageThai    Language = "th"
+	LanguageEnglish Language = "en"
+)
+
+/* DefaultLanguage ภาษาที่ใช้เมื่อ request และผู้ใช้ไม่ได้ระบุ */
+const DefaultLanguage = LanguageThai
+
+var Languages = []Language{LanguageThai, LanguageEnglish}
+
+func (l Language) IsValid() bool {
+	for _, language := range Languages {
+		if l == language {
+			return true
+		}
+	}
+	return false
+}
+
+/* Name ชื่อภาษาที่ใส่ใน prompt ให้ agent ตอบเป็นภาษานั้น */
+func (l Language) Name() string {
+	switch l {
+	case LanguageEnglish:
+		return "ภาษาอังกฤษ"
+	default:
+		return "ภาษาไทย"
+	}
+}
+
+/* ParseLanguage อ่าน language tag เช่น "en-US" หรือ "th_TH" ใช้เฉพาะ primary subtag */
+func ParseLanguage(tag string) (Language, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if index := strings.IndexAny(tag, "-_"); index >= 0 {
+		tag = tag[:index]
+	}
+	language := Language(tag)
+	return language, language.IsValid()
+}
+
+/* NegotiateLanguage เลือกภาษาที่รองรับจาก header Accept-Language ตามค่า q สูงสุด ไม่มีภาษาที่รองรับคืน false */
+func NegotiateLanguage(acceptLanguage string) (Language, bool) {
+	type candidate struct {
+		language Language
+		quality  float64
+	}
+	candidates := make([]candidate, 0)
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		language, ok := ParseLanguage(tag)
+		if !ok {
+			continue
+		}
+		quality := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			quality = cast.ToFloat64(value)
+		}
+		if quality > 0 {
+			candidates = append(candidates, candidate{language: language, quality: quality})
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	return candidates[0].language, true
+}
+
+type languageKey struct{}
+
+func ContextWithLanguage(ctx context.Context, language Language) context.Context {
+	return context.WithValue(ctx, languageKey{}, language)
+}
+
+func LanguageFromContext(ctx context.Context) (Language, bool) {
+	language, ok := ctx.Value(languageKey{}).(Language)
+	return language, ok
+}
+
+/* ResolveLanguage ภาษาที่ agent ใช้ตอบ ภาษาที่ request ขอมาก่อน แล้วจึงเป็นภาษาที่ผู้ใช้ตั้งไว้ */
+func ResolveLanguage(ctx context.Context, userInfo *UserInfo) Language {
+	if language, ok := LanguageFromContext(ctx); ok {
+		return language
+	}
+	if userInfo != nil && userInfo.Language.IsValid() {
+		return userInfo.Language
+	}
+	return DefaultLanguage
+}
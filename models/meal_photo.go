@@ -0,0 +1,204 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+/* ขนาดรูปอาหารสูงสุดที่ส่งให้โมเดล (5 MB) */
+const MAX_MEAL_PHOTO_SIZE = 5 * 1024 * 1024
+
+/* ชนิดรูปที่โมเดลส่วนใหญ่รองรับ */
+var MealPhotoMIMETypes = []string{"image/jpeg", "image/png", "image/webp"}
+
+/* MealPhotoJSONSchema รูปแบบที่บังคับให้โมเดลตอบกลับ ต้องตรงกับ struct MealPhotoAnalysis */
+const MealPhotoJSONSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["dishes"],
+  "properties": {
+    "dishes": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["name", "portion", "calories", "protein", "carbohydrate", "fat", "confidence"],
+        "properties": {
+          "name": { "type": "string", "minLength": 1 },
+          "portion": { "type": "string", "minLength": 1 },
+          "calories": { "type": "number", "minimum": 0 },
+          "protein": { "type": "number", "minimum": 0 },
+          "carbohydrate": { "type": "number", "minimum": 0 },
+          "fat": { "type": "number", "minimum": 0 },
+          "confidence": { "type": "number", "minimum": 0, "maximum": 1 }
+        }
+      }
+    },
+    "note": { "type": "string" }
+  }
+}`
+
+/* MealPhoto รูปอาหารที่ผู้ใช้อัปโหลด */
+type MealPhoto struct {
+	MIMEType string
+	Data     []byte
+}
+
+/* MealPhotoAnalysis ผลประเมินรูปอาหาร ยังไม่ถูกบันทึกจนกว่าผู้ใช้จะยืนยันผ่าน diary */
+type MealPhotoAnalysis struct {
+	Dishes        []*MealPhotoDish `json:"dishes"`
+	Note          string           `json:"note"`
+	Total         *Nutrition       `json:"total"`
+	Model         string           `json:"model,omitempty"`
+	PromptVersion string           `json:"prompt_version,omitempty"`
+}
+
+/* mealPhotoContent ส่วนที่โมเดลต้องตอบ */
+type mealPhotoContent struct {
+	Dishes []*MealPhotoDish `json:"dishes"`
+	Note   string           `json:"note"`
+}
+
+type MealPhotoDish struct {
+	Name         string  `json:"name"`
+	Portion      string  `json:"portion"`
+	Calories     float64 `json:"calories"`
+	Protein      float64 `json:"protein"`
+	Carbohydrate float64 `json:"carbohydrate"`
+	Fat          float64 `json:"fat"`
+	Confidence   float64 `json:"confidence"`
+}
+
+/* NewMealPhotoDishesWithParams แปลงรายการอาหารที่ผู้ใช้ยืนยัน (แก้ไขได้) จาก body */
+func NewMealPhotoDishesWithParams(val interface{}) []*MealPhotoDish {
+	dishes := make([]*MealPhotoDish, 0)
+	for _, item := range cast.ToSlice(val) {
+		params := cast.ToStringMap(item)
+		dish := new(MealPhotoDish)
+		for key, val := range params {
+			switch key {
+			case "name":
+				dish.Name = strings.TrimSpace(cast.ToString(val))
+			case "portion":
+				dish.Portion = strings.TrimSpace(cast.ToString(val))
+			case "calories":
+				dish.Calories = cast.ToFloat64(val)
+			case "protein":
+				dish.Protein = cast.ToFloat64(val)
+			case "carbohydrate":
+				dish.Carbohydrate = cast.ToFloat64(val)
+			case "fat":
+				dish.Fat = cast.ToFloat64(val)
+			case "confidence":
+				dish.Confidence = cast.ToFloat64(val)
+			}
+		}
+		dishes = append(dishes, dish)
+	}
+	return dishes
+}
+
+func (d *MealPhotoDish) GetNutrition() *Nutrition {
+	return &Nutrition{
+		Calories:     d.Calories,
+		Protein:      d.Protein,
+		Carbohydrate: d.Carbohydrate,
+		Fat:          d.Fat,
+	}
+}
+
+/* ToFoodDiary รายการอาหารแบบพิมพ์เอง หนึ่งจานต่อหนึ่ง serving โดยเก็บปริมาณที่ประเมินไว้ใน note */
+func (d *MealPhotoDish) ToFoodDiary(userId *uuid.UUID, mealType MealType, eatenAt *helper.Date) *FoodDiary {
+	diary := &FoodDiary{
+		UserId:       userId,
+		Name:         d.Name,
+		MealType:     mealType,
+		Quantity:     1,
+		Unit:         UNIT_SERVING,
+		Calories:     d.Calories,
+		Protein:      d.Protein,
+		Carbohydrate: d.Carbohydrate,
+		Fat:          d.Fat,
+		Note:         d.Portion,
+		EatenAt:      eatenAt,
+	}
+	diary.NewID()
+	diary.SetCreatedAt()
+	diary.SetUpdatedAt()
+	return diary
+}
+
+/* DecodeMealPhotoAnalysis แปลงข้อความจากโมเดลเป็นผลประเมินแบบเข้มงวดเหมือน DecodeMealPlan */
+func DecodeMealPhotoAnalysis(content string) (*MealPhotoAnalysis, error) {
+	raw := extractJSONObject(content)
+	if raw == "" {
+		return nil, errors.New("response is not a JSON object")
+	}
+
+	decoder := json.NewDecoder(bytes.NewBufferString(raw))
+	decoder.DisallowUnknownFields()
+	answer := new(mealPhotoContent)
+	if err := decoder.Decode(answer); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	if decoder.More() {
+		return nil, errors.New("invalid JSON: unexpected data after the analysis object")
+	}
+	if err := ValidateMealPhotoDishes(answer.Dishes, false); err != nil {
+		return nil, err
+	}
+	analysis := &MealPhotoAnalysis{Dishes: answer.Dishes, Note: answer.Note}
+	analysis.CalculateTotal()
+
+	return analysis, nil
+}
+
+/* ValidateMealPhotoDishes ตรวจทุกจาน requireDish คือต้องมีอย่างน้อยหนึ่งจาน (ตอนยืนยันบันทึก) */
+func ValidateMealPhotoDishes(dishes []*MealPhotoDish, requireDish bool) error {
+	var issues []string
+	if dishes == nil {
+		issues = append(issues, "dishes: must not be null")
+	}
+	if requireDish && len(dishes) == 0 {
+		issues = append(issues, "dishes: must not be empty")
+	}
+	for index, dish := range dishes {
+		path := fmt.Sprintf("dishes[%d]", index)
+		if dish == nil {
+			issues = append(issues, path+": must not be null")
+			continue
+		}
+		if strings.TrimSpace(dish.Name) == "" {
+			issues = append(issues, path+".name: must not be empty")
+		}
+		if strings.TrimSpace(dish.Portion) == "" {
+			issues = append(issues, path+".portion: must not be empty")
+		}
+		if dish.Calories < 0 || dish.Protein < 0 || dish.Carbohydrate < 0 || dish.Fat < 0 {
+			issues = append(issues, path+": calories and macros must not be negative")
+		}
+		if dish.Confidence < 0 || dish.Confidence > 1 {
+			issues = append(issues, path+".confidence: must be between 0 and 1")
+		}
+	}
+	if len(issues) > 0 {
+		return errors.New(strings.Join(issues, "; "))
+	}
+	return nil
+}
+
+func (a *MealPhotoAnalysis) CalculateTotal() {
+	a.Total = new(Nutrition)
+	for _, dish := range a.Dishes {
+		a.Total.Add(dish.GetNutrition())
+	}
+	a.Total.Round()
+}
@@ -0,0 +1,434 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+const (
+	DEFAULT_MEAL_PLAN_DAYS = 3
+	MAX_MEAL_PLAN_DAYS     = 7
+)
+
+/* MealPlanJSONSchema รูปแบบที่บังคับให้โมเดลตอบกลับ ต้องตรงกับ struct MealPlan */
+const MealPlanJSONSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["days"],
+  "properties": {
+    "days": {
+      "type": "array",
+      "minItems": 1,
+      "items": {
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["day", "meals"],
+        "properties": {
+          "day": { "type": "integer", "minimum": 1 },
+          "meals": {
+            "type": "array",
+            "minItems": 1,
+            "items": {
+              "type": "object",
+              "additionalProperties": false,
+              "required": ["meal_type", "time", "name", "items"],
+              "properties": {
+                "meal_type": { "type": "string", "enum": ["BREAKFAST", "LUNCH", "DINNER", "SNACK"] },
+                "time": { "type": "string", "pattern": "^([01][0-9]|2[0-3]):[0-5][0-9]$" },
+                "name": { "type": "string", "minLength": 1 },
+                "items": {
+                  "type": "array",
+                  "minItems": 1,
+                  "items": {
+                    "type": "object",
+                    "additionalProperties": false,
+                    "required": ["name", "portion", "calories", "protein", "carbohydrate", "fat"],
+                    "properties": {
+                      "name": { "type": "string", "minLength": 1 },
+                      "portion": { "type": "string", "minLength": 1 },
+                      "calories": { "type": "number", "minimum": 0 },
+                      "protein": { "type": "number", "minimum": 0 },
+                      "carbohydrate": { "type": "number", "minimum": 0 },
+                      "fat": { "type": "number", "minimum": 0 },
+                      "sodium": { "type": "number", "minimum": 0 },
+                      "potassium": { "type": "number", "minimum": 0 },
+                      "purine": { "type": "number", "minimum": 0 },
+                      "sugar": { "type": "number", "minimum": 0 }
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "note": { "type": "string" }
+  }
+}`
+
+var mealTimePattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+type MealPlan struct {
+	TableName      struct{}             `json:"-" db:"meal_plans" pk:"Id"`
+	Id             *uuid.UUID           `json:"id,omitempty" db:"id" type:"uuid"`
+	UserId         *uuid.UUID           `json:"user_id,omitempty" db:"user_id" type:"uuid"`
+	StartDate      *helper.Date         `json:"start_date,omitempty" db:"start_date" type:"date"`
+	EndDate        *helper.Date         `json:"end_date,omitempty" db:"end_date" type:"date"`
+	CaloriesTarget float64              `json:"calories_target,omitempty" db:"calories_target" type:"float64"`
+	Model          string               `json:"model,omitempty" db:"model" type:"string"`
+	PromptVersion  string               `json:"prompt_version,omitempty" db:"prompt_version" type:"string"`
+	IsActive       bool                 `json:"is_active" db:"is_active" type:"bool"`
+	Days           []*MealPlanDay       `json:"days" db:"-"`
+	Note           string               `json:"note" db:"note" type:"string"`
+	Total          *Nutrition           `json:"total,omitempty" db:"-"`
+	Cache          *CacheInfo           `json:"cache,omitempty" db:"-"`
+	Citations      []*KnowledgeCitation `json:"citations,omitempty" db:"citations"`
+	Safety         *MealPlanSafety      `json:"safety,omitempty" db:"safety"`
+	CreatedAt      *helper.Timestamp    `json:"created_at,omitempty" db:"created_at" type:"timestamp"`
+	UpdatedAt      *helper.Timestamp    `json:"updated_at,omitempty" db:"updated_at" type:"timestamp"`
+}
+
+type MealPlanDay struct {
+	TableName  struct{}        `json:"-" db:"meal_plan_days" pk:"Id"`
+	Id         *uuid.UUID      `json:"id,omitempty" db:"id" type:"uuid"`
+	MealPlanId *uuid.UUID      `json:"meal_plan_id,omitempty" db:"meal_plan_id" type:"uuid"`
+	Day        int             `json:"day" db:"day" type:"int"`
+	Date       *helper.Date    `json:"date,omitempty" db:"date" type:"date"`
+	Meals      []*MealPlanMeal `json:"meals" db:"-"`
+	Total      *Nutrition      `json:"total,omitempty" db:"-"`
+}
+
+type MealPlanMeal struct {
+	TableName     struct{}        `json:"-" db:"meal_plan_meals" pk:"Id"`
+	Id            *uuid.UUID      `json:"id,omitempty" db:"id" type:"uuid"`
+	MealPlanDayId *uuid.UUID      `json:"meal_plan_day_id,omitempty" db:"meal_plan_day_id" type:"uuid"`
+	MealType      MealType        `json:"meal_type" db:"meal_type" type:"string"`
+	Time          string          `json:"time" db:"time" type:"string"`
+	Name          string          `json:"name" db:"name" type:"string"`
+	Items         []*MealPlanItem `json:"items" db:"-"`
+	Total         *Nutrition      `json:"total,omitempty" db:"-"`
+}
+
+type MealPlanItem struct {
+	TableName      struct{}   `json:"-" db:"meal_plan_items" pk:"Id"`
+	Id             *uuid.UUID `json:"id,omitempty" db:"id" type:"uuid"`
+	MealPlanMealId *uuid.UUID `json:"meal_plan_meal_id,omitempty" db:"meal_plan_meal_id" type:"uuid"`
+	Name           string     `json:"name" db:"name" type:"string"`
+	Portion        string     `json:"portion" db:"portion" type:"string"`
+	Calories       float64    `json:"calories" db:"calories" type:"float64"`
+	Protein        float64    `json:"protein" db:"protein" type:"float64"`
+	Carbohydrate   float64    `json:"carbohydrate" db:"carbohydrate" type:"float64"`
+	Fat            float64    `json:"fat" db:"fat" type:"float64"`
+	/* สารอาหารที่ใช้ตรวจตามโรคประจำตัว โซเดียม โพแทสเซียม พิวรีนเป็นมิลลิกรัม น้ำตาลเป็นกรัม nil คือโมเดลไม่ได้ระบุ */
+	Sodium    *float64 `json:"sodium,omitempty" db:"sodium" type:"float64"`
+	Potassium *float64 `json:"potassium,omitempty" db:"potassium" type:"float64"`
+	Purine    *float64 `json:"purine,omitempty" db:"purine" type:"float64"`
+	Sugar     *float64 `json:"sugar,omitempty" db:"sugar" type:"float64"`
+}
+
+/* MealPlanOption ค่าที่ผู้ใช้กำหนดเพิ่มได้ตอนสร้างแผน งบประมาณเป็นบาทต่อวัน Fresh คือไม่ใช้แผนจาก cache */
+type MealPlanOption struct {
+	Days    int     `json:"days"`
+	Cuisine string  `json:"cuisine"`
+	Budget  float64 `json:"budget"`
+	Fresh   bool    `json:"fresh"`
+}
+
+func NewMealPlanOptionWithParams(params map[string]interface{}) *MealPlanOption {
+	option := &MealPlanOption{Days: DEFAULT_MEAL_PLAN_DAYS}
+	for key, val := range params {
+		switch key {
+		case "days":
+			if days := cast.ToInt(val); days > 0 {
+				option.Days = days
+			}
+		case "cuisine":
+			option.Cuisine = strings.TrimSpace(cast.ToString(val))
+		case "budget":
+			option.Budget = cast.ToFloat64(val)
+		case "fresh":
+			option.Fresh = cast.ToBool(val)
+		}
+	}
+
+	return option
+}
+
+/* mealPlanContent ส่วนที่โมเดลต้องตอบ แยกจาก MealPlan เพื่อไม่ให้ field ที่ระบบเป็นผู้กำหนดหลุดมาจากคำตอบ */
+type mealPlanContent struct {
+	Days []*MealPlanDay `json:"days"`
+	Note string         `json:"note"`
+}
+
+func (i *MealPlanItem) GetNutrition() *Nutrition {
+	return &Nutrition{
+		Calories:     i.Calories,
+		Protein:      i.Protein,
+		Carbohydrate: i.Carbohydrate,
+		Fat:          i.Fat,
+	}
+}
+
+/* DecodeMealPlan แปลงข้อความจากโมเดลเป็น MealPlan แบบเข้มงวด ห้ามมี field เกิน และต้องผ่าน Validate */
+func DecodeMealPlan(content string, days int) (*MealPlan, error) {
+	raw := extractJSONObject(content)
+	if raw == "" {
+		return nil, errors.New("response is not a JSON object")
+	}
+
+	decoder := json.NewDecoder(bytes.NewBufferString(raw))
+	decoder.DisallowUnknownFields()
+	answer := new(mealPlanContent)
+	if err := decoder.Decode(answer); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	if decoder.More() {
+		return nil, errors.New("invalid JSON: unexpected data after the plan object")
+	}
+	plan := &MealPlan{Days: answer.Days, Note: answer.Note}
+	if err := plan.Validate(days); err != nil {
+		return nil, err
+	}
+	plan.CalculateTotals()
+
+	return plan, nil
+}
+
+/* Validate ตรวจทุกจุดแล้วรวมข้อผิดพลาดไว้ในข้อความเดียว เพื่อส่งกลับไปให้โมเดลแก้ */
+func (m *MealPlan) Validate(days int) error {
+	var issues []string
+	if len(m.Days) == 0 {
+		issues = append(issues, "days: must not be empty")
+	}
+	if days > 0 && len(m.Days) != days {
+		issues = append(issues, fmt.Sprintf("days: must have exactly %d days, got %d", days, len(m.Days)))
+	}
+	for dayIndex, day := range m.Days {
+		dayPath := fmt.Sprintf("days[%d]", dayIndex)
+		if day == nil {
+			issues = append(issues, dayPath+": must not be null")
+			continue
+		}
+		if day.Day != dayIndex+1 {
+			issues = append(issues, fmt.Sprintf("%s.day: must be %d", dayPath, dayIndex+1))
+		}
+		if len(day.Meals) == 0 {
+			issues = append(issues, dayPath+".meals: must not be empty")
+		}
+		for mealIndex, meal := range day.Meals {
+			mealPath := fmt.Sprintf("%s.meals[%d]", dayPath, mealIndex)
+			if meal == nil {
+				issues = append(issues, mealPath+": must not be null")
+				continue
+			}
+			diary := &FoodDiary{MealType: meal.MealType}
+			if !diary.IsMealType() {
+				issues = append(issues, mealPath+".meal_type: must be one of BREAKFAST, LUNCH, DINNER, SNACK")
+			}
+			if !mealTimePattern.MatchString(meal.Time) {
+				issues = append(issues, mealPath+".time: must be HH:MM")
+			}
+			if strings.TrimSpace(meal.Name) == "" {
+				issues = append(issues, mealPath+".name: must not be empty")
+			}
+			if len(meal.Items) == 0 {
+				issues = append(issues, mealPath+".items: must not be empty")
+			}
+			for itemIndex, item := range meal.Items {
+				issues = append(issues, validateMealPlanItem(fmt.Sprintf("%s.items[%d]", mealPath, itemIndex), item)...)
+			}
+		}
+	}
+	if len(issues) > 0 {
+		return errors.New(strings.Join(issues, "; "))
+	}
+	return nil
+}
+
+func validateMealPlanItem(itemPath string, item *MealPlanItem) []string {
+	if item == nil {
+		return []string{itemPath + ": must not be null"}
+	}
+	var issues []string
+	if strings.TrimSpace(item.Name) == "" {
+		issues = append(issues, itemPath+".name: must not be empty")
+	}
+	if strings.TrimSpace(item.Portion) == "" {
+		issues = append(issues, itemPath+".portion: must not be empty")
+	}
+	if item.Calories < 0 || item.Protein < 0 || item.Carbohydrate < 0 || item.Fat < 0 {
+		issues = append(issues, itemPath+": calories and macros must not be negative")
+	}
+	for _, nutrient := range Nutrients {
+		if value := item.GetNutrient(nutrient); value != nil && *value < 0 {
+			issues = append(issues, fmt.Sprintf("%s.%s: must not be negative", itemPath, nutrient.Key()))
+		}
+	}
+	return issues
+}
+
+/* CalculateTotals รวมค่าโภชนาการจากรายการอาหารเอง ไม่เชื่อยอดรวมที่โมเดลคำนวณ */
+func (m *MealPlan) CalculateTotals() {
+	m.Total = new(Nutrition)
+	for _, day := range m.Days {
+		day.Total = new(Nutrition)
+		for _, meal := range day.Meals {
+			meal.Total = new(Nutrition)
+			for _, item := range meal.Items {
+				meal.Total.Add(item.GetNutrition())
+			}
+			meal.Total.Round()
+			day.Total.Add(meal.Total)
+		}
+		day.Total.Round()
+		m.Total.Add(day.Total)
+	}
+	m.Total.Round()
+}
+
+func (m *MealPlan) NewID() {
+	id := uuid.Must(uuid.NewV4())
+	m.Id = &id
+	for _, day := range m.Days {
+		dayId := uuid.Must(uuid.NewV4())
+		day.Id = &dayId
+		day.MealPlanId = m.Id
+		for _, meal := range day.Meals {
+			mealId := uuid.Must(uuid.NewV4())
+			meal.Id = &mealId
+			meal.MealPlanDayId = day.Id
+			for _, item := range meal.Items {
+				itemId := uuid.Must(uuid.NewV4())
+				item.Id = &itemId
+				item.MealPlanMealId = meal.Id
+			}
+		}
+	}
+}
+
+func (m *MealPlan) SetCreatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	m.CreatedAt = &ti
+}
+
+func (m *MealPlan) SetUpdatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	m.UpdatedAt = &ti
+}
+
+/* SetDateRange กำหนดวันที่ของแต่ละวันในแผนโดยนับต่อจากวันเริ่มต้น */
+func (m *MealPlan) SetDateRange(startDate *helper.Date) {
+	start := time.Time(*startDate)
+	for _, day := range m.Days {
+		date := helper.NewDateFromTime(start.AddDate(0, 0, day.Day-1))
+		day.Date = &date
+	}
+	endDate := helper.NewDateFromTime(start.AddDate(0, 0, len(m.Days)-1))
+	m.StartDate = startDate
+	m.EndDate = &endDate
+}
+
+/* GetDay คืนวันในแผนที่ตรงกับวันที่ ไม่พบคืน nil */
+func (m *MealPlan) GetDay(date *helper.Date) *MealPlanDay {
+	for _, day := range m.Days {
+		if day.Date != nil && day.Date.String() == date.String() {
+			return day
+		}
+	}
+	return nil
+}
+
+/* MealPlanComparison เทียบสิ่งที่กินจริงในไดอารี่กับแผนอาหารที่ใช้งานอยู่ของวันนั้น */
+type MealPlanComparison struct {
+	UserId     *uuid.UUID                   `json:"user_id"`
+	Date       *helper.Date                 `json:"date"`
+	MealPlanId *uuid.UUID                   `json:"meal_plan_id"`
+	Day        int                          `json:"day"`
+	Planned    *Nutrition                   `json:"planned"`
+	Eaten      *Nutrition                   `json:"eaten"`
+	Difference *Nutrition                   `json:"difference"`
+	Meals      map[MealType]*MealComparison `json:"meals"`
+}
+
+type MealComparison struct {
+	Planned      *Nutrition      `json:"planned"`
+	Eaten        *Nutrition      `json:"eaten"`
+	Difference   *Nutrition      `json:"difference"`
+	PlannedMeals []*MealPlanMeal `json:"planned_meals"`
+	Entries      []*FoodDiary    `json:"entries"`
+}
+
+/* NewMealPlanComparison รวมค่าโภชนาการตามมื้อทั้งฝั่งแผนและไดอารี่ ส่วนต่างคือกินจริงลบแผน (ค่าบวกคือกินเกินแผน) */
+func NewMealPlanComparison(userId *uuid.UUID, date *helper.Date, plan *MealPlan, entries []*FoodDiary) *MealPlanComparison {
+	comparison := &MealPlanComparison{
+		UserId:     userId,
+		Date:       date,
+		MealPlanId: plan.Id,
+		Planned:    new(Nutrition),
+		Eaten:      new(Nutrition),
+		Meals:      make(map[MealType]*MealComparison),
+	}
+	for index := range MealTypes {
+		comparison.Meals[MealTypes[index]] = &MealComparison{
+			Planned:      new(Nutrition),
+			Eaten:        new(Nutrition),
+			PlannedMeals: make([]*MealPlanMeal, 0),
+			Entries:      make([]*FoodDiary, 0),
+		}
+	}
+	if day := plan.GetDay(date); day != nil {
+		comparison.Day = day.Day
+		for _, meal := range day.Meals {
+			nutrition := new(Nutrition)
+			for _, item := range meal.Items {
+				nutrition.Add(item.GetNutrition())
+			}
+			comparison.Planned.Add(nutrition)
+			if mealComparison, ok := comparison.Meals[meal.MealType]; ok {
+				mealComparison.Planned.Add(nutrition)
+				mealComparison.PlannedMeals = append(mealComparison.PlannedMeals, meal)
+			}
+		}
+	}
+	for _, entry := range entries {
+		nutrition := entry.GetNutrition()
+		comparison.Eaten.Add(nutrition)
+		if mealComparison, ok := comparison.Meals[entry.MealType]; ok {
+			mealComparison.Eaten.Add(nutrition)
+			mealComparison.Entries = append(mealComparison.Entries, entry)
+		}
+	}
+	comparison.Planned.Round()
+	comparison.Eaten.Round()
+	comparison.Difference = comparison.Eaten.Sub(comparison.Planned)
+	comparison.Difference.Round()
+	for _, mealComparison := range comparison.Meals {
+		mealComparison.Planned.Round()
+		mealComparison.Eaten.Round()
+		mealComparison.Difference = mealComparison.Eaten.Sub(mealComparison.Planned)
+		mealComparison.Difference.Round()
+	}
+
+	return comparison
+}
+
+/* extractJSONObject ตัด markdown code fence และข้อความรอบนอกออก เหลือเฉพาะ object แรกถึงปีกกาปิดสุดท้าย */
+func extractJSONObject(content string) string {
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start < 0 || end < start {
+		return ""
+	}
+	return content[start : end+1]
+}
@@ -0,0 +1,399 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+/* เกณฑ์ของมื้อใหม่ พลังงานทั้งวันห่างจากเป้าได้ไม่เกินร้อยละ 10 สารอาหารหลักทั้งวันเปลี่ยนจากเดิมได้ไม่เกินร้อยละ 20 หรือ 10 กรัม */
+const (
+	MEAL_SWAP_CALORIES_TOLERANCE  = 0.1
+	MEAL_SWAP_MACRO_TOLERANCE     = 0.2
+	MEAL_SWAP_MIN_MACRO_TOLERANCE = 10
+	MAX_MEAL_SWAP_REASON_LENGTH   = 255
+)
+
+/* MealSwapJSONSchema รูปแบบที่บังคับให้โมเดลตอบกลับตอนเปลี่ยนมื้อ รายการอาหารเหมือนใน MealPlanJSONSchema */
+const MealSwapJSONSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["name", "items"],
+  "properties": {
+    "name": { "type": "string", "minLength": 1 },
+    "items": {
+      "type": "array",
+      "minItems": 1,
+      "items": {
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["name", "portion", "calories", "protein", "carbohydrate", "fat"],
+        "properties": {
+          "name": { "type": "string", "minLength": 1 },
+          "portion": { "type": "string", "minLength": 1 },
+          "calories": { "type": "number", "minimum": 0 },
+          "protein": { "type": "number", "minimum": 0 },
+          "carbohydrate": { "type": "number", "minimum": 0 },
+          "fat": { "type": "number", "minimum": 0 },
+          "sodium": { "type": "number", "minimum": 0 },
+          "potassium": { "type": "number", "minimum": 0 },
+          "purine": { "type": "number", "minimum": 0 },
+          "sugar": { "type": "number", "minimum": 0 }
+        }
+      }
+    }
+  }
+}`
+
+/* MealPlanSwapOption มื้อหรือรายการที่ผู้ใช้ต้องการเปลี่ยน ไม่ระบุ ItemId คือเปลี่ยนทั้งมื้อ */
+type MealPlanSwapOption struct {
+	MealId *uuid.UUID `json:"meal_id"`
+	ItemId *uuid.UUID `json:"item_id"`
+	Reason string     `json:"reason"`
+}
+
+func NewMealPlanSwapOptionWithParams(params map[string]interface{}) *MealPlanSwapOption {
+	option := new(MealPlanSwapOption)
+	for key, val := range params {
+		switch key {
+		case "meal_id":
+			if id, err := uuid.FromString(cast.ToString(val)); err == nil {
+				option.MealId = &id
+			}
+		case "item_id":
+			if id, err := uuid.FromString(cast.ToString(val)); err == nil {
+				option.ItemId = &id
+			}
+		case "reason":
+			option.Reason = strings.TrimSpace(cast.ToString(val))
+		}
+	}
+
+	return option
+}
+
+/* MealPlanSwap ประวัติการเปลี่ยนมื้อ Before และ After เก็บทั้งมื้อแม้จะเปลี่ยนเพียงรายการเดียว */
+type MealPlanSwap struct {
+	TableName      struct{}          `json:"-" db:"meal_plan_swaps" pk:"Id"`
+	Id             *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	MealPlanId     *uuid.UUID        `json:"meal_plan_id" db:"meal_plan_id" type:"uuid"`
+	MealPlanMealId *uuid.UUID        `json:"meal_plan_meal_id" db:"meal_plan_meal_id" type:"uuid"`
+	MealPlanItemId *uuid.UUID        `json:"meal_plan_item_id,omitempty" db:"meal_plan_item_id" type:"uuid"`
+	Day            int               `json:"day" db:"day" type:"int"`
+	MealType       MealType          `json:"meal_type" db:"meal_type" type:"string"`
+	Reason         string            `json:"reason" db:"reason" type:"string"`
+	Before         *MealPlanMeal     `json:"before" db:"before"`
+	After          *MealPlanMeal     `json:"after" db:"after"`
+	Model          string            `json:"model" db:"model" type:"string"`
+	PromptVersion  string            `json:"prompt_version" db:"prompt_version" type:"string"`
+	CreatedAt      *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+}
+
+func NewMealPlanSwap(planId *uuid.UUID, target *MealSwapTarget, after *MealPlanMeal, reason string) *MealPlanSwap {
+	id := uuid.Must(uuid.NewV4())
+	swap := &MealPlanSwap{
+		Id:             &id,
+		MealPlanId:     planId,
+		MealPlanMealId: target.Meal.Id,
+		Day:            target.Day.Day,
+		MealType:       target.Meal.MealType,
+		Reason:         reason,
+		Before:         target.Meal,
+		After:          after,
+	}
+	if target.Item != nil {
+		swap.MealPlanItemId = target.Item.Id
+	}
+	swap.SetCreatedAt()
+	return swap
+}
+
+func (s *MealPlanSwap) SetCreatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	s.CreatedAt = &ti
+}
+
+/* MealSwapTarget ตำแหน่งในแผนที่จะเปลี่ยน Item เป็น nil เมื่อเปลี่ยนทั้งมื้อ */
+type MealSwapTarget struct {
+	Day  *MealPlanDay
+	Meal *MealPlanMeal
+	Item *MealPlanItem
+}
+
+/* FindSwapTarget หามื้อและรายการตาม id ในแผน */
+func (m *MealPlan) FindSwapTarget(option *MealPlanSwapOption) (*MealSwapTarget, error) {
+	if option.MealId == nil {
+		return nil, apperror.NotFound(constants.ERROR_MEAL_PLAN_MEAL_NOT_FOUND)
+	}
+	for _, day := range m.Days {
+		for _, meal := range day.Meals {
+			if meal.Id == nil || *meal.Id != *option.MealId {
+				continue
+			}
+			target := &MealSwapTarget{Day: day, Meal: meal}
+			if option.ItemId == nil {
+				return target, nil
+			}
+			for _, item := range meal.Items {
+				if item.Id != nil && *item.Id == *option.ItemId {
+					target.Item = item
+					return target, nil
+				}
+			}
+			return nil, apperror.NotFound(constants.ERROR_MEAL_PLAN_ITEM_NOT_FOUND)
+		}
+	}
+	return nil, apperror.NotFound(constants.ERROR_MEAL_PLAN_MEAL_NOT_FOUND)
+}
+
+/* Replaced ค่าโภชนาการของส่วนที่จะถูกเปลี่ยน */
+func (t *MealSwapTarget) Replaced() *Nutrition {
+	if t.Item != nil {
+		return t.Item.GetNutrition()
+	}
+	return mealNutrition(t.Meal)
+}
+
+/* Budget พลังงานและสารอาหารหลักที่ส่วนใหม่ควรมีเพื่อให้ทั้งวันใกล้เป้า ไม่รู้เป้าพลังงาน (0) ให้ใกล้เคียงของเดิม */
+func (t *MealSwapTarget) Budget(caloriesTarget float64) *Nutrition {
+	budget := t.Replaced()
+	if caloriesTarget > 0 {
+		rest := dayNutrition(t.Day).Sub(budget)
+		if calories := caloriesTarget - rest.Calories; calories > 0 {
+			budget.Calories = calories
+		}
+	}
+	budget.Round()
+	return budget
+}
+
+/* SwappedDay สำเนาของวันที่แทนมื้อเดิมด้วยมื้อใหม่ ไม่แก้แผนเดิม */
+func (t *MealSwapTarget) SwappedDay(after *MealPlanMeal) *MealPlanDay {
+	day := *t.Day
+	day.Meals = make([]*MealPlanMeal, 0, len(t.Day.Meals))
+	for _, meal := range t.Day.Meals {
+		if meal == t.Meal {
+			meal = after
+		}
+		day.Meals = append(day.Meals, meal)
+	}
+	return &day
+}
+
+/* Apply แทนมื้อเดิมในแผนด้วยมื้อใหม่ */
+func (t *MealSwapTarget) Apply(after *MealPlanMeal) {
+	for index, meal := range t.Day.Meals {
+		if meal == t.Meal {
+			t.Day.Meals[index] = after
+		}
+	}
+	t.Meal = after
+}
+
+/* mealSwapContent ส่วนที่โมเดลต้องตอบตอนเปลี่ยนมื้อ */
+type mealSwapContent struct {
+	Name  string          `json:"name"`
+	Items []*MealPlanItem `json:"items"`
+}
+
+/* DecodeMealSwap แปลงคำตอบเป็นมื้อใหม่แบบเข้มงวด ถ้าเปลี่ยนรายการเดียวต้องตอบมาหนึ่งรายการ ซึ่งจะแทนที่รายการเดิมโดยรายการอื่นคงเดิม */
+func DecodeMealSwap(content string, target *MealSwapTarget) (*MealPlanMeal, error) {
+	raw := extractJSONObject(content)
+	if raw == "" {
+		return nil, errors.New("response is not a JSON object")
+	}
+
+	decoder := json.NewDecoder(bytes.NewBufferString(raw))
+	decoder.DisallowUnknownFields()
+	answer := new(mealSwapContent)
+	if err := decoder.Decode(answer); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	if decoder.More() {
+		return nil, errors.New("invalid JSON: unexpected data after the meal object")
+	}
+
+	var issues []string
+	if strings.TrimSpace(answer.Name) == "" {
+		issues = append(issues, "name: must not be empty")
+	}
+	if len(answer.Items) == 0 {
+		issues = append(issues, "items: must not be empty")
+	}
+	if target.Item != nil && len(answer.Items) > 1 {
+		issues = append(issues, fmt.Sprintf("items: must have exactly 1 item, got %d", len(answer.Items)))
+	}
+	for index, item := range answer.Items {
+		issues = append(issues, validateMealPlanItem(fmt.Sprintf("items[%d]", index), item)...)
+	}
+	if len(issues) > 0 {
+		return nil, errors.New(strings.Join(issues, "; "))
+	}
+
+	/* id ของรายการเป็นของระบบ ไม่ใช้ค่าที่โมเดลตอบมา */
+	for _, item := range answer.Items {
+		item.Id = nil
+	}
+	meal := &MealPlanMeal{
+		Id:            target.Meal.Id,
+		MealPlanDayId: target.Meal.MealPlanDayId,
+		MealType:      target.Meal.MealType,
+		Time:          target.Meal.Time,
+		Name:          answer.Name,
+		Items:         answer.Items,
+	}
+	if target.Item != nil {
+		replacement := answer.Items[0]
+		replacement.Id = target.Item.Id
+		meal.Name = target.Meal.Name
+		meal.Items = make([]*MealPlanItem, 0, len(target.Meal.Items))
+		for _, item := range target.Meal.Items {
+			if item == target.Item {
+				item = replacement
+			}
+			meal.Items = append(meal.Items, item)
+		}
+	}
+	for _, item := range meal.Items {
+		if item.Id == nil {
+			itemId := uuid.Must(uuid.NewV4())
+			item.Id = &itemId
+		}
+		item.MealPlanMealId = meal.Id
+	}
+	meal.Total = mealNutrition(meal)
+	meal.Total.Round()
+
+	return meal, nil
+}
+
+/* Check ตรวจว่ามื้อใหม่คุมทั้งวันไว้ในเป้า ไม่มีอาหารที่แพ้หรือไม่ชอบ ไม่ซ้ำของเดิม และไม่ทำให้เกินกฎตามโรคประจำตัวเพิ่มขึ้น */
+func (t *MealSwapTarget) Check(after *MealPlanMeal, caloriesTarget float64, userInfo *UserInfo, rules []*DiseaseRule) error {
+	var issues []string
+	day := t.SwappedDay(after)
+	before, changed := dayNutrition(t.Day), dayNutrition(day)
+
+	if caloriesTarget <= 0 {
+		caloriesTarget = before.Calories
+	}
+	/* วันที่ห่างจากเป้าอยู่แล้วต้องไม่ห่างกว่าเดิม */
+	tolerance := math.Max(caloriesTarget*MEAL_SWAP_CALORIES_TOLERANCE, math.Abs(before.Calories-caloriesTarget))
+	if math.Abs(changed.Calories-caloriesTarget) > tolerance {
+		issues = append(issues, fmt.Sprintf("day calories %.0f kcal is outside the target, keep the day within %.0f-%.0f kcal", changed.Calories, caloriesTarget-tolerance, caloriesTarget+tolerance))
+	}
+	macros := []struct {
+		name            string
+		before, changed float64
+	}{
+		{"protein", before.Protein, changed.Protein},
+		{"carbohydrate", before.Carbohydrate, changed.Carbohydrate},
+		{"fat", before.Fat, changed.Fat},
+	}
+	for _, macro := range macros {
+		tolerance := math.Max(macro.before*MEAL_SWAP_MACRO_TOLERANCE, MEAL_SWAP_MIN_MACRO_TOLERANCE)
+		if math.Abs(macro.changed-macro.before) > tolerance {
+			issues = append(issues, fmt.Sprintf("day %s %.1f g changed too much, keep it within %.1f-%.1f g", macro.name, macro.changed, macro.before-tolerance, macro.before+tolerance))
+		}
+	}
+
+	items := after.Items
+	if t.Item != nil {
+		items = make([]*MealPlanItem, 0, 1)
+		for _, item := range after.Items {
+			if item.Id != nil && t.Item.Id != nil && *item.Id == *t.Item.Id {
+				items = append(items, item)
+			}
+		}
+		for _, item := range items {
+			if strings.EqualFold(strings.TrimSpace(item.Name), strings.TrimSpace(t.Item.Name)) {
+				issues = append(issues, fmt.Sprintf("items[0].name: must be a different dish from %q", t.Item.Name))
+			}
+		}
+	} else if strings.EqualFold(strings.TrimSpace(after.Name), strings.TrimSpace(t.Meal.Name)) {
+		issues = append(issues, fmt.Sprintf("name: must be a different meal from %q", t.Meal.Name))
+	}
+	if userInfo != nil {
+		for _, item := range items {
+			for _, allergy := range userInfo.GetFoodPreferences(FoodPreferenceAllergy) {
+				if containsFold(item.Name, allergy) {
+					issues = append(issues, fmt.Sprintf("item %q contains %q which the user is allergic to", item.Name, allergy))
+				}
+			}
+			for _, dislike := range userInfo.GetFoodPreferences(FoodPreferenceDislike) {
+				if containsFold(item.Name, dislike) {
+					issues = append(issues, fmt.Sprintf("item %q contains %q which the user dislikes", item.Name, dislike))
+				}
+			}
+		}
+	}
+
+	if len(rules) > 0 {
+		var weight float64
+		if userInfo != nil {
+			weight = userInfo.Weight
+		}
+		/* ข้อจำกัดที่เกินอยู่แล้วก่อนเปลี่ยน นับเป็นปัญหาเฉพาะเมื่อของใหม่ทำให้เกินมากขึ้น */
+		exceeded := make(map[RuleScope]map[Nutrient]float64)
+		for _, scope := range []RuleScope{RuleScopeMeal, RuleScopeDay} {
+			exceeded[scope] = make(map[Nutrient]float64)
+		}
+		for _, violation := range CheckMealPlan(&MealPlan{Days: []*MealPlanDay{t.Day}}, rules, weight) {
+			if violation.Scope == RuleScopeDay || (violation.Scope == RuleScopeMeal && violation.MealType == t.Meal.MealType && violation.MealName == t.Meal.Name) {
+				exceeded[violation.Scope][violation.Nutrient] = violation.Value
+			}
+		}
+		for _, violation := range CheckMealPlan(&MealPlan{Days: []*MealPlanDay{day}}, rules, weight) {
+			if violation.Missing {
+				if !slices.ContainsFunc(items, func(item *MealPlanItem) bool { return item.Name == violation.ItemName }) {
+					continue
+				}
+			} else {
+				if violation.Scope == RuleScopeMeal && (violation.MealType != after.MealType || violation.MealName != after.Name) {
+					continue
+				}
+				if value, ok := exceeded[violation.Scope][violation.Nutrient]; ok && violation.Value <= value {
+					continue
+				}
+			}
+			issues = append(issues, violation.String())
+		}
+	}
+
+	if len(issues) > 0 {
+		return errors.New(strings.Join(issues, "; "))
+	}
+	return nil
+}
+
+func mealNutrition(meal *MealPlanMeal) *Nutrition {
+	nutrition := new(Nutrition)
+	for _, item := range meal.Items {
+		nutrition.Add(item.GetNutrition())
+	}
+	return nutrition
+}
+
+func dayNutrition(day *MealPlanDay) *Nutrition {
+	nutrition := new(Nutrition)
+	for _, meal := range day.Meals {
+		nutrition.Add(mealNutrition(meal))
+	}
+	return nutrition
+}
+
+func containsFold(s, substr string) bool {
+	substr = strings.TrimSpace(substr)
+	return substr != "" && strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+/* ชื่อ prompt ที่ agent ใช้ ต้องตรงกับชื่อไฟล์ใน templates */
+const (
+	PromptMealPlanSystem   = "meal_plan_system"
+	PromptUserInfoTemplate = "user_info_template"
+	PromptUserInfo         = "user_info"
+	PromptChatSystem       = "chat_system"
+	PromptChatSummary      = "chat_summary"
+	PromptMealPhotoSystem  = "meal_photo_system"
+)
+
+/* Prompt template หนึ่งเวอร์ชัน แต่ละชื่อมีเวอร์ชันที่ active ได้ครั้งละหนึ่งเวอร์ชัน */
+type Prompt struct {
+	TableName struct{}          `json:"-" db:"prompts" pk:"Id"`
+	Id        *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	Name      string            `json:"name" db:"name" type:"string"`
+	Version   int               `json:"version" db:"version" type:"int32"`
+	Content   string            `json:"content" db:"content" type:"string"`
+	IsActive  bool              `json:"is_active" db:"is_active" type:"bool"`
+	CreatedBy *uuid.UUID        `json:"created_by" db:"created_by" type:"uuid"`
+	CreatedAt *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+}
+
+func NewPromptWithParams(params map[string]interface{}, ptr *Prompt) *Prompt {
+	if ptr == nil {
+		ptr = new(Prompt)
+	}
+	for key, val := range params {
+		switch key {
+		case "name":
+			ptr.Name = strings.TrimSpace(cast.ToString(val))
+		case "content":
+			ptr.Content = cast.ToString(val)
+		case "is_active":
+			ptr.IsActive = cast.ToBool(val)
+		}
+	}
+
+	return ptr
+}
+
+func (p *Prompt) NewID() {
+	id := uuid.Must(uuid.NewV4())
+	p.Id = &id
+}
+
+func (p *Prompt) SetCreatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	p.CreatedAt = &ti
+}
+
+func (p *Prompt) SetUpdatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	p.UpdatedAt = &ti
+}
+
+/* PromptVersionLabel ป้ายเวอร์ชันที่บันทึกคู่กับผลลัพธ์ เวอร์ชัน 0 คือค่าตั้งต้นที่ฝังใน binary */
+func PromptVersionLabel(name string, version int) string {
+	if version == 0 {
+		return name + ".default"
+	}
+	return fmt.Sprintf("%s.v%d", name, version)
+}
+
+/* RenderedPrompt ข้อความที่ render แล้วพร้อมป้ายเวอร์ชันของ template ที่ใช้ */
+type RenderedPrompt struct {
+	Name    string
+	Version string
+	Text    string
+}
+
+/* JoinPromptVersions รวมป้ายเวอร์ชันของทุก prompt ที่ใช้ในการสร้างผลลัพธ์หนึ่งครั้ง */
+func JoinPromptVersions(prompts ...*RenderedPrompt) string {
+	versions := make([]string, 0, len(prompts))
+	for _, prompt := range prompts {
+		versions = append(versions, prompt.Version)
+	}
+	return strings.Join(versions, "+")
+}
@@ -0,0 +1,196 @@
+package models
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+/* Recipe ค่าโภชนาการ (calories, protein, carbohydrate, fat) คิดต่อ 1 ที่เสิร์ฟ */
+type Recipe struct {
+	TableName    struct{}          `json:"-" db:"recipes" pk:"Id"`
+	Id           *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	UserId       *uuid.UUID        `json:"user_id" db:"user_id" type:"uuid"`
+	Name         string            `json:"name" db:"name" type:"string"`
+	Description  string            `json:"description" db:"description" type:"string"`
+	Servings     int               `json:"servings" db:"servings" type:"int"`
+	Calories     float64           `json:"calories" db:"calories" type:"float64"`
+	Protein      float64           `json:"protein" db:"protein" type:"float64"`
+	Carbohydrate float64           `json:"carbohydrate" db:"carbohydrate" type:"float64"`
+	Fat          float64           `json:"fat" db:"fat" type:"float64"`
+	CreatedAt    *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt    *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+
+	Ingredients []*RecipeIngredient `json:"ingredients" db:"-" fk:"fk_field1:Id, fk_field2:RecipeId"`
+	Steps       []*RecipeStep       `json:"steps" db:"-" fk:"fk_field1:Id, fk_field2:RecipeId"`
+	Images      []*Image            `json:"images" db:"-" fk:"fk_field1:Id, fk_field2:RefId"`
+}
+
+type RecipeIngredient struct {
+	TableName struct{}          `json:"-" db:"recipe_ingredients" pk:"Id"`
+	Id        *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	RecipeId  *uuid.UUID        `json:"recipe_id" db:"recipe_id" type:"uuid"`
+	FoodId    *uuid.UUID        `json:"food_id" db:"food_id" type:"uuid"`
+	Quantity  float64           `json:"quantity" db:"quantity" type:"float64"`
+	Unit      string            `json:"unit" db:"unit" type:"string"`
+	Grams     float64           `json:"grams" db:"grams" type:"float64"`
+	Note      string            `json:"note" db:"note" type:"string"`
+	CreatedAt *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+
+	Food *Food `json:"food" db:"-" fk:"fk_field1:FoodId, fk_field2:Id"`
+}
+
+type RecipeStep struct {
+	TableName   struct{}          `json:"-" db:"recipe_steps" pk:"Id"`
+	Id          *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	RecipeId    *uuid.UUID        `json:"recipe_id" db:"recipe_id" type:"uuid"`
+	StepNo      int               `json:"step_no" db:"step_no" type:"int"`
+	Description string            `json:"description" db:"description" type:"string"`
+	CreatedAt   *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt   *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+}
+
+func NewRecipeWithParams(params map[string]interface{}, ptr *Recipe) *Recipe {
+	if ptr == nil {
+		ptr = new(Recipe)
+	}
+	for key, val := range params {
+		switch key {
+		case "user_id":
+			userId := uuid.FromStringOrNil(cast.ToString(val))
+			ptr.UserId = &userId
+		case "name":
+			ptr.Name = cast.ToString(val)
+		case "description":
+			ptr.Description = cast.ToString(val)
+		case "servings":
+			ptr.Servings = cast.ToInt(val)
+		case "ingredients":
+			ptr.Ingredients = make([]*RecipeIngredient, 0)
+			for _, item := range toSliceOfMap(val) {
+				ingredient := new(RecipeIngredient)
+				if foodId, ok := item["food_id"]; ok {
+					id := uuid.FromStringOrNil(cast.ToString(foodId))
+					ingredient.FoodId = &id
+				}
+				ingredient.Quantity = cast.ToFloat64(item["quantity"])
+				ingredient.Unit = cast.ToString(item["unit"])
+				ingredient.Note = cast.ToString(item["note"])
+				ptr.Ingredients = append(ptr.Ingredients, ingredient)
+			}
+		case "steps":
+			ptr.Steps = make([]*RecipeStep, 0)
+			for _, item := range cast.ToSlice(val) {
+				step := new(RecipeStep)
+				if reflect.TypeOf(item) != nil && reflect.TypeOf(item).Kind() == reflect.Map {
+					step.Description = cast.ToString(cast.ToStringMap(item)["description"])
+				} else {
+					step.Description = cast.ToString(item)
+				}
+				ptr.Steps = append(ptr.Steps, step)
+			}
+		}
+	}
+
+	return ptr
+}
+
+/* toSliceOfMap รองรับทั้ง array จาก json และ object ที่มี key เป็น index จาก multipart form */
+func toSliceOfMap(val interface{}) []map[string]interface{} {
+	items := make([]map[string]interface{}, 0)
+	if val == nil {
+		return items
+	}
+	switch reflect.TypeOf(val).Kind() {
+	case reflect.Slice:
+		for _, item := range cast.ToSlice(val) {
+			items = append(items, cast.ToStringMap(item))
+		}
+	case reflect.Map:
+		indexed := cast.ToStringMap(val)
+		for index := 0; index < len(indexed); index++ {
+			if item, ok := indexed[cast.ToString(index)]; ok {
+				items = append(items, cast.ToStringMap(item))
+			}
+		}
+	}
+	return items
+}
+
+func (r *Recipe) NewID() {
+	id := uuid.Must(uuid.NewV4())
+	r.Id = &id
+}
+
+func (r *Recipe) SetCreatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	r.CreatedAt = &ti
+}
+
+func (r *Recipe) SetUpdatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	r.UpdatedAt = &ti
+}
+
+/* PrepareChildren กำหนด id, ลำดับขั้นตอน และเวลาให้ส่วนผสมและขั้นตอนของสูตร */
+func (r *Recipe) PrepareChildren() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	for index := range r.Ingredients {
+		id := uuid.Must(uuid.NewV4())
+		r.Ingredients[index].Id = &id
+		r.Ingredients[index].RecipeId = r.Id
+		if r.Ingredients[index].Unit == "" {
+			r.Ingredients[index].Unit = UNIT_GRAM
+		}
+		r.Ingredients[index].CreatedAt = &ti
+		r.Ingredients[index].UpdatedAt = &ti
+	}
+	for index := range r.Steps {
+		id := uuid.Must(uuid.NewV4())
+		r.Steps[index].Id = &id
+		r.Steps[index].RecipeId = r.Id
+		r.Steps[index].StepNo = index + 1
+		r.Steps[index].CreatedAt = &ti
+		r.Steps[index].UpdatedAt = &ti
+	}
+}
+
+/* CalculateNutrition รวมค่าโภชนาการจากส่วนผสมทั้งหมด แล้วหารด้วยจำนวนที่เสิร์ฟ */
+func (r *Recipe) CalculateNutrition() {
+	if r.Servings <= 0 {
+		r.Servings = 1
+	}
+	total := new(Nutrition)
+	for index := range r.Ingredients {
+		ingredient := r.Ingredients[index]
+		if ingredient.Food == nil {
+			continue
+		}
+		ingredient.Grams = ConvertToGrams(ingredient.Quantity, ingredient.Unit, ingredient.Food)
+		total.Add(ingredient.Food.GetNutrition(ingredient.Grams))
+	}
+	perServing := &Nutrition{
+		Calories:     total.Calories / float64(r.Servings),
+		Protein:      total.Protein / float64(r.Servings),
+		Carbohydrate: total.Carbohydrate / float64(r.Servings),
+		Fat:          total.Fat / float64(r.Servings),
+	}
+	perServing.Round()
+	r.Calories = perServing.Calories
+	r.Protein = perServing.Protein
+	r.Carbohydrate = perServing.Carbohydrate
+	r.Fat = perServing.Fat
+}
+
+func (r *Recipe) GetNutritionPerServing() *Nutrition {
+	return &Nutrition{
+		Calories:     r.Calories,
+		Protein:      r.Protein,
+		Carbohydrate: r.Carbohydrate,
+		Fat:          r.Fat,
+	}
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+)
+
+/* CachedResponse คำตอบของโมเดลที่ผ่านการตรวจแล้ว เก็บไว้ตอบ prompt เดียวกันซ้ำโดยไม่ต้องเรียกโมเดล */
+type CachedResponse struct {
+	TableName     struct{}          `json:"-" db:"ai_response_caches" pk:"Key"`
+	Key           string            `json:"key" db:"key" type:"string"`
+	Model         string            `json:"model" db:"model" type:"string"`
+	PromptVersion string            `json:"prompt_version" db:"prompt_version" type:"string"`
+	Content       string            `json:"content" db:"content" type:"string"`
+	Hits          int               `json:"hits" db:"hits" type:"int32"`
+	CreatedAt     *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	ExpiresAt     *helper.Timestamp `json:"expires_at" db:"expires_at" type:"timestamp"`
+}
+
+func NewCachedResponse(key string, model string, promptVersion string, content string, ttl time.Duration) *CachedResponse {
+	now := time.Now()
+	createdAt := helper.NewTimestampFromTime(now)
+	expiresAt := helper.NewTimestampFromTime(now.Add(ttl))
+	return &CachedResponse{
+		Key:           key,
+		Model:         model,
+		PromptVersion: promptVersion,
+		Content:       content,
+		CreatedAt:     &createdAt,
+		ExpiresAt:     &expiresAt,
+	}
+}
+
+func (c *CachedResponse) IsExpired(now time.Time) bool {
+	return c.ExpiresAt == nil || !now.Before(c.ExpiresAt.ToTime())
+}
+
+/* CacheInfo บอกผู้เรียกว่าผลลัพธ์มาจาก cache หรือไม่ Bypass คือผู้ใช้ขอผลใหม่ */
+type CacheInfo struct {
+	Hit       bool              `json:"hit"`
+	Bypass    bool              `json:"bypass,omitempty"`
+	Key       string            `json:"key"`
+	Hits      int               `json:"hits,omitempty"`
+	CachedAt  *helper.Timestamp `json:"cached_at,omitempty"`
+	ExpiresAt *helper.Timestamp `json:"expires_at,omitempty"`
+}
@@ -0,0 +1,14 @@
+package models
+
+type StreamEvent string
+
+const (
+	StreamEventToken StreamEvent = "token"
+	StreamEventReset StreamEvent = "reset"
+	StreamEventTool  StreamEvent = "tool"
+	StreamEventDone  StreamEvent = "done"
+	StreamEventError StreamEvent = "error"
+)
+
+/* StreamFunc รับ event ระหว่างที่โมเดลกำลังตอบ คืน error เมื่อต้องการหยุด เช่น client ปิดการเชื่อมต่อ */
+type StreamFunc func(event StreamEvent, data map[string]interface{}) error
@@ -0,0 +1,36 @@
+package models
+
+const (
+	UNIT_GRAM       = "g"
+	UNIT_MILLILITER = "ml"
+	UNIT_TABLESPOON = "tbsp"
+	UNIT_CUP        = "cup"
+	UNIT_PIECE      = "piece"
+	UNIT_SERVING    = "serving"
+)
+
+/* น้ำหนักเป็นกรัมต่อหน่วย ของเหลวคิดความหนาแน่นเท่าน้ำ (1 ml = 1 g) */
+var unitGrams = map[string]float64{
+	UNIT_GRAM:       1,
+	UNIT_MILLILITER: 1,
+	UNIT_TABLESPOON: 15,
+	UNIT_CUP:        240,
+}
+
+func IsUnit(unit string) bool {
+	if _, ok := unitGrams[unit]; ok {
+		return true
+	}
+	return unit == UNIT_PIECE || unit == UNIT_SERVING
+}
+
+/* ConvertToGrams แปลงปริมาณเป็นกรัม หน่วยชิ้นและที่เสิร์ฟอิงขนาดเสิร์ฟของอาหาร */
+func ConvertToGrams(quantity float64, unit string, food *Food) float64 {
+	if grams, ok := unitGrams[unit]; ok {
+		return quantity * grams
+	}
+	if (unit == UNIT_PIECE || unit == UNIT_SERVING) && food != nil {
+		return quantity * food.ServingSize
+	}
+	return quantity
+}
@@ -1,7 +1,10 @@
 package models
 
 import (
+	"math"
 	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/Pheethy/psql/helper"
@@ -19,6 +22,15 @@ const (
 	veryActive ActiveLevel = "VERY_ACTIVE"
 )
 
+var ActiveLevels = []ActiveLevel{sedentary, light, moderate, active, veryActive}
+
+/* Genders ค่า gender ที่ใช้คำนวณ BMR ได้ */
+var Genders = []string{"MALE", "FEMALE"}
+
+func (a ActiveLevel) IsValid() bool {
+	return slices.Contains(ActiveLevels, a)
+}
+
 type UserInfo struct {
 	TableName         struct{}          `json:"-" db:"user_info" pk:"Id"`
 	Id                *uuid.UUID        `json:"id" db:"id" type:"uuid"`
@@ -31,11 +43,15 @@ type UserInfo struct {
 	Target            string            `json:"target" db:"target" type:"string"`
 	TargetWeight      float64           `json:"target_weight" db:"target_weight" type:"float64"`
 	ActiveLevel       ActiveLevel       `json:"active_level" db:"active_level" type:"string"`
+	Language          Language          `json:"language" db:"language" type:"string"`
 	Age               float64           `json:"age" db:"age" type:"float64"`
 	BMR               float64           `json:"bmr" db:"bmr" type:"float64"`
 	CaloriesLimit     float64           `json:"calories_limit" db:"calories_limit" type:"float64"`
+	WaterTarget       float64           `json:"water_target" db:"-"`
 	MedicalCondition  string            `json:"medical_condition" db:"medical_condition" type:"string"`
 	FoodOrIngredients []string          `json:"food_or_ingredients" db:"food_or_ingredients" type:"string"`
+	Diseases          []*Disease        `json:"diseases" db:"-"`
+	FoodPreferences   []*FoodPreference `json:"food_preferences" db:"-"`
 	DOB               *helper.Timestamp `json:"dob" db:"dob" type:"timestamp"`
 	CreatedAt         *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
 	UpdatedAt         *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
@@ -69,6 +85,10 @@ func NewUserInfoWithParams(params map[string]interface{}, ptr *UserInfo) *UserIn
 			ptr.TargetWeight = cast.ToFloat64(val)
 		case "active_level":
 			ptr.ActiveLevel = ActiveLevel(cast.ToString(val))
+		case "language":
+			if language, ok := ParseLanguage(cast.ToString(val)); ok {
+				ptr.Language = language
+			}
 		case "created_at":
 			if val != nil {
 				if reflect.TypeOf(val).Kind() == reflect.String {
@@ -120,6 +140,37 @@ func (u *UserInfo) SetUpdatedAt() {
 	u.UpdatedAt = &time
 }
 
+/* ข้อความของ MedicalCondition เมื่อผู้ใช้ไม่มีโรคประจำตัว */
+const NoMedicalCondition = "no medical condition"
+
+/* SetMedicalCondition รวมชื่อโรคที่ผูกกับผู้ใช้เป็นข้อความเดียวสำหรับใส่ใน prompt */
+func (u *UserInfo) SetMedicalCondition() {
+	names := make([]string, 0, len(u.Diseases))
+	for index := range u.Diseases {
+		names = append(names, u.Diseases[index].Name)
+	}
+	if len(names) == 0 {
+		u.MedicalCondition = NoMedicalCondition
+		return
+	}
+	u.MedicalCondition = strings.Join(names, ", ")
+}
+
+/* HasMedicalCondition ผู้ใช้มีโรคประจำตัวที่ต้องใช้แนวทางโภชนาการเฉพาะ */
+func (u *UserInfo) HasMedicalCondition() bool {
+	return u != nil && u.MedicalCondition != "" && u.MedicalCondition != NoMedicalCondition
+}
+
+func (u *UserInfo) GetFoodPreferences(preferenceType FoodPreferenceType) []string {
+	names := make([]string, 0)
+	for index := range u.FoodPreferences {
+		if u.FoodPreferences[index].PreferenceType == preferenceType {
+			names = append(names, u.FoodPreferences[index].Name)
+		}
+	}
+	return names
+}
+
 func (u *UserInfo) GetAge() {
 	currentTime := time.Now()
 	// ใช้ Year() แทน YearDay() เพื่อดึงปี
@@ -186,3 +237,19 @@ func (u *UserInfo) GetBMR() {
 		u.BMR = 9.082*u.Weight + 658.5
 	}
 }
+
+/* GetWaterTarget ปริมาณน้ำที่ควรดื่มต่อวัน (ml) 33 ml ต่อน้ำหนักตัว 1 kg บวกเพิ่มตามระดับกิจกรรม */
+func (u *UserInfo) GetWaterTarget() {
+	var extra float64
+	switch u.ActiveLevel {
+	case light:
+		extra = 250
+	case moderate:
+		extra = 500
+	case active:
+		extra = 750
+	case veryActive:
+		extra = 1000
+	}
+	u.WaterTarget = math.Round((u.Weight*33+extra)/10) * 10
+}
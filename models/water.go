@@ -0,0 +1,156 @@
+package models
+
+import (
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+const DRINK_TYPE_WATER = "WATER"
+
+type WaterLog struct {
+	TableName struct{}          `json:"-" db:"water_logs" pk:"Id"`
+	Id        *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	UserId    *uuid.UUID        `json:"user_id" db:"user_id" type:"uuid"`
+	AmountMl  float64           `json:"amount_ml" db:"amount_ml" type:"float64"`
+	DrinkType string            `json:"drink_type" db:"drink_type" type:"string"`
+	Note      string            `json:"note" db:"note" type:"string"`
+	DrankAt   *helper.Date      `json:"drank_at" db:"drank_at" type:"date"`
+	CreatedAt *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+}
+
+func NewWaterLogWithParams(params map[string]interface{}, ptr *WaterLog) *WaterLog {
+	if ptr == nil {
+		ptr = new(WaterLog)
+	}
+	for key, val := range params {
+		switch key {
+		case "amount_ml":
+			ptr.AmountMl = cast.ToFloat64(val)
+		case "drink_type":
+			ptr.DrinkType = cast.ToString(val)
+		case "note":
+			ptr.Note = cast.ToString(val)
+		case "drank_at":
+			if val != nil {
+				if reflect.TypeOf(val).Kind() == reflect.String {
+					date := helper.NewDateFromString(val.(string))
+					ptr.DrankAt = &date
+				} else if reflect.TypeOf(val).String() == "time.Time" {
+					date := helper.NewDateFromTime(val.(time.Time))
+					ptr.DrankAt = &date
+				}
+			}
+		}
+	}
+
+	return ptr
+}
+
+func (w *WaterLog) NewID() {
+	id := uuid.Must(uuid.NewV4())
+	w.Id = &id
+}
+
+func (w *WaterLog) SetCreatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	w.CreatedAt = &ti
+}
+
+func (w *WaterLog) SetUpdatedAt() {
+	ti := helper.NewTimestampFromTime(time.Now())
+	w.UpdatedAt = &ti
+}
+
+func (w *WaterLog) SetDefault() {
+	if w.DrankAt == nil {
+		date := helper.NewDateFromTime(time.Now())
+		w.DrankAt = &date
+	}
+	if w.DrinkType == "" {
+		w.DrinkType = DRINK_TYPE_WATER
+	}
+}
+
+/* WaterDailyTotal ยอดรวมการดื่มน้ำต่อวัน ใช้สำหรับดูย้อนหลังโดยไม่ต้องรวมจาก water_logs */
+type WaterDailyTotal struct {
+	TableName struct{}          `json:"-" db:"water_daily_totals" pk:"UserId,Date"`
+	UserId    *uuid.UUID        `json:"user_id" db:"user_id" type:"uuid"`
+	Date      *helper.Date      `json:"date" db:"date" type:"date"`
+	TotalMl   float64           `json:"total_ml" db:"total_ml" type:"float64"`
+	LogCount  int               `json:"log_count" db:"log_count" type:"int32"`
+	UpdatedAt *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+}
+
+type WaterSummary struct {
+	UserId    *uuid.UUID   `json:"user_id,omitempty"`
+	Date      *helper.Date `json:"date"`
+	Target    float64      `json:"target"`
+	Consumed  float64      `json:"consumed"`
+	Remaining float64      `json:"remaining"`
+	Progress  float64      `json:"progress"`
+	Reached   bool         `json:"reached"`
+	Logs      []*WaterLog  `json:"logs,omitempty"`
+}
+
+/* NewWaterSummary เทียบปริมาณน้ำที่ดื่มกับเป้าหมาย progress เป็นเปอร์เซ็นต์ */
+func NewWaterSummary(userId *uuid.UUID, date *helper.Date, target float64, consumed float64, logs []*WaterLog) *WaterSummary {
+	summary := &WaterSummary{
+		UserId:    userId,
+		Date:      date,
+		Target:    target,
+		Consumed:  consumed,
+		Remaining: math.Max(target-consumed, 0),
+		Reached:   target > 0 && consumed >= target,
+		Logs:      logs,
+	}
+	if target > 0 {
+		summary.Progress = math.Round(consumed/target*10000) / 100
+	}
+	return summary
+}
+
+type WaterWeeklySummary struct {
+	UserId      *uuid.UUID      `json:"user_id"`
+	StartDate   *helper.Date    `json:"start_date"`
+	EndDate     *helper.Date    `json:"end_date"`
+	Target      float64         `json:"target"`
+	Consumed    float64         `json:"consumed"`
+	Average     float64         `json:"average"`
+	DaysReached int             `json:"days_reached"`
+	Days        []*WaterSummary `json:"days"`
+}
+
+/* NewWaterWeeklySummary สรุป 7 วันย้อนหลังนับจาก endDate วันที่ไม่มีบันทึกถือว่าดื่ม 0 ml */
+func NewWaterWeeklySummary(userId *uuid.UUID, endDate *helper.Date, target float64, totals []*WaterDailyTotal) *WaterWeeklySummary {
+	totalByDate := make(map[string]float64)
+	for index := range totals {
+		totalByDate[totals[index].Date.String()] += totals[index].TotalMl
+	}
+
+	startDate := helper.NewDateFromTime(time.Time(*endDate).AddDate(0, 0, -6))
+	summary := &WaterWeeklySummary{
+		UserId:    userId,
+		StartDate: &startDate,
+		EndDate:   endDate,
+		Target:    target,
+		Days:      make([]*WaterSummary, 0),
+	}
+	for day := 0; day < 7; day++ {
+		date := helper.NewDateFromTime(time.Time(startDate).AddDate(0, 0, day))
+		daily := NewWaterSummary(nil, &date, target, totalByDate[date.String()], nil)
+		summary.Consumed += daily.Consumed
+		if daily.Reached {
+			summary.DaysReached++
+		}
+		summary.Days = append(summary.Days, daily)
+	}
+	summary.Average = math.Round(summary.Consumed/7*100) / 100
+
+	return summary
+}
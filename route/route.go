@@ -1,9 +1,37 @@
 package route
 
 import (
+	"healthmatefood-api/constants"
+	"healthmatefood-api/middleware"
+	"healthmatefood-api/service/activity"
+	activity_validator "healthmatefood-api/service/activity/validator"
 	agent_ai_handler "healthmatefood-api/service/agent-ai"
+	agent_ai_validator "healthmatefood-api/service/agent-ai/validator"
+	"healthmatefood-api/service/aiusage"
+	aiusage_validator "healthmatefood-api/service/aiusage/validator"
+	"healthmatefood-api/service/chat"
+	chat_validator "healthmatefood-api/service/chat/validator"
+	"healthmatefood-api/service/diary"
+	diary_validator "healthmatefood-api/service/diary/validator"
+	"healthmatefood-api/service/food"
+	"healthmatefood-api/service/grocery"
+	grocery_validator "healthmatefood-api/service/grocery/validator"
+	"healthmatefood-api/service/guardrail"
+	guardrail_validator "healthmatefood-api/service/guardrail/validator"
+	"healthmatefood-api/service/job"
+	job_validator "healthmatefood-api/service/job/validator"
+	"healthmatefood-api/service/knowledge"
+	knowledge_validator "healthmatefood-api/service/knowledge/validator"
+	"healthmatefood-api/service/mealplan"
+	mealplan_validator "healthmatefood-api/service/mealplan/validator"
+	"healthmatefood-api/service/prompt"
+	prompt_validator "healthmatefood-api/service/prompt/validator"
+	"healthmatefood-api/service/recipe"
+	recipe_validator "healthmatefood-api/service/recipe/validator"
 	"healthmatefood-api/service/user"
 	user_validator "healthmatefood-api/service/user/validator"
+	"healthmatefood-api/service/water"
+	water_validator "healthmatefood-api/service/water/validator"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -26,10 +54,117 @@ func (r *Route) RegisterUser(handler user.IUserHandler, validator user_validator
 	r.e.Post("/user/sign-up", validator.ValidateSignUp(), handler.SignUp)
 	r.e.Post("/user/admin", validator.ValidateSignUp(), handler.SignUpAdmin)
 	r.e.Post("/user/refresh", handler.RefreshUserPassport)
-	r.e.Post("/user/info", handler.CreateUserInfo)
-	r.e.Put("/user/info/:user_id", handler.UpdateUserInfo)
+	r.e.Post("/user/info", validator.ValidateUserInfoLanguage(), handler.CreateUserInfo)
+	r.e.Put("/user/info/:user_id", validator.ValidateUserInfoLanguage(), handler.UpdateUserInfo)
+	r.e.Put("/user/info/:user_id/preferences", validator.ValidateParams("user_id"), validator.ValidateUpdateFoodPreferences(), handler.UpdateFoodPreferences)
 }
 
-func (r *Route) RegisterAgentAI(handler agent_ai_handler.IAgentAIHandler) {
-	r.e.Post("/agent-ai/meals", handler.GenerateMealsPlan)
+func (r *Route) RegisterAgentAI(handler agent_ai_handler.IAgentAIHandler, validator agent_ai_validator.Validation, usageHandler aiusage.IAIUsageHandler, middlewareInf middleware.GoMiddlewareInf) {
+	r.e.Post("/agent-ai/meals", middlewareInf.JwtAuth(), validator.ValidateMealPlanProfile(), usageHandler.MeterUsage(), handler.GenerateMealsPlan)
+	r.e.Post("/agent-ai/meals/me", middlewareInf.JwtAuth(), validator.ValidateMealPlanOption(), usageHandler.MeterUsage(), handler.GenerateMyMealsPlan)
+	r.e.Post("/agent-ai/meals/stream", middlewareInf.JwtAuth(), validator.ValidateMealPlanProfile(), usageHandler.MeterUsage(), handler.StreamMealsPlan)
+	r.e.Post("/agent-ai/meals/me/stream", middlewareInf.JwtAuth(), validator.ValidateMealPlanOption(), usageHandler.MeterUsage(), handler.StreamMyMealsPlan)
+	r.e.Post("/agent-ai/meals/photo", middlewareInf.JwtAuth(), validator.ValidateMealPhoto(), usageHandler.MeterUsage(), handler.AnalyzeMyMealPhoto)
+	r.e.Post("/agent-ai/meals/me/:plan_id/swap", middlewareInf.JwtAuth(), validator.ValidateMealPlanSwap(), usageHandler.MeterUsage(), handler.SwapMyMealPlanMeal)
+}
+
+func (r *Route) RegisterFood(handler food.IFoodHandler) {
+	r.e.Get("/food/list", handler.FetchAllFoods)
+	r.e.Get("/food/:food_id", handler.FetchOneFoodById)
+}
+
+func (r *Route) RegisterDiary(handler diary.IDiaryHandler, validator diary_validator.Validation, middlewareInf middleware.GoMiddlewareInf) {
+	r.e.Get("/diary/:user_id", validator.ValidateParams("user_id"), validator.ValidateQueryDate("date"), handler.FetchAllFoodDiaries)
+	r.e.Get("/diary/:user_id/summary", validator.ValidateParams("user_id"), validator.ValidateQueryDate("date"), handler.FetchDailySummary)
+	r.e.Get("/diary/:user_id/plan-comparison", validator.ValidateParams("user_id"), validator.ValidateQueryDate("date"), handler.FetchPlanComparison)
+	r.e.Post("/diary/:user_id", validator.ValidateParams("user_id"), validator.ValidateCreateFoodDiary(), handler.CreateFoodDiary)
+	r.e.Post("/diary/:user_id/photo", middlewareInf.JwtAuth(), validator.ValidateParams("user_id"), validator.ValidateCreateFoodDiariesFromPhoto(), handler.CreateFoodDiariesFromPhoto)
+	r.e.Put("/diary/:user_id/:diary_id", validator.ValidateParams("user_id"), validator.ValidateParams("diary_id"), validator.ValidateUpdateFoodDiary(), handler.UpdateFoodDiary)
+	r.e.Delete("/diary/:user_id/:diary_id", validator.ValidateParams("user_id"), validator.ValidateParams("diary_id"), handler.DeleteFoodDiary)
+}
+
+func (r *Route) RegisterRecipe(handler recipe.IRecipeHandler, validator recipe_validator.Validation) {
+	r.e.Get("/recipe/list", handler.FetchAllRecipes)
+	r.e.Get("/recipe/:recipe_id", validator.ValidateParams("recipe_id"), handler.FetchOneRecipeById)
+	r.e.Post("/recipe", validator.ValidateCreateRecipe(), handler.CreateRecipe)
+	r.e.Put("/recipe/:recipe_id", validator.ValidateParams("recipe_id"), validator.ValidateUpdateRecipe(), handler.UpdateRecipe)
+	r.e.Delete("/recipe/:recipe_id", validator.ValidateParams("recipe_id"), handler.DeleteRecipe)
+}
+
+func (r *Route) RegisterActivity(handler activity.IActivityHandler, validator activity_validator.Validation) {
+	r.e.Get("/activity/list", handler.FetchAllActivities)
+	r.e.Get("/activity/log/:user_id", validator.ValidateParams("user_id"), validator.ValidateQueryDate("date"), handler.FetchAllActivityLogs)
+	r.e.Post("/activity/log/:user_id", validator.ValidateParams("user_id"), validator.ValidateCreateActivityLog(), handler.CreateActivityLog)
+	r.e.Put("/activity/log/:user_id/:log_id", validator.ValidateParams("user_id"), validator.ValidateParams("log_id"), validator.ValidateUpdateActivityLog(), handler.UpdateActivityLog)
+	r.e.Delete("/activity/log/:user_id/:log_id", validator.ValidateParams("user_id"), validator.ValidateParams("log_id"), handler.DeleteActivityLog)
+}
+
+func (r *Route) RegisterWater(handler water.IWaterHandler, validator water_validator.Validation) {
+	r.e.Get("/water/:user_id", validator.ValidateParams("user_id"), validator.ValidateQueryDate("date"), handler.FetchAllWaterLogs)
+	r.e.Get("/water/:user_id/summary", validator.ValidateParams("user_id"), validator.ValidateQueryDate("date"), handler.FetchDailyWaterSummary)
+	r.e.Get("/water/:user_id/summary/weekly", validator.ValidateParams("user_id"), validator.ValidateQueryDate("date"), handler.FetchWeeklyWaterSummary)
+	r.e.Post("/water/:user_id", validator.ValidateParams("user_id"), validator.ValidateCreateWaterLog(), handler.CreateWaterLog)
+	r.e.Put("/water/:user_id/:log_id", validator.ValidateParams("user_id"), validator.ValidateParams("log_id"), validator.ValidateUpdateWaterLog(), handler.UpdateWaterLog)
+	r.e.Delete("/water/:user_id/:log_id", validator.ValidateParams("user_id"), validator.ValidateParams("log_id"), handler.DeleteWaterLog)
+}
+
+func (r *Route) RegisterMealPlan(handler mealplan.IMealPlanHandler, validator mealplan_validator.Validation) {
+	r.e.Get("/meal-plan/:user_id", validator.ValidateParams("user_id"), handler.FetchAllMealPlans)
+	r.e.Get("/meal-plan/:user_id/:plan_id", validator.ValidateParams("user_id"), validator.ValidateParams("plan_id"), handler.FetchOneMealPlanById)
+	r.e.Put("/meal-plan/:user_id/:plan_id/active", validator.ValidateParams("user_id"), validator.ValidateParams("plan_id"), handler.ActivateMealPlan)
+	r.e.Delete("/meal-plan/:user_id/:plan_id", validator.ValidateParams("user_id"), validator.ValidateParams("plan_id"), handler.DeleteMealPlan)
+	r.e.Get("/meal-plan/:user_id/:plan_id/swaps", validator.ValidateParams("user_id"), validator.ValidateParams("plan_id"), handler.FetchAllMealPlanSwaps)
+}
+
+func (r *Route) RegisterGrocery(handler grocery.IGroceryHandler, validator grocery_validator.Validation) {
+	r.e.Get("/grocery-list/:user_id", validator.ValidateParams("user_id"), handler.FetchAllGroceryLists)
+	r.e.Get("/grocery-list/:user_id/:list_id", validator.ValidateParams("user_id"), validator.ValidateParams("list_id"), handler.FetchOneGroceryListById)
+	r.e.Get("/grocery-list/:user_id/:list_id/export", validator.ValidateParams("user_id"), validator.ValidateParams("list_id"), validator.ValidateExportGroceryList(), handler.ExportGroceryList)
+	r.e.Post("/grocery-list/:user_id", validator.ValidateParams("user_id"), validator.ValidateCreateGroceryList(), handler.CreateGroceryList)
+	r.e.Put("/grocery-list/:user_id/:list_id/items/:item_id", validator.ValidateParams("user_id"), validator.ValidateParams("list_id"), validator.ValidateParams("item_id"), validator.ValidateCheckGroceryItem(), handler.CheckGroceryItem)
+	r.e.Delete("/grocery-list/:user_id/:list_id", validator.ValidateParams("user_id"), validator.ValidateParams("list_id"), handler.DeleteGroceryList)
+}
+
+func (r *Route) RegisterChat(handler chat.IChatHandler, validator chat_validator.Validation, usageHandler aiusage.IAIUsageHandler, middlewareInf middleware.GoMiddlewareInf) {
+	r.e.Get("/chat", middlewareInf.JwtAuth(), handler.FetchAllConversations)
+	r.e.Get("/chat/:conversation_id", middlewareInf.JwtAuth(), validator.ValidateParams("conversation_id"), handler.FetchOneConversationById)
+	r.e.Post("/chat", middlewareInf.JwtAuth(), validator.ValidateStartConversation(), usageHandler.MeterUsage(), handler.StartConversation)
+	r.e.Post("/chat/:conversation_id/messages", middlewareInf.JwtAuth(), validator.ValidateParams("conversation_id"), validator.ValidateSendMessage(), usageHandler.MeterUsage(), handler.SendMessage)
+	r.e.Post("/chat/:conversation_id/messages/stream", middlewareInf.JwtAuth(), validator.ValidateParams("conversation_id"), validator.ValidateSendMessage(), usageHandler.MeterUsage(), handler.StreamMessage)
+}
+
+func (r *Route) RegisterAIUsage(handler aiusage.IAIUsageHandler, validator aiusage_validator.Validation, middlewareInf middleware.GoMiddlewareInf) {
+	r.e.Get("/ai-usage/me", middlewareInf.JwtAuth(), handler.FetchMyQuota)
+	r.e.Get("/ai-usage/report/daily", middlewareInf.JwtAuth(), middlewareInf.Authorize(constants.USER_ROLE_ADMIN), validator.ValidateReportQuery(), handler.FetchDailyReport)
+	r.e.Get("/ai-usage/report/users", middlewareInf.JwtAuth(), middlewareInf.Authorize(constants.USER_ROLE_ADMIN), validator.ValidateReportQuery(), handler.FetchUserReport)
+	r.e.Get("/ai-usage/quotas", middlewareInf.JwtAuth(), middlewareInf.Authorize(constants.USER_ROLE_ADMIN), handler.FetchAllQuotas)
+	r.e.Put("/ai-usage/quotas/:role_id", middlewareInf.JwtAuth(), middlewareInf.Authorize(constants.USER_ROLE_ADMIN), validator.ValidateRoleParams("role_id"), validator.ValidateUpdateQuota(), handler.UpdateQuota)
+}
+
+func (r *Route) RegisterPrompt(handler prompt.IPromptHandler, validator prompt_validator.Validation, middlewareInf middleware.GoMiddlewareInf) {
+	r.e.Get("/prompts", middlewareInf.JwtAuth(), middlewareInf.Authorize(constants.USER_ROLE_ADMIN), handler.FetchAllPrompts)
+	r.e.Get("/prompts/defaults", middlewareInf.JwtAuth(), middlewareInf.Authorize(constants.USER_ROLE_ADMIN), handler.FetchAllDefaultPrompts)
+	r.e.Get("/prompts/:prompt_id", middlewareInf.JwtAuth(), middlewareInf.Authorize(constants.USER_ROLE_ADMIN), validator.ValidateParams("prompt_id"), handler.FetchOnePromptById)
+	r.e.Post("/prompts", middlewareInf.JwtAuth(), middlewareInf.Authorize(constants.USER_ROLE_ADMIN), validator.ValidateCreatePrompt(), handler.CreatePrompt)
+	r.e.Put("/prompts/:prompt_id/activate", middlewareInf.JwtAuth(), middlewareInf.Authorize(constants.USER_ROLE_ADMIN), validator.ValidateParams("prompt_id"), handler.ActivatePrompt)
+}
+
+func (r *Route) RegisterJob(handler job.IJobHandler, validator job_validator.Validation, usageHandler aiusage.IAIUsageHandler, middlewareInf middleware.GoMiddlewareInf) {
+	r.e.Post("/jobs/meal-plans", middlewareInf.JwtAuth(), validator.ValidateMealPlanOption(), usageHandler.MeterUsage(), handler.EnqueueMealPlanJob)
+	r.e.Get("/jobs", middlewareInf.JwtAuth(), middlewareInf.Authorize(constants.USER_ROLE_ADMIN), validator.ValidateFetchAllJobs(), handler.FetchAllJobs)
+	r.e.Get("/jobs/:job_id", middlewareInf.JwtAuth(), validator.ValidateParams("job_id"), handler.FetchOneJobById)
+	r.e.Get("/jobs/:job_id/result", middlewareInf.JwtAuth(), validator.ValidateParams("job_id"), handler.FetchJobResult)
+	r.e.Post("/jobs/:job_id/retry", middlewareInf.JwtAuth(), middlewareInf.Authorize(constants.USER_ROLE_ADMIN), validator.ValidateParams("job_id"), handler.RetryJob)
+}
+
+func (r *Route) RegisterKnowledge(handler knowledge.IKnowledgeHandler, validator knowledge_validator.Validation, middlewareInf middleware.GoMiddlewareInf) {
+	r.e.Get("/knowledge/documents", middlewareInf.JwtAuth(), middlewareInf.Authorize(constants.USER_ROLE_ADMIN), handler.FetchAllKnowledgeDocuments)
+	r.e.Get("/knowledge/documents/:document_id", middlewareInf.JwtAuth(), middlewareInf.Authorize(constants.USER_ROLE_ADMIN), validator.ValidateParams("document_id"), handler.FetchOneKnowledgeDocumentById)
+	r.e.Post("/knowledge/documents", middlewareInf.JwtAuth(), middlewareInf.Authorize(constants.USER_ROLE_ADMIN), validator.ValidateCreateKnowledgeDocument(), handler.CreateKnowledgeDocument)
+	r.e.Delete("/knowledge/documents/:document_id", middlewareInf.JwtAuth(), middlewareInf.Authorize(constants.USER_ROLE_ADMIN), validator.ValidateParams("document_id"), handler.DeleteKnowledgeDocument)
+	r.e.Get("/knowledge/search", middlewareInf.JwtAuth(), middlewareInf.Authorize(constants.USER_ROLE_ADMIN), handler.SearchKnowledge)
+}
+
+func (r *Route) RegisterGuardrail(handler guardrail.IGuardrailHandler, validator guardrail_validator.Validation, middlewareInf middleware.GoMiddlewareInf) {
+	r.e.Get("/guardrails/rules", middlewareInf.JwtAuth(), validator.ValidateFetchAllDiseaseRules(), handler.FetchAllDiseaseRules)
 }
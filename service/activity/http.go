@@ -0,0 +1,11 @@
+package activity
+
+import "github.com/gofiber/fiber/v2"
+
+type IActivityHandler interface {
+	FetchAllActivities(c *fiber.Ctx) error
+	FetchAllActivityLogs(c *fiber.Ctx) error
+	CreateActivityLog(c *fiber.Ctx) error
+	UpdateActivityLog(c *fiber.Ctx) error
+	DeleteActivityLog(c *fiber.Ctx) error
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/activity"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+)
+
+type activityHandler struct {
+	activityUs activity.IActivityUsecase
+}
+
+func NewActivityHandler(activityUs activity.IActivityUsecase) activity.IActivityHandler {
+	return &activityHandler{
+		activityUs: activityUs,
+	}
+}
+
+// @Summary     FetchAllActivities
+// @Description Get list activities with MET by intensity
+// @Tags        activities
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       search_word query string false "example: วิ่ง"
+// @Success     200         {object}     map[string]interface{}
+// @Failure     500         {object}     constants.ErrorResponse
+// @Router      /v1/activity/list [get]
+func (a *activityHandler) FetchAllActivities(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	args := new(sync.Map)
+	if searchWord := c.Query("search_word"); searchWord != "" {
+		args.Store("search_word", searchWord)
+	}
+
+	activities, err := a.activityUs.FetchAllActivities(ctx, args)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"activities": activities,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     FetchAllActivityLogs
+// @Description Get activity logs of user on a date
+// @Tags        activities
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id path  string true  "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       date    query string false "example: 2025-03-01 (default today)"
+// @Success     200     {object}     map[string]interface{}
+// @Failure     400     {object}     constants.ErrorResponse
+// @Failure     500     {object}     constants.ErrorResponse
+// @Router      /v1/activity/log/{user_id} [get]
+func (a *activityHandler) FetchAllActivityLogs(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	args := new(sync.Map)
+	args.Store("user_id", &userId)
+	args.Store("performed_at", queryDate(c).String())
+
+	activityLogs, err := a.activityUs.FetchAllActivityLogs(ctx, args)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"activity_logs": activityLogs,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     CreateActivityLog
+// @Description Log an activity; calories burned = MET x weight (kg) x duration (hours)
+// @Tags        activities
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id          path     string true  "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       activity_id      formData string true  "activity id from /v1/activity/list"
+// @Param       duration_minutes formData number true  "duration in minutes"
+// @Param       intensity        formData string false "LIGHT, MODERATE or VIGOROUS" default(MODERATE)
+// @Param       performed_at     formData string false "example: 2025-03-01 (default today)"
+// @Param       note             formData string false "note"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse "intensity is invalid"
+// @Failure     404 {object} constants.ErrorResponse "activity not found, user info not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/activity/log/{user_id} [post]
+func (a *activityHandler) CreateActivityLog(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	params := c.Locals("params").(map[string]interface{})
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	activityLog := models.NewActivityLogWithParams(params, nil)
+	activityLog.NewID()
+	activityLog.UserId = &userId
+	activityLog.SetCreatedAt()
+	activityLog.SetUpdatedAt()
+
+	if err := a.activityUs.UpsertActivityLog(ctx, activityLog); err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message":      "successful",
+		"activity_log": activityLog,
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     UpdateActivityLog
+// @Description Edit an activity log; calories burned is recalculated
+// @Tags        activities
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id path string true "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       log_id  path string true "activity log id"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     404 {object} constants.ErrorResponse "activity log not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/activity/log/{user_id}/{log_id} [put]
+func (a *activityHandler) UpdateActivityLog(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	params := c.Locals("params").(map[string]interface{})
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	logId := uuid.FromStringOrNil(c.Params("log_id"))
+
+	existLog, err := a.fetchOwnActivityLog(c, &userId, &logId)
+	if err != nil {
+		return err
+	}
+	newLog := models.NewActivityLogWithParams(params, existLog)
+	newLog.SetUpdatedAt()
+
+	if err := a.activityUs.UpsertActivityLog(ctx, newLog); err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message":      "successful",
+		"activity_log": newLog,
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     DeleteActivityLog
+// @Description Delete an activity log
+// @Tags        activities
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id path string true "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       log_id  path string true "activity log id"
+// @Success     200 {object} map[string]interface{}
+// @Failure     404 {object} constants.ErrorResponse "activity log not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/activity/log/{user_id}/{log_id} [delete]
+func (a *activityHandler) DeleteActivityLog(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	logId := uuid.FromStringOrNil(c.Params("log_id"))
+
+	if _, err := a.fetchOwnActivityLog(c, &userId, &logId); err != nil {
+		return err
+	}
+	if err := a.activityUs.DeleteActivityLog(ctx, &logId); err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message": "successful",
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+/* fetchOwnActivityLog ดึงรายการและตรวจว่าเป็นของผู้ใช้ตาม path ไม่เช่นนั้นถือว่าไม่พบ */
+func (a *activityHandler) fetchOwnActivityLog(c *fiber.Ctx, userId *uuid.UUID, logId *uuid.UUID) (*models.ActivityLog, error) {
+	activityLog, err := a.activityUs.FetchOneActivityLogById(c.UserContext(), logId)
+	if err != nil {
+		return nil, err
+	}
+	if activityLog.UserId == nil || *activityLog.UserId != *userId {
+		return nil, apperror.NotFound(constants.ERROR_ACTIVITY_LOG_NOT_FOUND)
+	}
+	return activityLog, nil
+}
+
+func queryDate(c *fiber.Ctx) *helper.Date {
+	var date helper.Date
+	if dateStr := c.Query("date"); dateStr != "" {
+		date = helper.NewDateFromString(dateStr)
+	} else {
+		date = helper.NewDateFromTime(time.Now())
+	}
+	return &date
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"encoding/json"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/middleware"
+	"healthmatefood-api/models"
+	activity_mocks "healthmatefood-api/service/activity/mocks"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateActivityLog(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	activityId := uuid.FromStringOrNil("1c4e2d66-3b6f-4a1c-8b54-7a2c8c7b7b04")
+	body := `{"activity_id":"` + activityId.String() + `","duration_minutes":45,"intensity":"VIGOROUS"}`
+	newApp := func(activityUs *activity_mocks.IActivityUsecase) *fiber.App {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		activityHandler := NewActivityHandler(activityUs)
+		app.Post("/v1/activity/log/:user_id", func(c *fiber.Ctx) error {
+			params := map[string]interface{}{}
+			if err := json.Unmarshal(c.Body(), &params); err != nil {
+				return err
+			}
+			c.Locals("params", params)
+			return activityHandler.CreateActivityLog(c)
+		})
+		return app
+	}
+	t.Run("success", func(t *testing.T) {
+		activityUs := new(activity_mocks.IActivityUsecase)
+		activityUs.On("UpsertActivityLog", mock.Anything, mock.AnythingOfType("*models.ActivityLog")).Return(nil).Run(func(args mock.Arguments) {
+			activityLog := args.Get(1).(*models.ActivityLog)
+			assert.NotNil(t, activityLog.Id)
+			assert.Equal(t, userId, *activityLog.UserId)
+			assert.Equal(t, activityId, *activityLog.ActivityId)
+			assert.Equal(t, models.IntensityVigorous, activityLog.Intensity)
+			assert.Equal(t, float64(45), activityLog.DurationMinutes)
+
+			/* มวยไทยหนัก 10.3 MET น้ำหนัก 60 kg 45 นาที */
+			activityLog.SetCaloriesBurned(&models.Activity{Name: "มวยไทย", METVigorous: 10.3}, 60)
+			assert.Equal(t, 463.5, activityLog.CaloriesBurned)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/activity/log/"+userId.String(), strings.NewReader(body))
+		req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+		resp, err := newApp(activityUs).Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+	t.Run("error_activity_not_found", func(t *testing.T) {
+		activityUs := new(activity_mocks.IActivityUsecase)
+		activityUs.On("UpsertActivityLog", mock.Anything, mock.AnythingOfType("*models.ActivityLog")).Return(apperror.NotFound(constants.ERROR_ACTIVITY_NOT_FOUND))
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/activity/log/"+userId.String(), strings.NewReader(body))
+		req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+		resp, err := newApp(activityUs).Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,159 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "healthmatefood-api/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sync "sync"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IActivityUsecase is an autogenerated mock type for the IActivityUsecase type
+type IActivityUsecase struct {
+	mock.Mock
+}
+
+// DeleteActivityLog provides a mock function with given fields: ctx, id
+func (_m *IActivityUsecase) DeleteActivityLog(ctx context.Context, id *uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteActivityLog")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchAllActivities provides a mock function with given fields: ctx, args
+func (_m *IActivityUsecase) FetchAllActivities(ctx context.Context, args *sync.Map) ([]*models.Activity, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllActivities")
+	}
+
+	var r0 []*models.Activity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.Activity, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.Activity); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Activity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchAllActivityLogs provides a mock function with given fields: ctx, args
+func (_m *IActivityUsecase) FetchAllActivityLogs(ctx context.Context, args *sync.Map) ([]*models.ActivityLog, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllActivityLogs")
+	}
+
+	var r0 []*models.ActivityLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.ActivityLog, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.ActivityLog); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ActivityLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchOneActivityLogById provides a mock function with given fields: ctx, id
+func (_m *IActivityUsecase) FetchOneActivityLogById(ctx context.Context, id *uuid.UUID) (*models.ActivityLog, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOneActivityLogById")
+	}
+
+	var r0 *models.ActivityLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.ActivityLog, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.ActivityLog); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ActivityLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertActivityLog provides a mock function with given fields: ctx, activityLog
+func (_m *IActivityUsecase) UpsertActivityLog(ctx context.Context, activityLog *models.ActivityLog) error {
+	ret := _m.Called(ctx, activityLog)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertActivityLog")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ActivityLog) error); ok {
+		r0 = rf(ctx, activityLog)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIActivityUsecase creates a new instance of IActivityUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIActivityUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IActivityUsecase {
+	mock := &IActivityUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
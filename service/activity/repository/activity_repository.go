@@ -0,0 +1,348 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/activity"
+	"strings"
+	"sync"
+
+	"github.com/Pheethy/sqlx"
+	"github.com/gofrs/uuid"
+)
+
+type activityRepository struct {
+	psqlDB *sqlx.DB
+}
+
+func NewActivityRepository(psqlDB *sqlx.DB) activity.IActivityRepository {
+	return &activityRepository{
+		psqlDB: psqlDB,
+	}
+}
+
+const selectActivity = `
+        "activities"."id",
+        "activities"."code",
+        "activities"."name",
+        "activities"."met_light",
+        "activities"."met_moderate",
+        "activities"."met_vigorous",
+        to_char("activities"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+        to_char("activities"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at"`
+
+const selectActivityLog = `
+        "activity_logs"."id",
+        "activity_logs"."user_id",
+        "activity_logs"."activity_id",
+        "activity_logs"."name",
+        "activity_logs"."intensity",
+        "activity_logs"."duration_minutes",
+        "activity_logs"."met",
+        "activity_logs"."weight",
+        "activity_logs"."calories_burned",
+        COALESCE("activity_logs"."note", '') "note",
+        to_char("activity_logs"."performed_at", 'yyyy-MM-dd') "performed_at",
+        to_char("activity_logs"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+        to_char("activity_logs"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at",
+        (
+          SELECT
+            to_jsonb("ACTIVITY")
+          FROM (
+            SELECT` + selectActivity + `
+            FROM
+              "activities"
+            WHERE
+              "activities"."id" = "activity_logs"."activity_id"
+          ) AS "ACTIVITY"
+        ) AS "activity"`
+
+func (a *activityRepository) FetchAllActivities(ctx context.Context, args *sync.Map) ([]*models.Activity, error) {
+	var conds []interface{}
+	where := ""
+	if searchWord, ok := args.Load("search_word"); ok {
+		conds = append(conds, fmt.Sprintf("%%%s%%", searchWord))
+		where = fmt.Sprintf(`WHERE "activities"."name" ILIKE $%d::text OR "activities"."code" ILIKE $%d::text`, len(conds), len(conds))
+	}
+
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        %s
+      FROM
+        "activities"
+      %s
+      ORDER BY
+        "activities"."code" ASC
+    ) AS "json_data"
+  `, selectActivity, where)
+
+	stmt, err := a.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, conds...).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	activities := make([]*models.Activity, 0)
+	if err := json.Unmarshal(jsonData, &activities); err != nil {
+		return nil, err
+	}
+
+	return activities, nil
+}
+
+func (a *activityRepository) FetchOneActivityById(ctx context.Context, id *uuid.UUID) (*models.Activity, error) {
+	sql := fmt.Sprintf(`
+    SELECT
+      to_jsonb("json_data")
+    FROM (
+      SELECT
+        %s
+      FROM
+        "activities"
+      WHERE
+        "activities"."id" = $1::uuid
+    ) AS "json_data"
+  `, selectActivity)
+
+	stmt, err := a.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, id).Scan(&jsonData); err != nil {
+		return nil, apperror.FromDB(err, constants.ERROR_ACTIVITY_NOT_FOUND)
+	}
+
+	activity := new(models.Activity)
+	if err := json.Unmarshal(jsonData, &activity); err != nil {
+		return nil, err
+	}
+
+	return activity, nil
+}
+
+func (a *activityRepository) FetchAllActivityLogs(ctx context.Context, args *sync.Map) ([]*models.ActivityLog, error) {
+	var conds []interface{}
+	var wheres []string
+	if userId, ok := args.Load("user_id"); ok {
+		conds = append(conds, userId)
+		wheres = append(wheres, fmt.Sprintf(`"activity_logs"."user_id" = $%d::uuid`, len(conds)))
+	}
+	if performedAt, ok := args.Load("performed_at"); ok {
+		conds = append(conds, performedAt)
+		wheres = append(wheres, fmt.Sprintf(`"activity_logs"."performed_at" = $%d::date`, len(conds)))
+	}
+	if startDate, ok := args.Load("start_date"); ok {
+		conds = append(conds, startDate)
+		wheres = append(wheres, fmt.Sprintf(`"activity_logs"."performed_at" >= $%d::date`, len(conds)))
+	}
+	if endDate, ok := args.Load("end_date"); ok {
+		conds = append(conds, endDate)
+		wheres = append(wheres, fmt.Sprintf(`"activity_logs"."performed_at" <= $%d::date`, len(conds)))
+	}
+	where := ""
+	if len(wheres) > 0 {
+		where = "WHERE " + strings.Join(wheres, " AND ")
+	}
+
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        %s
+      FROM
+        "activity_logs"
+      %s
+      ORDER BY
+        "activity_logs"."performed_at" ASC,
+        "activity_logs"."created_at" ASC
+    ) AS "json_data"
+  `, selectActivityLog, where)
+
+	stmt, err := a.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, conds...).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	activityLogs := make([]*models.ActivityLog, 0)
+	if err := json.Unmarshal(jsonData, &activityLogs); err != nil {
+		return nil, err
+	}
+
+	return activityLogs, nil
+}
+
+func (a *activityRepository) FetchOneActivityLogById(ctx context.Context, id *uuid.UUID) (*models.ActivityLog, error) {
+	sql := fmt.Sprintf(`
+    SELECT
+      to_jsonb("json_data")
+    FROM (
+      SELECT
+        %s
+      FROM
+        "activity_logs"
+      WHERE
+        "activity_logs"."id" = $1::uuid
+    ) AS "json_data"
+  `, selectActivityLog)
+
+	stmt, err := a.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, id).Scan(&jsonData); err != nil {
+		return nil, apperror.FromDB(err, constants.ERROR_ACTIVITY_LOG_NOT_FOUND)
+	}
+
+	activityLog := new(models.ActivityLog)
+	if err := json.Unmarshal(jsonData, &activityLog); err != nil {
+		return nil, err
+	}
+
+	return activityLog, nil
+}
+
+func (a *activityRepository) UpsertActivityLog(ctx context.Context, activityLog *models.ActivityLog) error {
+	tx, err := a.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+	sql := `
+    INSERT INTO "activity_logs" (
+      "id",
+      "user_id",
+      "activity_id",
+      "name",
+      "intensity",
+      "duration_minutes",
+      "met",
+      "weight",
+      "calories_burned",
+      "note",
+      "performed_at",
+      "created_at",
+      "updated_at"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::uuid,
+      $4::text,
+      $5::activity_intensity,
+      $6::float,
+      $7::float,
+      $8::float,
+      $9::float,
+      $10::text,
+      $11::date,
+      $12::timestamp,
+      $13::timestamp
+    )
+    ON CONFLICT (id)
+    DO UPDATE SET
+      activity_id=$14::uuid,
+      name=$15::text,
+      intensity=$16::activity_intensity,
+      duration_minutes=$17::float,
+      met=$18::float,
+      weight=$19::float,
+      calories_burned=$20::float,
+      note=$21::text,
+      performed_at=$22::date,
+      updated_at=$23::timestamp
+  `
+	stmt, err := tx.PreparexContext(ctx, sql)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx,
+		/* Create */
+		activityLog.Id,
+		activityLog.UserId,
+		activityLog.ActivityId,
+		activityLog.Name,
+		activityLog.Intensity,
+		activityLog.DurationMinutes,
+		activityLog.MET,
+		activityLog.Weight,
+		activityLog.CaloriesBurned,
+		activityLog.Note,
+		activityLog.PerformedAt.String(),
+		activityLog.CreatedAt,
+		activityLog.UpdatedAt,
+		/* Update */
+		activityLog.ActivityId,
+		activityLog.Name,
+		activityLog.Intensity,
+		activityLog.DurationMinutes,
+		activityLog.MET,
+		activityLog.Weight,
+		activityLog.CaloriesBurned,
+		activityLog.Note,
+		activityLog.PerformedAt.String(),
+		activityLog.UpdatedAt,
+	)
+	if err != nil {
+		tx.Rollback()
+		return apperror.FromDB(err, "")
+	}
+
+	return tx.Commit()
+}
+
+func (a *activityRepository) DeleteActivityLog(ctx context.Context, id *uuid.UUID) error {
+	tx, err := a.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+	sql := `
+    DELETE FROM
+      "activity_logs"
+    WHERE
+      "activity_logs"."id" = $1::uuid
+  `
+	stmt, err := tx.PreparexContext(ctx, sql)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		tx.Rollback()
+		return apperror.FromDB(err, "")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		tx.Rollback()
+		return apperror.NotFound(constants.ERROR_ACTIVITY_LOG_NOT_FOUND)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,17 @@
+package activity
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type IActivityUsecase interface {
+	FetchAllActivities(ctx context.Context, args *sync.Map) ([]*models.Activity, error)
+	FetchAllActivityLogs(ctx context.Context, args *sync.Map) ([]*models.ActivityLog, error)
+	FetchOneActivityLogById(ctx context.Context, id *uuid.UUID) (*models.ActivityLog, error)
+	UpsertActivityLog(ctx context.Context, activityLog *models.ActivityLog) error
+	DeleteActivityLog(ctx context.Context, id *uuid.UUID) error
+}
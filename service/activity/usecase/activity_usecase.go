@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/activity"
+	"healthmatefood-api/service/user"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type activityUsecase struct {
+	activityRepo activity.IActivityRepository
+	userUs       user.IUserUsecase
+}
+
+func NewActivityUsecase(activityRepo activity.IActivityRepository, userUs user.IUserUsecase) activity.IActivityUsecase {
+	return &activityUsecase{
+		activityRepo: activityRepo,
+		userUs:       userUs,
+	}
+}
+
+func (a *activityUsecase) FetchAllActivities(ctx context.Context, args *sync.Map) ([]*models.Activity, error) {
+	return a.activityRepo.FetchAllActivities(ctx, args)
+}
+
+func (a *activityUsecase) FetchAllActivityLogs(ctx context.Context, args *sync.Map) ([]*models.ActivityLog, error) {
+	return a.activityRepo.FetchAllActivityLogs(ctx, args)
+}
+
+func (a *activityUsecase) FetchOneActivityLogById(ctx context.Context, id *uuid.UUID) (*models.ActivityLog, error) {
+	return a.activityRepo.FetchOneActivityLogById(ctx, id)
+}
+
+func (a *activityUsecase) UpsertActivityLog(ctx context.Context, activityLog *models.ActivityLog) error {
+	if activityLog.Intensity != "" && !activityLog.IsIntensity() {
+		return apperror.Validation(constants.ERROR_INTENSITY_IS_INVALID)
+	}
+	activityLog.SetPerformedAtIfEmpty()
+
+	activity, err := a.activityRepo.FetchOneActivityById(ctx, activityLog.ActivityId)
+	if err != nil {
+		return err
+	}
+	/* ใช้น้ำหนักปัจจุบันของผู้ใช้ในการคำนวณ และเก็บไว้กับรายการเพื่อไม่ให้ค่าย้อนหลังเปลี่ยนตาม */
+	userInfo, err := a.userUs.FetchOneUserInfoByUserId(ctx, activityLog.UserId)
+	if err != nil {
+		return err
+	}
+	activityLog.SetCaloriesBurned(activity, userInfo.Weight)
+
+	return a.activityRepo.UpsertActivityLog(ctx, activityLog)
+}
+
+func (a *activityUsecase) DeleteActivityLog(ctx context.Context, id *uuid.UUID) error {
+	return a.activityRepo.DeleteActivityLog(ctx, id)
+}
@@ -0,0 +1,110 @@
+package validator
+
+import (
+	"errors"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	diary_validator "healthmatefood-api/service/diary/validator"
+
+	"github.com/Pheethy/psql/helper"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofiber/fiber/v2"
+	"github.com/spf13/cast"
+)
+
+type Validation struct{}
+
+func (v Validation) ValidateCreateActivityLog() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		if params == nil {
+			return apperror.Validation(constants.ERROR_BODY_WAS_MISSING)
+		}
+		var key string
+		fields := apperror.Fields{}
+
+		/* key params */
+		key = "activity_id"
+		if _, ok := params[key]; !ok {
+			fields.Missing(key)
+		}
+		key = "duration_minutes"
+		if _, ok := params[key]; !ok {
+			fields.Missing(key)
+		}
+
+		validateActivityLogParams(params, &fields)
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateUpdateActivityLog() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		if params == nil {
+			return apperror.Validation(constants.ERROR_BODY_WAS_MISSING)
+		}
+		fields := apperror.Fields{}
+		validateActivityLogParams(params, &fields)
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateParams(key string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params := c.Params(key)
+		if err := validation.Validate(params, validation.By(helper.ValidateTypeUUID)); err != nil {
+			return apperror.Field(key, err.Error())
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateQueryDate(key string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		date := c.Query(key)
+		if date == "" {
+			return c.Next()
+		}
+		if err := validation.Validate(date, validation.By(diary_validator.ValidateDate)); err != nil {
+			return apperror.Field(key, err.Error())
+		}
+		return c.Next()
+	}
+}
+
+func validateActivityLogParams(params map[string]interface{}, fields *apperror.Fields) {
+	key := "activity_id"
+	if activityId, ok := params[key]; ok {
+		fields.Check(key, validation.Validate(activityId, validation.By(helper.ValidateTypeUUID)))
+	}
+	key = "duration_minutes"
+	if duration, ok := params[key]; ok {
+		fields.Check(key, validation.Validate(duration, validation.By(validatePositiveNumber)))
+	}
+	key = "intensity"
+	if intensity, ok := params[key]; ok {
+		fields.Check(key, validation.Validate(intensity, validation.By(helper.ValidateTypeString)))
+	}
+	key = "performed_at"
+	if performedAt, ok := params[key]; ok {
+		fields.Check(key, validation.Validate(performedAt, validation.By(diary_validator.ValidateDate)))
+	}
+}
+
+func validatePositiveNumber(val interface{}) error {
+	number, err := cast.ToFloat64E(val)
+	if err != nil {
+		return errors.New("is not type number")
+	}
+	if number <= 0 {
+		return errors.New("must be greater than 0")
+	}
+	return nil
+}
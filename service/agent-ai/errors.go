@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"net/http"
+	"time"
+)
+
+/* UpstreamError ข้อผิดพลาดจาก provider ของ LLM เก็บ status และข้อความจากต้นทางไว้ให้ handler แปลงเป็น 502/503/504 */
+type UpstreamError struct {
+	Provider string
+	/* StatusCode เป็น 0 เมื่อไม่ได้ response กลับมา เช่น เชื่อมต่อไม่ได้หรือหมดเวลา */
+	StatusCode  int
+	Message     string
+	RetryAfter  time.Duration
+	Timeout     bool
+	CircuitOpen bool
+}
+
+func (e *UpstreamError) Error() string {
+	switch {
+	case e.CircuitOpen:
+		return fmt.Sprintf("%s: %s circuit is open", constants.ERROR_AGENT_UNAVAILABLE, e.Provider)
+	case e.Timeout:
+		return fmt.Sprintf("%s: %s", constants.ERROR_AGENT_TIMEOUT, e.Provider)
+	case e.StatusCode > 0:
+		return fmt.Sprintf("%s: %s returned %d: %s", constants.ERROR_AGENT_UPSTREAM_FAILED, e.Provider, e.StatusCode, e.Message)
+	default:
+		return fmt.Sprintf("%s: %s: %s", constants.ERROR_AGENT_UPSTREAM_FAILED, e.Provider, e.Message)
+	}
+}
+
+/* Retryable ลองใหม่ได้เมื่อโดนจำกัด rate, ต้นทางล่ม หรือเชื่อมต่อไม่ได้ */
+func (e *UpstreamError) Retryable() bool {
+	if e.CircuitOpen {
+		return false
+	}
+	return e.StatusCode == 0 || e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+/* HTTPStatus status ที่ตอบ client: หมดเวลา 504, ต้นทางไม่พร้อม 503, อื่น ๆ 502 */
+func (e *UpstreamError) HTTPStatus() int {
+	switch {
+	case e.Timeout:
+		return http.StatusGatewayTimeout
+	case e.CircuitOpen, e.StatusCode == http.StatusTooManyRequests, e.StatusCode == http.StatusServiceUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+/* ErrorKind ทำให้ UpstreamError เป็น apperror.Typed ตอบ client ด้วย status จาก HTTPStatus */
+func (e *UpstreamError) ErrorKind() apperror.Kind {
+	return apperror.KindUpstream
+}
+
+/* UpstreamStatus คืน status ของ UpstreamError ที่อยู่ใน err */
+func UpstreamStatus(err error) (int, bool) {
+	var upstreamErr *UpstreamError
+	if errors.As(err, &upstreamErr) {
+		return upstreamErr.HTTPStatus(), true
+	}
+	return 0, false
+}
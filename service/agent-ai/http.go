@@ -4,4 +4,9 @@ import "github.com/gofiber/fiber/v2"
 
 type IAgentAIHandler interface {
 	GenerateMealsPlan(c *fiber.Ctx) error
+	GenerateMyMealsPlan(c *fiber.Ctx) error
+	StreamMealsPlan(c *fiber.Ctx) error
+	StreamMyMealsPlan(c *fiber.Ctx) error
+	AnalyzeMyMealPhoto(c *fiber.Ctx) error
+	SwapMyMealPlanMeal(c *fiber.Ctx) error
 }
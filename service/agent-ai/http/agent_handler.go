@@ -1,12 +1,20 @@
 package http
 
 import (
+	"context"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/middleware"
 	"healthmatefood-api/models"
 	"healthmatefood-api/service/agent-ai"
 	"healthmatefood-api/service/user"
+	"healthmatefood-api/utils"
+	"io"
+	"mime/multipart"
 	"net/http"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
 )
 
 type agentAIHandler struct {
@@ -21,18 +29,78 @@ func NewAgentAIHandler(agentUs agent.IAgentAIUsecase, userUs user.IUserUsecase)
 	}
 }
 
+// @Summary     GenerateMealsPlan
+// @Description Generate a typed meal plan (days, meals, items, portion, kcal and macros) from user info sent in the body and save it for the signed-in user, plan.cache tells whether the plan came from the response cache
+// @Tags        agent-ai
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true "Bearer access token"
+// @Param       gender       formData string true "MALE or FEMALE"
+// @Param       weight       formData number true "weight (kg)"
+// @Param       height       formData number true "height (cm)"
+// @Param       active_level formData string true "SEDENTARY, LIGHT, MODERATE, ACTIVE or VERY_ACTIVE"
+// @Param       dob          formData string true "example: 1995-03-01 00:00:00"
+// @Param       days         formData integer false "number of days (1-7)" default(3)
+// @Param       cuisine      formData string false "example: Thai, Japanese"
+// @Param       budget       formData number false "food budget per day (THB)"
+// @Param       fresh        formData boolean false "skip the cached plan and generate a new one"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     429 {object} constants.ErrorResponse "ai quota exceeded, problem has reset_at and quota members and Retry-After is set"
+// @Failure     502 {object} constants.ErrorResponse "meal plan is invalid or agent upstream failed"
+// @Failure     503 {object} constants.ErrorResponse "agent is unavailable"
+// @Failure     504 {object} constants.ErrorResponse "agent timed out"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/agent-ai/meals [post]
 func (h *agentAIHandler) GenerateMealsPlan(c *fiber.Ctx) error {
 	ctx := c.UserContext()
-	params := c.Locals("params").(map[string]interface{})
-	userInfo := models.NewUserInfoWithParams(params, nil)
-	userInfo.GetBMR()
-	userInfo.GetCaloriesLimit()
-	user := new(models.User)
-	user.UserInfo = userInfo
+	params, _ := c.Locals("params").(map[string]interface{})
+	userId, _ := c.Locals("user_id").(*uuid.UUID)
+	user := userFromParams(userId, params)
+
+	plan, err := h.agentUs.GenerateMealsPlan(ctx, user, models.NewMealPlanOptionWithParams(params))
+	if err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"plan": plan,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
 
-	plan, err := h.agentUs.GenerateMealsPlan(ctx, user)
+// @Summary     GenerateMyMealsPlan
+// @Description Generate and save a meal plan for the signed-in user from the stored user info, diseases, food preferences and current weight
+// @Tags        agent-ai
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true "Bearer access token"
+// @Param       days    formData integer false "number of days (1-7)" default(3)
+// @Param       cuisine formData string  false "example: Thai, Japanese"
+// @Param       budget  formData number  false "food budget per day (THB)"
+// @Param       fresh   formData boolean false "skip the cached plan and generate a new one"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     404 {object} constants.ErrorResponse "user info not found"
+// @Failure     502 {object} constants.ErrorResponse "meal plan is invalid or agent upstream failed"
+// @Failure     503 {object} constants.ErrorResponse "agent is unavailable"
+// @Failure     504 {object} constants.ErrorResponse "agent timed out"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/agent-ai/meals/me [post]
+func (h *agentAIHandler) GenerateMyMealsPlan(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	params, _ := c.Locals("params").(map[string]interface{})
+	user, err := h.fetchMyUser(c)
 	if err != nil {
-		return fiber.NewError(http.StatusInternalServerError, err.Error())
+		return err
+	}
+
+	plan, err := h.agentUs.GenerateMealsPlan(ctx, user, models.NewMealPlanOptionWithParams(params))
+	if err != nil {
+		return err
 	}
 
 	resp := map[string]interface{}{
@@ -41,3 +109,210 @@ func (h *agentAIHandler) GenerateMealsPlan(c *fiber.Ctx) error {
 
 	return c.Status(http.StatusOK).JSON(resp)
 }
+
+// @Summary     StreamMealsPlan
+// @Description Same as GenerateMealsPlan but answers with Server-Sent Events: token events while the model is writing, reset when the plan is regenerated, then done with the plan or error
+// @Tags        agent-ai
+// @Accept      json
+// @Produce     text/event-stream,application/problem+json
+// @Param       Authorization header string true "Bearer access token"
+// @Param       gender       formData string true "MALE or FEMALE"
+// @Param       weight       formData number true "weight (kg)"
+// @Param       height       formData number true "height (cm)"
+// @Param       active_level formData string true "SEDENTARY, LIGHT, MODERATE, ACTIVE or VERY_ACTIVE"
+// @Param       dob          formData string true "example: 1995-03-01 00:00:00"
+// @Param       days         formData integer false "number of days (1-7)" default(3)
+// @Param       cuisine      formData string false "example: Thai, Japanese"
+// @Param       budget       formData number false "food budget per day (THB)"
+// @Param       fresh        formData boolean false "skip the cached plan and generate a new one"
+// @Success     200 {string} string "event stream"
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     429 {object} constants.ErrorResponse "ai quota exceeded, problem has reset_at and quota members and Retry-After is set"
+// @Router      /v1/agent-ai/meals/stream [post]
+func (h *agentAIHandler) StreamMealsPlan(c *fiber.Ctx) error {
+	params, _ := c.Locals("params").(map[string]interface{})
+	userId, _ := c.Locals("user_id").(*uuid.UUID)
+	return h.streamMealsPlan(c, userFromParams(userId, params), models.NewMealPlanOptionWithParams(params))
+}
+
+// @Summary     StreamMyMealsPlan
+// @Description Same as GenerateMyMealsPlan but answers with Server-Sent Events: token events while the model is writing, reset when the plan is regenerated, then done with the plan or error
+// @Tags        agent-ai
+// @Accept      json
+// @Produce     text/event-stream,application/problem+json
+// @Param       Authorization header string true "Bearer access token"
+// @Param       days    formData integer false "number of days (1-7)" default(3)
+// @Param       cuisine formData string  false "example: Thai, Japanese"
+// @Param       budget  formData number  false "food budget per day (THB)"
+// @Param       fresh   formData boolean false "skip the cached plan and generate a new one"
+// @Success     200 {string} string "event stream"
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     404 {object} constants.ErrorResponse "user info not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/agent-ai/meals/me/stream [post]
+func (h *agentAIHandler) StreamMyMealsPlan(c *fiber.Ctx) error {
+	params, _ := c.Locals("params").(map[string]interface{})
+	user, err := h.fetchMyUser(c)
+	if err != nil {
+		return err
+	}
+	return h.streamMealsPlan(c, user, models.NewMealPlanOptionWithParams(params))
+}
+
+// @Summary     AnalyzeMyMealPhoto
+// @Description Identify dishes in a meal photo with estimated portion, kcal and macros using a vision model, nothing is saved until the result is confirmed with /v1/diary/{user_id}/photo
+// @Tags        agent-ai
+// @Accept      multipart/form-data
+// @Produce     json,application/problem+json
+// @Param       Authorization header   string true "Bearer access token"
+// @Param       images        formData file   true "meal photo (jpeg, png or webp, max 5 MB)"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse "image is missing, too large or not supported"
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     429 {object} constants.ErrorResponse "ai quota exceeded, problem has reset_at and quota members and Retry-After is set"
+// @Failure     502 {object} constants.ErrorResponse "meal photo analysis is invalid or agent upstream failed"
+// @Failure     503 {object} constants.ErrorResponse "agent is unavailable"
+// @Failure     504 {object} constants.ErrorResponse "agent timed out"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/agent-ai/meals/photo [post]
+func (h *agentAIHandler) AnalyzeMyMealPhoto(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId, _ := c.Locals("user_id").(*uuid.UUID)
+	if userId == nil {
+		return fiber.NewError(http.StatusUnauthorized, "no permission to access")
+	}
+	images, _ := c.Locals("images").([]*multipart.FileHeader)
+	if len(images) == 0 {
+		return fiber.NewError(http.StatusBadRequest, "images: was missing on form")
+	}
+	photo, err := readMealPhoto(images[0])
+	if err != nil {
+		return err
+	}
+
+	/* ข้อมูลผู้ใช้ใช้เตือนเรื่องแพ้อาหารและโรคประจำตัว ผู้ที่ยังไม่กรอกก็วิเคราะห์รูปได้ */
+	user, err := h.userUs.FetchOneUserById(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if user.UserInfo != nil {
+		user.UserInfo.SetMedicalCondition()
+	}
+
+	analysis, err := h.agentUs.AnalyzeMealPhoto(ctx, user.UserInfo, photo)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"analysis": analysis,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+func readMealPhoto(image *multipart.FileHeader) (*models.MealPhoto, error) {
+	file, err := image.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	return &models.MealPhoto{MIMEType: http.DetectContentType(data), Data: data}, nil
+}
+
+/* streamMealsPlan ส่ง token ของแผนอาหารเป็น SSE ระหว่างสร้าง และปิดท้ายด้วย done หรือ error */
+func (h *agentAIHandler) streamMealsPlan(c *fiber.Ctx, user *models.User, option *models.MealPlanOption) error {
+	problem := middleware.NewProblemFunc(c)
+	return utils.StreamSSE(c, func(ctx context.Context, send utils.SSESendFunc) {
+		plan, err := h.agentUs.StreamMealsPlan(ctx, user, option, func(event models.StreamEvent, data map[string]interface{}) error {
+			return send(string(event), data)
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			send(string(models.StreamEventError), problem(ctx, err))
+			return
+		}
+		send(string(models.StreamEventDone), map[string]interface{}{"plan": plan})
+	})
+}
+
+// @Summary     SwapMyMealPlanMeal
+// @Description Replace one meal, or one item when item_id is sent, in a saved meal plan of the signed-in user with an alternative that keeps the day within the calorie and macro target, respects food preferences, allergies and disease rules, and is recorded in the swap history
+// @Tags        agent-ai
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true  "Bearer access token"
+// @Param       plan_id       path   string true  "meal plan id"
+// @Param       meal_id       formData string true  "meal id in the plan"
+// @Param       item_id       formData string false "item id in the meal, swap only this item"
+// @Param       reason        formData string false "why the user wants another dish, example: ไม่ชอบปลา"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     404 {object} constants.ErrorResponse "meal plan, meal or item not found"
+// @Failure     429 {object} constants.ErrorResponse "ai quota exceeded, problem has reset_at and quota members and Retry-After is set"
+// @Failure     502 {object} constants.ErrorResponse "meal swap is invalid or agent upstream failed"
+// @Failure     503 {object} constants.ErrorResponse "agent is unavailable"
+// @Failure     504 {object} constants.ErrorResponse "agent timed out"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/agent-ai/meals/me/{plan_id}/swap [post]
+func (h *agentAIHandler) SwapMyMealPlanMeal(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	params, _ := c.Locals("params").(map[string]interface{})
+	planId := uuid.FromStringOrNil(c.Params("plan_id"))
+	user, err := h.fetchMyUser(c)
+	if err != nil {
+		return err
+	}
+
+	plan, swap, err := h.agentUs.SwapMealPlanMeal(ctx, user, &planId, models.NewMealPlanSwapOptionWithParams(params))
+	if err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"plan": plan,
+		"swap": swap,
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+/* userFromParams ใช้ข้อมูลร่างกายจาก body แต่ id มาจาก token เสมอ แผนที่สร้างจึงถูกบันทึกให้ผู้ที่ login ไม่ใช่ user_id ใน body */
+func userFromParams(userId *uuid.UUID, params map[string]interface{}) *models.User {
+	userInfo := models.NewUserInfoWithParams(params, nil)
+	userInfo.UserId = userId
+	userInfo.GetBMR()
+	userInfo.GetCaloriesLimit()
+	user := new(models.User)
+	user.Id = userId
+	user.UserInfo = userInfo
+	return user
+}
+
+/* fetchMyUser ดึงผู้ใช้ที่ login พร้อมคำนวณ BMR, พลังงานต่อวัน และโรคประจำตัวสำหรับ prompt */
+func (h *agentAIHandler) fetchMyUser(c *fiber.Ctx) (*models.User, error) {
+	userId, _ := c.Locals("user_id").(*uuid.UUID)
+	if userId == nil {
+		return nil, fiber.NewError(http.StatusUnauthorized, "no permission to access")
+	}
+
+	user, err := h.userUs.FetchOneUserById(c.UserContext(), userId)
+	if err != nil {
+		return nil, err
+	}
+	if user.UserInfo == nil {
+		return nil, apperror.NotFound(constants.ERROR_USER_INFO_NOT_FOUND)
+	}
+	user.UserInfo.GetBMR()
+	user.UserInfo.GetCaloriesLimit()
+	user.UserInfo.SetMedicalCondition()
+	return user, nil
+}
@@ -0,0 +1,229 @@
+package http
+
+import (
+	"encoding/json"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/middleware"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/agent-ai"
+	agent_mocks "healthmatefood-api/service/agent-ai/mocks"
+	"healthmatefood-api/service/agent-ai/validator"
+	user_mocks "healthmatefood-api/service/user/mocks"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGenerateMealsPlan(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	otherUserId := uuid.FromStringOrNil("0c3b6f8e-1d2a-4e5f-8a9b-7c6d5e4f3a21")
+	newApp := func(handler *agentAIHandler, params map[string]interface{}) *fiber.App {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		app.Post("/v1/agent-ai/meals", func(c *fiber.Ctx) error {
+			c.Locals("user_id", &userId)
+			if params != nil {
+				c.Locals("params", params)
+			}
+			return c.Next()
+		}, validator.Validation{}.ValidateMealPlanProfile(), handler.GenerateMealsPlan)
+		return app
+	}
+	t.Run("success", func(t *testing.T) {
+		agentUs := new(agent_mocks.IAgentAIUsecase)
+		agentUs.On("GenerateMealsPlan", mock.Anything, mock.MatchedBy(func(user *models.User) bool {
+			return *user.Id == userId && *user.UserInfo.UserId == userId && user.UserInfo.CaloriesLimit > 0
+		}), mock.Anything).Return(&models.MealPlan{}, nil)
+		app := newApp(&agentAIHandler{agentUs: agentUs}, map[string]interface{}{
+			"user_id":      otherUserId.String(),
+			"gender":       "FEMALE",
+			"weight":       "60",
+			"height":       "160",
+			"active_level": "SEDENTARY",
+			"dob":          "1995-03-01 00:00:00",
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/agent-ai/meals", strings.NewReader(""))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		agentUs.AssertExpectations(t)
+	})
+	t.Run("error_body_missing", func(t *testing.T) {
+		agentUs := new(agent_mocks.IAgentAIUsecase)
+		app := newApp(&agentAIHandler{agentUs: agentUs}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/agent-ai/meals", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		body := constants.ErrorResponse{}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, constants.ERROR_BODY_WAS_MISSING, body.Detail)
+		agentUs.AssertNotCalled(t, "GenerateMealsPlan", mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("error_profile_invalid", func(t *testing.T) {
+		agentUs := new(agent_mocks.IAgentAIUsecase)
+		app := newApp(&agentAIHandler{agentUs: agentUs}, map[string]interface{}{
+			"gender":       "OTHER",
+			"weight":       "-1",
+			"height":       "160",
+			"active_level": "LAZY",
+			"dob":          "01/03/1995",
+			"days":         "30",
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/agent-ai/meals", strings.NewReader(""))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		body := constants.ErrorResponse{}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		fields := []string{}
+		for _, field := range body.Errors {
+			fields = append(fields, field.Field)
+		}
+		assert.Equal(t, []string{"gender", "weight", "active_level", "dob", "days"}, fields)
+		agentUs.AssertNotCalled(t, "GenerateMealsPlan", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestStreamMealsPlan(t *testing.T) {
+	agentUs := new(agent_mocks.IAgentAIUsecase)
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	app.Post("/v1/agent-ai/meals/stream", validator.Validation{}.ValidateMealPlanProfile(), (&agentAIHandler{agentUs: agentUs}).StreamMealsPlan)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/agent-ai/meals/stream", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, middleware.MIMEApplicationProblemJSON, resp.Header.Get(fiber.HeaderContentType))
+	agentUs.AssertNotCalled(t, "StreamMealsPlan", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGenerateMyMealsPlan(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	dob := helper.NewTimestampFromString("1995-03-01 00:00:00")
+	newApp := func(handler *agentAIHandler) *fiber.App {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		app.Post("/v1/agent-ai/meals/me", func(c *fiber.Ctx) error {
+			c.Locals("user_id", &userId)
+			c.Locals("params", map[string]interface{}{"days": "5", "cuisine": "Thai"})
+			return c.Next()
+		}, handler.GenerateMyMealsPlan)
+		return app
+	}
+	t.Run("success", func(t *testing.T) {
+		userInfo := &models.UserInfo{
+			UserId:      &userId,
+			Gender:      "FEMALE",
+			Weight:      60,
+			Height:      160,
+			ActiveLevel: "SEDENTARY",
+			DOB:         &dob,
+			Diseases:    []*models.Disease{{Name: "เบาหวาน"}},
+		}
+		userUs := new(user_mocks.IUserUsecase)
+		userUs.On("FetchOneUserById", mock.Anything, &userId).Return(&models.User{Id: &userId, UserInfo: userInfo}, nil)
+		agentUs := new(agent_mocks.IAgentAIUsecase)
+		agentUs.On("GenerateMealsPlan", mock.Anything, mock.MatchedBy(func(user *models.User) bool {
+			return *user.Id == userId && user.UserInfo.MedicalCondition == "เบาหวาน" && user.UserInfo.CaloriesLimit > 0
+		}), &models.MealPlanOption{Days: 5, Cuisine: "Thai"}).Return(&models.MealPlan{Id: &userId}, nil)
+		app := newApp(&agentAIHandler{agentUs: agentUs, userUs: userUs})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/agent-ai/meals/me", strings.NewReader(""))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		agentUs.AssertExpectations(t)
+	})
+	t.Run("error_agent_unavailable", func(t *testing.T) {
+		userInfo := &models.UserInfo{UserId: &userId, Gender: "MALE", Weight: 70, DOB: &dob}
+		userUs := new(user_mocks.IUserUsecase)
+		userUs.On("FetchOneUserById", mock.Anything, &userId).Return(&models.User{Id: &userId, UserInfo: userInfo}, nil)
+		agentUs := new(agent_mocks.IAgentAIUsecase)
+		agentUs.On("GenerateMealsPlan", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, &agent.UpstreamError{Provider: "digitalocean-agent", CircuitOpen: true})
+		app := newApp(&agentAIHandler{agentUs: agentUs, userUs: userUs})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/agent-ai/meals/me", strings.NewReader(""))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	})
+	t.Run("error_user_info_not_found", func(t *testing.T) {
+		userUs := new(user_mocks.IUserUsecase)
+		userUs.On("FetchOneUserById", mock.Anything, &userId).Return(&models.User{Id: &userId}, nil)
+		agentUs := new(agent_mocks.IAgentAIUsecase)
+		app := newApp(&agentAIHandler{agentUs: agentUs, userUs: userUs})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/agent-ai/meals/me", strings.NewReader(""))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		agentUs.AssertNotCalled(t, "GenerateMealsPlan", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSwapMyMealPlanMeal(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	planId := uuid.FromStringOrNil("5b0e8f3a-2f4c-4d8e-9a31-6c1f0d7e2a10")
+	mealId := uuid.FromStringOrNil("0c3b6f8e-1d2a-4e5f-8a9b-7c6d5e4f3a21")
+	dob := helper.NewTimestampFromString("1995-03-01 00:00:00")
+	newApp := func(handler *agentAIHandler) *fiber.App {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		app.Post("/v1/agent-ai/meals/me/:plan_id/swap", func(c *fiber.Ctx) error {
+			c.Locals("user_id", &userId)
+			c.Locals("params", map[string]interface{}{"meal_id": mealId.String(), "reason": "ไม่ชอบปลา"})
+			return c.Next()
+		}, handler.SwapMyMealPlanMeal)
+		return app
+	}
+	newUserUs := func() *user_mocks.IUserUsecase {
+		userInfo := &models.UserInfo{UserId: &userId, Gender: "FEMALE", Weight: 60, Height: 160, ActiveLevel: "SEDENTARY", DOB: &dob}
+		userUs := new(user_mocks.IUserUsecase)
+		userUs.On("FetchOneUserById", mock.Anything, &userId).Return(&models.User{Id: &userId, UserInfo: userInfo}, nil)
+		return userUs
+	}
+	t.Run("success", func(t *testing.T) {
+		agentUs := new(agent_mocks.IAgentAIUsecase)
+		agentUs.On("SwapMealPlanMeal", mock.Anything, mock.Anything, &planId, &models.MealPlanSwapOption{MealId: &mealId, Reason: "ไม่ชอบปลา"}).
+			Return(&models.MealPlan{Id: &planId}, &models.MealPlanSwap{MealPlanId: &planId, MealPlanMealId: &mealId}, nil)
+		app := newApp(&agentAIHandler{agentUs: agentUs, userUs: newUserUs()})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/agent-ai/meals/me/"+planId.String()+"/swap", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		agentUs.AssertExpectations(t)
+	})
+	t.Run("error_meal_not_found", func(t *testing.T) {
+		agentUs := new(agent_mocks.IAgentAIUsecase)
+		agentUs.On("SwapMealPlanMeal", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, nil, apperror.NotFound(constants.ERROR_MEAL_PLAN_MEAL_NOT_FOUND))
+		app := newApp(&agentAIHandler{agentUs: agentUs, userUs: newUserUs()})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/agent-ai/meals/me/"+planId.String()+"/swap", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+	t.Run("error_swap_is_invalid", func(t *testing.T) {
+		agentUs := new(agent_mocks.IAgentAIUsecase)
+		agentUs.On("SwapMealPlanMeal", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, nil, apperror.Upstream(constants.ERROR_MEAL_SWAP_IS_INVALID).WithDetail("day calories 1200 kcal is outside the target"))
+		app := newApp(&agentAIHandler{agentUs: agentUs, userUs: newUserUs()})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/agent-ai/meals/me/"+planId.String()+"/swap", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	})
+}
@@ -0,0 +1,135 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IAgentAIHandler is an autogenerated mock type for the IAgentAIHandler type
+type IAgentAIHandler struct {
+	mock.Mock
+}
+
+// AnalyzeMyMealPhoto provides a mock function with given fields: c
+func (_m *IAgentAIHandler) AnalyzeMyMealPhoto(c *fiber.Ctx) error {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AnalyzeMyMealPhoto")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*fiber.Ctx) error); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GenerateMealsPlan provides a mock function with given fields: c
+func (_m *IAgentAIHandler) GenerateMealsPlan(c *fiber.Ctx) error {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateMealsPlan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*fiber.Ctx) error); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GenerateMyMealsPlan provides a mock function with given fields: c
+func (_m *IAgentAIHandler) GenerateMyMealsPlan(c *fiber.Ctx) error {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateMyMealsPlan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*fiber.Ctx) error); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StreamMealsPlan provides a mock function with given fields: c
+func (_m *IAgentAIHandler) StreamMealsPlan(c *fiber.Ctx) error {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamMealsPlan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*fiber.Ctx) error); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StreamMyMealsPlan provides a mock function with given fields: c
+func (_m *IAgentAIHandler) StreamMyMealsPlan(c *fiber.Ctx) error {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamMyMealsPlan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*fiber.Ctx) error); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SwapMyMealPlanMeal provides a mock function with given fields: c
+func (_m *IAgentAIHandler) SwapMyMealPlanMeal(c *fiber.Ctx) error {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SwapMyMealPlanMeal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*fiber.Ctx) error); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIAgentAIHandler creates a new instance of IAgentAIHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAgentAIHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAgentAIHandler {
+	mock := &IAgentAIHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,251 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "healthmatefood-api/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IAgentAIRepository is an autogenerated mock type for the IAgentAIRepository type
+type IAgentAIRepository struct {
+	mock.Mock
+}
+
+// AnalyzeMealPhoto provides a mock function with given fields: ctx, userInfo, photo
+func (_m *IAgentAIRepository) AnalyzeMealPhoto(ctx context.Context, userInfo *models.UserInfo, photo *models.MealPhoto) (*models.MealPhotoAnalysis, error) {
+	ret := _m.Called(ctx, userInfo, photo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AnalyzeMealPhoto")
+	}
+
+	var r0 *models.MealPhotoAnalysis
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserInfo, *models.MealPhoto) (*models.MealPhotoAnalysis, error)); ok {
+		return rf(ctx, userInfo, photo)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserInfo, *models.MealPhoto) *models.MealPhotoAnalysis); ok {
+		r0 = rf(ctx, userInfo, photo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MealPhotoAnalysis)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.UserInfo, *models.MealPhoto) error); ok {
+		r1 = rf(ctx, userInfo, photo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConversationWithChat provides a mock function with given fields: ctx, userInfo, conversation, history
+func (_m *IAgentAIRepository) ConversationWithChat(ctx context.Context, userInfo *models.UserInfo, conversation *models.Conversation, history []*models.ConversationMessage) (string, []*models.KnowledgeCitation, error) {
+	ret := _m.Called(ctx, userInfo, conversation, history)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConversationWithChat")
+	}
+
+	var r0 string
+	var r1 []*models.KnowledgeCitation
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserInfo, *models.Conversation, []*models.ConversationMessage) (string, []*models.KnowledgeCitation, error)); ok {
+		return rf(ctx, userInfo, conversation, history)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserInfo, *models.Conversation, []*models.ConversationMessage) string); ok {
+		r0 = rf(ctx, userInfo, conversation, history)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.UserInfo, *models.Conversation, []*models.ConversationMessage) []*models.KnowledgeCitation); ok {
+		r1 = rf(ctx, userInfo, conversation, history)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*models.KnowledgeCitation)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.UserInfo, *models.Conversation, []*models.ConversationMessage) error); ok {
+		r2 = rf(ctx, userInfo, conversation, history)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GenerateMealsPlan provides a mock function with given fields: ctx, user, option
+func (_m *IAgentAIRepository) GenerateMealsPlan(ctx context.Context, user *models.User, option *models.MealPlanOption) (*models.MealPlan, error) {
+	ret := _m.Called(ctx, user, option)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateMealsPlan")
+	}
+
+	var r0 *models.MealPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, *models.MealPlanOption) (*models.MealPlan, error)); ok {
+		return rf(ctx, user, option)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, *models.MealPlanOption) *models.MealPlan); ok {
+		r0 = rf(ctx, user, option)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MealPlan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.User, *models.MealPlanOption) error); ok {
+		r1 = rf(ctx, user, option)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StreamConversationWithChat provides a mock function with given fields: ctx, userInfo, conversation, history, stream
+func (_m *IAgentAIRepository) StreamConversationWithChat(ctx context.Context, userInfo *models.UserInfo, conversation *models.Conversation, history []*models.ConversationMessage, stream models.StreamFunc) (string, []*models.KnowledgeCitation, error) {
+	ret := _m.Called(ctx, userInfo, conversation, history, stream)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamConversationWithChat")
+	}
+
+	var r0 string
+	var r1 []*models.KnowledgeCitation
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserInfo, *models.Conversation, []*models.ConversationMessage, models.StreamFunc) (string, []*models.KnowledgeCitation, error)); ok {
+		return rf(ctx, userInfo, conversation, history, stream)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserInfo, *models.Conversation, []*models.ConversationMessage, models.StreamFunc) string); ok {
+		r0 = rf(ctx, userInfo, conversation, history, stream)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.UserInfo, *models.Conversation, []*models.ConversationMessage, models.StreamFunc) []*models.KnowledgeCitation); ok {
+		r1 = rf(ctx, userInfo, conversation, history, stream)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*models.KnowledgeCitation)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.UserInfo, *models.Conversation, []*models.ConversationMessage, models.StreamFunc) error); ok {
+		r2 = rf(ctx, userInfo, conversation, history, stream)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// StreamMealsPlan provides a mock function with given fields: ctx, user, option, stream
+func (_m *IAgentAIRepository) StreamMealsPlan(ctx context.Context, user *models.User, option *models.MealPlanOption, stream models.StreamFunc) (*models.MealPlan, error) {
+	ret := _m.Called(ctx, user, option, stream)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamMealsPlan")
+	}
+
+	var r0 *models.MealPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, *models.MealPlanOption, models.StreamFunc) (*models.MealPlan, error)); ok {
+		return rf(ctx, user, option, stream)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, *models.MealPlanOption, models.StreamFunc) *models.MealPlan); ok {
+		r0 = rf(ctx, user, option, stream)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MealPlan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.User, *models.MealPlanOption, models.StreamFunc) error); ok {
+		r1 = rf(ctx, user, option, stream)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SummarizeConversation provides a mock function with given fields: ctx, userInfo, summary, messages
+func (_m *IAgentAIRepository) SummarizeConversation(ctx context.Context, userInfo *models.UserInfo, summary string, messages []*models.ConversationMessage) (string, error) {
+	ret := _m.Called(ctx, userInfo, summary, messages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SummarizeConversation")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserInfo, string, []*models.ConversationMessage) (string, error)); ok {
+		return rf(ctx, userInfo, summary, messages)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserInfo, string, []*models.ConversationMessage) string); ok {
+		r0 = rf(ctx, userInfo, summary, messages)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.UserInfo, string, []*models.ConversationMessage) error); ok {
+		r1 = rf(ctx, userInfo, summary, messages)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SwapMealPlanMeal provides a mock function with given fields: ctx, user, plan, target, reason
+func (_m *IAgentAIRepository) SwapMealPlanMeal(ctx context.Context, user *models.User, plan *models.MealPlan, target *models.MealSwapTarget, reason string) (*models.MealPlanSwap, error) {
+	ret := _m.Called(ctx, user, plan, target, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SwapMealPlanMeal")
+	}
+
+	var r0 *models.MealPlanSwap
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, *models.MealPlan, *models.MealSwapTarget, string) (*models.MealPlanSwap, error)); ok {
+		return rf(ctx, user, plan, target, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, *models.MealPlan, *models.MealSwapTarget, string) *models.MealPlanSwap); ok {
+		r0 = rf(ctx, user, plan, target, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MealPlanSwap)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.User, *models.MealPlan, *models.MealSwapTarget, string) error); ok {
+		r1 = rf(ctx, user, plan, target, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIAgentAIRepository creates a new instance of IAgentAIRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAgentAIRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAgentAIRepository {
+	mock := &IAgentAIRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,160 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "healthmatefood-api/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IAgentAIUsecase is an autogenerated mock type for the IAgentAIUsecase type
+type IAgentAIUsecase struct {
+	mock.Mock
+}
+
+// AnalyzeMealPhoto provides a mock function with given fields: ctx, userInfo, photo
+func (_m *IAgentAIUsecase) AnalyzeMealPhoto(ctx context.Context, userInfo *models.UserInfo, photo *models.MealPhoto) (*models.MealPhotoAnalysis, error) {
+	ret := _m.Called(ctx, userInfo, photo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AnalyzeMealPhoto")
+	}
+
+	var r0 *models.MealPhotoAnalysis
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserInfo, *models.MealPhoto) (*models.MealPhotoAnalysis, error)); ok {
+		return rf(ctx, userInfo, photo)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserInfo, *models.MealPhoto) *models.MealPhotoAnalysis); ok {
+		r0 = rf(ctx, userInfo, photo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MealPhotoAnalysis)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.UserInfo, *models.MealPhoto) error); ok {
+		r1 = rf(ctx, userInfo, photo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateMealsPlan provides a mock function with given fields: ctx, user, option
+func (_m *IAgentAIUsecase) GenerateMealsPlan(ctx context.Context, user *models.User, option *models.MealPlanOption) (*models.MealPlan, error) {
+	ret := _m.Called(ctx, user, option)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateMealsPlan")
+	}
+
+	var r0 *models.MealPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, *models.MealPlanOption) (*models.MealPlan, error)); ok {
+		return rf(ctx, user, option)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, *models.MealPlanOption) *models.MealPlan); ok {
+		r0 = rf(ctx, user, option)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MealPlan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.User, *models.MealPlanOption) error); ok {
+		r1 = rf(ctx, user, option)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StreamMealsPlan provides a mock function with given fields: ctx, user, option, stream
+func (_m *IAgentAIUsecase) StreamMealsPlan(ctx context.Context, user *models.User, option *models.MealPlanOption, stream models.StreamFunc) (*models.MealPlan, error) {
+	ret := _m.Called(ctx, user, option, stream)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamMealsPlan")
+	}
+
+	var r0 *models.MealPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, *models.MealPlanOption, models.StreamFunc) (*models.MealPlan, error)); ok {
+		return rf(ctx, user, option, stream)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, *models.MealPlanOption, models.StreamFunc) *models.MealPlan); ok {
+		r0 = rf(ctx, user, option, stream)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MealPlan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.User, *models.MealPlanOption, models.StreamFunc) error); ok {
+		r1 = rf(ctx, user, option, stream)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SwapMealPlanMeal provides a mock function with given fields: ctx, user, planId, option
+func (_m *IAgentAIUsecase) SwapMealPlanMeal(ctx context.Context, user *models.User, planId *uuid.UUID, option *models.MealPlanSwapOption) (*models.MealPlan, *models.MealPlanSwap, error) {
+	ret := _m.Called(ctx, user, planId, option)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SwapMealPlanMeal")
+	}
+
+	var r0 *models.MealPlan
+	var r1 *models.MealPlanSwap
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, *uuid.UUID, *models.MealPlanSwapOption) (*models.MealPlan, *models.MealPlanSwap, error)); ok {
+		return rf(ctx, user, planId, option)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.User, *uuid.UUID, *models.MealPlanSwapOption) *models.MealPlan); ok {
+		r0 = rf(ctx, user, planId, option)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MealPlan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.User, *uuid.UUID, *models.MealPlanSwapOption) *models.MealPlanSwap); ok {
+		r1 = rf(ctx, user, planId, option)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.MealPlanSwap)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *models.User, *uuid.UUID, *models.MealPlanSwapOption) error); ok {
+		r2 = rf(ctx, user, planId, option)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewIAgentAIUsecase creates a new instance of IAgentAIUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAgentAIUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAgentAIUsecase {
+	mock := &IAgentAIUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
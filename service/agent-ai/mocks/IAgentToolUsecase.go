@@ -0,0 +1,79 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "healthmatefood-api/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IAgentToolUsecase is an autogenerated mock type for the IAgentToolUsecase type
+type IAgentToolUsecase struct {
+	mock.Mock
+}
+
+// ExecuteTool provides a mock function with given fields: ctx, userId, call
+func (_m *IAgentToolUsecase) ExecuteTool(ctx context.Context, userId *uuid.UUID, call *models.AgentToolCall) (string, error) {
+	ret := _m.Called(ctx, userId, call)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteTool")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *models.AgentToolCall) (string, error)); ok {
+		return rf(ctx, userId, call)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *models.AgentToolCall) string); ok {
+		r0 = rf(ctx, userId, call)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, *models.AgentToolCall) error); ok {
+		r1 = rf(ctx, userId, call)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Tools provides a mock function with no fields
+func (_m *IAgentToolUsecase) Tools() []*models.AgentTool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Tools")
+	}
+
+	var r0 []*models.AgentTool
+	if rf, ok := ret.Get(0).(func() []*models.AgentTool); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AgentTool)
+		}
+	}
+
+	return r0
+}
+
+// NewIAgentToolUsecase creates a new instance of IAgentToolUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAgentToolUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAgentToolUsecase {
+	mock := &IAgentToolUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,76 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IEmbedder is an autogenerated mock type for the IEmbedder type
+type IEmbedder struct {
+	mock.Mock
+}
+
+// EmbedTexts provides a mock function with given fields: ctx, texts
+func (_m *IEmbedder) EmbedTexts(ctx context.Context, texts []string) ([][]float32, error) {
+	ret := _m.Called(ctx, texts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EmbedTexts")
+	}
+
+	var r0 [][]float32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([][]float32, error)); ok {
+		return rf(ctx, texts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) [][]float32); ok {
+		r0 = rf(ctx, texts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]float32)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, texts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Model provides a mock function with no fields
+func (_m *IEmbedder) Model() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Model")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// NewIEmbedder creates a new instance of IEmbedder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIEmbedder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IEmbedder {
+	mock := &IEmbedder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
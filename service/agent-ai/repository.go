@@ -6,6 +6,23 @@ import (
 )
 
 type IAgentAIRepository interface {
-	GenerateMealsPlan(ctx context.Context, user *models.User) (string, error)
-	ConversationWithChat(ctx context.Context, prompt string) (string, error)
+	GenerateMealsPlan(ctx context.Context, user *models.User, option *models.MealPlanOption) (*models.MealPlan, error)
+	StreamMealsPlan(ctx context.Context, user *models.User, option *models.MealPlanOption, stream models.StreamFunc) (*models.MealPlan, error)
+	ConversationWithChat(ctx context.Context, userInfo *models.UserInfo, conversation *models.Conversation, history []*models.ConversationMessage) (string, []*models.KnowledgeCitation, error)
+	StreamConversationWithChat(ctx context.Context, userInfo *models.UserInfo, conversation *models.Conversation, history []*models.ConversationMessage, stream models.StreamFunc) (string, []*models.KnowledgeCitation, error)
+	SummarizeConversation(ctx context.Context, userInfo *models.UserInfo, summary string, messages []*models.ConversationMessage) (string, error)
+	AnalyzeMealPhoto(ctx context.Context, userInfo *models.UserInfo, photo *models.MealPhoto) (*models.MealPhotoAnalysis, error)
+	SwapMealPlanMeal(ctx context.Context, user *models.User, plan *models.MealPlan, target *models.MealSwapTarget, reason string) (*models.MealPlanSwap, error)
+}
+
+/* IResponseCache ที่เก็บคำตอบของโมเดลตาม key Get คืน nil เมื่อไม่พบหรือหมดอายุ */
+type IResponseCache interface {
+	Get(ctx context.Context, key string) (*models.CachedResponse, error)
+	Set(ctx context.Context, response *models.CachedResponse) error
+}
+
+/* IEmbedder แปลงข้อความเป็น vector ผ่าน provider เดียวกับ LLM ใช้ค้นหาใน knowledge base Model คือชื่อที่บันทึกคู่กับ vector */
+type IEmbedder interface {
+	Model() string
+	EmbedTexts(ctx context.Context, texts []string) ([][]float32, error)
 }
@@ -1,146 +1,703 @@
 package repository
 
 import (
-	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"healthmatefood-api/apperror"
 	"healthmatefood-api/config"
+	"healthmatefood-api/constants"
 	"healthmatefood-api/models"
 	"healthmatefood-api/service/agent-ai"
-	"html/template"
+	"healthmatefood-api/service/guardrail"
+	"healthmatefood-api/service/knowledge"
+	"healthmatefood-api/service/prompt"
 	"log"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/tmc/langchaingo/llms"
 )
 
+/* จำนวนครั้งสูงสุดที่ให้โมเดลตอบใหม่เมื่อ JSON ไม่ผ่านการตรวจ */
+const (
+	mealPlanMaxAttempts  = 3
+	mealPhotoMaxAttempts = 2
+)
+
+/* จำนวนครั้งที่ให้โมเดลปรับแผนที่เกินข้อจำกัดตามโรคประจำตัว ครบแล้วยังเกินจะแจ้งใน safety.violations */
+const mealPlanMaxRevisions = 2
+
 type agentAIRepository struct {
-	cfg             config.IAgentConfig
-	digitalOceanLLM *digitalOceanLLM
+	cfg         config.IAgentConfig
+	llm         LLMProvider
+	promptUs    prompt.IPromptUsecase
+	cache       agent.IResponseCache
+	cacheTTL    time.Duration
+	toolUs      agent.IAgentToolUsecase
+	knowledgeUs knowledge.IKnowledgeUsecase
+	guardrailUs guardrail.IGuardrailUsecase
 }
 
-func NewAgentAIRepository(cfg config.IAgentConfig) agent.IAgentAIRepository {
+func NewAgentAIRepository(cfg config.IAgentConfig, promptUs prompt.IPromptUsecase, cache agent.IResponseCache, toolUs agent.IAgentToolUsecase, knowledgeUs knowledge.IKnowledgeUsecase, guardrailUs guardrail.IGuardrailUsecase) agent.IAgentAIRepository {
 	return &agentAIRepository{
-		cfg:             cfg,
-		digitalOceanLLM: NewDigitalOceanLLM(cfg.AgentEndpoint(), cfg.AgentAccessKey()),
+		cfg:         cfg,
+		llm:         NewLLMProvider(cfg),
+		promptUs:    promptUs,
+		cache:       cache,
+		cacheTTL:    cfg.AgentCacheTTL(),
+		toolUs:      toolUs,
+		knowledgeUs: knowledgeUs,
+		guardrailUs: guardrailUs,
 	}
 }
 
-func (r *agentAIRepository) GenerateMealsPlan(ctx context.Context, user *models.User) (string, error) {
-	path := "templates/user_info.txt"
-	tmpl := template.Must(template.ParseFiles(path))
-	var prompt bytes.Buffer
-	if err := tmpl.Execute(&prompt, user.UserInfo); err != nil {
-		return "", err
+func (r *agentAIRepository) GenerateMealsPlan(ctx context.Context, user *models.User, option *models.MealPlanOption) (*models.MealPlan, error) {
+	return r.generateMealsPlan(ctx, user, option, nil)
+}
+
+/* StreamMealsPlan เหมือน GenerateMealsPlan แต่ส่ง token ให้ stream ระหว่างตอบ และแจ้ง reset เมื่อต้องให้โมเดลตอบใหม่ */
+func (r *agentAIRepository) StreamMealsPlan(ctx context.Context, user *models.User, option *models.MealPlanOption, stream models.StreamFunc) (*models.MealPlan, error) {
+	return r.generateMealsPlan(ctx, user, option, stream)
+}
+
+func (r *agentAIRepository) generateMealsPlan(ctx context.Context, user *models.User, option *models.MealPlanOption, stream models.StreamFunc) (*models.MealPlan, error) {
+	prompts, err := r.renderPrompts(ctx, user.UserInfo, models.PromptMealPlanSystem, models.PromptUserInfoTemplate, models.PromptUserInfo)
+	if err != nil {
+		return nil, err
+	}
+	days := option.Days
+	rules, err := r.diseaseRules(ctx, user.UserInfo)
+	if err != nil {
+		return nil, err
 	}
+	weight := user.UserInfo.Weight
 
-	log.Println("prompt", prompt.String())
 	messages := []llms.MessageContent{
 		{
 			Role:  llms.ChatMessageTypeSystem,
-			Parts: []llms.ContentPart{llms.TextContent{Text: "คุณเป็นผู้เชี่ยวชาญที่ให้คำตอบเป็นภาษาไทยเท่านั้น"}},
+			Parts: []llms.ContentPart{llms.TextContent{Text: prompts[0].Text}},
 		},
 		{
-			Role: llms.ChatMessageTypeSystem,
-			Parts: []llms.ContentPart{llms.TextContent{Text: `
-		    Variable Definitions
-		    [age]: The individual’s age in years.
-		    [gender]: The individual’s gender (e.g., male, female, or non-binary).
-		    [medical_condition]: A specific health condition that impacts dietary choices (e.g., diabetes, hypertension, renal impairment).
-		    [calories]: A specific calorie limit (e.g., daily total, per meal).
-		    [activity_level]: Physical activity intensity, such as sedentary, moderate, or active.
-		    [activity_level_active]: A lifestyle involving frequent physical activity.
-		    [food_or_ingredients]: The name of a dish, food item, or a list of ingredients.
-		    [food_log]: A record of meals consumed.
-		    [food_image]: An uploaded image of a meal or pantry contents.
-		    [cuisine_type]: The style or origin of cuisine (e.g., Italian, Thai).
-		    [carbohydrate_limit]: The maximum allowable carbohydrate intake in grams.
-		    [calories_burned]: Calories burned during physical activity.
-		    [nutritional_focus]: A dietary priority such as high-protein, low-fat, or fiber-rich.
-		    [weight_goal]: Desired weight change in kilograms.
-		    [timeframe]: The time period to achieve the weight goal (e.g., in weeks).
-		    [food_preferences_like]: Foods that the individual enjoys.
-		    [food_preferences_dislike]: Foods that the individual dislikes.
-
-		    General Food and Recipe Recommendations
-		    "Recommend meal plans for a [age]-year-old [gender] with [medical_condition] requiring a daily intake of [calories] calories."
-		    "Provide a list of recipes under [calories] calories, suitable for someone with [medical_condition]."
-		    "What are some high-protein, low-sodium dinner options for a [age]-year-old [gender] with [medical_condition]?"
-
-		    Nutritional Analysis and Recommendations
-		    "Calculate the daily energy requirement for a [age]-year-old [gender] with a weight of [weight] kg, height of [height] cm, and [activity_level], considering their [medical_condition]."
-		    "Analyze the nutritional content of this meal: [food_or_ingredients], and suggest healthier substitutions."
-		    "How many calories are in a serving of [food_or_ingredients]?"
-		    "Provide the macronutrient breakdown (carbs, protein, fat) for [food_or_ingredients]."
-
-		    Interactive Food Tracking
-		    "Log this meal: [food_or_ingredients], and calculate its calorie and nutritional value."
-		    "Based on today’s meals: [food_log], suggest a balanced dinner to meet my remaining nutritional goals."
-		    "Analyze this image of my meal: [food_image]. Estimate its calories and macronutrients."`}},
+			Role:  llms.ChatMessageTypeSystem,
+			Parts: []llms.ContentPart{llms.TextContent{Text: prompts[1].Text}},
+		},
+		{
+			Role:  llms.ChatMessageTypeSystem,
+			Parts: []llms.ContentPart{llms.TextContent{Text: mealPlanInstruction(user.UserInfo, option)}},
+		},
+	}
+	if len(rules) > 0 {
+		messages = append(messages, llms.MessageContent{
+			Role:  llms.ChatMessageTypeSystem,
+			Parts: []llms.ContentPart{llms.TextContent{Text: guardrailInstruction(rules, weight)}},
+		})
+	}
+	/* ผู้ใช้ที่มีโรคประจำตัวได้แนวทางโภชนาการจาก knowledge base ประกอบการจัดแผน */
+	var chunks []*models.KnowledgeChunk
+	if user.UserInfo.HasMedicalCondition() {
+		chunks = r.searchKnowledge(ctx, "แนวทางอาหารสำหรับผู้ที่เป็น "+user.UserInfo.MedicalCondition)
+	}
+	if len(chunks) > 0 {
+		messages = append(messages, knowledgeMessage(chunks))
+	}
+	citations := models.NewKnowledgeCitations(chunks)
+	messages = append(messages, llms.MessageContent{
+		Role:  llms.ChatMessageTypeHuman,
+		Parts: []llms.ContentPart{llms.TextContent{Text: prompts[2].Text}},
+	})
+
+	promptVersion := models.JoinPromptVersions(prompts...)
+	var cacheInfo *models.CacheInfo
+	if r.cache != nil {
+		cacheKey := responseCacheKey(messages, r.llm.Name(), promptVersion)
+		cacheInfo = &models.CacheInfo{Key: cacheKey, Bypass: option.Fresh}
+		if !option.Fresh {
+			/* แผนใน cache ที่ไม่ผ่านกฎ (เช่นกฎเพิ่งถูกแก้) ถือว่าไม่มีใน cache */
+			if plan := r.cachedMealPlan(ctx, cacheKey, days); plan != nil && len(models.CheckMealPlan(plan, rules, weight)) == 0 {
+				plan.CaloriesTarget = user.UserInfo.CaloriesLimit
+				plan.Citations = citations
+				if len(rules) > 0 {
+					plan.Safety = models.NewMealPlanSafety(rules, nil, 0)
+				}
+				return plan, nil
+			}
+		}
+	}
+
+	var lastErr error
+	invalids, revisions := 0, 0
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && stream != nil {
+			if err := stream(models.StreamEventReset, map[string]interface{}{"attempt": attempt, "reason": lastErr.Error()}); err != nil {
+				return nil, err
+			}
+		}
+		resp, err := r.llm.GenerateContent(ctx, messages, streamOptions(stream)...)
+		if err != nil {
+			log.Println("err", err)
+			return nil, err
+		}
+		if len(resp.Choices) == 0 {
+			return nil, fmt.Errorf("no response from AI")
+		}
+
+		content := resp.Choices[0].Content
+		plan, err := models.DecodeMealPlan(content, days)
+		if err != nil {
+			invalids++
+			lastErr = err
+			log.Printf("meal plan attempt %d is invalid: %v", attempt, err)
+			if invalids >= mealPlanMaxAttempts {
+				return nil, apperror.Upstream(constants.ERROR_MEAL_PLAN_IS_INVALID).Wrap(lastErr)
+			}
+			/* ส่งคำตอบเดิมพร้อมข้อผิดพลาดกลับไปให้โมเดลแก้ */
+			messages = append(messages, mealPlanRetryMessages(content, mealPlanRepairInstruction(err))...)
+			continue
+		}
+
+		violations := models.CheckMealPlan(plan, rules, weight)
+		if len(violations) > 0 && revisions < mealPlanMaxRevisions {
+			revisions++
+			lastErr = fmt.Errorf("%d guardrail violations", len(violations))
+			log.Printf("meal plan attempt %d violates disease rules: %d violations", attempt, len(violations))
+			messages = append(messages, mealPlanRetryMessages(content, mealPlanRevisionInstruction(violations))...)
+			continue
+		}
+
+		plan.Model = r.llm.Name()
+		if model, ok := resp.Choices[0].GenerationInfo["model"].(string); ok {
+			plan.Model = model
+		}
+		plan.PromptVersion = promptVersion
+		plan.CaloriesTarget = user.UserInfo.CaloriesLimit
+		plan.Cache = cacheInfo
+		plan.Citations = citations
+		if len(rules) > 0 {
+			plan.Safety = models.NewMealPlanSafety(rules, violations, revisions)
+		}
+		/* แผนที่ยังเกินข้อจำกัดไม่เก็บลง cache ครั้งหน้าจะได้ลองใหม่ */
+		if cacheInfo != nil && len(violations) == 0 {
+			if err := r.cache.Set(ctx, models.NewCachedResponse(cacheInfo.Key, plan.Model, promptVersion, content, r.cacheTTL)); err != nil {
+				log.Printf("cache meal plan failed: %v", err)
+			}
+		}
+		return plan, nil
+	}
+}
+
+/* mealPlanRetryMessages คำตอบเดิมของโมเดลตามด้วยคำสั่งให้แก้ */
+func mealPlanRetryMessages(content, instruction string) []llms.MessageContent {
+	return []llms.MessageContent{
+		{
+			Role:  llms.ChatMessageTypeAI,
+			Parts: []llms.ContentPart{llms.TextContent{Text: content}},
 		},
 		{
 			Role:  llms.ChatMessageTypeHuman,
-			Parts: []llms.ContentPart{llms.TextContent{Text: prompt.String()}},
+			Parts: []llms.ContentPart{llms.TextContent{Text: instruction}},
 		},
 	}
+}
+
+/* diseaseRules กฎตามโรคประจำตัวของผู้ใช้ ถ้าอ่านกฎไม่ได้จะไม่สร้างแผนเพราะตรวจความปลอดภัยไม่ได้ */
+func (r *agentAIRepository) diseaseRules(ctx context.Context, userInfo *models.UserInfo) ([]*models.DiseaseRule, error) {
+	if r.guardrailUs == nil {
+		return nil, nil
+	}
+	return r.guardrailUs.FetchRulesByUserInfo(ctx, userInfo)
+}
 
-	resp, err := r.digitalOceanLLM.GenerateContent(ctx, messages)
+/* responseCacheKey hash ของทุกข้อความหลังตัดช่องว่างซ้ำและแปลงเป็นตัวพิมพ์เล็ก รวมกับโมเดลและเวอร์ชันของ prompt */
+func responseCacheKey(messages []llms.MessageContent, model string, promptVersion string) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%s\n", model, promptVersion)
+	for _, message := range messages {
+		text := strings.ToLower(strings.Join(strings.Fields(messageText(message)), " "))
+		fmt.Fprintf(hash, "%s:%s\n", messageRole(message.Role), text)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+/* cachedMealPlan คืนแผนจาก cache ถ้าอ่าน cache ไม่ได้หรือแผนไม่ผ่านการตรวจแล้วจะคืน nil ให้สร้างใหม่ */
+func (r *agentAIRepository) cachedMealPlan(ctx context.Context, key string, days int) *models.MealPlan {
+	cached, err := r.cache.Get(ctx, key)
 	if err != nil {
-		log.Println("err", err)
-		return "", err
+		log.Printf("read meal plan cache failed: %v", err)
+		return nil
 	}
+	if cached == nil {
+		return nil
+	}
+	plan, err := models.DecodeMealPlan(cached.Content, days)
+	if err != nil {
+		log.Printf("cached meal plan %s is invalid: %v", key, err)
+		return nil
+	}
+	plan.Model = cached.Model
+	plan.PromptVersion = cached.PromptVersion
+	plan.Cache = &models.CacheInfo{
+		Hit:       true,
+		Key:       key,
+		Hits:      cached.Hits,
+		CachedAt:  cached.CreatedAt,
+		ExpiresAt: cached.ExpiresAt,
+	}
+	return plan
+}
 
-	if len(resp.Choices) == 0 {
-		return "", fmt.Errorf("no response from AI")
+/* renderPrompts render prompt ตามชื่อที่ระบุ เรียงตามลำดับเดียวกัน ตอบเป็นภาษาที่ request ขอหรือที่ผู้ใช้ตั้งไว้ */
+func (r *agentAIRepository) renderPrompts(ctx context.Context, userInfo *models.UserInfo, names ...string) ([]*models.RenderedPrompt, error) {
+	language := models.ResolveLanguage(ctx, userInfo)
+	prompts := make([]*models.RenderedPrompt, 0, len(names))
+	for _, name := range names {
+		rendered, err := r.promptUs.RenderPrompt(ctx, name, userInfo, language)
+		if err != nil {
+			return nil, err
+		}
+		prompts = append(prompts, rendered)
+	}
+	return prompts, nil
+}
+
+/* streamOptions แปลง StreamFunc เป็น llms.CallOption ถ้าไม่มี stream จะเรียกแบบปกติ */
+func streamOptions(stream models.StreamFunc) []llms.CallOption {
+	if stream == nil {
+		return nil
+	}
+	return []llms.CallOption{
+		llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+			return stream(models.StreamEventToken, map[string]interface{}{"content": string(chunk)})
+		}),
+	}
+}
+
+/* mealPlanInstruction และ mealPlanRepairInstruction อยู่ในโค้ดเพราะผูกกับ schema ที่ DecodeMealPlan ตรวจ ไม่ได้อยู่ใน prompt registry */
+func mealPlanInstruction(userInfo *models.UserInfo, option *models.MealPlanOption) string {
+	var preference strings.Builder
+	if option.Cuisine != "" {
+		fmt.Fprintf(&preference, "เน้นอาหารสไตล์ %s\n", option.Cuisine)
+	}
+	if option.Budget > 0 {
+		fmt.Fprintf(&preference, "งบค่าอาหารไม่เกิน %.0f บาทต่อวัน\n", option.Budget)
+	}
+	preference.WriteString(foodPreferenceInstruction(userInfo))
+	return fmt.Sprintf(`วางแผนอาหาร %d วัน แต่ละวันพลังงานรวมใกล้เคียง %.0f kcal
+%sตอบกลับเป็น JSON object เดียวเท่านั้น ห้ามมีข้อความอื่นหรือ markdown
+ค่า calories เป็น kcal ส่วน protein, carbohydrate, fat เป็นกรัม ของแต่ละรายการตาม portion
+JSON ต้องตรงตาม schema นี้:
+%s`, option.Days, userInfo.CaloriesLimit, preference.String(), models.MealPlanJSONSchema)
+}
+
+/* foodPreferenceInstruction อาหารที่ชอบ ไม่ชอบ และแพ้ของผู้ใช้ ใช้ทั้งตอนสร้างแผนและตอนเปลี่ยนมื้อ */
+func foodPreferenceInstruction(userInfo *models.UserInfo) string {
+	var preference strings.Builder
+	if likes := userInfo.GetFoodPreferences(models.FoodPreferenceLike); len(likes) > 0 {
+		fmt.Fprintf(&preference, "อาหารที่ชอบ: %s\n", strings.Join(likes, ", "))
+	}
+	if dislikes := userInfo.GetFoodPreferences(models.FoodPreferenceDislike); len(dislikes) > 0 {
+		fmt.Fprintf(&preference, "หลีกเลี่ยงอาหารที่ไม่ชอบ: %s\n", strings.Join(dislikes, ", "))
+	}
+	if allergies := userInfo.GetFoodPreferences(models.FoodPreferenceAllergy); len(allergies) > 0 {
+		fmt.Fprintf(&preference, "ห้ามมีส่วนผสมที่แพ้โดยเด็ดขาด: %s\n", strings.Join(allergies, ", "))
 	}
+	return preference.String()
+}
+
+func mealPlanRepairInstruction(err error) string {
+	return fmt.Sprintf(`คำตอบก่อนหน้าไม่ผ่านการตรวจสอบ: %s
+แก้ไขแล้วตอบใหม่เป็น JSON object เดียวตาม schema เท่านั้น`, err.Error())
+}
+
+/* guardrailInstruction ข้อจำกัดตามโรคประจำตัวที่แผนจะถูกตรวจ พร้อมบังคับให้ระบุสารอาหารที่มีกฎในทุกรายการ */
+func guardrailInstruction(rules []*models.DiseaseRule, weight float64) string {
+	var instruction strings.Builder
+	instruction.WriteString("ข้อจำกัดตามโรคประจำตัวของผู้ใช้ แผนจะถูกตรวจและต้องไม่เกินทุกข้อ:")
+	keys := make([]string, 0)
+	for _, rule := range rules {
+		limit, ok := rule.Limit(weight)
+		if !ok {
+			continue
+		}
+		scope := "ต่อวัน"
+		if rule.Scope == models.RuleScopeMeal {
+			scope = "ต่อมื้อ"
+		}
+		fmt.Fprintf(&instruction, "\n- %s (%s รวมไม่เกิน %.1f %s %s)", rule.Description, rule.Nutrient.Key(), limit, rule.Nutrient.Unit(), scope)
+		if key := rule.Nutrient.Key(); !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	fmt.Fprintf(&instruction, "\nทุกรายการต้องมี field %s ตามปริมาณใน portion (sodium, potassium, purine เป็นมิลลิกรัม sugar เป็นกรัม)", strings.Join(keys, ", "))
+	return instruction.String()
+}
 
-	return resp.Choices[0].Content, nil
+/* mealPlanRevisionInstruction ให้โมเดลปรับเฉพาะมื้อที่เกินข้อจำกัด */
+func mealPlanRevisionInstruction(violations []*models.GuardrailViolation) string {
+	lines := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		lines = append(lines, "- "+violation.String())
+	}
+	return fmt.Sprintf(`แผนก่อนหน้าเกินข้อจำกัดตามโรคประจำตัวของผู้ใช้:
+%s
+ปรับเฉพาะมื้อที่ระบุ (เปลี่ยนเมนู ลดปริมาณ หรือเติมค่าสารอาหารที่ขาด) มื้ออื่นให้คงเดิม แล้วตอบใหม่ทั้งแผนเป็น JSON object เดียวตาม schema เท่านั้น`, strings.Join(lines, "\n"))
 }
 
-func (r *agentAIRepository) ConversationWithChat(ctx context.Context, prompt string) (string, error) {
+/* SwapMealPlanMeal ให้โมเดลเสนอมื้อหรือรายการใหม่แทนของเดิม โดยพลังงานและสารอาหารหลักของทั้งวันต้องยังอยู่ในเป้า ตอบไม่ผ่านการตรวจจะส่งข้อผิดพลาดกลับไปให้แก้เหมือนแผนอาหาร */
+func (r *agentAIRepository) SwapMealPlanMeal(ctx context.Context, user *models.User, plan *models.MealPlan, target *models.MealSwapTarget, reason string) (*models.MealPlanSwap, error) {
+	prompts, err := r.renderPrompts(ctx, user.UserInfo, models.PromptMealPlanSystem, models.PromptUserInfoTemplate)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := r.diseaseRules(ctx, user.UserInfo)
+	if err != nil {
+		return nil, err
+	}
+	caloriesTarget := plan.CaloriesTarget
+	if caloriesTarget <= 0 {
+		caloriesTarget = user.UserInfo.CaloriesLimit
+	}
+
 	messages := []llms.MessageContent{
 		{
 			Role:  llms.ChatMessageTypeSystem,
-			Parts: []llms.ContentPart{llms.TextContent{Text: "คุณเป็นผู้เชี่ยวชาญที่ให้คำตอบเป็นภาษาไทยเท่านั้น"}},
+			Parts: []llms.ContentPart{llms.TextContent{Text: prompts[0].Text}},
 		},
 		{
 			Role:  llms.ChatMessageTypeSystem,
-			Parts: []llms.ContentPart{llms.TextContent{Text: "ตอบเป็นแต่ละวันทำอะไร เวลาไหน ด้วยนะ"}},
+			Parts: []llms.ContentPart{llms.TextContent{Text: prompts[1].Text}},
 		},
+	}
+	if len(rules) > 0 {
+		messages = append(messages, llms.MessageContent{
+			Role:  llms.ChatMessageTypeSystem,
+			Parts: []llms.ContentPart{llms.TextContent{Text: guardrailInstruction(rules, user.UserInfo.Weight)}},
+		})
+	}
+	messages = append(messages, llms.MessageContent{
+		Role:  llms.ChatMessageTypeHuman,
+		Parts: []llms.ContentPart{llms.TextContent{Text: mealSwapInstruction(user.UserInfo, target, caloriesTarget, reason)}},
+	})
+
+	var lastErr error
+	for attempt := 1; attempt <= mealPlanMaxAttempts; attempt++ {
+		resp, err := r.llm.GenerateContent(ctx, messages)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Choices) == 0 {
+			return nil, fmt.Errorf("no response from AI")
+		}
+
+		content := resp.Choices[0].Content
+		after, err := models.DecodeMealSwap(content, target)
+		if err == nil {
+			err = target.Check(after, caloriesTarget, user.UserInfo, rules)
+		}
+		if err == nil {
+			swap := models.NewMealPlanSwap(plan.Id, target, after, reason)
+			swap.Model = r.llm.Name()
+			if model, ok := resp.Choices[0].GenerationInfo["model"].(string); ok {
+				swap.Model = model
+			}
+			swap.PromptVersion = models.JoinPromptVersions(prompts...)
+			return swap, nil
+		}
+		lastErr = err
+		log.Printf("meal swap attempt %d is invalid: %v", attempt, err)
+		messages = append(messages, mealPlanRetryMessages(content, mealPlanRepairInstruction(err))...)
+	}
+
+	return nil, apperror.Upstream(constants.ERROR_MEAL_SWAP_IS_INVALID).Wrap(lastErr)
+}
+
+/* mealSwapInstruction บอกส่วนที่ต้องเปลี่ยน งบพลังงานและสารอาหารหลักที่เหลือของวัน และมื้ออื่นของวันเพื่อไม่ให้ซ้ำ */
+func mealSwapInstruction(userInfo *models.UserInfo, target *models.MealSwapTarget, caloriesTarget float64, reason string) string {
+	var instruction strings.Builder
+	if target.Item != nil {
+		fmt.Fprintf(&instruction, "เปลี่ยนรายการ %q (%s) ในมื้อ %s %q ของวันที่ %d เป็นอาหารอื่นหนึ่งรายการ รายการอื่นในมื้อคงเดิม\n", target.Item.Name, target.Item.Portion, target.Meal.MealType, target.Meal.Name, target.Day.Day)
+	} else {
+		fmt.Fprintf(&instruction, "เปลี่ยนมื้อ %s %q ของวันที่ %d เป็นเมนูอื่น\n", target.Meal.MealType, target.Meal.Name, target.Day.Day)
+	}
+	if reason != "" {
+		fmt.Fprintf(&instruction, "เหตุผลที่ผู้ใช้ขอเปลี่ยน: %s\n", reason)
+	}
+	budget := target.Budget(caloriesTarget)
+	fmt.Fprintf(&instruction, "ของใหม่ควรมีพลังงานประมาณ %.0f kcal โปรตีน %.0f กรัม คาร์โบไฮเดรต %.0f กรัม ไขมัน %.0f กรัม เพื่อให้ทั้งวันยังอยู่ในเป้า\n", budget.Calories, budget.Protein, budget.Carbohydrate, budget.Fat)
+	others := make([]string, 0, len(target.Day.Meals))
+	for _, meal := range target.Day.Meals {
+		if meal != target.Meal {
+			others = append(others, meal.Name)
+		}
+	}
+	if len(others) > 0 {
+		fmt.Fprintf(&instruction, "มื้ออื่นของวันนี้ (ห้ามซ้ำ): %s\n", strings.Join(others, ", "))
+	}
+	instruction.WriteString(foodPreferenceInstruction(userInfo))
+	items := "items คือรายการอาหารของมื้อใหม่"
+	if target.Item != nil {
+		items = "name ให้ใช้ชื่อมื้อเดิม items มีรายการใหม่เพียงรายการเดียว"
+	}
+	fmt.Fprintf(&instruction, `ตอบกลับเป็น JSON object เดียวเท่านั้น ห้ามมีข้อความอื่นหรือ markdown %s
+ค่า calories เป็น kcal ส่วน protein, carbohydrate, fat เป็นกรัม ของแต่ละรายการตาม portion
+JSON ต้องตรงตาม schema นี้:
+%s`, items, models.MealSwapJSONSchema)
+	return instruction.String()
+}
+
+/* AnalyzeMealPhoto ส่งรูปเป็น image part ให้โมเดลที่อ่านรูปได้ (AGENT_VISION_MODEL) แล้วตรวจ JSON แบบเดียวกับแผนอาหาร */
+func (r *agentAIRepository) AnalyzeMealPhoto(ctx context.Context, userInfo *models.UserInfo, photo *models.MealPhoto) (*models.MealPhotoAnalysis, error) {
+	prompts, err := r.renderPrompts(ctx, userInfo, models.PromptMealPhotoSystem)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []llms.MessageContent{
 		{
 			Role:  llms.ChatMessageTypeSystem,
-			Parts: []llms.ContentPart{llms.TextContent{Text: "ตอบเป็น json format เท่านั้น"}},
+			Parts: []llms.ContentPart{llms.TextContent{Text: prompts[0].Text}},
 		},
 		{
-			Role: llms.ChatMessageTypeSystem,
-			Parts: []llms.ContentPart{llms.TextContent{Text: `
-                JSON FORMAT: 
-                {
-                    "plan": {
-                        "desination": "ระยอง",
-                        "start_date": "2023-01-01",
-                        "end_date": "2023-01-02",
-                        "total_day": 2,
-                        "itinerary": [
-                            {
-                                "day": 1,
-                                "activity": ["เช้า: ดำน้ำ", "เที่ยง: กินอาหารทะเล", "เย็น: ไปดู pingpong show"]
-                            },
-                            {
-                                "day": 2,
-                                "activity": ["เช้า: เที่ยวเกาะล้าน", "เที่ยง: กินอาหารบนเกาะ", "เย็น: รับประทานอาหารเย็นและชมการแสดง"]
-                            }
-                        ]
-                    }
-                }
-            `}},
+			Role: llms.ChatMessageTypeHuman,
+			Parts: []llms.ContentPart{
+				llms.TextContent{Text: mealPhotoInstruction()},
+				llms.BinaryPart(photo.MIMEType, photo.Data),
+			},
 		},
+	}
+	var opts []llms.CallOption
+	if model := r.cfg.AgentVisionModel(); model != "" {
+		opts = append(opts, llms.WithModel(model))
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= mealPhotoMaxAttempts; attempt++ {
+		resp, err := r.llm.GenerateContent(ctx, messages, opts...)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Choices) == 0 {
+			return nil, fmt.Errorf("no response from AI")
+		}
+
+		content := resp.Choices[0].Content
+		analysis, err := models.DecodeMealPhotoAnalysis(content)
+		if err == nil {
+			analysis.Model = r.llm.Name()
+			if model, ok := resp.Choices[0].GenerationInfo["model"].(string); ok {
+				analysis.Model = model
+			}
+			analysis.PromptVersion = models.JoinPromptVersions(prompts...)
+			return analysis, nil
+		}
+		lastErr = err
+		log.Printf("meal photo attempt %d is invalid: %v", attempt, err)
+
+		messages = append(messages,
+			llms.MessageContent{
+				Role:  llms.ChatMessageTypeAI,
+				Parts: []llms.ContentPart{llms.TextContent{Text: content}},
+			},
+			llms.MessageContent{
+				Role:  llms.ChatMessageTypeHuman,
+				Parts: []llms.ContentPart{llms.TextContent{Text: mealPlanRepairInstruction(err)}},
+			},
+		)
+	}
+
+	return nil, apperror.Upstream(constants.ERROR_MEAL_PHOTO_IS_INVALID).Wrap(lastErr)
+}
+
+func mealPhotoInstruction() string {
+	return fmt.Sprintf(`วิเคราะห์รูปอาหารนี้ ประเมินปริมาณ พลังงาน และสารอาหารหลักของแต่ละจาน
+ตอบกลับเป็น JSON object เดียวเท่านั้น ห้ามมีข้อความอื่นหรือ markdown
+ค่า calories เป็น kcal ส่วน protein, carbohydrate, fat เป็นกรัม ตาม portion ที่ประเมิน confidence คือความมั่นใจ 0 ถึง 1
+JSON ต้องตรงตาม schema นี้:
+%s`, models.MealPhotoJSONSchema)
+}
+
+/* ConversationWithChat ตอบข้อความล่าสุดใน history โดยมีข้อมูลผู้ใช้และสรุปบทสนทนาก่อนหน้าเป็น system prompt คืนแหล่งอ้างอิงจาก knowledge base ที่แนบไปด้วย */
+func (r *agentAIRepository) ConversationWithChat(ctx context.Context, userInfo *models.UserInfo, conversation *models.Conversation, history []*models.ConversationMessage) (string, []*models.KnowledgeCitation, error) {
+	return r.conversationWithChat(ctx, userInfo, conversation, history, nil)
+}
+
+func (r *agentAIRepository) StreamConversationWithChat(ctx context.Context, userInfo *models.UserInfo, conversation *models.Conversation, history []*models.ConversationMessage, stream models.StreamFunc) (string, []*models.KnowledgeCitation, error) {
+	return r.conversationWithChat(ctx, userInfo, conversation, history, stream)
+}
+
+func (r *agentAIRepository) conversationWithChat(ctx context.Context, userInfo *models.UserInfo, conversation *models.Conversation, history []*models.ConversationMessage, stream models.StreamFunc) (string, []*models.KnowledgeCitation, error) {
+	system, err := r.promptUs.RenderPrompt(ctx, models.PromptChatSystem, userInfo, models.ResolveLanguage(ctx, userInfo))
+	if err != nil {
+		return "", nil, err
+	}
+	messages := []llms.MessageContent{
 		{
-			Role:  llms.ChatMessageTypeHuman,
-			Parts: []llms.ContentPart{llms.TextContent{Text: prompt}},
+			Role:  llms.ChatMessageTypeSystem,
+			Parts: []llms.ContentPart{llms.TextContent{Text: system.Text}},
 		},
 	}
+	if conversation.Summary != "" {
+		messages = append(messages, llms.MessageContent{
+			Role:  llms.ChatMessageTypeSystem,
+			Parts: []llms.ContentPart{llms.TextContent{Text: "สรุปบทสนทนาก่อนหน้า:\n" + conversation.Summary}},
+		})
+	}
+	chunks := r.searchKnowledge(ctx, chatKnowledgeQuery(userInfo, history))
+	if len(chunks) > 0 {
+		messages = append(messages, knowledgeMessage(chunks))
+	}
+	citations := models.NewKnowledgeCitations(chunks)
+	for _, message := range history {
+		role := llms.ChatMessageTypeHuman
+		if message.Role == models.ChatRoleAssistant {
+			role = llms.ChatMessageTypeAI
+		}
+		messages = append(messages, llms.MessageContent{
+			Role:  role,
+			Parts: []llms.ContentPart{llms.TextContent{Text: message.Content}},
+		})
+	}
 
-	response, err := r.digitalOceanLLM.GenerateContent(ctx, messages)
+	tools, maxIterations := r.chatTools()
+	for iteration := 0; ; iteration++ {
+		opts := streamOptions(stream)
+		/* ครบจำนวนรอบแล้วไม่ส่ง tools ไป โมเดลต้องตอบจากข้อมูลที่มี */
+		if iteration < maxIterations {
+			opts = append(opts, llms.WithTools(tools))
+		}
+		response, err := r.llm.GenerateContent(ctx, messages, opts...)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if len(response.Choices) == 0 {
+			return "", nil, fmt.Errorf("no response from AI")
+		}
+		choice := response.Choices[0]
+		if len(choice.ToolCalls) == 0 || iteration >= maxIterations {
+			return choice.Content, citations, nil
+		}
+
+		toolMessages, err := r.executeToolCalls(ctx, conversation.UserId, choice, stream)
+		if err != nil {
+			return "", nil, err
+		}
+		messages = append(messages, toolMessages...)
+	}
+}
+
+/* searchKnowledge ค้นแนวทางที่เกี่ยวข้องจาก knowledge base ค้นไม่ได้ก็ตอบต่อได้โดยไม่มีแหล่งอ้างอิง */
+func (r *agentAIRepository) searchKnowledge(ctx context.Context, query string) []*models.KnowledgeChunk {
+	if r.knowledgeUs == nil || strings.TrimSpace(query) == "" {
+		return nil
+	}
+	chunks, err := r.knowledgeUs.SearchKnowledge(ctx, query)
+	if err != nil {
+		log.Printf("search knowledge failed: %v", err)
+		return nil
+	}
+	return chunks
+}
+
+/* chatKnowledgeQuery คำค้นของแชทคือคำถามล่าสุดของผู้ใช้ต่อด้วยโรคประจำตัว */
+func chatKnowledgeQuery(userInfo *models.UserInfo, history []*models.ConversationMessage) string {
+	query := ""
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Role == models.ChatRoleUser {
+			query = history[i].Content
+			break
+		}
+	}
+	if query != "" && userInfo.HasMedicalCondition() {
+		query += " " + userInfo.MedicalCondition
+	}
+	return query
+}
+
+func knowledgeMessage(chunks []*models.KnowledgeChunk) llms.MessageContent {
+	return llms.MessageContent{
+		Role:  llms.ChatMessageTypeSystem,
+		Parts: []llms.ContentPart{llms.TextContent{Text: models.KnowledgeContext(chunks)}},
+	}
+}
+
+/* chatTools tool ที่แชทใช้ได้ ไม่มี tool usecase หรือ AGENT_MAX_TOOL_ITERATIONS เป็น 0 คือไม่ใช้ tool */
+func (r *agentAIRepository) chatTools() ([]llms.Tool, int) {
+	if r.toolUs == nil || r.cfg == nil || r.cfg.AgentMaxToolIterations() <= 0 {
+		return nil, 0
+	}
+	tools := make([]llms.Tool, 0)
+	for _, tool := range r.toolUs.Tools() {
+		tools = append(tools, llms.Tool{
+			Type:     "function",
+			Function: &llms.FunctionDefinition{Name: tool.Name, Description: tool.Description, Parameters: tool.Parameters},
+		})
+	}
+	return tools, r.cfg.AgentMaxToolIterations()
+}
+
+/* executeToolCalls เรียก tool ฝั่ง server แล้วคืนข้อความของ assistant ที่ขอเรียกกับผลของทุก tool เพื่อส่งให้โมเดลรอบถัดไป tool ที่ error จะส่ง error กลับไปให้โมเดลแทนการหยุดแชท */
+func (r *agentAIRepository) executeToolCalls(ctx context.Context, userId *uuid.UUID, choice *llms.ContentChoice, stream models.StreamFunc) ([]llms.MessageContent, error) {
+	request := llms.MessageContent{Role: llms.ChatMessageTypeAI}
+	if choice.Content != "" {
+		request.Parts = append(request.Parts, llms.TextContent{Text: choice.Content})
+	}
+	result := llms.MessageContent{Role: llms.ChatMessageTypeTool}
+	for _, toolCall := range choice.ToolCalls {
+		if toolCall.FunctionCall == nil {
+			continue
+		}
+		request.Parts = append(request.Parts, toolCall)
+		call := &models.AgentToolCall{Id: toolCall.ID, Name: toolCall.FunctionCall.Name, Arguments: toolCall.FunctionCall.Arguments}
+		if stream != nil {
+			if err := stream(models.StreamEventTool, map[string]interface{}{"name": call.Name}); err != nil {
+				return nil, err
+			}
+		}
+
+		content, err := r.toolUs.ExecuteTool(ctx, userId, call)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			log.Printf("tool %s failed: %v", call.Name, err)
+			errContent, _ := json.Marshal(map[string]interface{}{"error": err.Error()})
+			content = string(errContent)
+		}
+		result.Parts = append(result.Parts, llms.ToolCallResponse{ToolCallID: call.Id, Name: call.Name, Content: content})
+	}
+	return []llms.MessageContent{request, result}, nil
+}
+
+/* SummarizeConversation รวมสรุปเดิมกับข้อความที่หลุดจาก window เป็นสรุปใหม่ เพื่อไม่ให้ history ยาวเกิน context สรุปเป็นภาษาเดียวกับที่ตอบผู้ใช้ */
+func (r *agentAIRepository) SummarizeConversation(ctx context.Context, userInfo *models.UserInfo, summary string, messages []*models.ConversationMessage) (string, error) {
+	var transcript strings.Builder
+	if summary != "" {
+		fmt.Fprintf(&transcript, "สรุปเดิม:\n%s\n\n", summary)
+	}
+	transcript.WriteString("บทสนทนาเพิ่มเติม:\n")
+	for _, message := range messages {
+		fmt.Fprintf(&transcript, "%s: %s\n", message.Role, message.Content)
+	}
+
+	instruction, err := r.promptUs.RenderPrompt(ctx, models.PromptChatSummary, nil, models.ResolveLanguage(ctx, userInfo))
+	if err != nil {
+		return "", err
+	}
+	response, err := r.llm.GenerateContent(ctx, []llms.MessageContent{
+		{
+			Role:  llms.ChatMessageTypeSystem,
+			Parts: []llms.ContentPart{llms.TextContent{Text: instruction.Text}},
+		},
+		{
+			Role:  llms.ChatMessageTypeHuman,
+			Parts: []llms.ContentPart{llms.TextContent{Text: transcript.String()}},
+		},
+	})
 	if err != nil {
 		return "", err
 	}
@@ -149,5 +706,5 @@ func (r *agentAIRepository) ConversationWithChat(ctx context.Context, prompt str
 		return "", fmt.Errorf("no response from AI")
 	}
 
-	return response.Choices[0].Content, nil
+	return strings.TrimSpace(response.Choices[0].Content), nil
 }
@@ -0,0 +1,723 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/agent-ai"
+	agent_usecase "healthmatefood-api/service/agent-ai/usecase"
+	diary_mocks "healthmatefood-api/service/diary/mocks"
+	food_mocks "healthmatefood-api/service/food/mocks"
+	guardrail_mocks "healthmatefood-api/service/guardrail/mocks"
+	knowledge_mocks "healthmatefood-api/service/knowledge/mocks"
+	"healthmatefood-api/service/prompt"
+	prompt_mocks "healthmatefood-api/service/prompt/mocks"
+	prompt_usecase "healthmatefood-api/service/prompt/usecase"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/tmc/langchaingo/llms"
+)
+
+const validMealPlan = `{"days":[
+  {"day":1,"meals":[{"meal_type":"BREAKFAST","time":"07:30","name":"ข้าวต้มไก่","items":[{"name":"ข้าวต้มไก่","portion":"1 ชาม (300 g)","calories":320,"protein":18,"carbohydrate":45,"fat":7}]}]},
+  {"day":2,"meals":[{"meal_type":"LUNCH","time":"12:00","name":"ผัดกะเพราไก่","items":[{"name":"ผัดกะเพราไก่","portion":"1 จาน","calories":480,"protein":28,"carbohydrate":52,"fat":16},{"name":"ไข่ต้ม","portion":"1 ฟอง","calories":78,"protein":6.3,"carbohydrate":0.6,"fat":5.3}]}]},
+  {"day":3,"meals":[{"meal_type":"DINNER","time":"18:30","name":"ปลานิลนึ่ง","items":[{"name":"ปลานิลนึ่งมะนาว","portion":"1 ตัว (200 g)","calories":256,"protein":52,"carbohydrate":4,"fat":5}]}]}
+]}`
+
+/* newTestPromptUsecase ไม่มีเวอร์ชันใน database จึงใช้ prompt ตั้งต้นที่ฝังใน binary */
+func newTestPromptUsecase() prompt.IPromptUsecase {
+	promptRepo := new(prompt_mocks.IPromptRepository)
+	promptRepo.On("FetchActivePromptByName", mock.Anything, mock.Anything).Return(nil, apperror.NotFound(constants.ERROR_PROMPT_NOT_FOUND))
+	return prompt_usecase.NewPromptUsecase(promptRepo)
+}
+
+/* newLLMServer ตอบตามลำดับ contents ที่กำหนด และเก็บ request ไว้ตรวจ */
+func newLLMServer(t *testing.T, contents []string, requests *[]map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		*requests = append(*requests, body)
+		content := contents[len(*requests)-1]
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []interface{}{
+				map[string]interface{}{"message": map[string]interface{}{"role": "assistant", "content": content}},
+			},
+		})
+	}))
+}
+
+func TestGenerateMealsPlan(t *testing.T) {
+	user := &models.User{UserInfo: &models.UserInfo{Gender: "MALE", Age: 30, Weight: 70, Height: 175, CaloriesLimit: 2200}}
+	option := &models.MealPlanOption{Days: models.DEFAULT_MEAL_PLAN_DAYS}
+	newRepo := func(url string) *agentAIRepository {
+		return &agentAIRepository{
+			llm:      NewDigitalOceanLLM(url, "test"),
+			promptUs: newTestPromptUsecase(),
+		}
+	}
+	t.Run("success_active_prompt_version", func(t *testing.T) {
+		requests := []map[string]interface{}{}
+		server := newLLMServer(t, []string{validMealPlan}, &requests)
+		defer server.Close()
+		promptRepo := new(prompt_mocks.IPromptRepository)
+		promptRepo.On("FetchActivePromptByName", mock.Anything, models.PromptUserInfo).
+			Return(&models.Prompt{Name: models.PromptUserInfo, Version: 2, Content: "ผู้ใช้อายุ {{.Age}} ปี พลังงาน {{.CaloriesLimit}} kcal", IsActive: true}, nil)
+		promptRepo.On("FetchActivePromptByName", mock.Anything, mock.Anything).Return(nil, apperror.NotFound(constants.ERROR_PROMPT_NOT_FOUND))
+		repo := &agentAIRepository{llm: NewDigitalOceanLLM(server.URL, "test"), promptUs: prompt_usecase.NewPromptUsecase(promptRepo)}
+
+		plan, err := repo.GenerateMealsPlan(t.Context(), user, option)
+		assert.NoError(t, err)
+		assert.Equal(t, "meal_plan_system.default+user_info_template.default+user_info.v2", plan.PromptVersion)
+		messages := requests[0]["messages"].([]interface{})
+		assert.Equal(t, "ผู้ใช้อายุ 30 ปี พลังงาน 2200 kcal", messages[len(messages)-1].(map[string]interface{})["content"])
+	})
+	t.Run("success_with_code_fence", func(t *testing.T) {
+		requests := []map[string]interface{}{}
+		server := newLLMServer(t, []string{"```json\n" + validMealPlan + "\n```"}, &requests)
+		defer server.Close()
+
+		plan, err := newRepo(server.URL).GenerateMealsPlan(t.Context(), user, option)
+		assert.NoError(t, err)
+		assert.Len(t, requests, 1)
+		assert.Len(t, plan.Days, 3)
+		assert.Equal(t, models.MealTypeLunch, plan.Days[1].Meals[0].MealType)
+		assert.Equal(t, float64(558), plan.Days[1].Total.Calories)
+		assert.Equal(t, float64(1134), plan.Total.Calories)
+		assert.Equal(t, digitalOceanAgentName, plan.Model)
+		assert.Equal(t, "meal_plan_system.default+user_info_template.default+user_info.default", plan.PromptVersion)
+		assert.Equal(t, float64(2200), plan.CaloriesTarget)
+	})
+	t.Run("success_with_preferences", func(t *testing.T) {
+		requests := []map[string]interface{}{}
+		server := newLLMServer(t, []string{validMealPlan}, &requests)
+		defer server.Close()
+
+		preferUser := &models.User{UserInfo: &models.UserInfo{CaloriesLimit: 1800, FoodPreferences: []*models.FoodPreference{
+			{Name: "ปลา", PreferenceType: models.FoodPreferenceLike},
+			{Name: "กุ้ง", PreferenceType: models.FoodPreferenceAllergy},
+		}}}
+		_, err := newRepo(server.URL).GenerateMealsPlan(t.Context(), preferUser, &models.MealPlanOption{Days: 3, Cuisine: "อาหารอีสาน", Budget: 250})
+		assert.NoError(t, err)
+
+		messages := requests[0]["messages"].([]interface{})
+		instruction := messages[2].(map[string]interface{})["content"].(string)
+		assert.Contains(t, instruction, "1800 kcal")
+		assert.Contains(t, instruction, "อาหารอีสาน")
+		assert.Contains(t, instruction, "250 บาทต่อวัน")
+		assert.Contains(t, instruction, "อาหารที่ชอบ: ปลา")
+		assert.Contains(t, instruction, "แพ้โดยเด็ดขาด: กุ้ง")
+	})
+	t.Run("success_after_repair", func(t *testing.T) {
+		requests := []map[string]interface{}{}
+		invalid := strings.Replace(validMealPlan, `"time":"07:30"`, `"time":"เช้า"`, 1)
+		server := newLLMServer(t, []string{"นี่คือแผนอาหารของคุณ", invalid, validMealPlan}, &requests)
+		defer server.Close()
+
+		plan, err := newRepo(server.URL).GenerateMealsPlan(t.Context(), user, option)
+		assert.NoError(t, err)
+		assert.Len(t, requests, 3)
+		assert.Equal(t, "07:30", plan.Days[0].Meals[0].Time)
+
+		/* รอบที่สามต้องมีคำตอบเดิมและข้อผิดพลาดของรอบที่สองแนบไปด้วย */
+		messages := requests[2]["messages"].([]interface{})
+		assistant := messages[len(messages)-2].(map[string]interface{})
+		repair := messages[len(messages)-1].(map[string]interface{})
+		assert.Equal(t, "assistant", assistant["role"])
+		assert.Contains(t, repair["content"], "days[0].meals[0].time: must be HH:MM")
+	})
+	t.Run("error_meal_plan_is_invalid", func(t *testing.T) {
+		requests := []map[string]interface{}{}
+		unknownField := strings.Replace(validMealPlan, `"days":[`, `"calories_target":2200,"days":[`, 1)
+		server := newLLMServer(t, []string{unknownField, unknownField, unknownField}, &requests)
+		defer server.Close()
+
+		plan, err := newRepo(server.URL).GenerateMealsPlan(t.Context(), user, option)
+		assert.Nil(t, plan)
+		assert.ErrorContains(t, err, constants.ERROR_MEAL_PLAN_IS_INVALID)
+		assert.ErrorContains(t, err, "unknown field")
+		assert.Len(t, requests, mealPlanMaxAttempts)
+	})
+}
+
+func TestAnalyzeMealPhoto(t *testing.T) {
+	const validPhoto = `{"dishes":[{"name":"ข้าวมันไก่","portion":"1 จาน","calories":596,"protein":25,"carbohydrate":70,"fat":22,"confidence":0.8}],"note":"มีน้ำจิ้ม"}`
+	photo := &models.MealPhoto{MIMEType: "image/jpeg", Data: []byte("jpeg")}
+	userInfo := &models.UserInfo{FoodPreferences: []*models.FoodPreference{{Name: "กุ้ง", PreferenceType: models.FoodPreferenceAllergy}}}
+	newRepo := func(url string) *agentAIRepository {
+		return &agentAIRepository{
+			cfg:      agentConfig{model: "gpt-4o"},
+			llm:      NewOpenAICompatibleLLM(url, "test", "gpt-4o-mini"),
+			promptUs: newTestPromptUsecase(),
+		}
+	}
+	t.Run("success_after_repair", func(t *testing.T) {
+		requests := []map[string]interface{}{}
+		server := newLLMServer(t, []string{`{"dishes":[{"name":"","portion":"1 จาน","calories":-1}]}`, validPhoto}, &requests)
+		defer server.Close()
+
+		analysis, err := newRepo(server.URL).AnalyzeMealPhoto(t.Context(), userInfo, photo)
+		assert.NoError(t, err)
+		assert.Len(t, requests, 2)
+		assert.Equal(t, "gpt-4o", requests[0]["model"])
+		assert.Equal(t, float64(596), analysis.Total.Calories)
+		assert.Equal(t, "มีน้ำจิ้ม", analysis.Note)
+
+		messages := requests[0]["messages"].([]interface{})
+		assert.Contains(t, messages[0].(map[string]interface{})["content"], "กุ้ง")
+		parts := messages[1].(map[string]interface{})["content"].([]interface{})
+		image := parts[1].(map[string]interface{})["image_url"].(map[string]interface{})
+		assert.Equal(t, "data:image/jpeg;base64,anBlZw==", image["url"])
+	})
+	t.Run("error_meal_photo_is_invalid", func(t *testing.T) {
+		requests := []map[string]interface{}{}
+		server := newLLMServer(t, []string{"ไม่ใช่ JSON", "ไม่ใช่ JSON"}, &requests)
+		defer server.Close()
+
+		analysis, err := newRepo(server.URL).AnalyzeMealPhoto(t.Context(), nil, photo)
+		assert.Nil(t, analysis)
+		assert.ErrorContains(t, err, constants.ERROR_MEAL_PHOTO_IS_INVALID)
+		assert.Len(t, requests, mealPhotoMaxAttempts)
+	})
+}
+
+func TestConversationWithChat(t *testing.T) {
+	userInfo := &models.UserInfo{Gender: "FEMALE", Age: 28, Weight: 55, Height: 160, CaloriesLimit: 1800, MedicalCondition: "เบาหวาน"}
+	conversation := &models.Conversation{Summary: "ผู้ใช้ต้องการลดน้ำหนัก 3 kg"}
+	history := []*models.ConversationMessage{
+		{Role: models.ChatRoleUser, Content: "มื้อเช้ากินอะไรดี"},
+		{Role: models.ChatRoleAssistant, Content: "ข้าวต้มไก่"},
+		{Role: models.ChatRoleUser, Content: "แล้วมื้อเที่ยงล่ะ"},
+	}
+	requests := []map[string]interface{}{}
+	server := newLLMServer(t, []string{"สลัดอกไก่"}, &requests)
+	defer server.Close()
+	repo := &agentAIRepository{llm: NewDigitalOceanLLM(server.URL, "test"), promptUs: newTestPromptUsecase()}
+
+	reply, _, err := repo.ConversationWithChat(t.Context(), userInfo, conversation, history)
+	assert.NoError(t, err)
+	assert.Equal(t, "สลัดอกไก่", reply)
+
+	messages := requests[0]["messages"].([]interface{})
+	assert.Len(t, messages, 5)
+	system := messages[0].(map[string]interface{})["content"].(string)
+	assert.Contains(t, system, "1800 kcal")
+	assert.Contains(t, system, "เบาหวาน")
+	assert.Contains(t, messages[1].(map[string]interface{})["content"], conversation.Summary)
+	assert.Equal(t, "assistant", messages[3].(map[string]interface{})["role"])
+	assert.Equal(t, "แล้วมื้อเที่ยงล่ะ", messages[4].(map[string]interface{})["content"])
+}
+
+func TestConversationWithChatLanguage(t *testing.T) {
+	conversation := &models.Conversation{}
+	history := []*models.ConversationMessage{{Role: models.ChatRoleUser, Content: "What should I eat for lunch?"}}
+	systemPrompt := func(t *testing.T, ctx context.Context, userInfo *models.UserInfo) string {
+		llm := NewFakeLLM("Grilled chicken salad")
+		repo := &agentAIRepository{llm: llm, promptUs: newTestPromptUsecase()}
+		_, _, err := repo.ConversationWithChat(ctx, userInfo, conversation, history)
+		assert.NoError(t, err)
+		return messageText(llm.Calls()[0][0])
+	}
+	t.Run("default_thai", func(t *testing.T) {
+		system := systemPrompt(t, t.Context(), &models.UserInfo{CaloriesLimit: 1800})
+		assert.Contains(t, system, "ให้คำตอบเป็นภาษาไทยเท่านั้น")
+	})
+	t.Run("user_preference", func(t *testing.T) {
+		system := systemPrompt(t, t.Context(), &models.UserInfo{CaloriesLimit: 1800, Language: models.LanguageEnglish})
+		assert.Contains(t, system, "ให้คำตอบเป็นภาษาอังกฤษเท่านั้น")
+	})
+	t.Run("request_language_over_user_preference", func(t *testing.T) {
+		ctx := models.ContextWithLanguage(t.Context(), models.LanguageThai)
+		system := systemPrompt(t, ctx, &models.UserInfo{CaloriesLimit: 1800, Language: models.LanguageEnglish})
+		assert.Contains(t, system, "ให้คำตอบเป็นภาษาไทยเท่านั้น")
+	})
+}
+
+/* newStreamLLMServer ตอบแบบ stream: true โดยแบ่งแต่ละ content เป็น chunk ละไม่เกิน 40 ตัวอักษร */
+func newStreamLLMServer(t *testing.T, contents []string, requests *[]map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		*requests = append(*requests, body)
+		content := []rune(contents[len(*requests)-1])
+		w.Header().Set("Content-Type", "text/event-stream")
+		for len(content) > 0 {
+			size := min(40, len(content))
+			chunk, _ := json.Marshal(map[string]interface{}{
+				"model":   "test-model",
+				"choices": []interface{}{map[string]interface{}{"delta": map[string]interface{}{"content": string(content[:size])}}},
+			})
+			fmt.Fprintf(w, "data: %s\n\n", chunk)
+			content = content[size:]
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+}
+
+func TestStreamMealsPlan(t *testing.T) {
+	user := &models.User{UserInfo: &models.UserInfo{Gender: "MALE", Age: 30, Weight: 70, Height: 175, CaloriesLimit: 2200}}
+	option := &models.MealPlanOption{Days: models.DEFAULT_MEAL_PLAN_DAYS}
+	requests := []map[string]interface{}{}
+	server := newStreamLLMServer(t, []string{`{"days":[]}`, validMealPlan}, &requests)
+	defer server.Close()
+	repo := &agentAIRepository{
+		llm:      NewDigitalOceanLLM(server.URL, "test"),
+		promptUs: newTestPromptUsecase(),
+	}
+
+	var tokens strings.Builder
+	events := []models.StreamEvent{}
+	plan, err := repo.StreamMealsPlan(t.Context(), user, option, func(event models.StreamEvent, data map[string]interface{}) error {
+		if event == models.StreamEventToken {
+			tokens.WriteString(data["content"].(string))
+		}
+		if len(events) == 0 || events[len(events)-1] != event {
+			events = append(events, event)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, requests, 2)
+	assert.Equal(t, true, requests[0]["stream"])
+	assert.Equal(t, []models.StreamEvent{models.StreamEventToken, models.StreamEventReset, models.StreamEventToken}, events)
+	assert.Equal(t, `{"days":[]}`+validMealPlan, tokens.String())
+	assert.Len(t, plan.Days, 3)
+	assert.Equal(t, "test-model", plan.Model)
+}
+
+func TestStreamConversationWithChat(t *testing.T) {
+	t.Run("error_stop_stream", func(t *testing.T) {
+		requests := []map[string]interface{}{}
+		server := newStreamLLMServer(t, []string{strings.Repeat("ข้าวกล้อง", 20)}, &requests)
+		defer server.Close()
+		repo := &agentAIRepository{llm: NewDigitalOceanLLM(server.URL, "test"), promptUs: newTestPromptUsecase()}
+
+		calls := 0
+		_, _, err := repo.StreamConversationWithChat(t.Context(), nil, &models.Conversation{}, nil, func(event models.StreamEvent, data map[string]interface{}) error {
+			calls++
+			return errors.New("client closed")
+		})
+		assert.EqualError(t, err, "client closed")
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestConversationWithChatTools(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	date := helper.NewDateFromString("2025-03-02")
+	conversation := &models.Conversation{UserId: &userId}
+	history := []*models.ConversationMessage{{Role: models.ChatRoleUser, Content: "วันนี้กินได้อีกกี่แคล ข้าวมันไก่กี่แคล"}}
+	newToolUs := func() agent.IAgentToolUsecase {
+		foodUs := new(food_mocks.IFoodUsecase)
+		foodUs.On("FetchAllFoods", mock.Anything, mock.AnythingOfType("*sync.Map")).Return([]*models.Food{
+			{Name: "ข้าวมันไก่", ServingSize: 1, ServingUnit: "จาน", Calories: 596},
+		}, nil)
+		diaryUs := new(diary_mocks.IDiaryUsecase)
+		diaryUs.On("FetchDailySummary", mock.Anything, &userId, &date).Return(
+			models.NewDailySummary(&userId, &date, 2000, []*models.FoodDiary{{MealType: models.MealTypeBreakfast, Calories: 500}}, nil), nil)
+		return agent_usecase.NewAgentToolUsecase(foodUs, diaryUs, nil)
+	}
+	t.Run("success", func(t *testing.T) {
+		llm := NewFakeLLM(
+			FakeToolCall(models.AgentToolRemainingCalories, `{"date":"2025-03-02"}`)+"\n"+FakeToolCall(models.AgentToolSearchFoods, `{"query":"ข้าวมันไก่"}`),
+			"เหลืออีก 1500 kcal ข้าวมันไก่ 596 kcal",
+		)
+		repo := &agentAIRepository{cfg: agentConfig{}, llm: llm, promptUs: newTestPromptUsecase(), toolUs: newToolUs()}
+
+		reply, _, err := repo.ConversationWithChat(t.Context(), nil, conversation, history)
+		assert.NoError(t, err)
+		assert.Equal(t, "เหลืออีก 1500 kcal ข้าวมันไก่ 596 kcal", reply)
+
+		calls := llm.Calls()
+		assert.Len(t, calls, 2)
+		messages := calls[1]
+		request, result := messages[len(messages)-2], messages[len(messages)-1]
+		assert.Equal(t, llms.ChatMessageTypeAI, request.Role)
+		assert.Len(t, messageToolCalls(request), 2)
+		responses := messageToolResponses(result)
+		assert.Len(t, responses, 2)
+		assert.Equal(t, "call_0_0", responses[0].ToolCallID)
+		assert.Contains(t, responses[0].Content, `"remaining_calories":1500`)
+		assert.Contains(t, responses[1].Content, `"calories":596`)
+	})
+	t.Run("success_tool_error", func(t *testing.T) {
+		llm := NewFakeLLM(FakeToolCall(models.AgentToolDiaryTotals, `{"date":"2 มี.ค."}`), "ขอวันที่อีกครั้ง")
+		repo := &agentAIRepository{cfg: agentConfig{}, llm: llm, promptUs: newTestPromptUsecase(), toolUs: newToolUs()}
+
+		reply, _, err := repo.ConversationWithChat(t.Context(), nil, conversation, history)
+		assert.NoError(t, err)
+		assert.Equal(t, "ขอวันที่อีกครั้ง", reply)
+		messages := llm.Calls()[1]
+		assert.Contains(t, messageToolResponses(messages[len(messages)-1])[0].Content, "is not date format")
+	})
+	t.Run("success_max_iterations", func(t *testing.T) {
+		llm := NewFakeLLM(FakeToolCall(models.AgentToolSearchFoods, `{"query":"ข้าว"}`))
+		repo := &agentAIRepository{cfg: agentConfig{}, llm: llm, promptUs: newTestPromptUsecase(), toolUs: newToolUs()}
+
+		_, _, err := repo.ConversationWithChat(t.Context(), nil, conversation, history)
+		assert.NoError(t, err)
+		/* AGENT_MAX_TOOL_ITERATIONS ของเทสคือ 2 รอบสุดท้ายต้องไม่ส่ง tools ไป */
+		assert.Len(t, llm.Calls(), 3)
+	})
+}
+
+func TestMealPlanResponseCache(t *testing.T) {
+	user := &models.User{UserInfo: &models.UserInfo{Gender: "MALE", Age: 30, Weight: 70, Height: 175, CaloriesLimit: 2200}}
+	requests := []map[string]interface{}{}
+	server := newLLMServer(t, []string{validMealPlan, validMealPlan}, &requests)
+	defer server.Close()
+	repo := &agentAIRepository{
+		llm:      NewDigitalOceanLLM(server.URL, "test"),
+		promptUs: newTestPromptUsecase(),
+		cache:    NewMemoryResponseCache(10),
+		cacheTTL: time.Hour,
+	}
+
+	plan, err := repo.GenerateMealsPlan(t.Context(), user, &models.MealPlanOption{Days: models.DEFAULT_MEAL_PLAN_DAYS})
+	assert.NoError(t, err)
+	assert.False(t, plan.Cache.Hit)
+	assert.Len(t, requests, 1)
+
+	t.Run("success_hit", func(t *testing.T) {
+		cached, err := repo.GenerateMealsPlan(t.Context(), user, &models.MealPlanOption{Days: models.DEFAULT_MEAL_PLAN_DAYS})
+		assert.NoError(t, err)
+		assert.Len(t, requests, 1)
+		assert.True(t, cached.Cache.Hit)
+		assert.Equal(t, plan.Cache.Key, cached.Cache.Key)
+		assert.Equal(t, 1, cached.Cache.Hits)
+		assert.Equal(t, plan.PromptVersion, cached.PromptVersion)
+		assert.Equal(t, plan.Total.Calories, cached.Total.Calories)
+	})
+	t.Run("success_bypass", func(t *testing.T) {
+		fresh, err := repo.GenerateMealsPlan(t.Context(), user, &models.MealPlanOption{Days: models.DEFAULT_MEAL_PLAN_DAYS, Fresh: true})
+		assert.NoError(t, err)
+		assert.Len(t, requests, 2)
+		assert.False(t, fresh.Cache.Hit)
+		assert.True(t, fresh.Cache.Bypass)
+	})
+	t.Run("success_miss_other_option", func(t *testing.T) {
+		other := newLLMServer(t, []string{validMealPlan, validMealPlan, validMealPlan, validMealPlan}, &requests)
+		defer other.Close()
+		repo.llm = NewDigitalOceanLLM(other.URL, "test")
+		_, err := repo.GenerateMealsPlan(t.Context(), user, &models.MealPlanOption{Days: models.DEFAULT_MEAL_PLAN_DAYS, Cuisine: "อีสาน"})
+		assert.NoError(t, err)
+		assert.Len(t, requests, 3)
+	})
+}
+
+func TestResponseCacheKey(t *testing.T) {
+	message := func(text string) []llms.MessageContent {
+		return []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, text)}
+	}
+	key := responseCacheKey(message("อายุ 30 ปี\nเพศ MALE"), "gpt-4o-mini", "user_info.v1")
+	assert.Equal(t, key, responseCacheKey(message("  อายุ 30   ปี เพศ male "), "gpt-4o-mini", "user_info.v1"))
+	assert.NotEqual(t, key, responseCacheKey(message("อายุ 31 ปี เพศ male"), "gpt-4o-mini", "user_info.v1"))
+	assert.NotEqual(t, key, responseCacheKey(message("อายุ 30 ปี เพศ male"), "gpt-4o", "user_info.v1"))
+	assert.NotEqual(t, key, responseCacheKey(message("อายุ 30 ปี เพศ male"), "gpt-4o-mini", "user_info.v2"))
+}
+
+func TestMemoryResponseCache(t *testing.T) {
+	now := time.Now()
+	cache := NewMemoryResponseCache(2)
+	cache.now = func() time.Time { return now }
+	for _, key := range []string{"a", "b"} {
+		assert.NoError(t, cache.Set(t.Context(), models.NewCachedResponse(key, "test", "v1", "{}", time.Minute)))
+	}
+
+	/* อ่าน a ทำให้ b เป็นรายการที่ไม่ได้ใช้นานที่สุดและถูกลบเมื่อเพิ่ม c */
+	hit, err := cache.Get(t.Context(), "a")
+	assert.NoError(t, err)
+	assert.NotNil(t, hit)
+	assert.NoError(t, cache.Set(t.Context(), models.NewCachedResponse("c", "test", "v1", "{}", time.Minute)))
+	hit, _ = cache.Get(t.Context(), "b")
+	assert.Nil(t, hit)
+
+	now = now.Add(2 * time.Minute)
+	hit, _ = cache.Get(t.Context(), "a")
+	assert.Nil(t, hit)
+}
+
+func TestConversationWithChatKnowledge(t *testing.T) {
+	documentId := uuid.FromStringOrNil("5b0a7c6e-3c3f-4d7e-9a51-0d6f0b8f2a11")
+	userInfo := &models.UserInfo{CaloriesLimit: 1800, MedicalCondition: "โรคไต"}
+	history := []*models.ConversationMessage{{Role: models.ChatRoleUser, Content: "กินกล้วยได้ไหม"}}
+	t.Run("success", func(t *testing.T) {
+		knowledgeUs := new(knowledge_mocks.IKnowledgeUsecase)
+		knowledgeUs.On("SearchKnowledge", mock.Anything, "กินกล้วยได้ไหม โรคไต").Return([]*models.KnowledgeChunk{
+			{DocumentId: &documentId, ChunkIndex: 1, Title: "แนวทางโรคไต", Content: "ควรเลี่ยงอาหารโพแทสเซียมสูง เช่น กล้วย ส้ม", Score: 0.81234},
+		}, nil)
+		llm := NewFakeLLM("ควรเลี่ยงกล้วยเพราะโพแทสเซียมสูง [1]")
+		repo := &agentAIRepository{llm: llm, promptUs: newTestPromptUsecase(), knowledgeUs: knowledgeUs}
+
+		reply, citations, err := repo.ConversationWithChat(t.Context(), userInfo, &models.Conversation{}, history)
+		assert.NoError(t, err)
+		assert.Equal(t, "ควรเลี่ยงกล้วยเพราะโพแทสเซียมสูง [1]", reply)
+		assert.Len(t, citations, 1)
+		assert.Equal(t, 1, citations[0].Index)
+		assert.Equal(t, &documentId, citations[0].DocumentId)
+		assert.Equal(t, 0.812, citations[0].Score)
+
+		messages := llm.Calls()[0]
+		assert.Len(t, messages, 3)
+		assert.Equal(t, llms.ChatMessageTypeSystem, messages[1].Role)
+		assert.Contains(t, messageText(messages[1]), "[1] แนวทางโรคไต\nควรเลี่ยงอาหารโพแทสเซียมสูง")
+	})
+	t.Run("success_search_error", func(t *testing.T) {
+		knowledgeUs := new(knowledge_mocks.IKnowledgeUsecase)
+		knowledgeUs.On("SearchKnowledge", mock.Anything, mock.Anything).Return(nil, errors.New("embedding unavailable"))
+		llm := NewFakeLLM("กินได้เล็กน้อย")
+		repo := &agentAIRepository{llm: llm, promptUs: newTestPromptUsecase(), knowledgeUs: knowledgeUs}
+
+		reply, citations, err := repo.ConversationWithChat(t.Context(), userInfo, &models.Conversation{}, history)
+		assert.NoError(t, err)
+		assert.Equal(t, "กินได้เล็กน้อย", reply)
+		assert.Empty(t, citations)
+		assert.Len(t, llm.Calls()[0], 2)
+	})
+}
+
+func TestGenerateMealsPlanKnowledge(t *testing.T) {
+	documentId := uuid.FromStringOrNil("5b0a7c6e-3c3f-4d7e-9a51-0d6f0b8f2a11")
+	option := &models.MealPlanOption{Days: models.DEFAULT_MEAL_PLAN_DAYS}
+	t.Run("success", func(t *testing.T) {
+		user := &models.User{UserInfo: &models.UserInfo{CaloriesLimit: 2000, MedicalCondition: "โรคเกาต์"}}
+		knowledgeUs := new(knowledge_mocks.IKnowledgeUsecase)
+		knowledgeUs.On("SearchKnowledge", mock.Anything, "แนวทางอาหารสำหรับผู้ที่เป็น โรคเกาต์").Return([]*models.KnowledgeChunk{
+			{DocumentId: &documentId, Title: "แนวทางโรคเกาต์", Content: "ควรเลี่ยงเครื่องในสัตว์และยอดผัก", Score: 0.7},
+		}, nil)
+		llm := NewFakeLLM(validMealPlan)
+		repo := &agentAIRepository{llm: llm, promptUs: newTestPromptUsecase(), knowledgeUs: knowledgeUs}
+
+		plan, err := repo.GenerateMealsPlan(t.Context(), user, option)
+		assert.NoError(t, err)
+		assert.Len(t, plan.Citations, 1)
+		assert.Equal(t, "แนวทางโรคเกาต์", plan.Citations[0].Title)
+		messages := llm.Calls()[0]
+		assert.Contains(t, messageText(messages[len(messages)-2]), "ควรเลี่ยงเครื่องในสัตว์")
+		assert.Equal(t, llms.ChatMessageTypeHuman, messages[len(messages)-1].Role)
+	})
+	t.Run("success_no_medical_condition", func(t *testing.T) {
+		user := &models.User{UserInfo: &models.UserInfo{CaloriesLimit: 2000, MedicalCondition: models.NoMedicalCondition}}
+		knowledgeUs := new(knowledge_mocks.IKnowledgeUsecase)
+		repo := &agentAIRepository{llm: NewFakeLLM(validMealPlan), promptUs: newTestPromptUsecase(), knowledgeUs: knowledgeUs}
+
+		plan, err := repo.GenerateMealsPlan(t.Context(), user, option)
+		assert.NoError(t, err)
+		assert.Empty(t, plan.Citations)
+		knowledgeUs.AssertNotCalled(t, "SearchKnowledge", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGenerateMealsPlanGuardrail(t *testing.T) {
+	diseaseId := uuid.FromStringOrNil("262bf726-63e6-4932-8ddd-e06237740c90")
+	user := &models.User{UserInfo: &models.UserInfo{Weight: 60, CaloriesLimit: 1800, Diseases: []*models.Disease{{Id: &diseaseId, Name: "โรคไต"}}}}
+	option := &models.MealPlanOption{Days: 1}
+	rules := []*models.DiseaseRule{
+		{DiseaseId: &diseaseId, Nutrient: models.NutrientProtein, Scope: models.RuleScopeDay, MaxValue: 0.8, PerKgBodyWeight: true, Description: "โรคไต: โปรตีน"},
+		{DiseaseId: &diseaseId, Nutrient: models.NutrientPotassium, Scope: models.RuleScopeMeal, MaxValue: 700, Description: "โรคไต: โพแทสเซียม"},
+	}
+	const highPlan = `{"days":[{"day":1,"meals":[{"meal_type":"LUNCH","time":"12:00","name":"สเต๊กเนื้อ","items":[{"name":"สเต๊กเนื้อ","portion":"300 g","calories":700,"protein":75,"carbohydrate":0,"fat":40,"potassium":900}]}]}]}`
+	const missingPlan = `{"days":[{"day":1,"meals":[{"meal_type":"LUNCH","time":"12:00","name":"ข้าวผัด","items":[{"name":"ข้าวผัด","portion":"1 จาน","calories":550,"protein":15,"carbohydrate":80,"fat":18}]}]}]}`
+	const safePlan = `{"days":[{"day":1,"meals":[{"meal_type":"LUNCH","time":"12:00","name":"ข้าวต้มปลา","items":[{"name":"ข้าวต้มปลา","portion":"1 ชาม","calories":320,"protein":20,"carbohydrate":45,"fat":5,"potassium":400}]}]}]}`
+	newGuardrailUs := func() *guardrail_mocks.IGuardrailUsecase {
+		guardrailUs := new(guardrail_mocks.IGuardrailUsecase)
+		guardrailUs.On("FetchRulesByUserInfo", mock.Anything, user.UserInfo).Return(rules, nil)
+		return guardrailUs
+	}
+	t.Run("success_revised", func(t *testing.T) {
+		llm := NewFakeLLM(highPlan, safePlan)
+		repo := &agentAIRepository{llm: llm, promptUs: newTestPromptUsecase(), guardrailUs: newGuardrailUs()}
+
+		plan, err := repo.GenerateMealsPlan(t.Context(), user, option)
+		assert.NoError(t, err)
+		assert.Equal(t, "ข้าวต้มปลา", plan.Days[0].Meals[0].Name)
+		assert.Equal(t, models.GuardrailDisclaimer, plan.Safety.Disclaimer)
+		assert.Len(t, plan.Safety.Rules, 2)
+		assert.Empty(t, plan.Safety.Violations)
+		assert.Equal(t, 1, plan.Safety.Revisions)
+
+		calls := llm.Calls()
+		assert.Len(t, calls, 2)
+		assert.Contains(t, messageText(calls[0][3]), "protein รวมไม่เกิน 48.0 g ต่อวัน")
+		revision := messageText(calls[1][len(calls[1])-1])
+		assert.Contains(t, revision, `day 1 LUNCH "สเต๊กเนื้อ": potassium 900.0 mg exceeds 700.0 mg per meal`)
+		assert.Contains(t, revision, "day 1: total protein 75.0 g exceeds 48.0 g per day")
+	})
+	t.Run("success_missing_nutrient", func(t *testing.T) {
+		llm := NewFakeLLM(missingPlan, safePlan)
+		repo := &agentAIRepository{llm: llm, promptUs: newTestPromptUsecase(), guardrailUs: newGuardrailUs()}
+
+		plan, err := repo.GenerateMealsPlan(t.Context(), user, option)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, plan.Safety.Revisions)
+		calls := llm.Calls()
+		assert.Contains(t, messageText(calls[1][len(calls[1])-1]), `item "ข้าวผัด": potassium is missing`)
+	})
+	t.Run("success_violations_remain", func(t *testing.T) {
+		llm := NewFakeLLM(highPlan)
+		repo := &agentAIRepository{llm: llm, promptUs: newTestPromptUsecase(), guardrailUs: newGuardrailUs()}
+
+		plan, err := repo.GenerateMealsPlan(t.Context(), user, option)
+		assert.NoError(t, err)
+		assert.Len(t, llm.Calls(), 1+mealPlanMaxRevisions)
+		assert.Equal(t, mealPlanMaxRevisions, plan.Safety.Revisions)
+		assert.Len(t, plan.Safety.Violations, 2)
+	})
+	t.Run("error_fetch_rules", func(t *testing.T) {
+		guardrailUs := new(guardrail_mocks.IGuardrailUsecase)
+		guardrailUs.On("FetchRulesByUserInfo", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+		llm := NewFakeLLM(safePlan)
+		repo := &agentAIRepository{llm: llm, promptUs: newTestPromptUsecase(), guardrailUs: guardrailUs}
+
+		_, err := repo.GenerateMealsPlan(t.Context(), user, option)
+		assert.EqualError(t, err, "connection refused")
+		assert.Empty(t, llm.Calls())
+	})
+}
+
+func TestSwapMealPlanMeal(t *testing.T) {
+	user := &models.User{UserInfo: &models.UserInfo{Weight: 60, CaloriesLimit: 1800, FoodPreferences: []*models.FoodPreference{
+		{Name: "กุ้ง", PreferenceType: models.FoodPreferenceAllergy},
+	}}}
+	newPlan := func() *models.MealPlan {
+		plan, err := models.DecodeMealPlan(validMealPlan, 3)
+		assert.NoError(t, err)
+		plan.NewID()
+		plan.CaloriesTarget = 560
+		return plan
+	}
+	const highMeal = `{"name":"ข้าวมันไก่","items":[{"name":"ข้าวมันไก่","portion":"1 จาน","calories":1200,"protein":40,"carbohydrate":150,"fat":45}]}`
+	const shrimpMeal = `{"name":"ข้าวผัดกุ้ง","items":[{"name":"ข้าวผัดกุ้ง","portion":"1 จาน","calories":540,"protein":33,"carbohydrate":55,"fat":20}]}`
+	const chickenMeal = `{"name":"ข้าวผัดไก่","items":[{"name":"ข้าวผัดไก่","portion":"1 จาน","calories":540,"protein":33,"carbohydrate":55,"fat":20}]}`
+	t.Run("success_item", func(t *testing.T) {
+		plan := newPlan()
+		meal := plan.Days[1].Meals[0]
+		target, err := plan.FindSwapTarget(&models.MealPlanSwapOption{MealId: meal.Id, ItemId: meal.Items[1].Id})
+		assert.NoError(t, err)
+		llm := NewFakeLLM(`{"name":"ผัดกะเพราไก่","items":[{"name":"เต้าหู้ไข่นึ่ง","portion":"1 ถ้วย","calories":85,"protein":7,"carbohydrate":2,"fat":5}]}`)
+		repo := &agentAIRepository{llm: llm, promptUs: newTestPromptUsecase()}
+
+		swap, err := repo.SwapMealPlanMeal(t.Context(), user, plan, target, "ไม่อยากกินไข่")
+		assert.NoError(t, err)
+		assert.Equal(t, plan.Id, swap.MealPlanId)
+		assert.Equal(t, meal.Items[1].Id, swap.MealPlanItemId)
+		assert.Equal(t, 2, swap.Day)
+		assert.Equal(t, "ผัดกะเพราไก่", swap.After.Name)
+		assert.Len(t, swap.After.Items, 2)
+		assert.Equal(t, meal.Items[0], swap.After.Items[0])
+		assert.Equal(t, "เต้าหู้ไข่นึ่ง", swap.After.Items[1].Name)
+		assert.Equal(t, meal.Items[1].Id, swap.After.Items[1].Id)
+		assert.Equal(t, float64(565), swap.After.Total.Calories)
+		assert.Equal(t, "meal_plan_system.default+user_info_template.default", swap.PromptVersion)
+
+		instruction := messageText(llm.Calls()[0][2])
+		assert.Contains(t, instruction, `เปลี่ยนรายการ "ไข่ต้ม"`)
+		assert.Contains(t, instruction, "ไม่อยากกินไข่")
+		assert.Contains(t, instruction, "พลังงานประมาณ 80 kcal")
+		assert.Contains(t, instruction, "แพ้โดยเด็ดขาด: กุ้ง")
+	})
+	t.Run("success_meal_after_repair", func(t *testing.T) {
+		plan := newPlan()
+		target, err := plan.FindSwapTarget(&models.MealPlanSwapOption{MealId: plan.Days[1].Meals[0].Id})
+		assert.NoError(t, err)
+		llm := NewFakeLLM(highMeal, shrimpMeal, chickenMeal)
+		repo := &agentAIRepository{llm: llm, promptUs: newTestPromptUsecase()}
+
+		swap, err := repo.SwapMealPlanMeal(t.Context(), user, plan, target, "")
+		assert.NoError(t, err)
+		assert.Nil(t, swap.MealPlanItemId)
+		assert.Equal(t, "ข้าวผัดไก่", swap.After.Name)
+		assert.Equal(t, plan.Days[1].Meals[0].Id, swap.After.Id)
+		assert.Equal(t, "ผัดกะเพราไก่", swap.Before.Name)
+
+		calls := llm.Calls()
+		assert.Len(t, calls, 3)
+		assert.Contains(t, messageText(calls[1][len(calls[1])-1]), "day calories 1200 kcal is outside the target, keep the day within 504-616 kcal")
+		assert.Contains(t, messageText(calls[2][len(calls[2])-1]), `item "ข้าวผัดกุ้ง" contains "กุ้ง" which the user is allergic to`)
+	})
+	t.Run("success_guardrail_revised", func(t *testing.T) {
+		diseaseId := uuid.FromStringOrNil("262bf726-63e6-4932-8ddd-e06237740c90")
+		guardrailUs := new(guardrail_mocks.IGuardrailUsecase)
+		guardrailUs.On("FetchRulesByUserInfo", mock.Anything, user.UserInfo).Return([]*models.DiseaseRule{
+			{DiseaseId: &diseaseId, Nutrient: models.NutrientSodium, Scope: models.RuleScopeMeal, MaxValue: 600, Description: "ความดันโลหิตสูง: โซเดียม"},
+		}, nil)
+		plan := newPlan()
+		target, err := plan.FindSwapTarget(&models.MealPlanSwapOption{MealId: plan.Days[1].Meals[0].Id})
+		assert.NoError(t, err)
+		llm := NewFakeLLM(
+			`{"name":"ข้าวผัดไก่","items":[{"name":"ข้าวผัดไก่","portion":"1 จาน","calories":540,"protein":33,"carbohydrate":55,"fat":20,"sodium":1100}]}`,
+			`{"name":"ข้าวผัดไก่","items":[{"name":"ข้าวผัดไก่","portion":"1 จาน","calories":540,"protein":33,"carbohydrate":55,"fat":20,"sodium":450}]}`,
+		)
+		repo := &agentAIRepository{llm: llm, promptUs: newTestPromptUsecase(), guardrailUs: guardrailUs}
+
+		swap, err := repo.SwapMealPlanMeal(t.Context(), user, plan, target, "")
+		assert.NoError(t, err)
+		assert.Equal(t, float64(450), *swap.After.Items[0].Sodium)
+		calls := llm.Calls()
+		assert.Len(t, calls, 2)
+		assert.Contains(t, messageText(calls[0][2]), "sodium รวมไม่เกิน 600.0 mg ต่อมื้อ")
+		assert.Contains(t, messageText(calls[1][len(calls[1])-1]), `day 2 LUNCH "ข้าวผัดไก่": sodium 1100.0 mg exceeds 600.0 mg per meal`)
+	})
+	t.Run("error_same_meal", func(t *testing.T) {
+		plan := newPlan()
+		target, err := plan.FindSwapTarget(&models.MealPlanSwapOption{MealId: plan.Days[0].Meals[0].Id})
+		assert.NoError(t, err)
+		sameMeal := `{"name":"ข้าวต้มไก่","items":[{"name":"ข้าวต้มไก่","portion":"1 ชาม (300 g)","calories":320,"protein":18,"carbohydrate":45,"fat":7}]}`
+		llm := NewFakeLLM(sameMeal)
+		repo := &agentAIRepository{llm: llm, promptUs: newTestPromptUsecase()}
+
+		_, err = repo.SwapMealPlanMeal(t.Context(), user, plan, target, "")
+		assert.ErrorContains(t, err, constants.ERROR_MEAL_SWAP_IS_INVALID)
+		assert.ErrorContains(t, err, `name: must be a different meal from "ข้าวต้มไก่"`)
+		assert.Len(t, llm.Calls(), mealPlanMaxAttempts)
+	})
+	t.Run("error_meal_not_found", func(t *testing.T) {
+		mealId := uuid.Must(uuid.NewV4())
+		_, err := newPlan().FindSwapTarget(&models.MealPlanSwapOption{MealId: &mealId})
+		assert.EqualError(t, err, constants.ERROR_MEAL_PLAN_MEAL_NOT_FOUND)
+	})
+}
+
+func TestEvaluateSuite(t *testing.T) {
+	const shrimpPlan = `{"days":[{"day":1,"meals":[{"meal_type":"LUNCH","time":"12:00","name":"ผัดไทยกุ้งสด","items":[{"name":"ผัดไทยกุ้งสด","portion":"1 จาน","calories":1200,"protein":30,"carbohydrate":150,"fat":45}]}]}]}`
+	suite, err := models.DecodeEvalSuite([]byte(`{"name":"test","cases":[
+		{"name":"allergy","user_info":{"calories_limit":1800,"food_preferences":[{"name":"กุ้ง","preference_type":"ALLERGY"}]},"option":{"days":1},"responses":[` + strconv.Quote(shrimpPlan) + `]},
+		{"name":"invalid","user_info":{"calories_limit":1800},"option":{"days":1},"responses":["ไม่ใช่ JSON"]}
+	]}`))
+	assert.NoError(t, err)
+
+	report := EvaluateSuite(t.Context(), nil, newTestPromptUsecase(), suite)
+	assert.Equal(t, "fixtures", report.Provider)
+	assert.Equal(t, 0, report.Passed)
+	assert.Equal(t, 2, report.Failed)
+
+	allergy := report.Results[0]
+	assert.Equal(t, 1, allergy.Attempts)
+	checks := make(map[string]bool)
+	for _, check := range allergy.Checks {
+		checks[check.Name] = check.Passed
+	}
+	assert.Equal(t, map[string]bool{"valid_json": true, "calories day 1": false, "no_allergens": false, "disease_rules": true}, checks)
+
+	invalid := report.Results[1]
+	assert.Equal(t, mealPlanMaxAttempts, invalid.Attempts)
+	assert.Contains(t, invalid.Error, constants.ERROR_MEAL_PLAN_IS_INVALID)
+
+	text := report.Text()
+	assert.Contains(t, text, "FAIL allergy attempts=1")
+	assert.Contains(t, text, "FAIL no_allergens: day 1 ผัดไทยกุ้งสด: กุ้ง")
+	assert.Contains(t, text, "passed 0, failed 2")
+	assert.Equal(t, text, EvaluateSuite(t.Context(), nil, newTestPromptUsecase(), suite).Text())
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"sync"
+	"time"
+)
+
+/* circuitBreaker เปิดวงจรเมื่อ provider ล้มเหลวติดกันครบ threshold ระหว่าง cooldown จะตอบ error ทันที พอครบเวลาจะปล่อยให้ลองได้ครั้งเดียว (half-open) */
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openedAt  time.Time
+	trial     bool
+	now       func() time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+/* Allow ตรวจว่าเรียก provider ได้หรือไม่ ทุกครั้งที่ได้ true ต้องตามด้วย Success, Failure หรือ Release */
+func (b *circuitBreaker) Allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.trial || b.now().Sub(b.openedAt) < b.cooldown {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+func (b *circuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.trial = false
+}
+
+func (b *circuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.trial = false
+	if b.failures >= b.threshold {
+		b.openedAt = b.now()
+	}
+}
+
+/* Release คืนสิทธิ์ทดลองโดยไม่นับผล ใช้เมื่อผู้เรียกยกเลิกเองก่อนรู้ผล */
+func (b *circuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
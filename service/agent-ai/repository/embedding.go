@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"healthmatefood-api/config"
+	"healthmatefood-api/service/agent-ai"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+/* จำนวนข้อความต่อหนึ่ง request ของ embedding */
+const embeddingBatchSize = 64
+
+/* โมเดล embedding ตั้งต้นของแต่ละ provider เมื่อไม่ได้ตั้ง KNOWLEDGE_EMBEDDING_MODEL */
+const (
+	defaultOpenAIEmbeddingModel = "text-embedding-3-small"
+	defaultOllamaEmbeddingModel = "nomic-embed-text"
+)
+
+/* NewEmbedder เลือก embedding ตาม AGENT_PROVIDER ใช้ endpoint และ key เดียวกับ LLM provider ที่ไม่มี embedding (DigitalOcean agent, fake) ใช้ hash embedding ในเครื่อง */
+func NewEmbedder(cfg config.IAgentConfig, knowledgeCfg config.IKnowledgeConfig) agent.IEmbedder {
+	model := knowledgeCfg.EmbeddingModel()
+	client := &http.Client{Timeout: cfg.AgentTimeout()}
+	switch cfg.AgentProvider() {
+	case config.AGENT_PROVIDER_OPENAI:
+		if model == "" {
+			model = defaultOpenAIEmbeddingModel
+		}
+		return &openAIEmbedder{endpoint: embeddingEndpoint(cfg.AgentEndpoint(), "/chat/completions", "/embeddings"), accessKey: cfg.AgentAccessKey(), model: model, client: client}
+	case config.AGENT_PROVIDER_OLLAMA:
+		if model == "" {
+			model = defaultOllamaEmbeddingModel
+		}
+		return &ollamaEmbedder{endpoint: embeddingEndpoint(cfg.AgentEndpoint(), "/api/chat", "/api/embed"), model: model, client: client}
+	default:
+		return NewHashEmbedder(hashEmbeddingDimensions)
+	}
+}
+
+/* embeddingEndpoint แปลง url ของ chat เป็น url ของ embedding ของ provider เดียวกัน */
+func embeddingEndpoint(endpoint, chatPath, embeddingPath string) string {
+	endpoint = strings.TrimSuffix(strings.TrimRight(endpoint, "/"), chatPath)
+	return endpoint + embeddingPath
+}
+
+/* embedInBatches เรียก embed ทีละไม่เกิน embeddingBatchSize ข้อความ แล้วรวมผลตามลำดับเดิม */
+func embedInBatches(ctx context.Context, texts []string, embed func(ctx context.Context, batch []string) ([][]float32, error)) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += embeddingBatchSize {
+		batch := texts[start:min(start+embeddingBatchSize, len(texts))]
+		embeddings, err := embed(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		if len(embeddings) != len(batch) {
+			return nil, fmt.Errorf("embedding returned %d vectors for %d texts", len(embeddings), len(batch))
+		}
+		vectors = append(vectors, embeddings...)
+	}
+	return vectors, nil
+}
+
+/* postEmbedding ส่ง request แบบ JSON แล้วอ่าน response ลง result */
+func postEmbedding(ctx context.Context, client *http.Client, name, endpoint, accessKey string, requestBody map[string]interface{}, result interface{}) error {
+	body, err := json.Marshal(requestBody)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if accessKey != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessKey))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return requestError(ctx, name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return responseError(name, resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return requestError(ctx, name, err)
+	}
+	return nil
+}
+
+/* openAIEmbedder เรียก /embeddings แบบ OpenAI */
+type openAIEmbedder struct {
+	endpoint  string
+	accessKey string
+	model     string
+	client    *http.Client
+}
+
+func (o *openAIEmbedder) Model() string {
+	return o.model
+}
+
+func (o *openAIEmbedder) EmbedTexts(ctx context.Context, texts []string) ([][]float32, error) {
+	return embedInBatches(ctx, texts, func(ctx context.Context, batch []string) ([][]float32, error) {
+		var result struct {
+			Data []struct {
+				Index     int       `json:"index"`
+				Embedding []float32 `json:"embedding"`
+			} `json:"data"`
+		}
+		if err := postEmbedding(ctx, o.client, o.model, o.endpoint, o.accessKey, map[string]interface{}{"model": o.model, "input": batch}, &result); err != nil {
+			return nil, err
+		}
+		sort.Slice(result.Data, func(i, j int) bool {
+			return result.Data[i].Index < result.Data[j].Index
+		})
+		embeddings := make([][]float32, 0, len(result.Data))
+		for _, data := range result.Data {
+			embeddings = append(embeddings, data.Embedding)
+		}
+		return embeddings, nil
+	})
+}
+
+/* ollamaEmbedder เรียก /api/embed ของ Ollama */
+type ollamaEmbedder struct {
+	endpoint string
+	model    string
+	client   *http.Client
+}
+
+func (o *ollamaEmbedder) Model() string {
+	return o.model
+}
+
+func (o *ollamaEmbedder) EmbedTexts(ctx context.Context, texts []string) ([][]float32, error) {
+	return embedInBatches(ctx, texts, func(ctx context.Context, batch []string) ([][]float32, error) {
+		var result struct {
+			Embeddings [][]float32 `json:"embeddings"`
+		}
+		if err := postEmbedding(ctx, o.client, o.model, o.endpoint, "", map[string]interface{}{"model": o.model, "input": batch}, &result); err != nil {
+			return nil, err
+		}
+		return result.Embeddings, nil
+	})
+}
+
+/* จำนวนมิติของ hash embedding */
+const hashEmbeddingDimensions = 256
+
+/* hashEmbedder embedding ในเครื่องจาก trigram ของตัวอักษร (ภาษาไทยไม่มีช่องว่างระหว่างคำ) ไม่เข้าใจความหมายแต่จับคำที่ตรงกันได้ ใช้เมื่อ provider ไม่มี embedding และในเทส */
+type hashEmbedder struct {
+	dimensions int
+}
+
+func NewHashEmbedder(dimensions int) *hashEmbedder {
+	return &hashEmbedder{dimensions: dimensions}
+}
+
+func (h *hashEmbedder) Model() string {
+	return fmt.Sprintf("hash-%d", h.dimensions)
+}
+
+func (h *hashEmbedder) EmbedTexts(ctx context.Context, texts []string) ([][]float32, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	embeddings := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		embeddings = append(embeddings, h.embed(text))
+	}
+	return embeddings, nil
+}
+
+func (h *hashEmbedder) embed(text string) []float32 {
+	vector := make([]float32, h.dimensions)
+	runes := make([]rune, 0, len(text))
+	for _, char := range strings.ToLower(text) {
+		if unicode.IsLetter(char) || unicode.IsNumber(char) || unicode.Is(unicode.Mn, char) {
+			runes = append(runes, char)
+		}
+	}
+	for i := 0; i+3 <= len(runes); i++ {
+		hash := fnv.New32a()
+		hash.Write([]byte(string(runes[i : i+3])))
+		vector[hash.Sum32()%uint32(h.dimensions)]++
+	}
+
+	var norm float64
+	for _, val := range vector {
+		norm += float64(val) * float64(val)
+	}
+	if norm == 0 {
+		return vector
+	}
+	norm = math.Sqrt(norm)
+	for i := range vector {
+		vector[i] = float32(float64(vector[i]) / norm)
+	}
+	return vector
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"encoding/json"
+	"healthmatefood-api/config"
+	"healthmatefood-api/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type knowledgeConfig struct {
+	embeddingModel string
+}
+
+func (k knowledgeConfig) EmbeddingModel() string { return k.embeddingModel }
+func (k knowledgeConfig) VectorStore() string    { return config.KNOWLEDGE_VECTOR_STORE_GO }
+func (k knowledgeConfig) TopK() int              { return 4 }
+func (k knowledgeConfig) MinScore() float64      { return 0.3 }
+func (k knowledgeConfig) ChunkSize() int         { return 800 }
+func (k knowledgeConfig) ChunkOverlap() int      { return 100 }
+
+func TestNewEmbedder(t *testing.T) {
+	t.Run("success_hash_fallback", func(t *testing.T) {
+		embedder := NewEmbedder(agentConfig{provider: config.AGENT_PROVIDER_FAKE}, knowledgeConfig{})
+		assert.Equal(t, "hash-256", embedder.Model())
+
+		embeddings, err := embedder.EmbedTexts(t.Context(), []string{"ผู้ป่วยโรคไตควรจำกัดโปรตีน", "ผู้ป่วยโรคไตควรจำกัดโซเดียม", "ออกกำลังกายวันละ 30 นาที"})
+		assert.NoError(t, err)
+		assert.Len(t, embeddings, 3)
+		assert.Greater(t, models.CosineSimilarity(embeddings[0], embeddings[1]), models.CosineSimilarity(embeddings[0], embeddings[2]))
+	})
+	t.Run("success_openai", func(t *testing.T) {
+		var path string
+		var body map[string]interface{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			/* OpenAI ไม่รับประกันลำดับ ต้องเรียงตาม index เอง */
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []interface{}{
+					map[string]interface{}{"index": 1, "embedding": []float32{0, 1}},
+					map[string]interface{}{"index": 0, "embedding": []float32{1, 0}},
+				},
+			})
+		}))
+		defer server.Close()
+		embedder := NewEmbedder(agentConfig{provider: config.AGENT_PROVIDER_OPENAI, endpoint: server.URL + "/v1/chat/completions"}, knowledgeConfig{})
+
+		embeddings, err := embedder.EmbedTexts(t.Context(), []string{"ข้าว", "ไก่"})
+		assert.NoError(t, err)
+		assert.Equal(t, "/v1/embeddings", path)
+		assert.Equal(t, defaultOpenAIEmbeddingModel, body["model"])
+		assert.Equal(t, [][]float32{{1, 0}, {0, 1}}, embeddings)
+	})
+	t.Run("error_missing_vectors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{"embeddings": [][]float32{{1, 0}}})
+		}))
+		defer server.Close()
+		embedder := NewEmbedder(agentConfig{provider: config.AGENT_PROVIDER_OLLAMA, endpoint: server.URL + "/api/chat"}, knowledgeConfig{embeddingModel: "bge-m3"})
+		assert.Equal(t, "bge-m3", embedder.Model())
+
+		_, err := embedder.EmbedTexts(t.Context(), []string{"ข้าว", "ไก่"})
+		assert.ErrorContains(t, err, "embedding returned 1 vectors for 2 texts")
+	})
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/prompt"
+	"sync"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+/* ชื่อ provider ในรายงานเมื่อใช้คำตอบที่บันทึกไว้ใน suite */
+const evalFixtureProvider = "fixtures"
+
+/* EvaluateSuite ส่งทุก case ใน suite ผ่านขั้นตอนสร้างแผนอาหารเดียวกับ API (รวมการให้โมเดลแก้คำตอบ) แล้วตรวจผล llm เป็น nil จะใช้ Responses ที่บันทึกไว้ของแต่ละ case แทนการเรียกโมเดล */
+func EvaluateSuite(ctx context.Context, llm LLMProvider, promptUs prompt.IPromptUsecase, suite *models.EvalSuite) *models.EvalReport {
+	provider := evalFixtureProvider
+	if llm != nil {
+		provider = llm.Name()
+	}
+	results := make([]*models.EvalResult, 0, len(suite.Cases))
+	for _, evalCase := range suite.Cases {
+		var counter *countingLLM
+		if llm != nil {
+			counter = &countingLLM{LLMProvider: llm}
+		} else {
+			counter = &countingLLM{LLMProvider: NewFakeLLM(evalCase.Responses...)}
+		}
+		repo := &agentAIRepository{llm: counter, promptUs: promptUs, guardrailUs: staticRulesUsecase(evalCase.Rules)}
+		plan, err := repo.GenerateMealsPlan(ctx, &models.User{UserInfo: evalCase.UserInfo}, evalCase.Option)
+		result := models.EvaluateMealPlan(evalCase, plan, err)
+		result.Attempts = counter.count
+		results = append(results, result)
+	}
+	return models.NewEvalReport(suite, provider, results)
+}
+
+/* countingLLM นับจำนวนครั้งที่เรียกโมเดลใน case หนึ่ง */
+type countingLLM struct {
+	LLMProvider
+	count int
+}
+
+func (c *countingLLM) GenerateContent(ctx context.Context, messages []llms.MessageContent, opts ...llms.CallOption) (*llms.ContentResponse, error) {
+	c.count++
+	return c.LLMProvider.GenerateContent(ctx, messages, opts...)
+}
+
+/* staticRulesUsecase กฎโรคประจำตัวที่กำหนดไว้ใน case ใช้แทนกฎใน database เพื่อให้ผลประเมินไม่ขึ้นกับข้อมูลที่ admin แก้ */
+type staticRulesUsecase []*models.DiseaseRule
+
+func (s staticRulesUsecase) FetchAllDiseaseRules(ctx context.Context, args *sync.Map) ([]*models.DiseaseRule, error) {
+	return s, nil
+}
+
+func (s staticRulesUsecase) FetchRulesByUserInfo(ctx context.Context, userInfo *models.UserInfo) ([]*models.DiseaseRule, error) {
+	return s, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+/* ชื่อโมเดลของ fake provider */
+const fakeLLMName = "fake"
+
+/* คำตอบที่ขึ้นต้นด้วย prefix นี้คือการเรียก tool หนึ่งบรรทัดต่อหนึ่ง tool */
+const fakeToolCallPrefix = "tool_call:"
+
+/* FakeToolCall คำตอบของ fake provider ที่ขอเรียก tool ใช้เฉพาะเมื่อ request ส่ง tools มา */
+func FakeToolCall(name, arguments string) string {
+	return fmt.Sprintf("%s%s %s", fakeToolCallPrefix, name, arguments)
+}
+
+/* fakeLLM provider ที่ไม่ออก network ตอบตาม responses ที่กำหนดตามลำดับ (ตัวสุดท้ายใช้ซ้ำ) ถ้าไม่กำหนดจะทวนข้อความล่าสุดของผู้ใช้ ใช้ในเทสและตอนพัฒนาแบบไม่มี LLM */
+type fakeLLM struct {
+	mu        sync.Mutex
+	responses []string
+	calls     [][]llms.MessageContent
+}
+
+func NewFakeLLM(responses ...string) *fakeLLM {
+	return &fakeLLM{
+		responses: responses,
+	}
+}
+
+func (f *fakeLLM) Name() string {
+	return fakeLLMName
+}
+
+/* Calls ข้อความที่ได้รับในแต่ละครั้งที่ถูกเรียก */
+func (f *fakeLLM) Calls() [][]llms.MessageContent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func (f *fakeLLM) GenerateContent(ctx context.Context, messages []llms.MessageContent, opts ...llms.CallOption) (*llms.ContentResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	options := callOptions(opts)
+
+	f.mu.Lock()
+	index := len(f.calls)
+	f.calls = append(f.calls, messages)
+	f.mu.Unlock()
+
+	content := f.reply(index, messages)
+	var toolCalls []llms.ToolCall
+	if len(options.Tools) > 0 && strings.HasPrefix(content, fakeToolCallPrefix) {
+		toolCalls = fakeToolCalls(index, content)
+		content = ""
+	}
+	if options.StreamingFunc != nil {
+		for _, char := range content {
+			if err := options.StreamingFunc(ctx, []byte(string(char))); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	/* นับ token แบบประมาณ 4 ตัวอักษรต่อ token ให้ผลคงที่ทุกครั้ง */
+	promptLength := 0
+	for _, message := range messages {
+		promptLength += utf8.RuneCountInString(messageText(message))
+	}
+	generationInfo := map[string]interface{}{"model": fakeLLMName}
+	setTokenUsage(generationInfo, (promptLength+3)/4, (utf8.RuneCountInString(content)+3)/4, 0)
+
+	return &llms.ContentResponse{
+		Choices: []*llms.ContentChoice{
+			{Content: content, GenerationInfo: generationInfo, ToolCalls: toolCalls},
+		},
+	}, nil
+}
+
+/* fakeToolCalls แปลงบรรทัด tool_call:<name> <arguments> เป็น tool call */
+func fakeToolCalls(index int, content string) []llms.ToolCall {
+	calls := make([]llms.ToolCall, 0)
+	for _, line := range strings.Split(content, "\n") {
+		line, ok := strings.CutPrefix(strings.TrimSpace(line), fakeToolCallPrefix)
+		if !ok {
+			continue
+		}
+		name, arguments, _ := strings.Cut(line, " ")
+		calls = append(calls, llms.ToolCall{
+			ID:           fmt.Sprintf("call_%d_%d", index, len(calls)),
+			Type:         "function",
+			FunctionCall: &llms.FunctionCall{Name: name, Arguments: strings.TrimSpace(arguments)},
+		})
+	}
+	return calls
+}
+
+func (f *fakeLLM) reply(index int, messages []llms.MessageContent) string {
+	if len(f.responses) > 0 {
+		return f.responses[min(index, len(f.responses)-1)]
+	}
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == llms.ChatMessageTypeHuman {
+			return fmt.Sprintf("fake: %s", messageText(messages[i]))
+		}
+	}
+	return "fake: ok"
+}
+
+func (f *fakeLLM) Call(ctx context.Context, prompt string, opts ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, f, prompt, opts...)
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"healthmatefood-api/service/agent-ai"
+	"net/http"
+	"strings"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+/* ollamaLLM เรียก /api/chat ของ Ollama ซึ่ง stream เป็น JSON ทีละบรรทัด */
+type ollamaLLM struct {
+	endpoint string
+	model    string
+	client   *http.Client
+}
+
+/* NewOllamaLLM รับ base url ของ Ollama (เช่น http://localhost:11434) */
+func NewOllamaLLM(endpoint, model string) *ollamaLLM {
+	endpoint = strings.TrimRight(endpoint, "/")
+	if !strings.HasSuffix(endpoint, "/api/chat") {
+		endpoint += "/api/chat"
+	}
+	return &ollamaLLM{
+		endpoint: endpoint,
+		model:    model,
+		client:   &http.Client{},
+	}
+}
+
+func (o *ollamaLLM) Name() string {
+	return o.model
+}
+
+type ollamaResponse struct {
+	Model   string `json:"model"`
+	Message struct {
+		Role      string `json:"role"`
+		Content   string `json:"content"`
+		ToolCalls []struct {
+			Function struct {
+				Name      string          `json:"name"`
+				Arguments json.RawMessage `json:"arguments"`
+			} `json:"function"`
+		} `json:"tool_calls"`
+	} `json:"message"`
+	Done            bool   `json:"done"`
+	Error           string `json:"error"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+}
+
+func (o *ollamaLLM) GenerateContent(ctx context.Context, messages []llms.MessageContent, opts ...llms.CallOption) (*llms.ContentResponse, error) {
+	options := callOptions(opts)
+
+	requestMessages := ollamaMessages(messages)
+
+	model := o.model
+	if options.Model != "" {
+		model = options.Model
+	}
+	modelOptions := map[string]interface{}{}
+	if options.Temperature > 0 {
+		modelOptions["temperature"] = options.Temperature
+	}
+	if options.MaxTokens > 0 {
+		modelOptions["num_predict"] = options.MaxTokens
+	}
+	if options.TopP > 0 {
+		modelOptions["top_p"] = options.TopP
+	}
+	if options.TopK > 0 {
+		modelOptions["top_k"] = options.TopK
+	}
+	if len(options.StopWords) > 0 {
+		modelOptions["stop"] = options.StopWords
+	}
+	if options.Seed != 0 {
+		modelOptions["seed"] = options.Seed
+	}
+	requestBody := map[string]interface{}{
+		"model":    model,
+		"messages": requestMessages,
+		"stream":   options.StreamingFunc != nil,
+		"options":  modelOptions,
+	}
+	if options.JSONMode {
+		requestBody["format"] = "json"
+	}
+	if len(options.Tools) > 0 {
+		requestBody["tools"] = options.Tools
+	}
+	body, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", o.endpoint, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, requestError(ctx, o.Name(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, responseError(o.Name(), resp)
+	}
+
+	/* แบบไม่ stream จะได้ object เดียว แบบ stream ได้หลายบรรทัดจนถึง done: true อ่านด้วยวิธีเดียวกันได้ */
+	var content strings.Builder
+	generationInfo := map[string]interface{}{}
+	toolCalls := make([]llms.ToolCall, 0)
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var chunk ollamaResponse
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			return nil, fmt.Errorf("invalid ollama response: %v", err)
+		}
+		if chunk.Error != "" {
+			return nil, &agent.UpstreamError{Provider: o.Name(), StatusCode: resp.StatusCode, Message: chunk.Error}
+		}
+		if chunk.Model != "" {
+			generationInfo["model"] = chunk.Model
+		}
+		/* Ollama ไม่ส่ง id ของ tool call มา จึงตั้งตามลำดับ */
+		for _, call := range chunk.Message.ToolCalls {
+			toolCalls = append(toolCalls, llms.ToolCall{
+				ID:           fmt.Sprintf("call_%d", len(toolCalls)),
+				Type:         "function",
+				FunctionCall: &llms.FunctionCall{Name: call.Function.Name, Arguments: string(call.Function.Arguments)},
+			})
+		}
+		if chunk.Message.Content != "" {
+			content.WriteString(chunk.Message.Content)
+			if options.StreamingFunc != nil {
+				if err := options.StreamingFunc(ctx, []byte(chunk.Message.Content)); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if chunk.Done {
+			setTokenUsage(generationInfo, chunk.PromptEvalCount, chunk.EvalCount, 0)
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, requestError(ctx, o.Name(), err)
+	}
+
+	choice := &llms.ContentChoice{Content: content.String(), GenerationInfo: generationInfo}
+	if len(toolCalls) > 0 {
+		choice.ToolCalls = toolCalls
+	}
+	return &llms.ContentResponse{
+		Choices: []*llms.ContentChoice{choice},
+	}, nil
+}
+
+/* ollamaMessages แปลงข้อความเป็นรูปแบบ /api/chat arguments ของ tool call เป็น object ไม่ใช่ string */
+func ollamaMessages(messages []llms.MessageContent) []map[string]interface{} {
+	requestMessages := make([]map[string]interface{}, 0, len(messages))
+	for _, msg := range messages {
+		if responses := messageToolResponses(msg); len(responses) > 0 {
+			for _, response := range responses {
+				requestMessages = append(requestMessages, map[string]interface{}{
+					"role":      "tool",
+					"tool_name": response.Name,
+					"content":   response.Content,
+				})
+			}
+			continue
+		}
+
+		requestMessage := map[string]interface{}{
+			"role":    messageRole(msg.Role),
+			"content": messageText(msg),
+		}
+		if images := messageImages(msg); len(images) > 0 {
+			requestMessage["images"] = images
+		}
+		if calls := messageToolCalls(msg); len(calls) > 0 {
+			toolCalls := make([]map[string]interface{}, 0, len(calls))
+			for _, call := range calls {
+				arguments := json.RawMessage(call.FunctionCall.Arguments)
+				if !json.Valid(arguments) {
+					arguments = json.RawMessage("{}")
+				}
+				toolCalls = append(toolCalls, map[string]interface{}{
+					"function": map[string]interface{}{"name": call.FunctionCall.Name, "arguments": arguments},
+				})
+			}
+			requestMessage["tool_calls"] = toolCalls
+		}
+		requestMessages = append(requestMessages, requestMessage)
+	}
+	return requestMessages
+}
+
+func (o *ollamaLLM) Call(ctx context.Context, prompt string, opts ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, o, prompt, opts...)
+}
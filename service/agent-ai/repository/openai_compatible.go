@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"healthmatefood-api/service/agent-ai"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cast"
+	"github.com/tmc/langchaingo/llms"
+)
+
+/* จำนวน tool call สูงสุดที่รับจาก stream หนึ่งครั้ง index ของ tool call มาจากต้นทาง ต้องจำกัดไว้ไม่ให้จองหน่วยความจำตามค่าที่ส่งมา */
+const maxStreamToolCalls = 32
+
+/* openAICompatibleLLM เรียก endpoint ที่รับ request แบบ OpenAI chat completions */
+type openAICompatibleLLM struct {
+	name      string
+	endpoint  string
+	accessKey string
+	model     string
+	client    *http.Client
+}
+
+/* NewOpenAICompatibleLLM รับ base url (เช่น https://api.openai.com/v1) หรือ url ของ /chat/completions โดยตรง */
+func NewOpenAICompatibleLLM(endpoint, accessKey, model string) *openAICompatibleLLM {
+	endpoint = strings.TrimRight(endpoint, "/")
+	if !strings.HasSuffix(endpoint, "/chat/completions") {
+		endpoint += "/chat/completions"
+	}
+	return &openAICompatibleLLM{
+		name:      model,
+		endpoint:  endpoint,
+		accessKey: accessKey,
+		model:     model,
+		client:    &http.Client{},
+	}
+}
+
+func (o *openAICompatibleLLM) Name() string {
+	return o.name
+}
+
+/* GenerateContent ถ้ามี llms.WithStreamingFunc จะขอแบบ stream: true แล้วส่ง token ให้ทีละส่วน ผลลัพธ์สุดท้ายเหมือนแบบไม่ stream */
+func (o *openAICompatibleLLM) GenerateContent(ctx context.Context, messages []llms.MessageContent, opts ...llms.CallOption) (*llms.ContentResponse, error) {
+	options := callOptions(opts)
+
+	// สร้าง request body
+	requestBody := map[string]interface{}{
+		"messages": openAIMessages(messages),
+	}
+	if model := options.Model; model != "" {
+		requestBody["model"] = model
+	} else if o.model != "" {
+		requestBody["model"] = o.model
+	}
+	if options.Temperature > 0 {
+		requestBody["temperature"] = options.Temperature
+	}
+	if options.MaxTokens > 0 {
+		requestBody["max_tokens"] = options.MaxTokens
+	}
+	if options.TopP > 0 {
+		requestBody["top_p"] = options.TopP
+	}
+	if len(options.StopWords) > 0 {
+		requestBody["stop"] = options.StopWords
+	}
+	if options.Seed != 0 {
+		requestBody["seed"] = options.Seed
+	}
+	if options.JSONMode {
+		requestBody["response_format"] = map[string]interface{}{"type": "json_object"}
+	}
+	if len(options.Tools) > 0 {
+		requestBody["tools"] = options.Tools
+		if options.ToolChoice != nil {
+			requestBody["tool_choice"] = options.ToolChoice
+		}
+	}
+	if options.StreamingFunc != nil {
+		requestBody["stream"] = true
+		requestBody["stream_options"] = map[string]interface{}{"include_usage": true}
+	}
+	body, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	// สร้าง HTTP request
+	req, err := http.NewRequestWithContext(ctx, "POST", o.endpoint, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+
+	// ตั้ง header
+	req.Header.Set("Content-Type", "application/json")
+	if o.accessKey != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", o.accessKey))
+	}
+
+	// ส่ง request
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, requestError(ctx, o.Name(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, responseError(o.Name(), resp)
+	}
+
+	if options.StreamingFunc != nil {
+		return o.readStream(ctx, resp, options.StreamingFunc)
+	}
+
+	// อ่าน response
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, requestError(ctx, o.Name(), err)
+	}
+
+	// ดึง content จาก response
+	choices, ok := result["choices"].([]interface{})
+	if !ok || len(choices) == 0 {
+		return nil, &agent.UpstreamError{Provider: o.Name(), StatusCode: resp.StatusCode, Message: "no choices in response"}
+	}
+	message, _ := choices[0].(map[string]interface{})["message"].(map[string]interface{})
+	toolCalls := openAIToolCalls(message["tool_calls"])
+	content, ok := message["content"].(string)
+	/* ตอนเรียก tool content อาจเป็น null */
+	if !ok && (message["content"] != nil || len(toolCalls) == 0) {
+		return nil, &agent.UpstreamError{Provider: o.Name(), StatusCode: resp.StatusCode, Message: "invalid content format"}
+	}
+
+	// เก็บชื่อโมเดลที่ตอบไว้ใน GenerationInfo (ถ้ามี)
+	generationInfo := map[string]interface{}{}
+	if model, ok := result["model"].(string); ok && model != "" {
+		generationInfo["model"] = model
+	}
+	if usage, ok := result["usage"].(map[string]interface{}); ok {
+		setTokenUsage(generationInfo, cast.ToInt(usage["prompt_tokens"]), cast.ToInt(usage["completion_tokens"]), cast.ToInt(usage["total_tokens"]))
+	}
+
+	// สร้าง response สำหรับ LangChainGo
+	return &llms.ContentResponse{
+		Choices: []*llms.ContentChoice{
+			{Content: content, GenerationInfo: generationInfo, ToolCalls: toolCalls},
+		},
+	}, nil
+}
+
+/* openAIMessages แปลงข้อความเป็นรูปแบบ chat completions ผลของแต่ละ tool แยกเป็นข้อความ role tool ของตัวเอง */
+func openAIMessages(messages []llms.MessageContent) []map[string]interface{} {
+	requestMessages := make([]map[string]interface{}, 0, len(messages))
+	for _, msg := range messages {
+		if responses := messageToolResponses(msg); len(responses) > 0 {
+			for _, response := range responses {
+				requestMessages = append(requestMessages, map[string]interface{}{
+					"role":         "tool",
+					"tool_call_id": response.ToolCallID,
+					"content":      response.Content,
+				})
+			}
+			continue
+		}
+
+		requestMessage := map[string]interface{}{
+			"role":    messageRole(msg.Role),
+			"content": messageContent(msg),
+		}
+		if calls := messageToolCalls(msg); len(calls) > 0 {
+			toolCalls := make([]map[string]interface{}, 0, len(calls))
+			for _, call := range calls {
+				toolCalls = append(toolCalls, map[string]interface{}{
+					"id":       call.ID,
+					"type":     "function",
+					"function": map[string]interface{}{"name": call.FunctionCall.Name, "arguments": call.FunctionCall.Arguments},
+				})
+			}
+			requestMessage["tool_calls"] = toolCalls
+		}
+		requestMessages = append(requestMessages, requestMessage)
+	}
+	return requestMessages
+}
+
+/* openAIToolCalls อ่าน message.tool_calls ของ response */
+func openAIToolCalls(val interface{}) []llms.ToolCall {
+	items, _ := val.([]interface{})
+	if len(items) == 0 {
+		return nil
+	}
+	calls := make([]llms.ToolCall, 0, len(items))
+	for _, item := range items {
+		call, _ := item.(map[string]interface{})
+		function, _ := call["function"].(map[string]interface{})
+		if function == nil {
+			continue
+		}
+		calls = append(calls, llms.ToolCall{
+			ID:           cast.ToString(call["id"]),
+			Type:         "function",
+			FunctionCall: &llms.FunctionCall{Name: cast.ToString(function["name"]), Arguments: cast.ToString(function["arguments"])},
+		})
+	}
+	return calls
+}
+
+/* readStream อ่าน chunk รูปแบบ OpenAI (data: {...} จบด้วย data: [DONE]) แล้วรวม content ไว้ตอบกลับ */
+func (o *openAICompatibleLLM) readStream(ctx context.Context, resp *http.Response, streamingFunc func(ctx context.Context, chunk []byte) error) (*llms.ContentResponse, error) {
+	var content strings.Builder
+	generationInfo := map[string]interface{}{}
+	/* tool_calls มาเป็นชิ้น ๆ ตาม index ต้องต่อ arguments เอง */
+	toolCalls := make([]llms.ToolCall, 0)
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk struct {
+			Model string `json:"model"`
+			Usage *struct {
+				PromptTokens     int `json:"prompt_tokens"`
+				CompletionTokens int `json:"completion_tokens"`
+				TotalTokens      int `json:"total_tokens"`
+			} `json:"usage"`
+			Choices []struct {
+				Delta struct {
+					Content   string `json:"content"`
+					ToolCalls []struct {
+						Index    int    `json:"index"`
+						Id       string `json:"id"`
+						Function struct {
+							Name      string `json:"name"`
+							Arguments string `json:"arguments"`
+						} `json:"function"`
+					} `json:"tool_calls"`
+				} `json:"delta"`
+			} `json:"choices"`
+		}
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return nil, fmt.Errorf("invalid stream chunk: %v", err)
+		}
+		if chunk.Model != "" {
+			generationInfo["model"] = chunk.Model
+		}
+		/* usage มากับ chunk สุดท้ายเมื่อขอ stream_options.include_usage */
+		if chunk.Usage != nil {
+			setTokenUsage(generationInfo, chunk.Usage.PromptTokens, chunk.Usage.CompletionTokens, chunk.Usage.TotalTokens)
+		}
+		if len(chunk.Choices) == 0 {
+			continue
+		}
+		for _, delta := range chunk.Choices[0].Delta.ToolCalls {
+			if delta.Index < 0 || delta.Index >= maxStreamToolCalls {
+				return nil, &agent.UpstreamError{Provider: o.Name(), StatusCode: resp.StatusCode, Message: fmt.Sprintf("invalid tool call index %d", delta.Index)}
+			}
+			for len(toolCalls) <= delta.Index {
+				toolCalls = append(toolCalls, llms.ToolCall{Type: "function", FunctionCall: &llms.FunctionCall{}})
+			}
+			call := &toolCalls[delta.Index]
+			if delta.Id != "" {
+				call.ID = delta.Id
+			}
+			call.FunctionCall.Name += delta.Function.Name
+			call.FunctionCall.Arguments += delta.Function.Arguments
+		}
+		if chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+		content.WriteString(chunk.Choices[0].Delta.Content)
+		if err := streamingFunc(ctx, []byte(chunk.Choices[0].Delta.Content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, requestError(ctx, o.Name(), err)
+	}
+
+	choice := &llms.ContentChoice{Content: content.String(), GenerationInfo: generationInfo}
+	if len(toolCalls) > 0 {
+		choice.ToolCalls = toolCalls
+	}
+	return &llms.ContentResponse{
+		Choices: []*llms.ContentChoice{choice},
+	}, nil
+}
+
+// Call ฟังก์ชันที่ LangChainGo ต้องการ (สำหรับ prompt เดี่ยว)
+func (o *openAICompatibleLLM) Call(ctx context.Context, prompt string, opts ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, o, prompt, opts...)
+}
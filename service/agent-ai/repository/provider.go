@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"healthmatefood-api/config"
+	"healthmatefood-api/service/agent-ai"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+/* LLMProvider คือ backend ของโมเดลที่ agent ใช้ ทุกตัวต้องรองรับ llms.WithStreamingFunc */
+type LLMProvider interface {
+	llms.Model
+	/* Name ชื่อโมเดลที่ใช้เมื่อ response ไม่ได้บอกชื่อโมเดลกลับมา */
+	Name() string
+}
+
+/* NewLLMProvider เลือก provider ตาม AGENT_PROVIDER แล้วครอบด้วย timeout, retry และ circuit breaker */
+func NewLLMProvider(cfg config.IAgentConfig) LLMProvider {
+	var provider LLMProvider
+	switch cfg.AgentProvider() {
+	case config.AGENT_PROVIDER_OPENAI:
+		provider = NewOpenAICompatibleLLM(cfg.AgentEndpoint(), cfg.AgentAccessKey(), cfg.AgentModel())
+	case config.AGENT_PROVIDER_OLLAMA:
+		provider = NewOllamaLLM(cfg.AgentEndpoint(), cfg.AgentModel())
+	case config.AGENT_PROVIDER_FAKE:
+		provider = NewFakeLLM()
+	default:
+		provider = NewDigitalOceanLLM(cfg.AgentEndpoint(), cfg.AgentAccessKey())
+	}
+	return NewResilientLLM(provider, cfg)
+}
+
+/* callOptions รวม llms.CallOption ทั้งหมดเป็น struct เดียว */
+func callOptions(opts []llms.CallOption) llms.CallOptions {
+	options := llms.CallOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+/* messageRole แปลง role ของ langchaingo เป็น role ของ chat API */
+func messageRole(role llms.ChatMessageType) string {
+	switch role {
+	case llms.ChatMessageTypeSystem:
+		return "system"
+	case llms.ChatMessageTypeAI:
+		return "assistant"
+	case llms.ChatMessageTypeTool:
+		return "tool"
+	default:
+		return "user"
+	}
+}
+
+/* messageText รวม text ทุก part ของข้อความ */
+func messageText(message llms.MessageContent) string {
+	texts := make([]string, 0, len(message.Parts))
+	for _, part := range message.Parts {
+		if text, ok := part.(llms.TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+/* messageToolCalls tool ที่โมเดลขอเรียกในข้อความของ assistant */
+func messageToolCalls(message llms.MessageContent) []llms.ToolCall {
+	calls := make([]llms.ToolCall, 0)
+	for _, part := range message.Parts {
+		if call, ok := part.(llms.ToolCall); ok && call.FunctionCall != nil {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+/* messageToolResponses ผลของ tool ในข้อความ role tool หนึ่งข้อความอาจมีผลของหลาย tool */
+func messageToolResponses(message llms.MessageContent) []llms.ToolCallResponse {
+	responses := make([]llms.ToolCallResponse, 0)
+	for _, part := range message.Parts {
+		if response, ok := part.(llms.ToolCallResponse); ok {
+			responses = append(responses, response)
+		}
+	}
+	return responses
+}
+
+/* messageContent เนื้อหาข้อความแบบ OpenAI ถ้ามีรูปภาพจะเป็น array ของ text และ image_url ถ้าไม่มีเป็น string เหมือนเดิม */
+func messageContent(message llms.MessageContent) interface{} {
+	hasImage := false
+	parts := make([]map[string]interface{}, 0, len(message.Parts))
+	for _, part := range message.Parts {
+		switch content := part.(type) {
+		case llms.TextContent:
+			parts = append(parts, map[string]interface{}{"type": "text", "text": content.Text})
+		case llms.ImageURLContent:
+			imageURL := map[string]interface{}{"url": content.URL}
+			if content.Detail != "" {
+				imageURL["detail"] = content.Detail
+			}
+			parts = append(parts, map[string]interface{}{"type": "image_url", "image_url": imageURL})
+			hasImage = true
+		case llms.BinaryContent:
+			if !strings.HasPrefix(content.MIMEType, "image/") {
+				continue
+			}
+			parts = append(parts, map[string]interface{}{"type": "image_url", "image_url": map[string]interface{}{"url": imageDataURL(content)}})
+			hasImage = true
+		}
+	}
+	if !hasImage {
+		return messageText(message)
+	}
+	return parts
+}
+
+/* messageImages รูปภาพในข้อความเป็น base64 สำหรับ provider ที่รับรูปแยกจากข้อความ (Ollama) รับเฉพาะรูปที่แนบมาหรือ data url */
+func messageImages(message llms.MessageContent) []string {
+	images := make([]string, 0)
+	for _, part := range message.Parts {
+		switch content := part.(type) {
+		case llms.BinaryContent:
+			if strings.HasPrefix(content.MIMEType, "image/") {
+				images = append(images, base64.StdEncoding.EncodeToString(content.Data))
+			}
+		case llms.ImageURLContent:
+			if _, data, ok := strings.Cut(content.URL, ";base64,"); ok && strings.HasPrefix(content.URL, "data:") {
+				images = append(images, data)
+			}
+		}
+	}
+	return images
+}
+
+func imageDataURL(image llms.BinaryContent) string {
+	return fmt.Sprintf("data:%s;base64,%s", image.MIMEType, base64.StdEncoding.EncodeToString(image.Data))
+}
+
+/* setTokenUsage เก็บจำนวน token ใน GenerationInfo ด้วย key เดียวกับ provider openai ของ langchaingo */
+func setTokenUsage(generationInfo map[string]interface{}, promptTokens int, completionTokens int, totalTokens int) {
+	if totalTokens == 0 {
+		totalTokens = promptTokens + completionTokens
+	}
+	generationInfo["PromptTokens"] = promptTokens
+	generationInfo["CompletionTokens"] = completionTokens
+	generationInfo["TotalTokens"] = totalTokens
+}
+
+/* responseError อ่าน body ของ response ที่ผิดพลาดเป็น UpstreamError พร้อม Retry-After */
+func responseError(provider string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4*1024))
+	return &agent.UpstreamError{
+		Provider:   provider,
+		StatusCode: resp.StatusCode,
+		Message:    upstreamMessage(body, resp.Status),
+		RetryAfter: retryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+/* requestError แปลงข้อผิดพลาดระหว่างเรียก provider เป็น UpstreamError ยกเว้นกรณีที่ ctx ของผู้เรียกถูก cancel */
+func requestError(ctx context.Context, provider string, err error) error {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return ctx.Err()
+	}
+	var netErr net.Error
+	timeout := errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+	return &agent.UpstreamError{
+		Provider: provider,
+		Message:  err.Error(),
+		Timeout:  timeout,
+	}
+}
+
+/* upstreamMessage ดึงข้อความจาก body รูปแบบ {"error":{"message":..}}, {"error":..} หรือ {"message":..} ถ้าไม่ใช่ JSON ใช้ body ตรง ๆ */
+func upstreamMessage(body []byte, status string) string {
+	var payload struct {
+		Error   json.RawMessage `json:"error"`
+		Message string          `json:"message"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		var nested struct {
+			Message string `json:"message"`
+		}
+		var text string
+		switch {
+		case json.Unmarshal(payload.Error, &nested) == nil && nested.Message != "":
+			return nested.Message
+		case json.Unmarshal(payload.Error, &text) == nil && text != "":
+			return text
+		case payload.Message != "":
+			return payload.Message
+		}
+	}
+	if message := strings.TrimSpace(string(body)); message != "" {
+		return message
+	}
+	return status
+}
+
+/* retryAfter รองรับทั้งจำนวนวินาทีและ HTTP date */
+func retryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
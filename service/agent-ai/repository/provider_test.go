@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"healthmatefood-api/config"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/agent-ai"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmc/langchaingo/llms"
+)
+
+type agentConfig struct {
+	provider string
+	endpoint string
+	model    string
+}
+
+func (a agentConfig) AgentProvider() string  { return a.provider }
+func (a agentConfig) AgentAccessKey() string { return "test" }
+func (a agentConfig) AgentEndpoint() string  { return a.endpoint }
+func (a agentConfig) AgentModel() string     { return a.model }
+func (a agentConfig) AgentVisionModel() string {
+	return a.model
+}
+func (a agentConfig) AgentTimeout() time.Duration {
+	return time.Second
+}
+func (a agentConfig) AgentMaxRetries() int       { return 2 }
+func (a agentConfig) AgentBreakerThreshold() int { return 3 }
+func (a agentConfig) AgentBreakerCooldown() time.Duration {
+	return time.Minute
+}
+func (a agentConfig) AgentCache() string { return config.AGENT_CACHE_MEMORY }
+func (a agentConfig) AgentCacheTTL() time.Duration {
+	return time.Hour
+}
+func (a agentConfig) AgentCacheMaxEntries() int   { return 2 }
+func (a agentConfig) AgentMaxToolIterations() int { return 2 }
+
+func TestNewLLMProvider(t *testing.T) {
+	cases := map[string]string{
+		config.AGENT_PROVIDER_DIGITAL_OCEAN: digitalOceanAgentName,
+		config.AGENT_PROVIDER_OPENAI:        "gpt-4o-mini",
+		config.AGENT_PROVIDER_OLLAMA:        "gpt-4o-mini",
+		config.AGENT_PROVIDER_FAKE:          fakeLLMName,
+	}
+	for provider, name := range cases {
+		llm := NewLLMProvider(agentConfig{provider: provider, endpoint: "http://localhost", model: "gpt-4o-mini"})
+		assert.Equal(t, name, llm.Name(), provider)
+	}
+}
+
+func TestOpenAICompatibleLLM(t *testing.T) {
+	var request map[string]interface{}
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"model":   "gpt-4o-mini-2024",
+			"choices": []interface{}{map[string]interface{}{"message": map[string]interface{}{"role": "assistant", "content": "สวัสดี"}}},
+			"usage":   map[string]interface{}{"prompt_tokens": 42, "completion_tokens": 3, "total_tokens": 45},
+		})
+	}))
+	defer server.Close()
+	llm := NewOpenAICompatibleLLM(server.URL+"/v1/", "test", "gpt-4o-mini")
+
+	resp, err := llm.GenerateContent(t.Context(), []llms.MessageContent{
+		{Role: llms.ChatMessageTypeSystem, Parts: []llms.ContentPart{llms.TextContent{Text: "ตอบภาษาไทย"}, llms.TextContent{Text: "สั้น ๆ"}}},
+		{Role: llms.ChatMessageTypeAI, Parts: []llms.ContentPart{llms.TextContent{Text: "มีอะไรให้ช่วย"}}},
+		{Role: llms.ChatMessageTypeTool, Parts: []llms.ContentPart{llms.TextContent{Text: "{}"}}},
+		{Role: llms.ChatMessageTypeHuman, Parts: []llms.ContentPart{llms.TextContent{Text: "hello"}}},
+	}, llms.WithTemperature(0.2), llms.WithMaxTokens(256), llms.WithJSONMode())
+	assert.NoError(t, err)
+	assert.Equal(t, "/v1/chat/completions", path)
+	assert.Equal(t, "สวัสดี", resp.Choices[0].Content)
+	assert.Equal(t, "gpt-4o-mini-2024", resp.Choices[0].GenerationInfo["model"])
+	assert.Equal(t, 42, resp.Choices[0].GenerationInfo["PromptTokens"])
+	assert.Equal(t, 3, resp.Choices[0].GenerationInfo["CompletionTokens"])
+	assert.Equal(t, 45, resp.Choices[0].GenerationInfo["TotalTokens"])
+
+	assert.Equal(t, "gpt-4o-mini", request["model"])
+	assert.Equal(t, 0.2, request["temperature"])
+	assert.Equal(t, float64(256), request["max_tokens"])
+	assert.Equal(t, map[string]interface{}{"type": "json_object"}, request["response_format"])
+	messages := request["messages"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"role": "system", "content": "ตอบภาษาไทย\nสั้น ๆ"}, messages[0])
+	roles := []string{}
+	for _, message := range messages {
+		roles = append(roles, message.(map[string]interface{})["role"].(string))
+	}
+	assert.Equal(t, []string{"system", "assistant", "tool", "user"}, roles)
+}
+
+func TestOllamaLLM(t *testing.T) {
+	requests := []map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/chat", r.URL.Path)
+		body := map[string]interface{}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		requests = append(requests, body)
+		if body["stream"] == true {
+			for _, content := range []string{"ข้าว", "ต้ม"} {
+				fmt.Fprintf(w, `{"model":"llama3","message":{"role":"assistant","content":%q},"done":false}`+"\n", content)
+			}
+			fmt.Fprint(w, `{"model":"llama3","message":{"role":"assistant","content":""},"done":true}`+"\n")
+			return
+		}
+		fmt.Fprint(w, `{"model":"llama3","message":{"role":"assistant","content":"ข้าวต้ม"},"done":true}`)
+	}))
+	defer server.Close()
+	llm := NewOllamaLLM(server.URL, "llama3")
+
+	t.Run("success", func(t *testing.T) {
+		answer, err := llm.Call(t.Context(), "มื้อเช้า", llms.WithMaxTokens(100))
+		assert.NoError(t, err)
+		assert.Equal(t, "ข้าวต้ม", answer)
+		assert.Equal(t, "llama3", requests[0]["model"])
+		assert.Equal(t, map[string]interface{}{"num_predict": float64(100)}, requests[0]["options"])
+	})
+	t.Run("success_stream", func(t *testing.T) {
+		chunks := []string{}
+		resp, err := llm.GenerateContent(t.Context(), []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "มื้อเช้า")},
+			llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+				chunks = append(chunks, string(chunk))
+				return nil
+			}))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ข้าว", "ต้ม"}, chunks)
+		assert.Equal(t, "ข้าวต้ม", resp.Choices[0].Content)
+		assert.Equal(t, "llama3", resp.Choices[0].GenerationInfo["model"])
+	})
+}
+
+func TestFakeLLM(t *testing.T) {
+	t.Run("success_meal_plan_retry", func(t *testing.T) {
+		llm := NewFakeLLM("ไม่ใช่ JSON", validMealPlan)
+		repo := &agentAIRepository{llm: llm, promptUs: newTestPromptUsecase()}
+		user := &models.User{UserInfo: &models.UserInfo{Gender: "MALE", Age: 30, Weight: 70, Height: 175, CaloriesLimit: 2200}}
+
+		plan, err := repo.GenerateMealsPlan(t.Context(), user, &models.MealPlanOption{Days: models.DEFAULT_MEAL_PLAN_DAYS})
+		assert.NoError(t, err)
+		assert.Len(t, plan.Days, 3)
+		assert.Equal(t, fakeLLMName, plan.Model)
+		assert.Len(t, llm.Calls(), 2)
+	})
+	t.Run("success_summarize", func(t *testing.T) {
+		llm := NewFakeLLM()
+		repo := &agentAIRepository{llm: llm, promptUs: newTestPromptUsecase()}
+
+		summary, err := repo.SummarizeConversation(t.Context(), nil, "ผู้ใช้เป็นเบาหวาน", []*models.ConversationMessage{
+			{Role: models.ChatRoleUser, Content: "กินทุเรียนได้ไหม"},
+		})
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(summary, "fake: "))
+		assert.Contains(t, summary, "ผู้ใช้เป็นเบาหวาน")
+		assert.Contains(t, summary, "USER: กินทุเรียนได้ไหม")
+		assert.Contains(t, messageText(llm.Calls()[0][0]), "เป็นภาษาไทยไม่เกิน")
+	})
+	t.Run("success_summarize_language", func(t *testing.T) {
+		llm := NewFakeLLM()
+		repo := &agentAIRepository{llm: llm, promptUs: newTestPromptUsecase()}
+
+		_, err := repo.SummarizeConversation(t.Context(), &models.UserInfo{Language: models.LanguageEnglish}, "", []*models.ConversationMessage{
+			{Role: models.ChatRoleUser, Content: "can I eat durian?"},
+		})
+		assert.NoError(t, err)
+		assert.Contains(t, messageText(llm.Calls()[0][0]), "เป็นภาษาอังกฤษไม่เกิน")
+	})
+	t.Run("error_canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		_, err := NewFakeLLM().Call(ctx, "hello")
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestMessageContentWithImage(t *testing.T) {
+	msg := llms.MessageContent{Role: llms.ChatMessageTypeHuman, Parts: []llms.ContentPart{
+		llms.TextContent{Text: "ประเมินรูปนี้"},
+		llms.BinaryPart("image/png", []byte("png")),
+	}}
+
+	parts := messageContent(msg).([]map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "text", "text": "ประเมินรูปนี้"}, parts[0])
+	assert.Equal(t, "image_url", parts[1]["type"])
+	assert.Equal(t, map[string]interface{}{"url": "data:image/png;base64,cG5n"}, parts[1]["image_url"])
+	assert.Equal(t, []string{"cG5n"}, messageImages(msg))
+	assert.Equal(t, "ประเมินรูปนี้", messageContent(llms.TextParts(llms.ChatMessageTypeHuman, "ประเมินรูปนี้")))
+}
+
+func TestOpenAICompatibleLLMTools(t *testing.T) {
+	var request map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []interface{}{map[string]interface{}{"message": map[string]interface{}{
+				"role":    "assistant",
+				"content": nil,
+				"tool_calls": []interface{}{map[string]interface{}{
+					"id": "call_1", "type": "function",
+					"function": map[string]interface{}{"name": models.AgentToolSearchFoods, "arguments": `{"query":"ส้มตำ"}`},
+				}},
+			}}},
+		})
+	}))
+	defer server.Close()
+	llm := NewOpenAICompatibleLLM(server.URL, "test", "gpt-4o-mini")
+
+	resp, err := llm.GenerateContent(t.Context(), []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeHuman, "ส้มตำกี่แคล"),
+		{Role: llms.ChatMessageTypeAI, Parts: []llms.ContentPart{llms.ToolCall{ID: "call_0", Type: "function", FunctionCall: &llms.FunctionCall{Name: models.AgentToolUserDiseases, Arguments: "{}"}}}},
+		{Role: llms.ChatMessageTypeTool, Parts: []llms.ContentPart{llms.ToolCallResponse{ToolCallID: "call_0", Name: models.AgentToolUserDiseases, Content: `{"diseases":[]}`}}},
+	}, llms.WithTools([]llms.Tool{{Type: "function", Function: &llms.FunctionDefinition{Name: models.AgentToolSearchFoods}}}))
+	assert.NoError(t, err)
+	assert.Equal(t, "", resp.Choices[0].Content)
+	assert.Equal(t, []llms.ToolCall{{ID: "call_1", Type: "function", FunctionCall: &llms.FunctionCall{Name: models.AgentToolSearchFoods, Arguments: `{"query":"ส้มตำ"}`}}}, resp.Choices[0].ToolCalls)
+
+	tools := request["tools"].([]interface{})
+	assert.Equal(t, models.AgentToolSearchFoods, tools[0].(map[string]interface{})["function"].(map[string]interface{})["name"])
+	messages := request["messages"].([]interface{})
+	toolCalls := messages[1].(map[string]interface{})["tool_calls"].([]interface{})
+	assert.Equal(t, "call_0", toolCalls[0].(map[string]interface{})["id"])
+	assert.Equal(t, map[string]interface{}{"role": "tool", "tool_call_id": "call_0", "content": `{"diseases":[]}`}, messages[2])
+}
+
+func TestOpenAICompatibleLLMStreamTools(t *testing.T) {
+	for name, tc := range map[string]struct {
+		chunks []string
+		err    string
+	}{
+		"success": {chunks: []string{
+			`{"choices":[{"delta":{"tool_calls":[{"index":0,"id":"call_1","function":{"name":"search_foods","arguments":"{\"query\":"}}]}}]}`,
+			`{"choices":[{"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"ส้มตำ\"}"}}]}}]}`,
+		}},
+		"error_negative_index":  {chunks: []string{`{"choices":[{"delta":{"tool_calls":[{"index":-1,"id":"call_1"}]}}]}`}, err: "invalid tool call index -1"},
+		"error_index_too_large": {chunks: []string{`{"choices":[{"delta":{"tool_calls":[{"index":1000000,"id":"call_1"}]}}]}`}, err: "invalid tool call index 1000000"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				for _, chunk := range tc.chunks {
+					fmt.Fprintf(w, "data: %s\n\n", chunk)
+				}
+				fmt.Fprint(w, "data: [DONE]\n\n")
+			}))
+			defer server.Close()
+			llm := NewOpenAICompatibleLLM(server.URL, "test", "gpt-4o-mini")
+
+			resp, err := llm.GenerateContent(t.Context(), []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "ส้มตำกี่แคล")},
+				llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error { return nil }))
+			if tc.err != "" {
+				var upstreamErr *agent.UpstreamError
+				assert.ErrorAs(t, err, &upstreamErr)
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, []llms.ToolCall{{ID: "call_1", Type: "function", FunctionCall: &llms.FunctionCall{Name: "search_foods", Arguments: `{"query":"ส้มตำ"}`}}}, resp.Choices[0].ToolCalls)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"healthmatefood-api/config"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/agent-ai"
+	"math/rand/v2"
+	"time"
+
+	"github.com/spf13/cast"
+	"github.com/tmc/langchaingo/llms"
+)
+
+/* ช่วงเวลารอก่อนลองใหม่ เริ่มที่ retryBaseDelay แล้วเพิ่มเท่าตัวจนถึง retryMaxDelay ถ้า Retry-After นานกว่า retryMaxDelay จะไม่รอ */
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 10 * time.Second
+)
+
+/* resilientLLM ครอบ provider ด้วย timeout ต่อครั้ง, retry แบบ exponential backoff + jitter และ circuit breaker */
+type resilientLLM struct {
+	LLMProvider
+	timeout    time.Duration
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	breaker    *circuitBreaker
+}
+
+func NewResilientLLM(provider LLMProvider, cfg config.IAgentConfig) *resilientLLM {
+	return &resilientLLM{
+		LLMProvider: provider,
+		timeout:     cfg.AgentTimeout(),
+		maxRetries:  cfg.AgentMaxRetries(),
+		baseDelay:   retryBaseDelay,
+		maxDelay:    retryMaxDelay,
+		breaker:     newCircuitBreaker(cfg.AgentBreakerThreshold(), cfg.AgentBreakerCooldown()),
+	}
+}
+
+/* GenerateContent ลองใหม่เฉพาะ 429, 5xx และเชื่อมต่อไม่ได้ ถ้า stream ส่ง token ออกไปแล้วจะไม่ลองใหม่เพื่อไม่ให้ client ได้ข้อความซ้ำ */
+func (r *resilientLLM) GenerateContent(ctx context.Context, messages []llms.MessageContent, opts ...llms.CallOption) (*llms.ContentResponse, error) {
+	streamed := false
+	if streamingFunc := callOptions(opts).StreamingFunc; streamingFunc != nil {
+		opts = append(opts, llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+			streamed = true
+			return streamingFunc(ctx, chunk)
+		}))
+	}
+
+	for attempt := 0; ; attempt++ {
+		if !r.breaker.Allow() {
+			return nil, &agent.UpstreamError{Provider: r.Name(), CircuitOpen: true}
+		}
+		resp, err := r.generate(ctx, messages, opts)
+		if err == nil {
+			r.breaker.Success()
+			r.meter(ctx, resp)
+			return resp, nil
+		}
+
+		var upstreamErr *agent.UpstreamError
+		if !errors.As(err, &upstreamErr) {
+			r.breaker.Release()
+			return nil, err
+		}
+		if !upstreamErr.Timeout && !upstreamErr.Retryable() {
+			/* ต้นทางยังตอบได้ เพียงแต่ request ไม่ถูกต้อง */
+			r.breaker.Success()
+			return nil, err
+		}
+		r.breaker.Failure()
+		if upstreamErr.Timeout || streamed || attempt >= r.maxRetries {
+			return nil, err
+		}
+
+		delay, ok := r.backoff(attempt, upstreamErr.RetryAfter)
+		if !ok {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+/* meter บันทึก token ของคำตอบลง AIUsageMeter ที่อยู่ใน ctx (ถ้ามี) */
+func (r *resilientLLM) meter(ctx context.Context, resp *llms.ContentResponse) {
+	meter := models.AIUsageMeterFromContext(ctx)
+	if meter == nil || len(resp.Choices) == 0 {
+		return
+	}
+	info := resp.Choices[0].GenerationInfo
+	model, _ := info["model"].(string)
+	if model == "" {
+		model = r.Name()
+	}
+	meter.Add(model, cast.ToInt(info["PromptTokens"]), cast.ToInt(info["CompletionTokens"]), cast.ToInt(info["TotalTokens"]))
+}
+
+/* generate เรียก provider หนึ่งครั้งภายใต้ timeout */
+func (r *resilientLLM) generate(ctx context.Context, messages []llms.MessageContent, opts []llms.CallOption) (*llms.ContentResponse, error) {
+	if r.timeout <= 0 {
+		return r.LLMProvider.GenerateContent(ctx, messages, opts...)
+	}
+	callCtx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	resp, err := r.LLMProvider.GenerateContent(callCtx, messages, opts...)
+	if err != nil && ctx.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		return nil, &agent.UpstreamError{Provider: r.Name(), Message: err.Error(), Timeout: true}
+	}
+	return resp, err
+}
+
+/* backoff คืนเวลารอก่อนครั้งถัดไป ถ้าต้นทางส่ง Retry-After มาจะใช้ค่านั้น ไม่เช่นนั้นสุ่มระหว่างครึ่งหนึ่งถึงเต็มของ base*2^attempt */
+func (r *resilientLLM) backoff(attempt int, retryAfter time.Duration) (time.Duration, bool) {
+	if retryAfter > 0 {
+		return retryAfter, retryAfter <= r.maxDelay
+	}
+	ceiling := min(r.baseDelay<<attempt, r.maxDelay)
+	half := ceiling / 2
+	return half + time.Duration(rand.Int64N(int64(half)+1)), true
+}
+
+func (r *resilientLLM) Call(ctx context.Context, prompt string, opts ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, r, prompt, opts...)
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/agent-ai"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tmc/langchaingo/llms"
+)
+
+/* scriptedLLM ตอบ error ตามลำดับที่กำหนด หมดแล้วตอบสำเร็จ */
+type scriptedLLM struct {
+	errs  []error
+	calls int
+	block bool
+}
+
+func (s *scriptedLLM) Name() string { return "scripted" }
+
+func (s *scriptedLLM) GenerateContent(ctx context.Context, messages []llms.MessageContent, opts ...llms.CallOption) (*llms.ContentResponse, error) {
+	s.calls++
+	if s.block {
+		<-ctx.Done()
+		return nil, requestError(ctx, s.Name(), ctx.Err())
+	}
+	if options := callOptions(opts); options.StreamingFunc != nil {
+		if err := options.StreamingFunc(ctx, []byte("token")); err != nil {
+			return nil, err
+		}
+	}
+	if s.calls <= len(s.errs) {
+		return nil, s.errs[s.calls-1]
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "ok"}}}, nil
+}
+
+func (s *scriptedLLM) Call(ctx context.Context, prompt string, opts ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, s, prompt, opts...)
+}
+
+func newTestResilientLLM(provider LLMProvider) *resilientLLM {
+	llm := NewResilientLLM(provider, agentConfig{})
+	llm.baseDelay = time.Millisecond
+	llm.maxDelay = 50 * time.Millisecond
+	return llm
+}
+
+func TestResilientLLM(t *testing.T) {
+	unavailable := &agent.UpstreamError{Provider: "scripted", StatusCode: http.StatusServiceUnavailable, Message: "overloaded"}
+	t.Run("success_after_retry", func(t *testing.T) {
+		provider := &scriptedLLM{errs: []error{unavailable, &agent.UpstreamError{Provider: "scripted", StatusCode: http.StatusTooManyRequests, RetryAfter: time.Millisecond}}}
+		answer, err := newTestResilientLLM(provider).Call(t.Context(), "hello")
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", answer)
+		assert.Equal(t, 3, provider.calls)
+	})
+	t.Run("error_retries_exhausted", func(t *testing.T) {
+		provider := &scriptedLLM{errs: []error{unavailable, unavailable, unavailable}}
+		_, err := newTestResilientLLM(provider).Call(t.Context(), "hello")
+		status, ok := agent.UpstreamStatus(err)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusServiceUnavailable, status)
+		assert.Contains(t, err.Error(), "overloaded")
+		assert.Equal(t, 3, provider.calls)
+	})
+	t.Run("error_retry_after_too_long", func(t *testing.T) {
+		provider := &scriptedLLM{errs: []error{&agent.UpstreamError{Provider: "scripted", StatusCode: http.StatusTooManyRequests, RetryAfter: time.Hour}}}
+		_, err := newTestResilientLLM(provider).Call(t.Context(), "hello")
+		assert.Error(t, err)
+		assert.Equal(t, 1, provider.calls)
+	})
+	t.Run("error_bad_request_not_retried", func(t *testing.T) {
+		provider := &scriptedLLM{errs: []error{&agent.UpstreamError{Provider: "scripted", StatusCode: http.StatusBadRequest, Message: "context too long"}}}
+		_, err := newTestResilientLLM(provider).Call(t.Context(), "hello")
+		status, _ := agent.UpstreamStatus(err)
+		assert.Equal(t, http.StatusBadGateway, status)
+		assert.Equal(t, 1, provider.calls)
+	})
+	t.Run("error_stream_not_retried", func(t *testing.T) {
+		provider := &scriptedLLM{errs: []error{unavailable}}
+		_, err := newTestResilientLLM(provider).GenerateContent(t.Context(), []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, "hello")},
+			llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error { return nil }))
+		assert.Error(t, err)
+		assert.Equal(t, 1, provider.calls)
+	})
+	t.Run("error_timeout", func(t *testing.T) {
+		provider := &scriptedLLM{block: true}
+		llm := newTestResilientLLM(provider)
+		llm.timeout = 10 * time.Millisecond
+		_, err := llm.Call(t.Context(), "hello")
+		status, _ := agent.UpstreamStatus(err)
+		assert.Equal(t, http.StatusGatewayTimeout, status)
+		assert.Equal(t, 1, provider.calls)
+	})
+	t.Run("error_canceled_by_caller", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		provider := &scriptedLLM{block: true}
+		time.AfterFunc(10*time.Millisecond, cancel)
+		_, err := newTestResilientLLM(provider).Call(ctx, "hello")
+		assert.ErrorIs(t, err, context.Canceled)
+		_, ok := agent.UpstreamStatus(err)
+		assert.False(t, ok)
+	})
+}
+
+func TestResilientLLMCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	unavailable := &agent.UpstreamError{Provider: "scripted", StatusCode: http.StatusBadGateway}
+	provider := &scriptedLLM{errs: []error{unavailable, unavailable, unavailable, unavailable}}
+	llm := newTestResilientLLM(provider)
+	llm.maxRetries = 0
+	llm.breaker.now = func() time.Time { return now }
+
+	for range 3 {
+		_, err := llm.Call(t.Context(), "hello")
+		assert.Error(t, err)
+	}
+	_, err := llm.Call(t.Context(), "hello")
+	var upstreamErr *agent.UpstreamError
+	assert.True(t, errors.As(err, &upstreamErr))
+	assert.True(t, upstreamErr.CircuitOpen)
+	assert.Equal(t, http.StatusServiceUnavailable, upstreamErr.HTTPStatus())
+	assert.Equal(t, 3, provider.calls)
+
+	/* ครบ cooldown ปล่อยให้ลองหนึ่งครั้ง ล้มเหลวก็เปิดวงจรต่อ */
+	now = now.Add(time.Minute)
+	_, err = llm.Call(t.Context(), "hello")
+	assert.Equal(t, 4, provider.calls)
+	assert.False(t, errors.As(err, &upstreamErr) && upstreamErr.CircuitOpen)
+	_, err = llm.Call(t.Context(), "hello")
+	assert.True(t, errors.As(err, &upstreamErr) && upstreamErr.CircuitOpen)
+
+	now = now.Add(time.Minute)
+	answer, err := llm.Call(t.Context(), "hello")
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", answer)
+	_, err = llm.Call(t.Context(), "hello")
+	assert.NoError(t, err)
+}
+
+func TestResilientLLMMeterUsage(t *testing.T) {
+	meter := models.NewAIUsageMeter(nil, "/v1/chat", nil)
+	ctx := models.ContextWithAIUsageMeter(t.Context(), meter)
+	llm := newTestResilientLLM(NewFakeLLM("สวัสดีครับ"))
+
+	_, err := llms.GenerateFromSinglePrompt(ctx, llm, "ทักทายหน่อย")
+	assert.NoError(t, err)
+	_, err = llms.GenerateFromSinglePrompt(t.Context(), llm, "ไม่นับ")
+	assert.NoError(t, err)
+
+	usages := meter.Usages()
+	if assert.Len(t, usages, 1) {
+		assert.Equal(t, "/v1/chat", usages[0].Endpoint)
+		assert.Equal(t, fakeLLMName, usages[0].Model)
+		assert.True(t, usages[0].PromptTokens > 0)
+		assert.True(t, usages[0].CompletionTokens > 0)
+		assert.Equal(t, usages[0].PromptTokens+usages[0].CompletionTokens, usages[0].TotalTokens)
+	}
+}
+
+func TestOpenAICompatibleLLMUpstreamError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":{"message":"Rate limit reached","type":"requests"}}`))
+	}))
+	defer server.Close()
+
+	_, err := NewOpenAICompatibleLLM(server.URL, "test", "gpt-4o-mini").Call(t.Context(), "hello")
+	var upstreamErr *agent.UpstreamError
+	assert.True(t, errors.As(err, &upstreamErr))
+	assert.Equal(t, http.StatusTooManyRequests, upstreamErr.StatusCode)
+	assert.Equal(t, "Rate limit reached", upstreamErr.Message)
+	assert.Equal(t, 7*time.Second, upstreamErr.RetryAfter)
+	assert.True(t, upstreamErr.Retryable())
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"container/list"
+	"context"
+	stdsql "database/sql"
+	"encoding/json"
+	"errors"
+	"healthmatefood-api/config"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/agent-ai"
+	"sync"
+	"time"
+
+	"github.com/Pheethy/sqlx"
+)
+
+/* NewResponseCache เลือกที่เก็บ cache ตาม AGENT_CACHE คืน nil เมื่อปิด cache */
+func NewResponseCache(cfg config.IAgentConfig, psqlDB *sqlx.DB) agent.IResponseCache {
+	switch cfg.AgentCache() {
+	case config.AGENT_CACHE_POSTGRES:
+		return NewPostgresResponseCache(psqlDB, cfg.AgentCacheMaxEntries())
+	case config.AGENT_CACHE_MEMORY:
+		return NewMemoryResponseCache(cfg.AgentCacheMaxEntries())
+	default:
+		return nil
+	}
+}
+
+/* memoryResponseCache cache ในหน่วยความจำแบบ LRU เมื่อเกิน maxEntries จะลบรายการที่ไม่ได้ใช้นานที่สุด */
+type memoryResponseCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List
+	now        func() time.Time
+}
+
+func NewMemoryResponseCache(maxEntries int) *memoryResponseCache {
+	return &memoryResponseCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		now:        time.Now,
+	}
+}
+
+func (m *memoryResponseCache) Get(ctx context.Context, key string) (*models.CachedResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	response := element.Value.(*models.CachedResponse)
+	if response.IsExpired(m.now()) {
+		m.order.Remove(element)
+		delete(m.entries, key)
+		return nil, nil
+	}
+	response.Hits++
+	m.order.MoveToFront(element)
+
+	hit := *response
+	return &hit, nil
+}
+
+func (m *memoryResponseCache) Set(ctx context.Context, response *models.CachedResponse) error {
+	if m.maxEntries <= 0 {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := *response
+	if element, ok := m.entries[response.Key]; ok {
+		element.Value = &entry
+		m.order.MoveToFront(element)
+		return nil
+	}
+	m.entries[response.Key] = m.order.PushFront(&entry)
+	for m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*models.CachedResponse).Key)
+	}
+	return nil
+}
+
+/* postgresResponseCache cache ที่ใช้ร่วมกันทุก instance เก็บในตาราง ai_response_caches */
+type postgresResponseCache struct {
+	psqlDB     *sqlx.DB
+	maxEntries int
+}
+
+func NewPostgresResponseCache(psqlDB *sqlx.DB, maxEntries int) *postgresResponseCache {
+	return &postgresResponseCache{
+		psqlDB:     psqlDB,
+		maxEntries: maxEntries,
+	}
+}
+
+/* Get นับจำนวนครั้งที่ถูกใช้ไปพร้อมกับอ่าน */
+func (p *postgresResponseCache) Get(ctx context.Context, key string) (*models.CachedResponse, error) {
+	sql := `
+    WITH "hit" AS (
+      UPDATE "ai_response_caches" SET
+        "hits" = "hits" + 1
+      WHERE
+        "key" = $1::varchar
+      AND
+        "expires_at" > now()
+      RETURNING *
+    )
+    SELECT
+      to_jsonb("json_data")
+    FROM (
+      SELECT
+        "hit"."key",
+        "hit"."model",
+        "hit"."prompt_version",
+        "hit"."content",
+        "hit"."hits",
+        to_char("hit"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+        to_char("hit"."expires_at", 'yyyy-MM-dd HH24:MI:SS') "expires_at"
+      FROM
+        "hit"
+    ) AS "json_data"
+  `
+	stmt, err := p.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, key).Scan(&jsonData); err != nil {
+		if errors.Is(err, stdsql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	response := new(models.CachedResponse)
+	if err := json.Unmarshal(jsonData, &response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+/* Set บันทึกแล้วลบรายการที่หมดอายุและรายการเก่าที่เกิน maxEntries ใน transaction เดียว */
+func (p *postgresResponseCache) Set(ctx context.Context, response *models.CachedResponse) error {
+	if p.maxEntries <= 0 {
+		return nil
+	}
+	tx, err := p.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+    INSERT INTO "ai_response_caches" (
+      "key",
+      "model",
+      "prompt_version",
+      "content",
+      "hits",
+      "created_at",
+      "expires_at"
+    ) VALUES (
+      $1::varchar,
+      $2::varchar,
+      $3::varchar,
+      $4::text,
+      0,
+      $5::timestamp,
+      $6::timestamp
+    )
+    ON CONFLICT (key)
+    DO UPDATE SET
+      model=$7::varchar,
+      prompt_version=$8::varchar,
+      content=$9::text,
+      hits=0,
+      created_at=$10::timestamp,
+      expires_at=$11::timestamp
+  `,
+		/* Create */
+		response.Key,
+		response.Model,
+		response.PromptVersion,
+		response.Content,
+		response.CreatedAt,
+		response.ExpiresAt,
+		/* Update */
+		response.Model,
+		response.PromptVersion,
+		response.Content,
+		response.CreatedAt,
+		response.ExpiresAt,
+	); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+    DELETE FROM "ai_response_caches"
+    WHERE
+      "expires_at" <= now()
+    OR
+      "key" IN (
+        SELECT "key" FROM "ai_response_caches" ORDER BY "created_at" DESC OFFSET $1::int
+      )
+  `, p.maxEntries); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
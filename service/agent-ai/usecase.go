@@ -3,8 +3,19 @@ package agent
 import (
 	"context"
 	"healthmatefood-api/models"
+
+	"github.com/gofrs/uuid"
 )
 
 type IAgentAIUsecase interface {
-	GenerateMealsPlan(ctx context.Context, user *models.User) (string, error)
+	GenerateMealsPlan(ctx context.Context, user *models.User, option *models.MealPlanOption) (*models.MealPlan, error)
+	StreamMealsPlan(ctx context.Context, user *models.User, option *models.MealPlanOption, stream models.StreamFunc) (*models.MealPlan, error)
+	AnalyzeMealPhoto(ctx context.Context, userInfo *models.UserInfo, photo *models.MealPhoto) (*models.MealPhotoAnalysis, error)
+	SwapMealPlanMeal(ctx context.Context, user *models.User, planId *uuid.UUID, option *models.MealPlanSwapOption) (*models.MealPlan, *models.MealPlanSwap, error)
+}
+
+/* IAgentToolUsecase tool ที่โมเดลเรียกได้ระหว่างแชท ทำงานฝั่ง server ด้วยสิทธิ์ของผู้ใช้เจ้าของบทสนทนา */
+type IAgentToolUsecase interface {
+	Tools() []*models.AgentTool
+	ExecuteTool(ctx context.Context, userId *uuid.UUID, call *models.AgentToolCall) (string, error)
 }
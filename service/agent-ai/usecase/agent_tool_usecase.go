@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/agent-ai"
+	"healthmatefood-api/service/diary"
+	"healthmatefood-api/service/food"
+	"healthmatefood-api/service/user"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+/* จำนวนอาหารที่ค้นได้ต่อครั้ง ถ้าโมเดลไม่ระบุ limit */
+const defaultFoodSearchLimit = 5
+
+type agentToolUsecase struct {
+	foodUs  food.IFoodUsecase
+	diaryUs diary.IDiaryUsecase
+	userUs  user.IUserUsecase
+}
+
+func NewAgentToolUsecase(foodUs food.IFoodUsecase, diaryUs diary.IDiaryUsecase, userUs user.IUserUsecase) agent.IAgentToolUsecase {
+	return &agentToolUsecase{
+		foodUs:  foodUs,
+		diaryUs: diaryUs,
+		userUs:  userUs,
+	}
+}
+
+func (u *agentToolUsecase) Tools() []*models.AgentTool {
+	return models.AgentTools
+}
+
+/* ExecuteTool ผลลัพธ์เป็น JSON string ที่ส่งกลับให้โมเดลอ่าน */
+func (u *agentToolUsecase) ExecuteTool(ctx context.Context, userId *uuid.UUID, call *models.AgentToolCall) (string, error) {
+	args, err := call.DecodeArguments()
+	if err != nil {
+		return "", err
+	}
+
+	var result interface{}
+	switch call.Name {
+	case models.AgentToolSearchFoods:
+		result, err = u.searchFoods(ctx, args)
+	case models.AgentToolDiaryTotals:
+		result, err = u.diaryTotals(ctx, userId, args)
+	case models.AgentToolRemainingCalories:
+		result, err = u.remainingCalories(ctx, userId, args)
+	case models.AgentToolUserDiseases:
+		result, err = u.userDiseases(ctx, userId)
+	default:
+		return "", fmt.Errorf("unknown tool: %s", call.Name)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	content, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+func (u *agentToolUsecase) searchFoods(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	query := strings.TrimSpace(cast.ToString(args["query"]))
+	if query == "" {
+		return nil, errors.New("query: must not be empty")
+	}
+	limit := cast.ToInt(args["limit"])
+	if limit <= 0 || limit > 10 {
+		limit = defaultFoodSearchLimit
+	}
+
+	foodArgs := new(sync.Map)
+	foodArgs.Store("search_word", query)
+	foodArgs.Store("page", 1)
+	foodArgs.Store("per_page", limit)
+	foods, err := u.foodUs.FetchAllFoods(ctx, foodArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]map[string]interface{}, 0, len(foods))
+	for _, food := range foods {
+		results = append(results, map[string]interface{}{
+			"name":         food.Name,
+			"serving_size": food.ServingSize,
+			"serving_unit": food.ServingUnit,
+			"calories":     food.Calories,
+			"protein":      food.Protein,
+			"carbohydrate": food.Carbohydrate,
+			"fat":          food.Fat,
+		})
+	}
+	return map[string]interface{}{"query": query, "foods": results}, nil
+}
+
+func (u *agentToolUsecase) diaryTotals(ctx context.Context, userId *uuid.UUID, args map[string]interface{}) (interface{}, error) {
+	summary, err := u.dailySummary(ctx, userId, args)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"date":     summary.Date,
+		"consumed": summary.Consumed,
+		"meals":    summary.Meals,
+		"entries":  len(summary.Entries),
+	}, nil
+}
+
+func (u *agentToolUsecase) remainingCalories(ctx context.Context, userId *uuid.UUID, args map[string]interface{}) (interface{}, error) {
+	summary, err := u.dailySummary(ctx, userId, args)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"date":               summary.Date,
+		"calories_limit":     summary.CaloriesLimit,
+		"consumed_calories":  summary.Consumed.Calories,
+		"calories_burned":    summary.CaloriesBurned,
+		"remaining_calories": summary.Remaining.Calories,
+		"remaining":          summary.Remaining,
+	}, nil
+}
+
+func (u *agentToolUsecase) userDiseases(ctx context.Context, userId *uuid.UUID) (interface{}, error) {
+	userInfo, err := u.userUs.FetchOneUserInfoByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	diseases := make([]map[string]interface{}, 0, len(userInfo.Diseases))
+	for _, disease := range userInfo.Diseases {
+		diseases = append(diseases, map[string]interface{}{
+			"name":        disease.Name,
+			"description": disease.Description,
+		})
+	}
+	return map[string]interface{}{"diseases": diseases}, nil
+}
+
+/* dailySummary วันที่ที่โมเดลส่งมาต้องเป็น YYYY-MM-DD ไม่ส่งคือวันนี้ */
+func (u *agentToolUsecase) dailySummary(ctx context.Context, userId *uuid.UUID, args map[string]interface{}) (*models.DailySummary, error) {
+	date := helper.NewDateFromTime(time.Now())
+	if val := strings.TrimSpace(cast.ToString(args["date"])); val != "" {
+		if _, err := time.Parse(helper.DateLayout, val); err != nil {
+			return nil, errors.New("date: is not date format yyyy-MM-dd")
+		}
+		date = helper.NewDateFromString(val)
+	}
+	return u.diaryUs.FetchDailySummary(ctx, userId, &date)
+}
@@ -2,20 +2,92 @@ package usecase
 
 import (
 	"context"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
 	"healthmatefood-api/models"
 	"healthmatefood-api/service/agent-ai"
+	"healthmatefood-api/service/mealplan"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
 )
 
 type agentAIUsecase struct {
-	agentRepo agent.IAgentAIRepository
+	agentRepo    agent.IAgentAIRepository
+	mealPlanRepo mealplan.IMealPlanRepository
 }
 
-func NewAgentAIUsecase(agentRepo agent.IAgentAIRepository) agent.IAgentAIUsecase {
+func NewAgentAIUsecase(agentRepo agent.IAgentAIRepository, mealPlanRepo mealplan.IMealPlanRepository) agent.IAgentAIUsecase {
 	return &agentAIUsecase{
-		agentRepo: agentRepo,
+		agentRepo:    agentRepo,
+		mealPlanRepo: mealPlanRepo,
+	}
+}
+
+/* GenerateMealsPlan สร้างแผนอาหาร ถ้ารู้ว่าเป็นของผู้ใช้คนไหนจะบันทึกแผนเริ่มนับจากวันนี้ */
+func (u *agentAIUsecase) GenerateMealsPlan(ctx context.Context, user *models.User, option *models.MealPlanOption) (*models.MealPlan, error) {
+	plan, err := u.agentRepo.GenerateMealsPlan(ctx, user, option)
+	if err != nil {
+		return nil, err
+	}
+	return u.saveMealPlan(ctx, user, plan)
+}
+
+func (u *agentAIUsecase) StreamMealsPlan(ctx context.Context, user *models.User, option *models.MealPlanOption, stream models.StreamFunc) (*models.MealPlan, error) {
+	plan, err := u.agentRepo.StreamMealsPlan(ctx, user, option, stream)
+	if err != nil {
+		return nil, err
+	}
+	return u.saveMealPlan(ctx, user, plan)
+}
+
+/* AnalyzeMealPhoto ผลประเมินยังไม่ถูกบันทึก ผู้ใช้ต้องยืนยันผ่าน diary ก่อน */
+func (u *agentAIUsecase) AnalyzeMealPhoto(ctx context.Context, userInfo *models.UserInfo, photo *models.MealPhoto) (*models.MealPhotoAnalysis, error) {
+	return u.agentRepo.AnalyzeMealPhoto(ctx, userInfo, photo)
+}
+
+/* SwapMealPlanMeal เปลี่ยนมื้อหรือรายการในแผนที่บันทึกไว้ของผู้ใช้ แล้วบันทึกมื้อใหม่พร้อมประวัติการเปลี่ยน */
+func (u *agentAIUsecase) SwapMealPlanMeal(ctx context.Context, user *models.User, planId *uuid.UUID, option *models.MealPlanSwapOption) (*models.MealPlan, *models.MealPlanSwap, error) {
+	plan, err := u.mealPlanRepo.FetchOneMealPlanById(ctx, planId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if plan.UserId == nil || user.Id == nil || *plan.UserId != *user.Id {
+		return nil, nil, apperror.NotFound(constants.ERROR_MEAL_PLAN_NOT_FOUND)
+	}
+	target, err := plan.FindSwapTarget(option)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	swap, err := u.agentRepo.SwapMealPlanMeal(ctx, user, plan, target, option.Reason)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := u.mealPlanRepo.SwapMealPlanMeal(ctx, swap); err != nil {
+		return nil, nil, err
 	}
+	target.Apply(swap.After)
+	plan.CalculateTotals()
+
+	return plan, swap, nil
 }
 
-func (u *agentAIUsecase) GenerateMealsPlan(ctx context.Context, user *models.User) (string, error) {
-	return u.agentRepo.GenerateMealsPlan(ctx, user)
+func (u *agentAIUsecase) saveMealPlan(ctx context.Context, user *models.User, plan *models.MealPlan) (*models.MealPlan, error) {
+	if user.Id == nil || user.Id.IsNil() {
+		return plan, nil
+	}
+
+	startDate := helper.NewDateFromTime(time.Now())
+	plan.NewID()
+	plan.UserId = user.Id
+	plan.SetDateRange(&startDate)
+	plan.SetCreatedAt()
+	plan.SetUpdatedAt()
+	if err := u.mealPlanRepo.UpsertMealPlan(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
 }
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"healthmatefood-api/models"
+	agent_mocks "healthmatefood-api/service/agent-ai/mocks"
+	mealplan_mocks "healthmatefood-api/service/mealplan/mocks"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGenerateMealsPlan(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	user := &models.User{Id: &userId, UserInfo: &models.UserInfo{UserId: &userId}}
+	option := &models.MealPlanOption{Days: models.DEFAULT_MEAL_PLAN_DAYS}
+	agentRepo := new(agent_mocks.IAgentAIRepository)
+	agentRepo.On("GenerateMealsPlan", mock.Anything, user, option).Return(&models.MealPlan{}, nil)
+	mealPlanRepo := new(mealplan_mocks.IMealPlanRepository)
+	mealPlanRepo.On("UpsertMealPlan", mock.Anything, mock.MatchedBy(func(plan *models.MealPlan) bool {
+		return plan.Id != nil && *plan.UserId == userId && plan.StartDate != nil
+	})).Return(nil)
+
+	plan, err := NewAgentAIUsecase(agentRepo, mealPlanRepo).GenerateMealsPlan(t.Context(), user, option)
+	assert.NoError(t, err)
+	assert.Equal(t, userId, *plan.UserId)
+	mealPlanRepo.AssertExpectations(t)
+}
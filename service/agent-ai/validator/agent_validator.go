@@ -0,0 +1,207 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Pheethy/psql/helper"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofiber/fiber/v2"
+	"github.com/spf13/cast"
+)
+
+type Validation struct{}
+
+/* ValidateMealPlanOption ตรวจค่าที่ override ได้ตอนสร้างแผน (days, cuisine, budget, fresh) */
+func (v Validation) ValidateMealPlanOption() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		fields := apperror.Fields{}
+		validateMealPlanOption(params, &fields)
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+/* ValidateMealPlanProfile ตรวจข้อมูลร่างกายใน body ที่ใช้คำนวณพลังงานแทนข้อมูลผู้ใช้ที่บันทึกไว้ พร้อมค่าที่ override ได้เหมือน ValidateMealPlanOption */
+func (v Validation) ValidateMealPlanProfile() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		if params == nil {
+			return apperror.Validation(constants.ERROR_BODY_WAS_MISSING)
+		}
+		fields := apperror.Fields{}
+
+		key := "gender"
+		if gender, ok := params[key]; !ok {
+			fields.Missing(key)
+		} else if !slices.Contains(models.Genders, cast.ToString(gender)) {
+			fields.Add(key, "must be "+strings.Join(models.Genders, " or "))
+		}
+		for _, key := range []string{"weight", "height"} {
+			if val, ok := params[key]; !ok {
+				fields.Missing(key)
+			} else {
+				fields.Check(key, validation.Validate(val, validation.By(validatePositiveNumber)))
+			}
+		}
+		key = "active_level"
+		if activeLevel, ok := params[key]; !ok {
+			fields.Missing(key)
+		} else if !models.ActiveLevel(cast.ToString(activeLevel)).IsValid() {
+			fields.Add(key, constants.ERROR_VALUE_IS_INVALID)
+		}
+		key = "dob"
+		if dob, ok := params[key]; !ok {
+			fields.Missing(key)
+		} else {
+			fields.Check(key, validation.Validate(dob, validation.By(validateDOB)))
+		}
+		validateMealPlanOption(params, &fields)
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func validateMealPlanOption(params map[string]interface{}, fields *apperror.Fields) {
+	key := "days"
+	if days, ok := params[key]; ok {
+		fields.Check(key, validation.Validate(days, validation.By(validateDays)))
+	}
+	key = "cuisine"
+	if cuisine, ok := params[key]; ok {
+		if _, err := cast.ToStringE(cuisine); err != nil {
+			fields.Add(key, "is not type string")
+		}
+	}
+	key = "budget"
+	if budget, ok := params[key]; ok {
+		fields.Check(key, validation.Validate(budget, validation.By(validatePositiveNumber)))
+	}
+	key = "fresh"
+	if fresh, ok := params[key]; ok {
+		if _, err := cast.ToBoolE(fresh); err != nil {
+			fields.Add(key, "is not type boolean")
+		}
+	}
+}
+
+/* ValidateMealPhoto ต้องมีรูปหนึ่งรูปใน images ขนาดไม่เกิน MAX_MEAL_PHOTO_SIZE และเป็นชนิดที่โมเดลอ่านได้ */
+func (v Validation) ValidateMealPhoto() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := "images"
+		images, _ := c.Locals(key).([]*multipart.FileHeader)
+		if len(images) == 0 {
+			return apperror.Field(key, "was missing on form")
+		}
+		if len(images) > 1 {
+			return apperror.Field(key, "send only one photo")
+		}
+		if images[0].Size > models.MAX_MEAL_PHOTO_SIZE {
+			return apperror.Field(key, fmt.Sprintf("must not be larger than %d MB", models.MAX_MEAL_PHOTO_SIZE/(1024*1024)))
+		}
+		mimeType, err := detectContentType(images[0])
+		if err != nil {
+			return apperror.Field(key, err.Error())
+		}
+		if !slices.Contains(models.MealPhotoMIMETypes, mimeType) {
+			return apperror.Field(key, fmt.Sprintf("type %s is not supported, use %s", mimeType, strings.Join(models.MealPhotoMIMETypes, ", ")))
+		}
+		return c.Next()
+	}
+}
+
+/* ValidateMealPlanSwap plan_id และ meal_id ต้องเป็น uuid ส่วน item_id และ reason ไม่บังคับ */
+func (v Validation) ValidateMealPlanSwap() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		fields := apperror.Fields{}
+		key := "plan_id"
+		fields.Check(key, validation.Validate(c.Params(key), validation.By(helper.ValidateTypeUUID)))
+
+		params, _ := c.Locals("params").(map[string]interface{})
+		key = "meal_id"
+		fields.Check(key, validation.Validate(params[key], validation.Required, validation.By(helper.ValidateTypeUUID)))
+		key = "item_id"
+		if itemId, ok := params[key]; ok {
+			fields.Check(key, validation.Validate(itemId, validation.By(helper.ValidateTypeUUID)))
+		}
+		key = "reason"
+		if reason, ok := params[key]; ok {
+			if text, err := cast.ToStringE(reason); err != nil {
+				fields.Add(key, "is not type string")
+			} else if utf8.RuneCountInString(text) > models.MAX_MEAL_SWAP_REASON_LENGTH {
+				fields.Add(key, fmt.Sprintf("must not be longer than %d characters", models.MAX_MEAL_SWAP_REASON_LENGTH))
+			}
+		}
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func detectContentType(image *multipart.FileHeader) (string, error) {
+	file, err := image.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", err
+	}
+	return http.DetectContentType(head[:n]), nil
+}
+
+func validateDays(val interface{}) error {
+	days, err := cast.ToIntE(val)
+	if err != nil {
+		return errors.New("is not type integer")
+	}
+	if days < 1 || days > models.MAX_MEAL_PLAN_DAYS {
+		return fmt.Errorf("must be between 1 and %d", models.MAX_MEAL_PLAN_DAYS)
+	}
+	return nil
+}
+
+/* validateDOB รูปแบบเดียวกับที่ models.NewUserInfoWithParams อ่าน และต้องไม่เป็นวันในอนาคต */
+func validateDOB(val interface{}) error {
+	if err := helper.ValidateTypeString(val); err != nil {
+		return err
+	}
+	dob, err := time.Parse(helper.TimestampLayout, val.(string))
+	if err != nil {
+		return errors.New("is not date format yyyy-MM-dd HH:mm:ss")
+	}
+	if dob.After(time.Now()) {
+		return errors.New("must not be in the future")
+	}
+	return nil
+}
+
+func validatePositiveNumber(val interface{}) error {
+	number, err := cast.ToFloat64E(val)
+	if err != nil {
+		return errors.New("is not type number")
+	}
+	if number <= 0 {
+		return errors.New("must be greater than 0")
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package aiusage
+
+import "github.com/gofiber/fiber/v2"
+
+type IAIUsageHandler interface {
+	MeterUsage() fiber.Handler
+	FetchMyQuota(c *fiber.Ctx) error
+	FetchDailyReport(c *fiber.Ctx) error
+	FetchUserReport(c *fiber.Ctx) error
+	FetchAllQuotas(c *fiber.Ctx) error
+	UpdateQuota(c *fiber.Ctx) error
+}
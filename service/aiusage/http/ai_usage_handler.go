@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"context"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/aiusage"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cast"
+)
+
+/* reportDefaultDays ช่วงวันของรายงานเมื่อไม่ได้ส่ง start_date */
+const reportDefaultDays = 30
+
+type aiUsageHandler struct {
+	aiUsageUs aiusage.IAIUsageUsecase
+}
+
+func NewAIUsageHandler(aiUsageUs aiusage.IAIUsageUsecase) aiusage.IAIUsageHandler {
+	return &aiUsageHandler{
+		aiUsageUs: aiUsageUs,
+	}
+}
+
+/* MeterUsage ใช้ต่อจาก JwtAuth ตรวจโควตาของผู้ใช้ก่อนเรียก AI และบันทึก token ที่ใช้หลัง handler ทำงานเสร็จ (endpoint แบบ stream บันทึกเมื่อ stream จบ) request ที่ไม่มีผู้ใช้ถูกปฏิเสธ เพื่อไม่ให้เรียกโมเดลได้โดยไม่ผ่านโควตา */
+func (h *aiUsageHandler) MeterUsage() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.UserContext()
+		userId, _ := c.Locals("user_id").(*uuid.UUID)
+		if userId == nil {
+			return fiber.NewError(http.StatusUnauthorized, "no permission to access")
+		}
+		roleId := cast.ToInt64(c.Locals("role_id"))
+		status, err := h.aiUsageUs.CheckQuota(ctx, userId, roleId)
+		if err != nil {
+			return err
+		}
+		if status.Exceeded {
+			retryAfter := int(time.Until(status.ResetAt.ToTime()).Seconds()) + 1
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(max(retryAfter, 1)))
+			return apperror.RateLimited(constants.ERROR_AI_QUOTA_EXCEEDED).
+				WithExtension("reset_at", status.ResetAt).
+				WithExtension("quota", status)
+		}
+
+		meter := models.NewAIUsageMeter(userId, c.Route().Path, func(usages []*models.AIUsage) {
+			if err := h.aiUsageUs.RecordUsages(context.Background(), usages); err != nil {
+				logrus.Errorf("record ai usages failed: %v", err)
+			}
+		})
+		c.SetUserContext(models.ContextWithAIUsageMeter(ctx, meter))
+
+		err = c.Next()
+		if !meter.Deferred() {
+			meter.Done()
+		}
+		return err
+	}
+}
+
+// @Summary     FetchMyQuota
+// @Description Get token usage of signed-in user for today and this month against the quota of their role, null limit means unlimited
+// @Tags        ai-usage
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true "Bearer access token"
+// @Success     200 {object} map[string]interface{}
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/ai-usage/me [get]
+func (h *aiUsageHandler) FetchMyQuota(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId, _ := c.Locals("user_id").(*uuid.UUID)
+	if userId == nil {
+		return fiber.NewError(http.StatusUnauthorized, "no permission to access")
+	}
+
+	status, err := h.aiUsageUs.CheckQuota(ctx, userId, cast.ToInt64(c.Locals("role_id")))
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"quota": status,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     FetchDailyReport
+// @Description Admin only, token usage grouped by day, endpoint and model
+// @Tags        ai-usage
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true  "Bearer access token"
+// @Param       start_date    query  string false "example: 2025-01-01, default 30 days ago"
+// @Param       end_date      query  string false "example: 2025-01-31, default today"
+// @Param       user_id       query  string false "filter by user id"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     403 {object} constants.ErrorResponse
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/ai-usage/report/daily [get]
+func (h *aiUsageHandler) FetchDailyReport(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	args := reportArgs(c)
+
+	reports, err := h.aiUsageUs.FetchDailyReport(ctx, args)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"reports": reports,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     FetchUserReport
+// @Description Admin only, token usage grouped by user, highest usage first
+// @Tags        ai-usage
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true  "Bearer access token"
+// @Param       start_date    query  string false "example: 2025-01-01, default 30 days ago"
+// @Param       end_date      query  string false "example: 2025-01-31, default today"
+// @Param       user_id       query  string false "filter by user id"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     403 {object} constants.ErrorResponse
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/ai-usage/report/users [get]
+func (h *aiUsageHandler) FetchUserReport(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	args := reportArgs(c)
+
+	reports, err := h.aiUsageUs.FetchUserReport(ctx, args)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"reports": reports,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     FetchAllQuotas
+// @Description Admin only, daily and monthly token quotas of every role, null limit means unlimited
+// @Tags        ai-usage
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true "Bearer access token"
+// @Success     200 {object} map[string]interface{}
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     403 {object} constants.ErrorResponse
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/ai-usage/quotas [get]
+func (h *aiUsageHandler) FetchAllQuotas(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	quotas, err := h.aiUsageUs.FetchAllQuotas(ctx)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"quotas": quotas,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     UpdateQuota
+// @Description Admin only, set daily and monthly token quotas of a role, send null for unlimited
+// @Tags        ai-usage
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization       header   string  true "Bearer access token"
+// @Param       role_id             path     integer true "role id"
+// @Param       daily_token_limit   formData integer true "tokens per day, null for unlimited"
+// @Param       monthly_token_limit formData integer true "tokens per month, null for unlimited"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     403 {object} constants.ErrorResponse
+// @Failure     404 {object} constants.ErrorResponse "roles not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/ai-usage/quotas/{role_id} [put]
+func (h *aiUsageHandler) UpdateQuota(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	params := c.Locals("params").(map[string]interface{})
+	roleId := cast.ToInt64(c.Params("role_id"))
+
+	quota := models.NewAIQuotaWithParams(params, nil)
+	quota.RoleId = roleId
+	quota.SetUpdatedAt()
+
+	if err := h.aiUsageUs.UpsertQuota(ctx, quota); err != nil {
+		return err
+	}
+
+	quota, err := h.aiUsageUs.FetchOneQuotaByRoleId(ctx, roleId)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"quota": quota,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+/* reportArgs ช่วงวันที่ของรายงาน ค่าเริ่มต้นคือ 30 วันล่าสุดถึงวันนี้ */
+func reportArgs(c *fiber.Ctx) *sync.Map {
+	args := new(sync.Map)
+	now := time.Now()
+	startDate := helper.NewDateFromTime(now.AddDate(0, 0, -reportDefaultDays))
+	if dateStr := c.Query("start_date"); dateStr != "" {
+		startDate = helper.NewDateFromString(dateStr)
+	}
+	endDate := helper.NewDateFromTime(now)
+	if dateStr := c.Query("end_date"); dateStr != "" {
+		endDate = helper.NewDateFromString(dateStr)
+	}
+	args.Store("start_date", startDate.String())
+	args.Store("end_date", endDate.String())
+	if userId := uuid.FromStringOrNil(c.Query("user_id")); !userId.IsNil() {
+		args.Store("user_id", &userId)
+	}
+	return args
+}
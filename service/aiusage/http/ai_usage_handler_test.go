@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/middleware"
+	"healthmatefood-api/models"
+	aiusage_mocks "healthmatefood-api/service/aiusage/mocks"
+	"healthmatefood-api/utils"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMeterUsage(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	dailyLimit := 1000
+	newApp := func(handler *aiUsageHandler, next fiber.Handler) *fiber.App {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		app.Post("/v1/agent-ai/meals/me", func(c *fiber.Ctx) error {
+			c.Locals("user_id", &userId)
+			c.Locals("role_id", int64(1))
+			return c.Next()
+		}, handler.MeterUsage(), next)
+		return app
+	}
+	t.Run("success", func(t *testing.T) {
+		quota := &models.AIQuota{RoleId: 1, DailyTokenLimit: &dailyLimit}
+		aiUsageUs := new(aiusage_mocks.IAIUsageUsecase)
+		aiUsageUs.On("CheckQuota", mock.Anything, &userId, int64(1)).
+			Return(models.NewAIQuotaStatus(&userId, quota, 200, 200, time.Now()), nil)
+		aiUsageUs.On("RecordUsages", mock.Anything, mock.MatchedBy(func(usages []*models.AIUsage) bool {
+			return len(usages) == 1 && usages[0].Endpoint == "/v1/agent-ai/meals/me" && usages[0].TotalTokens == 150 && *usages[0].UserId == userId
+		})).Return(nil)
+		app := newApp(&aiUsageHandler{aiUsageUs: aiUsageUs}, func(c *fiber.Ctx) error {
+			models.AIUsageMeterFromContext(c.UserContext()).Add("gpt-test", 100, 50, 150)
+			return c.SendStatus(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/agent-ai/meals/me", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		aiUsageUs.AssertExpectations(t)
+	})
+	t.Run("success_stream", func(t *testing.T) {
+		quota := &models.AIQuota{RoleId: 1}
+		aiUsageUs := new(aiusage_mocks.IAIUsageUsecase)
+		aiUsageUs.On("CheckQuota", mock.Anything, &userId, int64(1)).
+			Return(models.NewAIQuotaStatus(&userId, quota, 0, 0, time.Now()), nil)
+		aiUsageUs.On("RecordUsages", mock.Anything, mock.MatchedBy(func(usages []*models.AIUsage) bool {
+			return len(usages) == 1 && usages[0].TotalTokens == 30
+		})).Return(nil)
+		app := newApp(&aiUsageHandler{aiUsageUs: aiUsageUs}, func(c *fiber.Ctx) error {
+			return utils.StreamSSE(c, func(ctx context.Context, send utils.SSESendFunc) {
+				models.AIUsageMeterFromContext(ctx).Add("gpt-test", 10, 20, 30)
+				send("done", map[string]interface{}{})
+			})
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/agent-ai/meals/me", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		io.ReadAll(resp.Body)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		aiUsageUs.AssertExpectations(t)
+	})
+	t.Run("error_anonymous", func(t *testing.T) {
+		aiUsageUs := new(aiusage_mocks.IAIUsageUsecase)
+		called := false
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		app.Post("/v1/agent-ai/meals", (&aiUsageHandler{aiUsageUs: aiUsageUs}).MeterUsage(), func(c *fiber.Ctx) error {
+			called = true
+			return c.SendStatus(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/agent-ai/meals", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.False(t, called)
+		aiUsageUs.AssertNotCalled(t, "CheckQuota", mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("error_quota_exceeded", func(t *testing.T) {
+		quota := &models.AIQuota{RoleId: 1, DailyTokenLimit: &dailyLimit}
+		aiUsageUs := new(aiusage_mocks.IAIUsageUsecase)
+		aiUsageUs.On("CheckQuota", mock.Anything, &userId, int64(1)).
+			Return(models.NewAIQuotaStatus(&userId, quota, 1200, 1200, time.Now()), nil)
+		called := false
+		app := newApp(&aiUsageHandler{aiUsageUs: aiUsageUs}, func(c *fiber.Ctx) error {
+			called = true
+			return c.SendStatus(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/agent-ai/meals/me", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.False(t, called)
+
+		retryAfter, err := strconv.Atoi(resp.Header.Get(fiber.HeaderRetryAfter))
+		assert.NoError(t, err)
+		assert.True(t, retryAfter > 0 && retryAfter <= 24*60*60+1)
+
+		assert.Equal(t, middleware.MIMEApplicationProblemJSON, resp.Header.Get(fiber.HeaderContentType))
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, "/problems/rate-limited", body["type"])
+		assert.Equal(t, float64(http.StatusTooManyRequests), body["status"])
+		assert.Equal(t, constants.ERROR_AI_QUOTA_EXCEEDED, body["detail"])
+		assert.NotEmpty(t, body["reset_at"])
+		assert.Equal(t, true, body["quota"].(map[string]interface{})["exceeded"])
+		aiUsageUs.AssertNotCalled(t, "RecordUsages", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,137 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IAIUsageHandler is an autogenerated mock type for the IAIUsageHandler type
+type IAIUsageHandler struct {
+	mock.Mock
+}
+
+// FetchAllQuotas provides a mock function with given fields: c
+func (_m *IAIUsageHandler) FetchAllQuotas(c *fiber.Ctx) error {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllQuotas")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*fiber.Ctx) error); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchDailyReport provides a mock function with given fields: c
+func (_m *IAIUsageHandler) FetchDailyReport(c *fiber.Ctx) error {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchDailyReport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*fiber.Ctx) error); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchMyQuota provides a mock function with given fields: c
+func (_m *IAIUsageHandler) FetchMyQuota(c *fiber.Ctx) error {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchMyQuota")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*fiber.Ctx) error); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchUserReport provides a mock function with given fields: c
+func (_m *IAIUsageHandler) FetchUserReport(c *fiber.Ctx) error {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchUserReport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*fiber.Ctx) error); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MeterUsage provides a mock function with no fields
+func (_m *IAIUsageHandler) MeterUsage() func(*fiber.Ctx) error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MeterUsage")
+	}
+
+	var r0 func(*fiber.Ctx) error
+	if rf, ok := ret.Get(0).(func() func(*fiber.Ctx) error); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func(*fiber.Ctx) error)
+		}
+	}
+
+	return r0
+}
+
+// UpdateQuota provides a mock function with given fields: c
+func (_m *IAIUsageHandler) UpdateQuota(c *fiber.Ctx) error {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateQuota")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*fiber.Ctx) error); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIAIUsageHandler creates a new instance of IAIUsageHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAIUsageHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAIUsageHandler {
+	mock := &IAIUsageHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
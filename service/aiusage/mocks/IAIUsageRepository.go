@@ -0,0 +1,256 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "healthmatefood-api/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sync "sync"
+
+	time "time"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IAIUsageRepository is an autogenerated mock type for the IAIUsageRepository type
+type IAIUsageRepository struct {
+	mock.Mock
+}
+
+// FetchAllQuotas provides a mock function with given fields: ctx
+func (_m *IAIUsageRepository) FetchAllQuotas(ctx context.Context) ([]*models.AIQuota, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllQuotas")
+	}
+
+	var r0 []*models.AIQuota
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.AIQuota, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.AIQuota); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AIQuota)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchDailyReport provides a mock function with given fields: ctx, args
+func (_m *IAIUsageRepository) FetchDailyReport(ctx context.Context, args *sync.Map) ([]*models.AIUsageDailyReport, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchDailyReport")
+	}
+
+	var r0 []*models.AIUsageDailyReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.AIUsageDailyReport, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.AIUsageDailyReport); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AIUsageDailyReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchOneQuotaByRoleId provides a mock function with given fields: ctx, roleId
+func (_m *IAIUsageRepository) FetchOneQuotaByRoleId(ctx context.Context, roleId int64) (*models.AIQuota, error) {
+	ret := _m.Called(ctx, roleId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOneQuotaByRoleId")
+	}
+
+	var r0 *models.AIQuota
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*models.AIQuota, error)); ok {
+		return rf(ctx, roleId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.AIQuota); ok {
+		r0 = rf(ctx, roleId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AIQuota)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, roleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchOneQuotaByUserId provides a mock function with given fields: ctx, userId
+func (_m *IAIUsageRepository) FetchOneQuotaByUserId(ctx context.Context, userId *uuid.UUID) (*models.AIQuota, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOneQuotaByUserId")
+	}
+
+	var r0 *models.AIQuota
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.AIQuota, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.AIQuota); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AIQuota)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchUserReport provides a mock function with given fields: ctx, args
+func (_m *IAIUsageRepository) FetchUserReport(ctx context.Context, args *sync.Map) ([]*models.AIUsageUserReport, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchUserReport")
+	}
+
+	var r0 []*models.AIUsageUserReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.AIUsageUserReport, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.AIUsageUserReport); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AIUsageUserReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertUsages provides a mock function with given fields: ctx, usages
+func (_m *IAIUsageRepository) InsertUsages(ctx context.Context, usages []*models.AIUsage) error {
+	ret := _m.Called(ctx, usages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertUsages")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.AIUsage) error); ok {
+		r0 = rf(ctx, usages)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SumTotalTokens provides a mock function with given fields: ctx, userId, dayStart, monthStart
+func (_m *IAIUsageRepository) SumTotalTokens(ctx context.Context, userId *uuid.UUID, dayStart time.Time, monthStart time.Time) (int, int, error) {
+	ret := _m.Called(ctx, userId, dayStart, monthStart)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumTotalTokens")
+	}
+
+	var r0 int
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, time.Time, time.Time) (int, int, error)); ok {
+		return rf(ctx, userId, dayStart, monthStart)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, time.Time, time.Time) int); ok {
+		r0 = rf(ctx, userId, dayStart, monthStart)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, time.Time, time.Time) int); ok {
+		r1 = rf(ctx, userId, dayStart, monthStart)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *uuid.UUID, time.Time, time.Time) error); ok {
+		r2 = rf(ctx, userId, dayStart, monthStart)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UpsertQuota provides a mock function with given fields: ctx, quota
+func (_m *IAIUsageRepository) UpsertQuota(ctx context.Context, quota *models.AIQuota) error {
+	ret := _m.Called(ctx, quota)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertQuota")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AIQuota) error); ok {
+		r0 = rf(ctx, quota)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIAIUsageRepository creates a new instance of IAIUsageRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAIUsageRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAIUsageRepository {
+	mock := &IAIUsageRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,249 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "healthmatefood-api/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sync "sync"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IAIUsageUsecase is an autogenerated mock type for the IAIUsageUsecase type
+type IAIUsageUsecase struct {
+	mock.Mock
+}
+
+// CheckQuota provides a mock function with given fields: ctx, userId, roleId
+func (_m *IAIUsageUsecase) CheckQuota(ctx context.Context, userId *uuid.UUID, roleId int64) (*models.AIQuotaStatus, error) {
+	ret := _m.Called(ctx, userId, roleId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckQuota")
+	}
+
+	var r0 *models.AIQuotaStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, int64) (*models.AIQuotaStatus, error)); ok {
+		return rf(ctx, userId, roleId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, int64) *models.AIQuotaStatus); ok {
+		r0 = rf(ctx, userId, roleId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AIQuotaStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, int64) error); ok {
+		r1 = rf(ctx, userId, roleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckUserQuota provides a mock function with given fields: ctx, userId
+func (_m *IAIUsageUsecase) CheckUserQuota(ctx context.Context, userId *uuid.UUID) (*models.AIQuotaStatus, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckUserQuota")
+	}
+
+	var r0 *models.AIQuotaStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.AIQuotaStatus, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.AIQuotaStatus); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AIQuotaStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchAllQuotas provides a mock function with given fields: ctx
+func (_m *IAIUsageUsecase) FetchAllQuotas(ctx context.Context) ([]*models.AIQuota, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllQuotas")
+	}
+
+	var r0 []*models.AIQuota
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.AIQuota, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*models.AIQuota); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AIQuota)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchDailyReport provides a mock function with given fields: ctx, args
+func (_m *IAIUsageUsecase) FetchDailyReport(ctx context.Context, args *sync.Map) ([]*models.AIUsageDailyReport, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchDailyReport")
+	}
+
+	var r0 []*models.AIUsageDailyReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.AIUsageDailyReport, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.AIUsageDailyReport); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AIUsageDailyReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchOneQuotaByRoleId provides a mock function with given fields: ctx, roleId
+func (_m *IAIUsageUsecase) FetchOneQuotaByRoleId(ctx context.Context, roleId int64) (*models.AIQuota, error) {
+	ret := _m.Called(ctx, roleId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOneQuotaByRoleId")
+	}
+
+	var r0 *models.AIQuota
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*models.AIQuota, error)); ok {
+		return rf(ctx, roleId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.AIQuota); ok {
+		r0 = rf(ctx, roleId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AIQuota)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, roleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchUserReport provides a mock function with given fields: ctx, args
+func (_m *IAIUsageUsecase) FetchUserReport(ctx context.Context, args *sync.Map) ([]*models.AIUsageUserReport, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchUserReport")
+	}
+
+	var r0 []*models.AIUsageUserReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.AIUsageUserReport, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.AIUsageUserReport); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AIUsageUserReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordUsages provides a mock function with given fields: ctx, usages
+func (_m *IAIUsageUsecase) RecordUsages(ctx context.Context, usages []*models.AIUsage) error {
+	ret := _m.Called(ctx, usages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordUsages")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.AIUsage) error); ok {
+		r0 = rf(ctx, usages)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertQuota provides a mock function with given fields: ctx, quota
+func (_m *IAIUsageUsecase) UpsertQuota(ctx context.Context, quota *models.AIQuota) error {
+	ret := _m.Called(ctx, quota)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertQuota")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AIQuota) error); ok {
+		r0 = rf(ctx, quota)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIAIUsageUsecase creates a new instance of IAIUsageUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAIUsageUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAIUsageUsecase {
+	mock := &IAIUsageUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
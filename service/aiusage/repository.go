@@ -0,0 +1,21 @@
+package aiusage
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type IAIUsageRepository interface {
+	InsertUsages(ctx context.Context, usages []*models.AIUsage) error
+	SumTotalTokens(ctx context.Context, userId *uuid.UUID, dayStart time.Time, monthStart time.Time) (int, int, error)
+	FetchAllQuotas(ctx context.Context) ([]*models.AIQuota, error)
+	FetchOneQuotaByRoleId(ctx context.Context, roleId int64) (*models.AIQuota, error)
+	FetchOneQuotaByUserId(ctx context.Context, userId *uuid.UUID) (*models.AIQuota, error)
+	UpsertQuota(ctx context.Context, quota *models.AIQuota) error
+	FetchDailyReport(ctx context.Context, args *sync.Map) ([]*models.AIUsageDailyReport, error)
+	FetchUserReport(ctx context.Context, args *sync.Map) ([]*models.AIUsageUserReport, error)
+}
@@ -0,0 +1,393 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/aiusage"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Pheethy/sqlx"
+	"github.com/gofrs/uuid"
+)
+
+type aiUsageRepository struct {
+	psqlDB *sqlx.DB
+}
+
+func NewAIUsageRepository(psqlDB *sqlx.DB) aiusage.IAIUsageRepository {
+	return &aiUsageRepository{
+		psqlDB: psqlDB,
+	}
+}
+
+const selectAIQuota = `
+        "ai_quotas"."role_id",
+        "roles"."name" "role",
+        "ai_quotas"."daily_token_limit",
+        "ai_quotas"."monthly_token_limit",
+        to_char("ai_quotas"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at"`
+
+func (r *aiUsageRepository) InsertUsages(ctx context.Context, usages []*models.AIUsage) error {
+	tx, err := r.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PreparexContext(ctx, `
+    INSERT INTO "ai_usages" (
+      "id",
+      "user_id",
+      "endpoint",
+      "model",
+      "prompt_tokens",
+      "completion_tokens",
+      "total_tokens",
+      "created_at"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::varchar,
+      $4::varchar,
+      $5::int,
+      $6::int,
+      $7::int,
+      $8::timestamp
+    )
+  `)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for index := range usages {
+		usage := usages[index]
+		if _, err := stmt.ExecContext(ctx,
+			usage.Id,
+			usage.UserId,
+			usage.Endpoint,
+			usage.Model,
+			usage.PromptTokens,
+			usage.CompletionTokens,
+			usage.TotalTokens,
+			usage.CreatedAt,
+		); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("exec ai usage failed: %v", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+/* SumTotalTokens คืน token รวมของผู้ใช้ตั้งแต่ dayStart และตั้งแต่ monthStart */
+func (r *aiUsageRepository) SumTotalTokens(ctx context.Context, userId *uuid.UUID, dayStart time.Time, monthStart time.Time) (int, int, error) {
+	sql := `
+    SELECT
+      COALESCE(SUM("ai_usages"."total_tokens") FILTER (WHERE "ai_usages"."created_at" >= $2::timestamp), 0)::int "daily",
+      COALESCE(SUM("ai_usages"."total_tokens"), 0)::int "monthly"
+    FROM
+      "ai_usages"
+    WHERE
+      "ai_usages"."user_id" = $1::uuid
+    AND
+      "ai_usages"."created_at" >= $3::timestamp
+  `
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer stmt.Close()
+
+	var daily, monthly int
+	if err := stmt.QueryRowxContext(ctx, userId, dayStart, monthStart).Scan(&daily, &monthly); err != nil {
+		return 0, 0, err
+	}
+
+	return daily, monthly, nil
+}
+
+func (r *aiUsageRepository) FetchAllQuotas(ctx context.Context) ([]*models.AIQuota, error) {
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        %s
+      FROM
+        "ai_quotas"
+      JOIN
+        "roles"
+      ON
+        "roles"."id" = "ai_quotas"."role_id"
+      ORDER BY
+        "ai_quotas"."role_id" ASC
+    ) AS "json_data"
+  `, selectAIQuota)
+
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	quotas := make([]*models.AIQuota, 0)
+	if err := json.Unmarshal(jsonData, &quotas); err != nil {
+		return nil, err
+	}
+
+	return quotas, nil
+}
+
+func (r *aiUsageRepository) FetchOneQuotaByRoleId(ctx context.Context, roleId int64) (*models.AIQuota, error) {
+	sql := fmt.Sprintf(`
+    SELECT
+      to_jsonb("json_data")
+    FROM (
+      SELECT
+        %s
+      FROM
+        "ai_quotas"
+      JOIN
+        "roles"
+      ON
+        "roles"."id" = "ai_quotas"."role_id"
+      WHERE
+        "ai_quotas"."role_id" = $1::int
+    ) AS "json_data"
+  `, selectAIQuota)
+
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, roleId).Scan(&jsonData); err != nil {
+		return nil, apperror.FromDB(err, constants.ERROR_AI_QUOTA_NOT_FOUND)
+	}
+
+	quota := new(models.AIQuota)
+	if err := json.Unmarshal(jsonData, &quota); err != nil {
+		return nil, err
+	}
+
+	return quota, nil
+}
+
+/* FetchOneQuotaByUserId โควตาของ role ปัจจุบันของผู้ใช้ ใช้ตอนที่ไม่มี role จาก token เช่นใน job worker */
+func (r *aiUsageRepository) FetchOneQuotaByUserId(ctx context.Context, userId *uuid.UUID) (*models.AIQuota, error) {
+	sql := fmt.Sprintf(`
+    SELECT
+      to_jsonb("json_data")
+    FROM (
+      SELECT
+        %s
+      FROM
+        "users"
+      JOIN
+        "ai_quotas"
+      ON
+        "ai_quotas"."role_id" = "users"."role_id"
+      JOIN
+        "roles"
+      ON
+        "roles"."id" = "ai_quotas"."role_id"
+      WHERE
+        "users"."id" = $1::uuid
+    ) AS "json_data"
+  `, selectAIQuota)
+
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, userId).Scan(&jsonData); err != nil {
+		return nil, apperror.FromDB(err, constants.ERROR_AI_QUOTA_NOT_FOUND)
+	}
+
+	quota := new(models.AIQuota)
+	if err := json.Unmarshal(jsonData, &quota); err != nil {
+		return nil, err
+	}
+
+	return quota, nil
+}
+
+func (r *aiUsageRepository) UpsertQuota(ctx context.Context, quota *models.AIQuota) error {
+	sql := `
+    INSERT INTO "ai_quotas" (
+      "role_id",
+      "daily_token_limit",
+      "monthly_token_limit",
+      "updated_at"
+    ) VALUES (
+      $1::int,
+      $2::int,
+      $3::int,
+      $4::timestamp
+    )
+    ON CONFLICT (role_id)
+    DO UPDATE SET
+      daily_token_limit=$5::int,
+      monthly_token_limit=$6::int,
+      updated_at=$7::timestamp
+  `
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx,
+		/* Create */
+		quota.RoleId,
+		quota.DailyTokenLimit,
+		quota.MonthlyTokenLimit,
+		quota.UpdatedAt,
+		/* Update */
+		quota.DailyTokenLimit,
+		quota.MonthlyTokenLimit,
+		quota.UpdatedAt,
+	); err != nil {
+		return apperror.FromDB(err, "")
+	}
+
+	return nil
+}
+
+/* usageWhere สร้างเงื่อนไขช่วงวันที่และผู้ใช้ของรายงาน */
+func usageWhere(args *sync.Map) (string, []interface{}) {
+	var conds []interface{}
+	var wheres []string
+	if userId, ok := args.Load("user_id"); ok {
+		conds = append(conds, userId)
+		wheres = append(wheres, fmt.Sprintf(`"ai_usages"."user_id" = $%d::uuid`, len(conds)))
+	}
+	if endpoint, ok := args.Load("endpoint"); ok {
+		conds = append(conds, endpoint)
+		wheres = append(wheres, fmt.Sprintf(`"ai_usages"."endpoint" = $%d::varchar`, len(conds)))
+	}
+	if startDate, ok := args.Load("start_date"); ok {
+		conds = append(conds, startDate)
+		wheres = append(wheres, fmt.Sprintf(`"ai_usages"."created_at" >= $%d::date`, len(conds)))
+	}
+	if endDate, ok := args.Load("end_date"); ok {
+		conds = append(conds, endDate)
+		wheres = append(wheres, fmt.Sprintf(`"ai_usages"."created_at" < $%d::date + 1`, len(conds)))
+	}
+	where := ""
+	if len(wheres) > 0 {
+		where = "WHERE " + strings.Join(wheres, " AND ")
+	}
+	return where, conds
+}
+
+func (r *aiUsageRepository) FetchDailyReport(ctx context.Context, args *sync.Map) ([]*models.AIUsageDailyReport, error) {
+	where, conds := usageWhere(args)
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        to_char("ai_usages"."created_at"::date, 'yyyy-MM-dd') "date",
+        "ai_usages"."endpoint",
+        "ai_usages"."model",
+        COUNT(*)::int "requests",
+        SUM("ai_usages"."prompt_tokens")::int "prompt_tokens",
+        SUM("ai_usages"."completion_tokens")::int "completion_tokens",
+        SUM("ai_usages"."total_tokens")::int "total_tokens"
+      FROM
+        "ai_usages"
+      %s
+      GROUP BY
+        "ai_usages"."created_at"::date,
+        "ai_usages"."endpoint",
+        "ai_usages"."model"
+      ORDER BY
+        "ai_usages"."created_at"::date DESC,
+        "total_tokens" DESC
+    ) AS "json_data"
+  `, where)
+
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, conds...).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	reports := make([]*models.AIUsageDailyReport, 0)
+	if err := json.Unmarshal(jsonData, &reports); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+func (r *aiUsageRepository) FetchUserReport(ctx context.Context, args *sync.Map) ([]*models.AIUsageUserReport, error) {
+	where, conds := usageWhere(args)
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        "ai_usages"."user_id",
+        COALESCE("users"."username", '') "username",
+        COALESCE("users"."email", '') "email",
+        COUNT(*)::int "requests",
+        SUM("ai_usages"."prompt_tokens")::int "prompt_tokens",
+        SUM("ai_usages"."completion_tokens")::int "completion_tokens",
+        SUM("ai_usages"."total_tokens")::int "total_tokens"
+      FROM
+        "ai_usages"
+      LEFT JOIN
+        "users"
+      ON
+        "users"."id" = "ai_usages"."user_id"
+      %s
+      GROUP BY
+        "ai_usages"."user_id",
+        "users"."username",
+        "users"."email"
+      ORDER BY
+        "total_tokens" DESC
+    ) AS "json_data"
+  `, where)
+
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, conds...).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	reports := make([]*models.AIUsageUserReport, 0)
+	if err := json.Unmarshal(jsonData, &reports); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
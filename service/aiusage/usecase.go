@@ -0,0 +1,20 @@
+package aiusage
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type IAIUsageUsecase interface {
+	CheckQuota(ctx context.Context, userId *uuid.UUID, roleId int64) (*models.AIQuotaStatus, error)
+	CheckUserQuota(ctx context.Context, userId *uuid.UUID) (*models.AIQuotaStatus, error)
+	RecordUsages(ctx context.Context, usages []*models.AIUsage) error
+	FetchAllQuotas(ctx context.Context) ([]*models.AIQuota, error)
+	FetchOneQuotaByRoleId(ctx context.Context, roleId int64) (*models.AIQuota, error)
+	UpsertQuota(ctx context.Context, quota *models.AIQuota) error
+	FetchDailyReport(ctx context.Context, args *sync.Map) ([]*models.AIUsageDailyReport, error)
+	FetchUserReport(ctx context.Context, args *sync.Map) ([]*models.AIUsageUserReport, error)
+}
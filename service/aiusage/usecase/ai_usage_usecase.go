@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/aiusage"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type aiUsageUsecase struct {
+	aiUsageRepo aiusage.IAIUsageRepository
+}
+
+func NewAIUsageUsecase(aiUsageRepo aiusage.IAIUsageRepository) aiusage.IAIUsageUsecase {
+	return &aiUsageUsecase{
+		aiUsageRepo: aiUsageRepo,
+	}
+}
+
+/* CheckQuota role ที่ไม่มีโควตาในตาราง ai_quotas ถือว่าใช้ได้ไม่จำกัด */
+func (u *aiUsageUsecase) CheckQuota(ctx context.Context, userId *uuid.UUID, roleId int64) (*models.AIQuotaStatus, error) {
+	quota, err := u.aiUsageRepo.FetchOneQuotaByRoleId(ctx, roleId)
+	if err != nil {
+		if !errors.Is(err, apperror.ErrNotFound) {
+			return nil, err
+		}
+		quota = &models.AIQuota{RoleId: roleId}
+	}
+	return u.quotaStatus(ctx, userId, quota)
+}
+
+/* CheckUserQuota เหมือน CheckQuota แต่ใช้ role ปัจจุบันของผู้ใช้จาก database ใช้กับงานที่ไม่มี token เช่น job worker */
+func (u *aiUsageUsecase) CheckUserQuota(ctx context.Context, userId *uuid.UUID) (*models.AIQuotaStatus, error) {
+	quota, err := u.aiUsageRepo.FetchOneQuotaByUserId(ctx, userId)
+	if err != nil {
+		if !errors.Is(err, apperror.ErrNotFound) {
+			return nil, err
+		}
+		quota = &models.AIQuota{}
+	}
+	return u.quotaStatus(ctx, userId, quota)
+}
+
+func (u *aiUsageUsecase) quotaStatus(ctx context.Context, userId *uuid.UUID, quota *models.AIQuota) (*models.AIQuotaStatus, error) {
+	now := time.Now()
+	dayStart, monthStart := models.QuotaPeriodStarts(now)
+	dailyUsed, monthlyUsed, err := u.aiUsageRepo.SumTotalTokens(ctx, userId, dayStart, monthStart)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewAIQuotaStatus(userId, quota, dailyUsed, monthlyUsed, now), nil
+}
+
+func (u *aiUsageUsecase) RecordUsages(ctx context.Context, usages []*models.AIUsage) error {
+	if len(usages) == 0 {
+		return nil
+	}
+	return u.aiUsageRepo.InsertUsages(ctx, usages)
+}
+
+func (u *aiUsageUsecase) FetchAllQuotas(ctx context.Context) ([]*models.AIQuota, error) {
+	return u.aiUsageRepo.FetchAllQuotas(ctx)
+}
+
+func (u *aiUsageUsecase) FetchOneQuotaByRoleId(ctx context.Context, roleId int64) (*models.AIQuota, error) {
+	return u.aiUsageRepo.FetchOneQuotaByRoleId(ctx, roleId)
+}
+
+func (u *aiUsageUsecase) UpsertQuota(ctx context.Context, quota *models.AIQuota) error {
+	return u.aiUsageRepo.UpsertQuota(ctx, quota)
+}
+
+func (u *aiUsageUsecase) FetchDailyReport(ctx context.Context, args *sync.Map) ([]*models.AIUsageDailyReport, error) {
+	return u.aiUsageRepo.FetchDailyReport(ctx, args)
+}
+
+func (u *aiUsageUsecase) FetchUserReport(ctx context.Context, args *sync.Map) ([]*models.AIUsageUserReport, error) {
+	return u.aiUsageRepo.FetchUserReport(ctx, args)
+}
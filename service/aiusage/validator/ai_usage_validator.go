@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	diary_validator "healthmatefood-api/service/diary/validator"
+	"strconv"
+
+	"github.com/Pheethy/psql/helper"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofiber/fiber/v2"
+	"github.com/spf13/cast"
+)
+
+type Validation struct{}
+
+func (v Validation) ValidateReportQuery() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		fields := apperror.Fields{}
+		for _, key := range []string{"start_date", "end_date"} {
+			if date := c.Query(key); date != "" {
+				fields.Check(key, validation.Validate(date, validation.By(diary_validator.ValidateDate)))
+			}
+		}
+		key := "user_id"
+		if userId := c.Query(key); userId != "" {
+			fields.Check(key, validation.Validate(userId, validation.By(helper.ValidateTypeUUID)))
+		}
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateRoleParams(key string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		roleId, err := strconv.ParseInt(c.Params(key), 10, 64)
+		if err != nil || roleId <= 0 {
+			return apperror.Field(key, "must be a positive integer")
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateUpdateQuota() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		if params == nil {
+			return apperror.Validation(constants.ERROR_BODY_WAS_MISSING)
+		}
+		fields := apperror.Fields{}
+		for _, key := range []string{"daily_token_limit", "monthly_token_limit"} {
+			if val, ok := params[key]; !ok {
+				fields.Missing(key)
+			} else {
+				fields.Check(key, validation.Validate(val, validation.By(validateTokenLimit)))
+			}
+		}
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+/* validateTokenLimit รับจำนวนเต็มที่ไม่ติดลบ หรือ null/ค่าว่างเพื่อไม่จำกัด */
+func validateTokenLimit(val interface{}) error {
+	if val == nil || val == "" {
+		return nil
+	}
+	limit, err := cast.ToIntE(val)
+	if err != nil {
+		return fmt.Errorf("must be an integer or null")
+	}
+	if limit < 0 {
+		return fmt.Errorf("must not be negative")
+	}
+	return nil
+}
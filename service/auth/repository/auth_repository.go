@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"healthmatefood-api/apperror"
 	"healthmatefood-api/config"
 	"healthmatefood-api/constants"
 	"healthmatefood-api/models"
@@ -180,7 +181,7 @@ func (m *authRepository) ormRoles(ctx context.Context, rows *sqlx.Rows) ([]*mode
 	}
 	roles := mapper.GetData().([]*models.Roles)
 	if len(roles) == 0 {
-		return nil, errors.New(constants.ERROR_ROLES_NOT_FOUND)
+		return nil, apperror.NotFound(constants.ERROR_ROLES_NOT_FOUND)
 	}
 
 	return roles, nil
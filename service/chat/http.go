@@ -0,0 +1,11 @@
+package chat
+
+import "github.com/gofiber/fiber/v2"
+
+type IChatHandler interface {
+	FetchAllConversations(c *fiber.Ctx) error
+	FetchOneConversationById(c *fiber.Ctx) error
+	StartConversation(c *fiber.Ctx) error
+	SendMessage(c *fiber.Ctx) error
+	StreamMessage(c *fiber.Ctx) error
+}
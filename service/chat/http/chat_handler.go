@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"context"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/middleware"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/chat"
+	"healthmatefood-api/utils"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+type chatHandler struct {
+	chatUs chat.IChatUsecase
+}
+
+func NewChatHandler(chatUs chat.IChatUsecase) chat.IChatHandler {
+	return &chatHandler{
+		chatUs: chatUs,
+	}
+}
+
+// @Summary     FetchAllConversations
+// @Description Get conversations of signed-in user, latest activity first
+// @Tags        chat
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true "Bearer access token"
+// @Success     200 {object} map[string]interface{}
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/chat [get]
+func (h *chatHandler) FetchAllConversations(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId, _ := c.Locals("user_id").(*uuid.UUID)
+	if userId == nil {
+		return fiber.NewError(http.StatusUnauthorized, "no permission to access")
+	}
+	args := new(sync.Map)
+	args.Store("user_id", userId)
+
+	conversations, err := h.chatUs.FetchAllConversations(ctx, args)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"conversations": conversations,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     FetchOneConversationById
+// @Description Get a conversation with its message history
+// @Tags        chat
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization   header string true "Bearer access token"
+// @Param       conversation_id path   string true "conversation id"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     404 {object} constants.ErrorResponse "conversation not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/chat/{conversation_id} [get]
+func (h *chatHandler) FetchOneConversationById(c *fiber.Ctx) error {
+	conversation, err := h.fetchOwnConversation(c)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"conversation": conversation,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     StartConversation
+// @Description Start a new nutrition chat, when message is given the assistant replies in the same call
+// @Tags        chat
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true  "Bearer access token"
+// @Param       title         body   string false "conversation title, default from the first message"
+// @Param       message       body   string false "first message"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     500 {object} constants.ErrorResponse
+// @Failure     502 {object} constants.ErrorResponse "agent upstream failed"
+// @Failure     503 {object} constants.ErrorResponse "agent is unavailable"
+// @Failure     504 {object} constants.ErrorResponse "agent timed out"
+// @Router      /v1/chat [post]
+func (h *chatHandler) StartConversation(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	params, _ := c.Locals("params").(map[string]interface{})
+	userId, _ := c.Locals("user_id").(*uuid.UUID)
+	if userId == nil {
+		return fiber.NewError(http.StatusUnauthorized, "no permission to access")
+	}
+
+	conversation := models.NewConversationWithParams(params, nil)
+	conversation.NewID()
+	conversation.UserId = userId
+	conversation.SetCreatedAt()
+	conversation.SetUpdatedAt()
+
+	reply, err := h.chatUs.StartConversation(ctx, conversation, strings.TrimSpace(cast.ToString(params["message"])))
+	if err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message":      "successful",
+		"conversation": conversation,
+		"reply":        reply,
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     SendMessage
+// @Description Send a message in a conversation and get the assistant reply
+// @Tags        chat
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization   header string true "Bearer access token"
+// @Param       conversation_id path   string true "conversation id"
+// @Param       message         body   string true "message"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     404 {object} constants.ErrorResponse "conversation not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Failure     502 {object} constants.ErrorResponse "agent upstream failed"
+// @Failure     503 {object} constants.ErrorResponse "agent is unavailable"
+// @Failure     504 {object} constants.ErrorResponse "agent timed out"
+// @Router      /v1/chat/{conversation_id}/messages [post]
+func (h *chatHandler) SendMessage(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	params, _ := c.Locals("params").(map[string]interface{})
+
+	conversation, err := h.fetchOwnConversation(c)
+	if err != nil {
+		return err
+	}
+
+	reply, err := h.chatUs.SendMessage(ctx, conversation, strings.TrimSpace(cast.ToString(params["message"])))
+	if err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message": "successful",
+		"reply":   reply,
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     StreamMessage
+// @Description Same as SendMessage but answers with Server-Sent Events: token events while the assistant is writing, tool events when it looks up foods or the diary, then done with the saved reply or error
+// @Tags        chat
+// @Accept      json
+// @Produce     text/event-stream,application/problem+json
+// @Param       Authorization   header string true "Bearer access token"
+// @Param       conversation_id path   string true "conversation id"
+// @Param       message         body   string true "message"
+// @Success     200 {string} string "event stream"
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     404 {object} constants.ErrorResponse "conversation not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/chat/{conversation_id}/messages/stream [post]
+func (h *chatHandler) StreamMessage(c *fiber.Ctx) error {
+	params, _ := c.Locals("params").(map[string]interface{})
+	content := strings.TrimSpace(cast.ToString(params["message"]))
+
+	conversation, err := h.fetchOwnConversation(c)
+	if err != nil {
+		return err
+	}
+
+	problem := middleware.NewProblemFunc(c)
+	return utils.StreamSSE(c, func(ctx context.Context, send utils.SSESendFunc) {
+		reply, err := h.chatUs.StreamMessage(ctx, conversation, content, func(event models.StreamEvent, data map[string]interface{}) error {
+			return send(string(event), data)
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			send(string(models.StreamEventError), problem(ctx, err))
+			return
+		}
+		send(string(models.StreamEventDone), map[string]interface{}{"reply": reply})
+	})
+}
+
+/* fetchOwnConversation ดึงบทสนทนาและตรวจว่าเป็นของผู้ใช้ที่ login ไม่เช่นนั้นถือว่าไม่พบ */
+func (h *chatHandler) fetchOwnConversation(c *fiber.Ctx) (*models.Conversation, error) {
+	userId, _ := c.Locals("user_id").(*uuid.UUID)
+	if userId == nil {
+		return nil, fiber.NewError(http.StatusUnauthorized, "no permission to access")
+	}
+	conversationId := uuid.FromStringOrNil(c.Params("conversation_id"))
+
+	conversation, err := h.chatUs.FetchOneConversationById(c.UserContext(), &conversationId)
+	if err != nil {
+		return nil, err
+	}
+	if conversation.UserId == nil || *conversation.UserId != *userId {
+		return nil, apperror.NotFound(constants.ERROR_CONVERSATION_NOT_FOUND)
+	}
+	return conversation, nil
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"errors"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/middleware"
+	"healthmatefood-api/models"
+	chat_mocks "healthmatefood-api/service/chat/mocks"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSendMessage(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	otherId := uuid.FromStringOrNil("0d8f6c7e-3f1a-4c55-9a43-5b2f0c7a1e11")
+	conversationId := uuid.FromStringOrNil("c2b1e0f4-8a8f-4d3e-b1a2-6f0b9d7c5e33")
+	newApp := func(handler *chatHandler) *fiber.App {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		app.Post("/v1/chat/:conversation_id/messages", func(c *fiber.Ctx) error {
+			c.Locals("user_id", &userId)
+			c.Locals("params", map[string]interface{}{"message": " กินข้าวมันไก่ได้ไหม "})
+			return c.Next()
+		}, handler.SendMessage)
+		return app
+	}
+	t.Run("success", func(t *testing.T) {
+		conversation := &models.Conversation{Id: &conversationId, UserId: &userId}
+		chatUs := new(chat_mocks.IChatUsecase)
+		chatUs.On("FetchOneConversationById", mock.Anything, &conversationId).Return(conversation, nil)
+		chatUs.On("SendMessage", mock.Anything, conversation, "กินข้าวมันไก่ได้ไหม").
+			Return(models.NewConversationMessage(&conversationId, models.ChatRoleAssistant, "ได้ แต่ควรลดหนังไก่"), nil)
+		app := newApp(&chatHandler{chatUs: chatUs})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/chat/"+conversationId.String()+"/messages", strings.NewReader(""))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		chatUs.AssertExpectations(t)
+	})
+	t.Run("error_not_owner", func(t *testing.T) {
+		chatUs := new(chat_mocks.IChatUsecase)
+		chatUs.On("FetchOneConversationById", mock.Anything, &conversationId).Return(&models.Conversation{Id: &conversationId, UserId: &otherId}, nil)
+		app := newApp(&chatHandler{chatUs: chatUs})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/chat/"+conversationId.String()+"/messages", strings.NewReader(""))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		chatUs.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("error_conversation_not_found", func(t *testing.T) {
+		chatUs := new(chat_mocks.IChatUsecase)
+		chatUs.On("FetchOneConversationById", mock.Anything, &conversationId).Return(nil, apperror.NotFound(constants.ERROR_CONVERSATION_NOT_FOUND))
+		app := newApp(&chatHandler{chatUs: chatUs})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/chat/"+conversationId.String()+"/messages", strings.NewReader(""))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestStreamMessage(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	conversationId := uuid.FromStringOrNil("c2b1e0f4-8a8f-4d3e-b1a2-6f0b9d7c5e33")
+	conversation := &models.Conversation{Id: &conversationId, UserId: &userId}
+	chatUs := new(chat_mocks.IChatUsecase)
+	chatUs.On("FetchOneConversationById", mock.Anything, &conversationId).Return(conversation, nil)
+	chatUs.On("StreamMessage", mock.Anything, conversation, "มื้อเย็นกินอะไรดี", mock.Anything).
+		Run(func(args mock.Arguments) {
+			stream := args.Get(3).(models.StreamFunc)
+			stream(models.StreamEventToken, map[string]interface{}{"content": "ปลา"})
+			stream(models.StreamEventToken, map[string]interface{}{"content": "นึ่ง"})
+		}).
+		Return(models.NewConversationMessage(&conversationId, models.ChatRoleAssistant, "ปลานึ่ง"), nil)
+	handler := &chatHandler{chatUs: chatUs}
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	app.Post("/v1/chat/:conversation_id/messages/stream", func(c *fiber.Ctx) error {
+		c.Locals("user_id", &userId)
+		c.Locals("params", map[string]interface{}{"message": "มื้อเย็นกินอะไรดี"})
+		return c.Next()
+	}, handler.StreamMessage)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/"+conversationId.String()+"/messages/stream", strings.NewReader(""))
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(body), "event: token\n"))
+	assert.Contains(t, string(body), "data: {\"content\":\"นึ่ง\"}\n\n")
+	assert.Contains(t, string(body), "event: done\n")
+	chatUs.AssertExpectations(t)
+}
+
+func TestStreamMessageError(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	conversationId := uuid.FromStringOrNil("c2b1e0f4-8a8f-4d3e-b1a2-6f0b9d7c5e33")
+	conversation := &models.Conversation{Id: &conversationId, UserId: &userId}
+	for name, tc := range map[string]struct {
+		err  error
+		data string
+	}{
+		"internal": {err: errors.New("boom"), data: `"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal server error"`},
+		"typed":    {err: apperror.Upstream(constants.ERROR_AGENT_UPSTREAM_FAILED), data: `"type":"/problems/upstream","title":"Bad Gateway","status":502,"detail":"agent upstream failed"`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			chatUs := new(chat_mocks.IChatUsecase)
+			chatUs.On("FetchOneConversationById", mock.Anything, &conversationId).Return(conversation, nil)
+			chatUs.On("StreamMessage", mock.Anything, conversation, "มื้อเย็นกินอะไรดี", mock.Anything).Return(nil, tc.err)
+			handler := &chatHandler{chatUs: chatUs}
+			app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+			app.Post("/v1/chat/:conversation_id/messages/stream", func(c *fiber.Ctx) error {
+				c.Locals("user_id", &userId)
+				c.Locals("params", map[string]interface{}{"message": "มื้อเย็นกินอะไรดี"})
+				return c.Next()
+			}, handler.StreamMessage)
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/chat/"+conversationId.String()+"/messages/stream", strings.NewReader(""))
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			body, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			assert.Contains(t, string(body), "event: error\n")
+			assert.Contains(t, string(body), tc.data)
+			assert.NotContains(t, string(body), "event: done\n")
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "healthmatefood-api/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sync "sync"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IChatRepository is an autogenerated mock type for the IChatRepository type
+type IChatRepository struct {
+	mock.Mock
+}
+
+// FetchAllConversations provides a mock function with given fields: ctx, args
+func (_m *IChatRepository) FetchAllConversations(ctx context.Context, args *sync.Map) ([]*models.Conversation, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllConversations")
+	}
+
+	var r0 []*models.Conversation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.Conversation, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.Conversation); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Conversation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchOneConversationById provides a mock function with given fields: ctx, id
+func (_m *IChatRepository) FetchOneConversationById(ctx context.Context, id *uuid.UUID) (*models.Conversation, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOneConversationById")
+	}
+
+	var r0 *models.Conversation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.Conversation, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.Conversation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Conversation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertConversation provides a mock function with given fields: ctx, conversation, messages
+func (_m *IChatRepository) UpsertConversation(ctx context.Context, conversation *models.Conversation, messages []*models.ConversationMessage) error {
+	ret := _m.Called(ctx, conversation, messages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertConversation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Conversation, []*models.ConversationMessage) error); ok {
+		r0 = rf(ctx, conversation, messages)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIChatRepository creates a new instance of IChatRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIChatRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IChatRepository {
+	mock := &IChatRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,183 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "healthmatefood-api/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sync "sync"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IChatUsecase is an autogenerated mock type for the IChatUsecase type
+type IChatUsecase struct {
+	mock.Mock
+}
+
+// FetchAllConversations provides a mock function with given fields: ctx, args
+func (_m *IChatUsecase) FetchAllConversations(ctx context.Context, args *sync.Map) ([]*models.Conversation, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllConversations")
+	}
+
+	var r0 []*models.Conversation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.Conversation, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.Conversation); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Conversation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchOneConversationById provides a mock function with given fields: ctx, id
+func (_m *IChatUsecase) FetchOneConversationById(ctx context.Context, id *uuid.UUID) (*models.Conversation, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOneConversationById")
+	}
+
+	var r0 *models.Conversation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.Conversation, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.Conversation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Conversation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SendMessage provides a mock function with given fields: ctx, conversation, content
+func (_m *IChatUsecase) SendMessage(ctx context.Context, conversation *models.Conversation, content string) (*models.ConversationMessage, error) {
+	ret := _m.Called(ctx, conversation, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendMessage")
+	}
+
+	var r0 *models.ConversationMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Conversation, string) (*models.ConversationMessage, error)); ok {
+		return rf(ctx, conversation, content)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Conversation, string) *models.ConversationMessage); ok {
+		r0 = rf(ctx, conversation, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ConversationMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Conversation, string) error); ok {
+		r1 = rf(ctx, conversation, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartConversation provides a mock function with given fields: ctx, conversation, content
+func (_m *IChatUsecase) StartConversation(ctx context.Context, conversation *models.Conversation, content string) (*models.ConversationMessage, error) {
+	ret := _m.Called(ctx, conversation, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartConversation")
+	}
+
+	var r0 *models.ConversationMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Conversation, string) (*models.ConversationMessage, error)); ok {
+		return rf(ctx, conversation, content)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Conversation, string) *models.ConversationMessage); ok {
+		r0 = rf(ctx, conversation, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ConversationMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Conversation, string) error); ok {
+		r1 = rf(ctx, conversation, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StreamMessage provides a mock function with given fields: ctx, conversation, content, stream
+func (_m *IChatUsecase) StreamMessage(ctx context.Context, conversation *models.Conversation, content string, stream models.StreamFunc) (*models.ConversationMessage, error) {
+	ret := _m.Called(ctx, conversation, content, stream)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamMessage")
+	}
+
+	var r0 *models.ConversationMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Conversation, string, models.StreamFunc) (*models.ConversationMessage, error)); ok {
+		return rf(ctx, conversation, content, stream)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Conversation, string, models.StreamFunc) *models.ConversationMessage); ok {
+		r0 = rf(ctx, conversation, content, stream)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ConversationMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Conversation, string, models.StreamFunc) error); ok {
+		r1 = rf(ctx, conversation, content, stream)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIChatUsecase creates a new instance of IChatUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIChatUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IChatUsecase {
+	mock := &IChatUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
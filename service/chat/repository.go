@@ -0,0 +1,15 @@
+package chat
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type IChatRepository interface {
+	FetchAllConversations(ctx context.Context, args *sync.Map) ([]*models.Conversation, error)
+	FetchOneConversationById(ctx context.Context, id *uuid.UUID) (*models.Conversation, error)
+	UpsertConversation(ctx context.Context, conversation *models.Conversation, messages []*models.ConversationMessage) error
+}
@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/chat"
+	"strings"
+	"sync"
+
+	"github.com/Pheethy/sqlx"
+	"github.com/gofrs/uuid"
+)
+
+type chatRepository struct {
+	psqlDB *sqlx.DB
+}
+
+func NewChatRepository(psqlDB *sqlx.DB) chat.IChatRepository {
+	return &chatRepository{
+		psqlDB: psqlDB,
+	}
+}
+
+const selectConversation = `
+        "conversations"."id",
+        "conversations"."user_id",
+        "conversations"."title",
+        COALESCE("conversations"."summary", '') "summary",
+        "conversations"."summarized_count",
+        to_char("conversations"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+        to_char("conversations"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at"`
+
+func (r *chatRepository) FetchAllConversations(ctx context.Context, args *sync.Map) ([]*models.Conversation, error) {
+	var conds []interface{}
+	var wheres []string
+	if userId, ok := args.Load("user_id"); ok {
+		conds = append(conds, userId)
+		wheres = append(wheres, fmt.Sprintf(`"conversations"."user_id" = $%d::uuid`, len(conds)))
+	}
+	where := ""
+	if len(wheres) > 0 {
+		where = "WHERE " + strings.Join(wheres, " AND ")
+	}
+
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        %s
+      FROM
+        "conversations"
+      %s
+      ORDER BY
+        "conversations"."updated_at" DESC
+    ) AS "json_data"
+  `, selectConversation, where)
+
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, conds...).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	conversations := make([]*models.Conversation, 0)
+	if err := json.Unmarshal(jsonData, &conversations); err != nil {
+		return nil, err
+	}
+
+	return conversations, nil
+}
+
+func (r *chatRepository) FetchOneConversationById(ctx context.Context, id *uuid.UUID) (*models.Conversation, error) {
+	sql := fmt.Sprintf(`
+    SELECT
+      to_jsonb("json_data")
+    FROM (
+      SELECT
+        %s,
+        (
+          SELECT
+            COALESCE(array_to_json(array_agg("MSG")), '[]'::json)
+          FROM (
+            SELECT
+              "conversation_messages"."id",
+              "conversation_messages"."conversation_id",
+              "conversation_messages"."role",
+              "conversation_messages"."content",
+              "conversation_messages"."citations",
+              to_char("conversation_messages"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at"
+            FROM
+              "conversation_messages"
+            WHERE
+              "conversation_messages"."conversation_id" = "conversations"."id"
+            ORDER BY
+              "conversation_messages"."created_at" ASC,
+              "conversation_messages"."role" ASC
+          ) AS "MSG"
+        ) AS "messages"
+      FROM
+        "conversations"
+      WHERE
+        "conversations"."id" = $1::uuid
+    ) AS "json_data"
+  `, selectConversation)
+
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, id).Scan(&jsonData); err != nil {
+		return nil, apperror.FromDB(err, constants.ERROR_CONVERSATION_NOT_FOUND)
+	}
+
+	conversation := new(models.Conversation)
+	if err := json.Unmarshal(jsonData, &conversation); err != nil {
+		return nil, err
+	}
+
+	return conversation, nil
+}
+
+/* UpsertConversation บันทึกหัวบทสนทนา (ชื่อ, สรุป) พร้อมเพิ่มข้อความใหม่ภายใน transaction เดียว */
+func (r *chatRepository) UpsertConversation(ctx context.Context, conversation *models.Conversation, messages []*models.ConversationMessage) error {
+	tx, err := r.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+	sql := `
+    INSERT INTO "conversations" (
+      "id",
+      "user_id",
+      "title",
+      "summary",
+      "summarized_count",
+      "created_at",
+      "updated_at"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::text,
+      $4::text,
+      $5::int,
+      $6::timestamp,
+      $7::timestamp
+    )
+    ON CONFLICT (id)
+    DO UPDATE SET
+      title=$8::text,
+      summary=$9::text,
+      summarized_count=$10::int,
+      updated_at=$11::timestamp
+  `
+	stmt, err := tx.PreparexContext(ctx, sql)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx,
+		/* Create */
+		conversation.Id,
+		conversation.UserId,
+		conversation.Title,
+		conversation.Summary,
+		conversation.SummarizedCount,
+		conversation.CreatedAt,
+		conversation.UpdatedAt,
+		/* Update */
+		conversation.Title,
+		conversation.Summary,
+		conversation.SummarizedCount,
+		conversation.UpdatedAt,
+	); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	messageStmt, err := tx.PreparexContext(ctx, `
+    INSERT INTO "conversation_messages" (
+      "id",
+      "conversation_id",
+      "role",
+      "content",
+      "citations",
+      "created_at"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::chat_role,
+      $4::text,
+      $5::jsonb,
+      $6::timestamp
+    )
+  `)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer messageStmt.Close()
+
+	for index := range messages {
+		message := messages[index]
+		if _, err := messageStmt.ExecContext(ctx,
+			message.Id,
+			conversation.Id,
+			message.Role,
+			message.Content,
+			models.CitationsJSON(message.Citations),
+			message.CreatedAt,
+		); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("exec message failed: %v", err)
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,17 @@
+package chat
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type IChatUsecase interface {
+	FetchAllConversations(ctx context.Context, args *sync.Map) ([]*models.Conversation, error)
+	FetchOneConversationById(ctx context.Context, id *uuid.UUID) (*models.Conversation, error)
+	StartConversation(ctx context.Context, conversation *models.Conversation, content string) (*models.ConversationMessage, error)
+	SendMessage(ctx context.Context, conversation *models.Conversation, content string) (*models.ConversationMessage, error)
+	StreamMessage(ctx context.Context, conversation *models.Conversation, content string, stream models.StreamFunc) (*models.ConversationMessage, error)
+}
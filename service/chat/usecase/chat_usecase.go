@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/agent-ai"
+	"healthmatefood-api/service/chat"
+	"healthmatefood-api/service/user"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+/* จำนวนข้อความล่าสุดที่ส่งให้โมเดลตรง ๆ และจำนวนข้อความที่ยังไม่สรุปซึ่งถ้าเกินจะสรุปส่วนที่เก่ากว่า window */
+const (
+	chatHistoryWindow    = 10
+	chatSummaryThreshold = 20
+)
+
+type chatUsecase struct {
+	chatRepo  chat.IChatRepository
+	agentRepo agent.IAgentAIRepository
+	userUs    user.IUserUsecase
+}
+
+func NewChatUsecase(chatRepo chat.IChatRepository, agentRepo agent.IAgentAIRepository, userUs user.IUserUsecase) chat.IChatUsecase {
+	return &chatUsecase{
+		chatRepo:  chatRepo,
+		agentRepo: agentRepo,
+		userUs:    userUs,
+	}
+}
+
+func (u *chatUsecase) FetchAllConversations(ctx context.Context, args *sync.Map) ([]*models.Conversation, error) {
+	return u.chatRepo.FetchAllConversations(ctx, args)
+}
+
+func (u *chatUsecase) FetchOneConversationById(ctx context.Context, id *uuid.UUID) (*models.Conversation, error) {
+	return u.chatRepo.FetchOneConversationById(ctx, id)
+}
+
+/* StartConversation สร้างบทสนทนาใหม่ ถ้ามีข้อความแรกจะตอบกลับทันที */
+func (u *chatUsecase) StartConversation(ctx context.Context, conversation *models.Conversation, content string) (*models.ConversationMessage, error) {
+	conversation.SetTitleIfEmpty(content)
+	if content == "" {
+		return nil, u.chatRepo.UpsertConversation(ctx, conversation, nil)
+	}
+	return u.SendMessage(ctx, conversation, content)
+}
+
+/* SendMessage ส่งข้อความพร้อม history ที่ตัดตาม window ให้โมเดล แล้วบันทึกทั้งคำถามและคำตอบ */
+func (u *chatUsecase) SendMessage(ctx context.Context, conversation *models.Conversation, content string) (*models.ConversationMessage, error) {
+	return u.sendMessage(ctx, conversation, content, nil)
+}
+
+/* StreamMessage เหมือน SendMessage แต่ส่ง token ของคำตอบให้ stream ระหว่างที่โมเดลตอบ */
+func (u *chatUsecase) StreamMessage(ctx context.Context, conversation *models.Conversation, content string, stream models.StreamFunc) (*models.ConversationMessage, error) {
+	return u.sendMessage(ctx, conversation, content, stream)
+}
+
+func (u *chatUsecase) sendMessage(ctx context.Context, conversation *models.Conversation, content string, stream models.StreamFunc) (*models.ConversationMessage, error) {
+	userInfo, err := u.fetchUserInfo(ctx, conversation.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	question := models.NewConversationMessage(conversation.Id, models.ChatRoleUser, content)
+	messages := append(conversation.Messages, question)
+	olds, history := conversation.SplitHistory(messages, chatHistoryWindow, chatSummaryThreshold)
+	if len(olds) > 0 {
+		summary, err := u.agentRepo.SummarizeConversation(ctx, userInfo, conversation.Summary, olds)
+		if err != nil {
+			return nil, err
+		}
+		conversation.Summary = summary
+		conversation.SummarizedCount += len(olds)
+	}
+
+	var reply string
+	var citations []*models.KnowledgeCitation
+	if stream != nil {
+		reply, citations, err = u.agentRepo.StreamConversationWithChat(ctx, userInfo, conversation, history, stream)
+	} else {
+		reply, citations, err = u.agentRepo.ConversationWithChat(ctx, userInfo, conversation, history)
+	}
+	if err != nil {
+		return nil, err
+	}
+	answer := models.NewConversationMessage(conversation.Id, models.ChatRoleAssistant, reply)
+	answer.Citations = citations
+
+	conversation.SetUpdatedAt()
+	if err := u.chatRepo.UpsertConversation(ctx, conversation, []*models.ConversationMessage{question, answer}); err != nil {
+		return nil, err
+	}
+	conversation.Messages = append(messages, answer)
+
+	return answer, nil
+}
+
+/* fetchUserInfo ดึงข้อมูลสุขภาพของผู้ใช้ไว้ใส่ system prompt ผู้ใช้ที่ยังไม่กรอกข้อมูลก็คุยได้ตามปกติ */
+func (u *chatUsecase) fetchUserInfo(ctx context.Context, userId *uuid.UUID) (*models.UserInfo, error) {
+	user, err := u.userUs.FetchOneUserById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if user.UserInfo == nil {
+		return nil, nil
+	}
+	user.UserInfo.GetBMR()
+	user.UserInfo.GetCaloriesLimit()
+	user.UserInfo.SetMedicalCondition()
+	return user.UserInfo, nil
+}
@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"strings"
+
+	"github.com/Pheethy/psql/helper"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Validation struct{}
+
+func (v Validation) ValidateStartConversation() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		fields := apperror.Fields{}
+		for _, key := range []string{"title", "message"} {
+			if val, ok := params[key]; ok {
+				fields.Check(key, validation.Validate(val, validation.By(helper.ValidateTypeString)))
+			}
+		}
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateSendMessage() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		if params == nil {
+			return apperror.Validation(constants.ERROR_BODY_WAS_MISSING)
+		}
+
+		/* key params */
+		key := "message"
+		message, ok := params[key]
+		if !ok {
+			return apperror.Field(key, constants.ERROR_FIELD_WAS_MISSING)
+		}
+		if err := validation.Validate(message, validation.By(helper.ValidateTypeString)); err != nil {
+			return apperror.Field(key, err.Error())
+		}
+		if strings.TrimSpace(message.(string)) == "" {
+			return apperror.Field(key, "must not be empty")
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateParams(key string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params := c.Params(key)
+		if err := validation.Validate(params, validation.By(helper.ValidateTypeUUID)); err != nil {
+			return apperror.Field(key, err.Error())
+		}
+		return c.Next()
+	}
+}
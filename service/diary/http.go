@@ -0,0 +1,13 @@
+package diary
+
+import "github.com/gofiber/fiber/v2"
+
+type IDiaryHandler interface {
+	FetchAllFoodDiaries(c *fiber.Ctx) error
+	FetchDailySummary(c *fiber.Ctx) error
+	FetchPlanComparison(c *fiber.Ctx) error
+	CreateFoodDiary(c *fiber.Ctx) error
+	CreateFoodDiariesFromPhoto(c *fiber.Ctx) error
+	UpdateFoodDiary(c *fiber.Ctx) error
+	DeleteFoodDiary(c *fiber.Ctx) error
+}
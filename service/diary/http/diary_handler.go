@@ -0,0 +1,294 @@
+package handler
+
+import (
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/diary"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+type diaryHandler struct {
+	diaryUs diary.IDiaryUsecase
+}
+
+func NewDiaryHandler(diaryUs diary.IDiaryUsecase) diary.IDiaryHandler {
+	return &diaryHandler{
+		diaryUs: diaryUs,
+	}
+}
+
+// @Summary     FetchAllFoodDiaries
+// @Description Get food diary entries of user on a date
+// @Tags        diaries
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id   path  string true  "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       date      query string false "example: 2025-03-01 (default today)"
+// @Param       meal_type query string false "BREAKFAST, LUNCH, DINNER or SNACK"
+// @Success     200       {object}     map[string]interface{}
+// @Failure     400       {object}     constants.ErrorResponse
+// @Failure     500       {object}     constants.ErrorResponse
+// @Router      /v1/diary/{user_id} [get]
+func (d *diaryHandler) FetchAllFoodDiaries(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	args := new(sync.Map)
+	args.Store("user_id", &userId)
+	args.Store("eaten_at", queryDate(c).String())
+	if mealType := c.Query("meal_type"); mealType != "" {
+		diary := &models.FoodDiary{MealType: models.MealType(mealType)}
+		if ok := diary.IsMealType(); !ok {
+			return fiber.NewError(http.StatusBadRequest, constants.ERROR_MEAL_TYPE_IS_INVALID)
+		}
+		args.Store("meal_type", mealType)
+	}
+
+	diaries, err := d.diaryUs.FetchAllFoodDiaries(ctx, args)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"diaries": diaries,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     FetchDailySummary
+// @Description Compare consumed energy and macros of a day against user calories limit; calories burned from activity logs is added back to remaining
+// @Tags        diaries
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id path  string true  "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       date    query string false "example: 2025-03-01 (default today)"
+// @Success     200     {object}     map[string]interface{}
+// @Failure     400     {object}     constants.ErrorResponse
+// @Failure     404     {object}     constants.ErrorResponse "user info not found"
+// @Failure     500     {object}     constants.ErrorResponse
+// @Router      /v1/diary/{user_id}/summary [get]
+func (d *diaryHandler) FetchDailySummary(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+
+	summary, err := d.diaryUs.FetchDailySummary(ctx, &userId, queryDate(c))
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"summary": summary,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     FetchPlanComparison
+// @Description Compare what was eaten on a date against the active meal plan, per meal type; difference is eaten minus planned
+// @Tags        diaries
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id path  string true  "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       date    query string false "example: 2025-03-01 (default today)"
+// @Success     200     {object}     map[string]interface{}
+// @Failure     400     {object}     constants.ErrorResponse
+// @Failure     404     {object}     constants.ErrorResponse "active meal plan not found"
+// @Failure     500     {object}     constants.ErrorResponse
+// @Router      /v1/diary/{user_id}/plan-comparison [get]
+func (d *diaryHandler) FetchPlanComparison(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+
+	comparison, err := d.diaryUs.FetchPlanComparison(ctx, &userId, queryDate(c))
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"comparison": comparison,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     CreateFoodDiary
+// @Description Log a food item into a meal slot; send food_id or recipe_id with quantity, or a free-text name with nutrition
+// @Tags        diaries
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id      path     string true  "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       meal_type    formData string true  "BREAKFAST, LUNCH, DINNER or SNACK"
+// @Param       food_id      formData string false "food id from /v1/food/list"
+// @Param       recipe_id    formData string false "recipe id from /v1/recipe/list (quantity is servings)"
+// @Param       quantity     formData number false "quantity of unit"
+// @Param       unit         formData string false "g, ml, tbsp, cup, piece or serving"
+// @Param       name         formData string false "free-text food name"
+// @Param       calories     formData number false "free-text calories (kcal)"
+// @Param       protein      formData number false "free-text protein (g)"
+// @Param       carbohydrate formData number false "free-text carbohydrate (g)"
+// @Param       fat          formData number false "free-text fat (g)"
+// @Param       eaten_at     formData string false "example: 2025-03-01 (default today)"
+// @Param       note         formData string false "note"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse "meal type is invalid, unit is invalid"
+// @Failure     404 {object} constants.ErrorResponse "food not found, recipe not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/diary/{user_id} [post]
+func (d *diaryHandler) CreateFoodDiary(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	params := c.Locals("params").(map[string]interface{})
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	diary := models.NewFoodDiaryWithParams(params, nil)
+	diary.NewID()
+	diary.UserId = &userId
+	diary.SetCreatedAt()
+	diary.SetUpdatedAt()
+
+	if err := d.diaryUs.UpsertFoodDiary(ctx, diary); err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message": "successful",
+		"diary":   diary,
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     CreateFoodDiariesFromPhoto
+// @Description Log the dishes from /v1/agent-ai/meals/photo after the user confirmed them, dishes can be edited or removed before sending, each dish is saved as one serving with its portion in note
+// @Tags        diaries
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true "Bearer access token"
+// @Param       user_id   path     string true  "must be the signed-in user, example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       meal_type formData string true  "BREAKFAST, LUNCH, DINNER or SNACK"
+// @Param       eaten_at  formData string false "example: 2025-03-01 (default today)"
+// @Param       dishes    body     []models.MealPhotoDish true "confirmed dishes from analysis.dishes"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse "meal type is invalid, meal photo analysis is invalid"
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     403 {object} constants.ErrorResponse "user_id is not the signed-in user"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/diary/{user_id}/photo [post]
+func (d *diaryHandler) CreateFoodDiariesFromPhoto(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	params, _ := c.Locals("params").(map[string]interface{})
+	/* บันทึกลง diary ของผู้ใช้ใน token เท่านั้น user_id ใน path ต้องตรงกัน */
+	userId, _ := c.Locals("user_id").(*uuid.UUID)
+	if userId == nil {
+		return fiber.NewError(http.StatusUnauthorized, "no permission to access")
+	}
+	if uuid.FromStringOrNil(c.Params("user_id")) != *userId {
+		return fiber.NewError(http.StatusForbidden, "no permission to access")
+	}
+	mealType := models.MealType(cast.ToString(params["meal_type"]))
+	var eatenAt *helper.Date
+	if val := cast.ToString(params["eaten_at"]); val != "" {
+		date := helper.NewDateFromString(val)
+		eatenAt = &date
+	}
+
+	diaries, err := d.diaryUs.CreateFoodDiariesFromPhoto(ctx, userId, mealType, eatenAt, models.NewMealPhotoDishesWithParams(params["dishes"]))
+	if err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message": "successful",
+		"diaries": diaries,
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     UpdateFoodDiary
+// @Description Edit a food diary entry
+// @Tags        diaries
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id  path string true "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       diary_id path string true "food diary id"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     404 {object} constants.ErrorResponse "food diary not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/diary/{user_id}/{diary_id} [put]
+func (d *diaryHandler) UpdateFoodDiary(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	params := c.Locals("params").(map[string]interface{})
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	diaryId := uuid.FromStringOrNil(c.Params("diary_id"))
+
+	existDiary, err := d.fetchOwnFoodDiary(c, &userId, &diaryId)
+	if err != nil {
+		return err
+	}
+	newDiary := models.NewFoodDiaryWithParams(params, existDiary)
+	newDiary.SetUpdatedAt()
+
+	if err := d.diaryUs.UpsertFoodDiary(ctx, newDiary); err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message": "successful",
+		"diary":   newDiary,
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     DeleteFoodDiary
+// @Description Delete a food diary entry
+// @Tags        diaries
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id  path string true "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       diary_id path string true "food diary id"
+// @Success     200 {object} map[string]interface{}
+// @Failure     404 {object} constants.ErrorResponse "food diary not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/diary/{user_id}/{diary_id} [delete]
+func (d *diaryHandler) DeleteFoodDiary(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	diaryId := uuid.FromStringOrNil(c.Params("diary_id"))
+
+	if _, err := d.fetchOwnFoodDiary(c, &userId, &diaryId); err != nil {
+		return err
+	}
+	if err := d.diaryUs.DeleteFoodDiary(ctx, &diaryId); err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message": "successful",
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+/* fetchOwnFoodDiary ดึงรายการและตรวจว่าเป็นของผู้ใช้ตาม path ไม่เช่นนั้นถือว่าไม่พบ */
+func (d *diaryHandler) fetchOwnFoodDiary(c *fiber.Ctx, userId *uuid.UUID, diaryId *uuid.UUID) (*models.FoodDiary, error) {
+	diary, err := d.diaryUs.FetchOneFoodDiaryById(c.UserContext(), diaryId)
+	if err != nil {
+		return nil, err
+	}
+	if diary.UserId == nil || *diary.UserId != *userId {
+		return nil, apperror.NotFound(constants.ERROR_FOOD_DIARY_NOT_FOUND)
+	}
+	return diary, nil
+}
+
+func queryDate(c *fiber.Ctx) *helper.Date {
+	var date helper.Date
+	if dateStr := c.Query("date"); dateStr != "" {
+		date = helper.NewDateFromString(dateStr)
+	} else {
+		date = helper.NewDateFromTime(time.Now())
+	}
+	return &date
+}
@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"encoding/json"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/middleware"
+	"healthmatefood-api/models"
+	diary_mocks "healthmatefood-api/service/diary/mocks"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFetchDailySummary(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	date := helper.NewDateFromString("2025-03-01")
+	entries := []*models.FoodDiary{
+		{MealType: models.MealTypeBreakfast, Calories: 300, Protein: 20, Carbohydrate: 30, Fat: 10},
+		{MealType: models.MealTypeLunch, Calories: 500, Protein: 25, Carbohydrate: 60, Fat: 15},
+	}
+	t.Run("success", func(t *testing.T) {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		diaryUs := new(diary_mocks.IDiaryUsecase)
+		diaryUs.On("FetchDailySummary", mock.Anything, &userId, &date).Return(models.NewDailySummary(&userId, &date, 2000, entries, nil), nil)
+		diaryHandler := NewDiaryHandler(diaryUs)
+		app.Get("/v1/diary/:user_id/summary", diaryHandler.FetchDailySummary)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/diary/"+userId.String()+"/summary?date=2025-03-01", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		body, _ := io.ReadAll(resp.Body)
+		result := map[string]*models.DailySummary{}
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, float64(800), result["summary"].Consumed.Calories)
+		assert.Equal(t, float64(1200), result["summary"].Remaining.Calories)
+		assert.Equal(t, float64(300), result["summary"].Meals[models.MealTypeBreakfast].Calories)
+	})
+	t.Run("success_with_activity", func(t *testing.T) {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		activities := []*models.ActivityLog{
+			{Intensity: models.IntensityModerate, DurationMinutes: 30, CaloriesBurned: 250.5},
+		}
+		diaryUs := new(diary_mocks.IDiaryUsecase)
+		diaryUs.On("FetchDailySummary", mock.Anything, &userId, &date).Return(models.NewDailySummary(&userId, &date, 2000, entries, activities), nil)
+		diaryHandler := NewDiaryHandler(diaryUs)
+		app.Get("/v1/diary/:user_id/summary", diaryHandler.FetchDailySummary)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/diary/"+userId.String()+"/summary?date=2025-03-01", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		body, _ := io.ReadAll(resp.Body)
+		result := map[string]*models.DailySummary{}
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 250.5, result["summary"].CaloriesBurned)
+		assert.Equal(t, 1450.5, result["summary"].Remaining.Calories)
+	})
+	t.Run("error_user_info_not_found", func(t *testing.T) {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		diaryUs := new(diary_mocks.IDiaryUsecase)
+		diaryUs.On("FetchDailySummary", mock.Anything, &userId, &date).Return(nil, apperror.NotFound(constants.ERROR_USER_INFO_NOT_FOUND))
+		diaryHandler := NewDiaryHandler(diaryUs)
+		app.Get("/v1/diary/:user_id/summary", diaryHandler.FetchDailySummary)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/diary/"+userId.String()+"/summary?date=2025-03-01", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestFetchPlanComparison(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	planId := uuid.FromStringOrNil("5b0e8f3a-2f4c-4d8e-9a31-6c1f0d7e2a10")
+	date := helper.NewDateFromString("2025-03-02")
+	startDate := helper.NewDateFromString("2025-03-01")
+	plan := &models.MealPlan{Id: &planId, UserId: &userId, Days: []*models.MealPlanDay{
+		{Day: 1, Meals: []*models.MealPlanMeal{{MealType: models.MealTypeLunch, Items: []*models.MealPlanItem{{Calories: 400}}}}},
+		{Day: 2, Meals: []*models.MealPlanMeal{
+			{MealType: models.MealTypeBreakfast, Items: []*models.MealPlanItem{{Calories: 300, Protein: 20}}},
+			{MealType: models.MealTypeLunch, Items: []*models.MealPlanItem{{Calories: 450, Protein: 30}, {Calories: 78, Protein: 6.3}}},
+		}},
+	}}
+	plan.SetDateRange(&startDate)
+	entries := []*models.FoodDiary{
+		{MealType: models.MealTypeBreakfast, Calories: 350, Protein: 18},
+		{MealType: models.MealTypeSnack, Calories: 120, Protein: 2},
+	}
+	t.Run("success", func(t *testing.T) {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		diaryUs := new(diary_mocks.IDiaryUsecase)
+		diaryUs.On("FetchPlanComparison", mock.Anything, &userId, &date).Return(models.NewMealPlanComparison(&userId, &date, plan, entries), nil)
+		diaryHandler := NewDiaryHandler(diaryUs)
+		app.Get("/v1/diary/:user_id/plan-comparison", diaryHandler.FetchPlanComparison)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/diary/"+userId.String()+"/plan-comparison?date=2025-03-02", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		body, _ := io.ReadAll(resp.Body)
+		result := map[string]*models.MealPlanComparison{}
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 2, result["comparison"].Day)
+		assert.Equal(t, float64(828), result["comparison"].Planned.Calories)
+		assert.Equal(t, float64(470), result["comparison"].Eaten.Calories)
+		assert.Equal(t, float64(-358), result["comparison"].Difference.Calories)
+		assert.Equal(t, float64(50), result["comparison"].Meals[models.MealTypeBreakfast].Difference.Calories)
+		assert.Equal(t, float64(-528), result["comparison"].Meals[models.MealTypeLunch].Difference.Calories)
+		assert.Equal(t, float64(120), result["comparison"].Meals[models.MealTypeSnack].Difference.Calories)
+	})
+	t.Run("error_active_meal_plan_not_found", func(t *testing.T) {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		diaryUs := new(diary_mocks.IDiaryUsecase)
+		diaryUs.On("FetchPlanComparison", mock.Anything, &userId, &date).Return(nil, apperror.NotFound(constants.ERROR_ACTIVE_MEAL_PLAN_NOT_FOUND))
+		diaryHandler := NewDiaryHandler(diaryUs)
+		app.Get("/v1/diary/:user_id/plan-comparison", diaryHandler.FetchPlanComparison)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/diary/"+userId.String()+"/plan-comparison?date=2025-03-02", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestCreateFoodDiary(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	body := `{"meal_type":"LUNCH","name":"ข้าวมันไก่","calories":596}`
+	newApp := func(diaryUs *diary_mocks.IDiaryUsecase) *fiber.App {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		diaryHandler := NewDiaryHandler(diaryUs)
+		app.Post("/v1/diary/:user_id", func(c *fiber.Ctx) error {
+			params := map[string]interface{}{}
+			if err := json.Unmarshal(c.Body(), &params); err != nil {
+				return err
+			}
+			c.Locals("params", params)
+			return diaryHandler.CreateFoodDiary(c)
+		})
+		return app
+	}
+	t.Run("success", func(t *testing.T) {
+		diaryUs := new(diary_mocks.IDiaryUsecase)
+		diaryUs.On("UpsertFoodDiary", mock.Anything, mock.AnythingOfType("*models.FoodDiary")).Return(nil).Run(func(args mock.Arguments) {
+			diary := args.Get(1).(*models.FoodDiary)
+			assert.NotNil(t, diary.Id)
+			assert.Equal(t, userId, *diary.UserId)
+			assert.Equal(t, models.MealTypeLunch, diary.MealType)
+			assert.Equal(t, "ข้าวมันไก่", diary.Name)
+			assert.Equal(t, float64(596), diary.Calories)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/diary/"+userId.String(), strings.NewReader(body))
+		req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+		resp, err := newApp(diaryUs).Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+	t.Run("error_meal_type_is_invalid", func(t *testing.T) {
+		diaryUs := new(diary_mocks.IDiaryUsecase)
+		diaryUs.On("UpsertFoodDiary", mock.Anything, mock.AnythingOfType("*models.FoodDiary")).Return(apperror.Validation(constants.ERROR_MEAL_TYPE_IS_INVALID))
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/diary/"+userId.String(), strings.NewReader(body))
+		req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+		resp, err := newApp(diaryUs).Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestCreateFoodDiariesFromPhoto(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	otherUserId := uuid.FromStringOrNil("0c3b6f8e-1d2a-4e5f-8a9b-7c6d5e4f3a21")
+	body := `{"meal_type":"LUNCH","eaten_at":"2025-03-02","dishes":[{"name":"ข้าวมันไก่","portion":"1 จาน","calories":596,"protein":25,"carbohydrate":70,"fat":22,"confidence":0.8}]}`
+	newApp := func(diaryUs *diary_mocks.IDiaryUsecase) *fiber.App {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		diaryHandler := NewDiaryHandler(diaryUs)
+		app.Post("/v1/diary/:user_id/photo", func(c *fiber.Ctx) error {
+			params := map[string]interface{}{}
+			if err := json.Unmarshal(c.Body(), &params); err != nil {
+				return err
+			}
+			c.Locals("user_id", &userId)
+			c.Locals("params", params)
+			return diaryHandler.CreateFoodDiariesFromPhoto(c)
+		})
+		return app
+	}
+	t.Run("success", func(t *testing.T) {
+		diaryUs := new(diary_mocks.IDiaryUsecase)
+		diaryUs.On("CreateFoodDiariesFromPhoto", mock.Anything, &userId, models.MealTypeLunch, mock.AnythingOfType("*helper.Date"), mock.MatchedBy(func(dishes []*models.MealPhotoDish) bool {
+			return len(dishes) == 1 && dishes[0].Name == "ข้าวมันไก่" && dishes[0].Calories == 596 && dishes[0].Portion == "1 จาน"
+		})).Return([]*models.FoodDiary{{Name: "ข้าวมันไก่"}}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/diary/"+userId.String()+"/photo", strings.NewReader(body))
+		req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+		resp, err := newApp(diaryUs).Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		diaryUs.AssertExpectations(t)
+	})
+	t.Run("error_other_user", func(t *testing.T) {
+		diaryUs := new(diary_mocks.IDiaryUsecase)
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/diary/"+otherUserId.String()+"/photo", strings.NewReader(body))
+		req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+		resp, err := newApp(diaryUs).Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		diaryUs.AssertNotCalled(t, "CreateFoodDiariesFromPhoto", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("error_meal_photo_is_invalid", func(t *testing.T) {
+		diaryUs := new(diary_mocks.IDiaryUsecase)
+		diaryUs.On("CreateFoodDiariesFromPhoto", mock.Anything, &userId, models.MealTypeLunch, mock.Anything, mock.Anything).
+			Return(nil, apperror.Validation(constants.ERROR_MEAL_PHOTO_IS_INVALID).WithDetail("dishes[0].name: must not be empty"))
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/diary/"+userId.String()+"/photo", strings.NewReader(body))
+		req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+		resp, err := newApp(diaryUs).Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,148 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "healthmatefood-api/models"
+
+	sync "sync"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IDiaryRepository is an autogenerated mock type for the IDiaryRepository type
+type IDiaryRepository struct {
+	mock.Mock
+}
+
+// DeleteFoodDiary provides a mock function with given fields: ctx, id
+func (_m *IDiaryRepository) DeleteFoodDiary(ctx context.Context, id *uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFoodDiary")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchAllFoodDiaries provides a mock function with given fields: ctx, args
+func (_m *IDiaryRepository) FetchAllFoodDiaries(ctx context.Context, args *sync.Map) ([]*models.FoodDiary, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllFoodDiaries")
+	}
+
+	var r0 []*models.FoodDiary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.FoodDiary, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.FoodDiary); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.FoodDiary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchOneFoodDiaryById provides a mock function with given fields: ctx, id
+func (_m *IDiaryRepository) FetchOneFoodDiaryById(ctx context.Context, id *uuid.UUID) (*models.FoodDiary, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOneFoodDiaryById")
+	}
+
+	var r0 *models.FoodDiary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.FoodDiary, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.FoodDiary); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FoodDiary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertFoodDiaries provides a mock function with given fields: ctx, diaries
+func (_m *IDiaryRepository) UpsertFoodDiaries(ctx context.Context, diaries []*models.FoodDiary) error {
+	ret := _m.Called(ctx, diaries)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertFoodDiaries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.FoodDiary) error); ok {
+		r0 = rf(ctx, diaries)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertFoodDiary provides a mock function with given fields: ctx, _a1
+func (_m *IDiaryRepository) UpsertFoodDiary(ctx context.Context, _a1 *models.FoodDiary) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertFoodDiary")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.FoodDiary) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIDiaryRepository creates a new instance of IDiaryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIDiaryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IDiaryRepository {
+	mock := &IDiaryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
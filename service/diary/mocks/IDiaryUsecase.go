@@ -0,0 +1,222 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	helper "github.com/Pheethy/psql/helper"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "healthmatefood-api/models"
+
+	sync "sync"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IDiaryUsecase is an autogenerated mock type for the IDiaryUsecase type
+type IDiaryUsecase struct {
+	mock.Mock
+}
+
+// CreateFoodDiariesFromPhoto provides a mock function with given fields: ctx, userId, mealType, eatenAt, dishes
+func (_m *IDiaryUsecase) CreateFoodDiariesFromPhoto(ctx context.Context, userId *uuid.UUID, mealType models.MealType, eatenAt *helper.Date, dishes []*models.MealPhotoDish) ([]*models.FoodDiary, error) {
+	ret := _m.Called(ctx, userId, mealType, eatenAt, dishes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFoodDiariesFromPhoto")
+	}
+
+	var r0 []*models.FoodDiary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, models.MealType, *helper.Date, []*models.MealPhotoDish) ([]*models.FoodDiary, error)); ok {
+		return rf(ctx, userId, mealType, eatenAt, dishes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, models.MealType, *helper.Date, []*models.MealPhotoDish) []*models.FoodDiary); ok {
+		r0 = rf(ctx, userId, mealType, eatenAt, dishes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.FoodDiary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, models.MealType, *helper.Date, []*models.MealPhotoDish) error); ok {
+		r1 = rf(ctx, userId, mealType, eatenAt, dishes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteFoodDiary provides a mock function with given fields: ctx, id
+func (_m *IDiaryUsecase) DeleteFoodDiary(ctx context.Context, id *uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFoodDiary")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchAllFoodDiaries provides a mock function with given fields: ctx, args
+func (_m *IDiaryUsecase) FetchAllFoodDiaries(ctx context.Context, args *sync.Map) ([]*models.FoodDiary, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllFoodDiaries")
+	}
+
+	var r0 []*models.FoodDiary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.FoodDiary, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.FoodDiary); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.FoodDiary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchDailySummary provides a mock function with given fields: ctx, userId, date
+func (_m *IDiaryUsecase) FetchDailySummary(ctx context.Context, userId *uuid.UUID, date *helper.Date) (*models.DailySummary, error) {
+	ret := _m.Called(ctx, userId, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchDailySummary")
+	}
+
+	var r0 *models.DailySummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *helper.Date) (*models.DailySummary, error)); ok {
+		return rf(ctx, userId, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *helper.Date) *models.DailySummary); ok {
+		r0 = rf(ctx, userId, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DailySummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, *helper.Date) error); ok {
+		r1 = rf(ctx, userId, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchOneFoodDiaryById provides a mock function with given fields: ctx, id
+func (_m *IDiaryUsecase) FetchOneFoodDiaryById(ctx context.Context, id *uuid.UUID) (*models.FoodDiary, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOneFoodDiaryById")
+	}
+
+	var r0 *models.FoodDiary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.FoodDiary, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.FoodDiary); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FoodDiary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchPlanComparison provides a mock function with given fields: ctx, userId, date
+func (_m *IDiaryUsecase) FetchPlanComparison(ctx context.Context, userId *uuid.UUID, date *helper.Date) (*models.MealPlanComparison, error) {
+	ret := _m.Called(ctx, userId, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchPlanComparison")
+	}
+
+	var r0 *models.MealPlanComparison
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *helper.Date) (*models.MealPlanComparison, error)); ok {
+		return rf(ctx, userId, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *helper.Date) *models.MealPlanComparison); ok {
+		r0 = rf(ctx, userId, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MealPlanComparison)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, *helper.Date) error); ok {
+		r1 = rf(ctx, userId, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertFoodDiary provides a mock function with given fields: ctx, _a1
+func (_m *IDiaryUsecase) UpsertFoodDiary(ctx context.Context, _a1 *models.FoodDiary) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertFoodDiary")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.FoodDiary) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIDiaryUsecase creates a new instance of IDiaryUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIDiaryUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IDiaryUsecase {
+	mock := &IDiaryUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,17 @@
+package diary
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type IDiaryRepository interface {
+	FetchAllFoodDiaries(ctx context.Context, args *sync.Map) ([]*models.FoodDiary, error)
+	FetchOneFoodDiaryById(ctx context.Context, id *uuid.UUID) (*models.FoodDiary, error)
+	UpsertFoodDiary(ctx context.Context, diary *models.FoodDiary) error
+	UpsertFoodDiaries(ctx context.Context, diaries []*models.FoodDiary) error
+	DeleteFoodDiary(ctx context.Context, id *uuid.UUID) error
+}
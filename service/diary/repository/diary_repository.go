@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/diary"
+	"strings"
+	"sync"
+
+	"github.com/Pheethy/sqlx"
+	"github.com/gofrs/uuid"
+)
+
+type diaryRepository struct {
+	psqlDB *sqlx.DB
+}
+
+func NewDiaryRepository(psqlDB *sqlx.DB) diary.IDiaryRepository {
+	return &diaryRepository{
+		psqlDB: psqlDB,
+	}
+}
+
+const selectFoodDiary = `
+        "food_diaries"."id",
+        "food_diaries"."user_id",
+        "food_diaries"."food_id",
+        "food_diaries"."recipe_id",
+        "food_diaries"."name",
+        "food_diaries"."meal_type",
+        "food_diaries"."quantity",
+        "food_diaries"."unit",
+        "food_diaries"."calories",
+        "food_diaries"."protein",
+        "food_diaries"."carbohydrate",
+        "food_diaries"."fat",
+        COALESCE("food_diaries"."note", '') "note",
+        to_char("food_diaries"."eaten_at", 'yyyy-MM-dd') "eaten_at",
+        to_char("food_diaries"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+        to_char("food_diaries"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at",
+        (
+          SELECT
+            to_jsonb("FOOD")
+          FROM (
+            SELECT
+              "foods"."id",
+              "foods"."name",
+              "foods"."serving_size",
+              "foods"."serving_unit",
+              "foods"."calories",
+              "foods"."protein",
+              "foods"."carbohydrate",
+              "foods"."fat",
+              to_char("foods"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+              to_char("foods"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at"
+            FROM
+              "foods"
+            WHERE
+              "foods"."id" = "food_diaries"."food_id"
+          ) AS "FOOD"
+        ) AS "food"`
+
+func (d *diaryRepository) FetchAllFoodDiaries(ctx context.Context, args *sync.Map) ([]*models.FoodDiary, error) {
+	var conds []interface{}
+	var wheres []string
+	if userId, ok := args.Load("user_id"); ok {
+		conds = append(conds, userId)
+		wheres = append(wheres, fmt.Sprintf(`"food_diaries"."user_id" = $%d::uuid`, len(conds)))
+	}
+	if eatenAt, ok := args.Load("eaten_at"); ok {
+		conds = append(conds, eatenAt)
+		wheres = append(wheres, fmt.Sprintf(`"food_diaries"."eaten_at" = $%d::date`, len(conds)))
+	}
+	if startDate, ok := args.Load("start_date"); ok {
+		conds = append(conds, startDate)
+		wheres = append(wheres, fmt.Sprintf(`"food_diaries"."eaten_at" >= $%d::date`, len(conds)))
+	}
+	if endDate, ok := args.Load("end_date"); ok {
+		conds = append(conds, endDate)
+		wheres = append(wheres, fmt.Sprintf(`"food_diaries"."eaten_at" <= $%d::date`, len(conds)))
+	}
+	if mealType, ok := args.Load("meal_type"); ok {
+		conds = append(conds, mealType)
+		wheres = append(wheres, fmt.Sprintf(`"food_diaries"."meal_type" = $%d::meal_type`, len(conds)))
+	}
+	where := ""
+	if len(wheres) > 0 {
+		where = "WHERE " + strings.Join(wheres, " AND ")
+	}
+
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        %s
+      FROM
+        "food_diaries"
+      %s
+      ORDER BY
+        "food_diaries"."eaten_at" ASC,
+        "food_diaries"."meal_type" ASC,
+        "food_diaries"."created_at" ASC
+    ) AS "json_data"
+  `, selectFoodDiary, where)
+
+	stmt, err := d.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, conds...).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	diaries := make([]*models.FoodDiary, 0)
+	if err := json.Unmarshal(jsonData, &diaries); err != nil {
+		return nil, err
+	}
+
+	return diaries, nil
+}
+
+func (d *diaryRepository) FetchOneFoodDiaryById(ctx context.Context, id *uuid.UUID) (*models.FoodDiary, error) {
+	sql := fmt.Sprintf(`
+    SELECT
+      to_jsonb("json_data")
+    FROM (
+      SELECT
+        %s
+      FROM
+        "food_diaries"
+      WHERE
+        "food_diaries"."id" = $1::uuid
+    ) AS "json_data"
+  `, selectFoodDiary)
+
+	stmt, err := d.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, id).Scan(&jsonData); err != nil {
+		return nil, apperror.FromDB(err, constants.ERROR_FOOD_DIARY_NOT_FOUND)
+	}
+
+	diary := new(models.FoodDiary)
+	if err := json.Unmarshal(jsonData, &diary); err != nil {
+		return nil, err
+	}
+
+	return diary, nil
+}
+
+func (d *diaryRepository) UpsertFoodDiary(ctx context.Context, diary *models.FoodDiary) error {
+	return d.UpsertFoodDiaries(ctx, []*models.FoodDiary{diary})
+}
+
+/* UpsertFoodDiaries บันทึกหลายรายการใน transaction เดียว ถ้ารายการใดผิดพลาดจะไม่บันทึกเลย */
+func (d *diaryRepository) UpsertFoodDiaries(ctx context.Context, diaries []*models.FoodDiary) error {
+	tx, err := d.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+	sql := `
+    INSERT INTO "food_diaries" (
+      "id",
+      "user_id",
+      "food_id",
+      "recipe_id",
+      "name",
+      "meal_type",
+      "quantity",
+      "unit",
+      "calories",
+      "protein",
+      "carbohydrate",
+      "fat",
+      "note",
+      "eaten_at",
+      "created_at",
+      "updated_at"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::uuid,
+      $4::uuid,
+      $5::text,
+      $6::meal_type,
+      $7::float,
+      $8::text,
+      $9::float,
+      $10::float,
+      $11::float,
+      $12::float,
+      $13::text,
+      $14::date,
+      $15::timestamp,
+      $16::timestamp
+    )
+    ON CONFLICT (id)
+    DO UPDATE SET
+      food_id=$17::uuid,
+      recipe_id=$18::uuid,
+      name=$19::text,
+      meal_type=$20::meal_type,
+      quantity=$21::float,
+      unit=$22::text,
+      calories=$23::float,
+      protein=$24::float,
+      carbohydrate=$25::float,
+      fat=$26::float,
+      note=$27::text,
+      eaten_at=$28::date,
+      updated_at=$29::timestamp
+  `
+	stmt, err := tx.PreparexContext(ctx, sql)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for _, diary := range diaries {
+		_, err = stmt.ExecContext(ctx,
+			/* Create */
+			diary.Id,
+			diary.UserId,
+			diary.FoodId,
+			diary.RecipeId,
+			diary.Name,
+			diary.MealType,
+			diary.Quantity,
+			diary.Unit,
+			diary.Calories,
+			diary.Protein,
+			diary.Carbohydrate,
+			diary.Fat,
+			diary.Note,
+			diary.EatenAt.String(),
+			diary.CreatedAt,
+			diary.UpdatedAt,
+			/* Update */
+			diary.FoodId,
+			diary.RecipeId,
+			diary.Name,
+			diary.MealType,
+			diary.Quantity,
+			diary.Unit,
+			diary.Calories,
+			diary.Protein,
+			diary.Carbohydrate,
+			diary.Fat,
+			diary.Note,
+			diary.EatenAt.String(),
+			diary.UpdatedAt,
+		)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (d *diaryRepository) DeleteFoodDiary(ctx context.Context, id *uuid.UUID) error {
+	tx, err := d.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+	sql := `
+    DELETE FROM
+      "food_diaries"
+    WHERE
+      "food_diaries"."id" = $1::uuid
+  `
+	stmt, err := tx.PreparexContext(ctx, sql)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		tx.Rollback()
+		return apperror.NotFound(constants.ERROR_FOOD_DIARY_NOT_FOUND)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,20 @@
+package diary
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+)
+
+type IDiaryUsecase interface {
+	FetchAllFoodDiaries(ctx context.Context, args *sync.Map) ([]*models.FoodDiary, error)
+	FetchOneFoodDiaryById(ctx context.Context, id *uuid.UUID) (*models.FoodDiary, error)
+	FetchDailySummary(ctx context.Context, userId *uuid.UUID, date *helper.Date) (*models.DailySummary, error)
+	FetchPlanComparison(ctx context.Context, userId *uuid.UUID, date *helper.Date) (*models.MealPlanComparison, error)
+	UpsertFoodDiary(ctx context.Context, diary *models.FoodDiary) error
+	CreateFoodDiariesFromPhoto(ctx context.Context, userId *uuid.UUID, mealType models.MealType, eatenAt *helper.Date, dishes []*models.MealPhotoDish) ([]*models.FoodDiary, error)
+	DeleteFoodDiary(ctx context.Context, id *uuid.UUID) error
+}
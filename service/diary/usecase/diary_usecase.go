@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/activity"
+	"healthmatefood-api/service/diary"
+	"healthmatefood-api/service/food"
+	"healthmatefood-api/service/mealplan"
+	"healthmatefood-api/service/recipe"
+	"healthmatefood-api/service/user"
+	"sync"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+)
+
+type diaryUsecase struct {
+	diaryRepo    diary.IDiaryRepository
+	foodRepo     food.IFoodRepository
+	recipeRepo   recipe.IRecipeRepository
+	activityRepo activity.IActivityRepository
+	mealPlanRepo mealplan.IMealPlanRepository
+	userUs       user.IUserUsecase
+}
+
+func NewDiaryUsecase(diaryRepo diary.IDiaryRepository, foodRepo food.IFoodRepository, recipeRepo recipe.IRecipeRepository, activityRepo activity.IActivityRepository, mealPlanRepo mealplan.IMealPlanRepository, userUs user.IUserUsecase) diary.IDiaryUsecase {
+	return &diaryUsecase{
+		diaryRepo:    diaryRepo,
+		foodRepo:     foodRepo,
+		recipeRepo:   recipeRepo,
+		activityRepo: activityRepo,
+		mealPlanRepo: mealPlanRepo,
+		userUs:       userUs,
+	}
+}
+
+func (d *diaryUsecase) FetchAllFoodDiaries(ctx context.Context, args *sync.Map) ([]*models.FoodDiary, error) {
+	return d.diaryRepo.FetchAllFoodDiaries(ctx, args)
+}
+
+func (d *diaryUsecase) FetchOneFoodDiaryById(ctx context.Context, id *uuid.UUID) (*models.FoodDiary, error) {
+	return d.diaryRepo.FetchOneFoodDiaryById(ctx, id)
+}
+
+func (d *diaryUsecase) FetchDailySummary(ctx context.Context, userId *uuid.UUID, date *helper.Date) (*models.DailySummary, error) {
+	userInfo, err := d.userUs.FetchOneUserInfoByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	userInfo.GetBMR()
+	userInfo.GetCaloriesLimit()
+
+	args := new(sync.Map)
+	args.Store("user_id", userId)
+	args.Store("eaten_at", date.String())
+	entries, err := d.diaryRepo.FetchAllFoodDiaries(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	activityArgs := new(sync.Map)
+	activityArgs.Store("user_id", userId)
+	activityArgs.Store("performed_at", date.String())
+	activities, err := d.activityRepo.FetchAllActivityLogs(ctx, activityArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewDailySummary(userId, date, userInfo.CaloriesLimit, entries, activities), nil
+}
+
+/* FetchPlanComparison เทียบรายการในไดอารี่ของวันนั้นกับแผนอาหารที่ active และครอบคลุมวันนั้น */
+func (d *diaryUsecase) FetchPlanComparison(ctx context.Context, userId *uuid.UUID, date *helper.Date) (*models.MealPlanComparison, error) {
+	planArgs := new(sync.Map)
+	planArgs.Store("user_id", userId)
+	planArgs.Store("is_active", true)
+	planArgs.Store("date", date.String())
+	plans, err := d.mealPlanRepo.FetchAllMealPlans(ctx, planArgs)
+	if err != nil {
+		return nil, err
+	}
+	if len(plans) == 0 {
+		return nil, apperror.NotFound(constants.ERROR_ACTIVE_MEAL_PLAN_NOT_FOUND)
+	}
+
+	args := new(sync.Map)
+	args.Store("user_id", userId)
+	args.Store("eaten_at", date.String())
+	entries, err := d.diaryRepo.FetchAllFoodDiaries(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewMealPlanComparison(userId, date, plans[0], entries), nil
+}
+
+func (d *diaryUsecase) UpsertFoodDiary(ctx context.Context, diary *models.FoodDiary) error {
+	if ok := diary.IsMealType(); !ok {
+		return apperror.Validation(constants.ERROR_MEAL_TYPE_IS_INVALID)
+	}
+	diary.SetEatenAtIfEmpty()
+
+	/* รายการที่อ้างอิงอาหารหรือสูตรในระบบจะคำนวณค่าโภชนาการใหม่ทุกครั้ง ส่วนรายการที่พิมพ์เองใช้ค่าที่ผู้ใช้ส่งมา */
+	switch {
+	case diary.RecipeId != nil:
+		recipe, err := d.recipeRepo.FetchOneRecipeById(ctx, diary.RecipeId)
+		if err != nil {
+			return err
+		}
+		diary.FoodId = nil
+		diary.Food = nil
+		diary.SetNutritionFromRecipe(recipe)
+	case diary.FoodId != nil:
+		if diary.Unit != "" && !models.IsUnit(diary.Unit) {
+			return apperror.Validation(constants.ERROR_UNIT_IS_INVALID)
+		}
+		food, err := d.foodRepo.FetchOneFoodById(ctx, diary.FoodId)
+		if err != nil {
+			return err
+		}
+		diary.SetNutritionFromFood(food)
+	default:
+		diary.FoodId = nil
+		diary.Food = nil
+		if diary.Unit == "" {
+			diary.Unit = models.UNIT_SERVING
+		}
+		if diary.Quantity <= 0 {
+			diary.Quantity = 1
+		}
+	}
+
+	return d.diaryRepo.UpsertFoodDiary(ctx, diary)
+}
+
+/* CreateFoodDiariesFromPhoto บันทึกจานที่ผู้ใช้ยืนยันจากผลวิเคราะห์รูป (แก้ไขค่าได้ก่อนยืนยัน) เป็นรายการแบบพิมพ์เองในมื้อเดียวกัน */
+func (d *diaryUsecase) CreateFoodDiariesFromPhoto(ctx context.Context, userId *uuid.UUID, mealType models.MealType, eatenAt *helper.Date, dishes []*models.MealPhotoDish) ([]*models.FoodDiary, error) {
+	if err := models.ValidateMealPhotoDishes(dishes, true); err != nil {
+		return nil, apperror.Validation(constants.ERROR_MEAL_PHOTO_IS_INVALID).Wrap(err)
+	}
+
+	diaries := make([]*models.FoodDiary, 0, len(dishes))
+	for _, dish := range dishes {
+		diary := dish.ToFoodDiary(userId, mealType, eatenAt)
+		if ok := diary.IsMealType(); !ok {
+			return nil, apperror.Validation(constants.ERROR_MEAL_TYPE_IS_INVALID)
+		}
+		diary.SetEatenAtIfEmpty()
+		diaries = append(diaries, diary)
+	}
+	if err := d.diaryRepo.UpsertFoodDiaries(ctx, diaries); err != nil {
+		return nil, err
+	}
+	return diaries, nil
+}
+
+func (d *diaryUsecase) DeleteFoodDiary(ctx context.Context, id *uuid.UUID) error {
+	return d.diaryRepo.DeleteFoodDiary(ctx, id)
+}
@@ -0,0 +1,214 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofiber/fiber/v2"
+	"github.com/spf13/cast"
+)
+
+type Validation struct{}
+
+func (v Validation) ValidateCreateFoodDiary() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		if params == nil {
+			return apperror.Validation(constants.ERROR_BODY_WAS_MISSING)
+		}
+		var key string
+		fields := apperror.Fields{}
+
+		/* key params */
+		key = "meal_type"
+		if mealType, ok := params[key]; !ok {
+			fields.Missing(key)
+		} else {
+			fields.Check(key, validation.Validate(mealType, validation.By(helper.ValidateTypeString)))
+		}
+
+		/* อ้างอิงอาหารหรือสูตรในระบบต้องระบุปริมาณ ถ้าพิมพ์เองต้องระบุชื่อและพลังงาน */
+		foodId, foodIdOK := params["food_id"]
+		recipeId, recipeIdOK := params["recipe_id"]
+		hasFood := foodIdOK && cast.ToString(foodId) != ""
+		hasRecipe := recipeIdOK && cast.ToString(recipeId) != ""
+		if hasFood || hasRecipe {
+			validateRefParams(params, &fields)
+
+			key = "quantity"
+			if quantity, ok := params[key]; !ok {
+				fields.Missing(key)
+			} else {
+				fields.Check(key, validation.Validate(quantity, validation.By(validatePositiveNumber)))
+			}
+		} else {
+			key = "name"
+			if name, ok := params[key]; !ok {
+				fields.Missing(key)
+			} else {
+				fields.Check(key, validation.Validate(name, validation.By(helper.ValidateTypeString)))
+			}
+
+			key = "calories"
+			if calories, ok := params[key]; !ok {
+				fields.Missing(key)
+			} else {
+				fields.Check(key, validation.Validate(calories, validation.By(validateNonNegativeNumber)))
+			}
+		}
+
+		validateOptionalParams(params, &fields)
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateCreateFoodDiariesFromPhoto() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		if params == nil {
+			return apperror.Validation(constants.ERROR_BODY_WAS_MISSING)
+		}
+
+		fields := apperror.Fields{}
+		key := "meal_type"
+		if mealType, ok := params[key]; !ok {
+			fields.Missing(key)
+		} else {
+			fields.Check(key, validation.Validate(mealType, validation.By(helper.ValidateTypeString)))
+		}
+		key = "eaten_at"
+		if eatenAt, ok := params[key]; ok {
+			fields.Check(key, validation.Validate(eatenAt, validation.By(ValidateDate)))
+		}
+		key = "dishes"
+		if dishes, ok := params[key]; !ok {
+			fields.Missing(key)
+		} else if items, err := cast.ToSliceE(dishes); err != nil || len(items) == 0 {
+			fields.Add(key, "must be a non-empty array")
+		} else {
+			for index, item := range items {
+				if _, err := cast.ToStringMapE(item); err != nil {
+					fields.Add(fmt.Sprintf("%s[%d]", key, index), "must be an object")
+				}
+			}
+		}
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateUpdateFoodDiary() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		if params == nil {
+			return apperror.Validation(constants.ERROR_BODY_WAS_MISSING)
+		}
+		var key string
+		fields := apperror.Fields{}
+
+		/* key params */
+		validateRefParams(params, &fields)
+		key = "quantity"
+		if quantity, quantityOK := params[key]; quantityOK {
+			fields.Check(key, validation.Validate(quantity, validation.By(validatePositiveNumber)))
+		}
+
+		validateOptionalParams(params, &fields)
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateParams(key string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params := c.Params(key)
+		if err := validation.Validate(params, validation.By(helper.ValidateTypeUUID)); err != nil {
+			return apperror.Field(key, err.Error())
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateQueryDate(key string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		date := c.Query(key)
+		if date == "" {
+			return c.Next()
+		}
+		if err := validation.Validate(date, validation.By(ValidateDate)); err != nil {
+			return apperror.Field(key, err.Error())
+		}
+		return c.Next()
+	}
+}
+
+func validateRefParams(params map[string]interface{}, fields *apperror.Fields) {
+	foodId, foodIdOK := params["food_id"]
+	recipeId, recipeIdOK := params["recipe_id"]
+	if foodIdOK && cast.ToString(foodId) != "" && recipeIdOK && cast.ToString(recipeId) != "" {
+		fields.Add("food_id, recipe_id", "must send only one")
+		return
+	}
+	for _, key := range []string{"food_id", "recipe_id"} {
+		if val, ok := params[key]; ok && cast.ToString(val) != "" {
+			fields.Check(key, validation.Validate(val, validation.By(helper.ValidateTypeUUID)))
+		}
+	}
+}
+
+func validateOptionalParams(params map[string]interface{}, fields *apperror.Fields) {
+	for _, key := range []string{"protein", "carbohydrate", "fat"} {
+		if val, ok := params[key]; ok {
+			fields.Check(key, validation.Validate(val, validation.By(validateNonNegativeNumber)))
+		}
+	}
+	key := "eaten_at"
+	if eatenAt, ok := params[key]; ok {
+		fields.Check(key, validation.Validate(eatenAt, validation.By(ValidateDate)))
+	}
+}
+
+/* ValidateDate ตรวจรูปแบบวันที่ yyyy-MM-dd */
+func ValidateDate(val interface{}) error {
+	if err := helper.ValidateTypeString(val); err != nil {
+		return err
+	}
+	if _, err := time.Parse(helper.DateLayout, val.(string)); err != nil {
+		return errors.New("is not date format yyyy-MM-dd")
+	}
+	return nil
+}
+
+func validatePositiveNumber(val interface{}) error {
+	number, err := cast.ToFloat64E(val)
+	if err != nil {
+		return errors.New("is not type number")
+	}
+	if number <= 0 {
+		return errors.New("must be greater than 0")
+	}
+	return nil
+}
+
+func validateNonNegativeNumber(val interface{}) error {
+	number, err := cast.ToFloat64E(val)
+	if err != nil {
+		return errors.New("is not type number")
+	}
+	if number < 0 {
+		return errors.New("must not be negative")
+	}
+	return nil
+}
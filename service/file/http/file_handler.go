@@ -32,7 +32,7 @@ func (f *fileHandler) UploadFile(c *fiber.Ctx) error {
 
 	form, err := c.MultipartForm()
 	if err != nil {
-		return fiber.NewError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 	/* ทำการรับ Files จาก Form */
 	files := form.File["files"]
@@ -59,7 +59,7 @@ func (f *fileHandler) UploadFile(c *fiber.Ctx) error {
 
 	newFileInfo, err := f.fileUs.UploadToGCP(ctx, req)
 	if err != nil {
-		return fiber.NewError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	resp := map[string]interface{}{
@@ -73,11 +73,11 @@ func (f *fileHandler) UploadFile(c *fiber.Ctx) error {
 func (f *fileHandler) DeleteFile(c *fiber.Ctx) error {
 	req := make([]*models.DeleteFileReq, 0)
 	if err := c.BodyParser(req); err != nil {
-		return fiber.NewError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	if err := f.fileUs.DeleteOnGCP(req); err != nil {
-		return fiber.NewError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	resp := map[string]interface{}{
@@ -0,0 +1,8 @@
+package food
+
+import "github.com/gofiber/fiber/v2"
+
+type IFoodHandler interface {
+	FetchAllFoods(c *fiber.Ctx) error
+	FetchOneFoodById(c *fiber.Ctx) error
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"healthmatefood-api/service/food"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+)
+
+type foodHandler struct {
+	foodUs food.IFoodUsecase
+}
+
+func NewFoodHandler(foodUs food.IFoodUsecase) food.IFoodHandler {
+	return &foodHandler{
+		foodUs: foodUs,
+	}
+}
+
+// @Summary     FetchAllFoods
+// @Description Get list foods with nutrition per 100 g
+// @Tags        foods
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       search_word query string false "example: ข้าว"
+// @Param       page        query int    false "example: 1"
+// @Param       per_page    query int    false "example: 10"
+// @Success     200         {object}     map[string]interface{}
+// @Failure     500         {object}     constants.ErrorResponse
+// @Router      /v1/food/list [get]
+func (f *foodHandler) FetchAllFoods(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	args := new(sync.Map)
+	searchWord := c.Query("search_word")
+	page, pageErr := strconv.Atoi(c.Query("page", "1"))
+	perPage, perPageErr := strconv.Atoi(c.Query("per_page", "10"))
+	if pageErr == nil && perPageErr == nil && page > 0 && perPage > 0 {
+		args.Store("page", page)
+		args.Store("per_page", perPage)
+	}
+	if searchWord != "" {
+		args.Store("search_word", searchWord)
+	}
+
+	foods, err := f.foodUs.FetchAllFoods(ctx, args)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"foods": foods,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     FetchOneFoodById
+// @Description Get one food
+// @Tags        foods
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       food_id path string true "example:0b3f1c55-2a5e-4f0b-9a43-6f1f5b7b6a01"
+// @Success     200         {object}     map[string]interface{}
+// @Failure     404         {object}     constants.ErrorResponse
+// @Failure     500         {object}     constants.ErrorResponse
+// @Router      /v1/food/{food_id} [get]
+func (f *foodHandler) FetchOneFoodById(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	id := uuid.FromStringOrNil(c.Params("food_id"))
+
+	food, err := f.foodUs.FetchOneFoodById(ctx, &id)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"food": food,
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
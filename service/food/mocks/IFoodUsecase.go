@@ -0,0 +1,94 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "healthmatefood-api/models"
+
+	sync "sync"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IFoodUsecase is an autogenerated mock type for the IFoodUsecase type
+type IFoodUsecase struct {
+	mock.Mock
+}
+
+// FetchAllFoods provides a mock function with given fields: ctx, args
+func (_m *IFoodUsecase) FetchAllFoods(ctx context.Context, args *sync.Map) ([]*models.Food, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllFoods")
+	}
+
+	var r0 []*models.Food
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.Food, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.Food); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Food)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchOneFoodById provides a mock function with given fields: ctx, id
+func (_m *IFoodUsecase) FetchOneFoodById(ctx context.Context, id *uuid.UUID) (*models.Food, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOneFoodById")
+	}
+
+	var r0 *models.Food
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.Food, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.Food); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Food)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIFoodUsecase creates a new instance of IFoodUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIFoodUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IFoodUsecase {
+	mock := &IFoodUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,14 @@
+package food
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type IFoodRepository interface {
+	FetchAllFoods(ctx context.Context, args *sync.Map) ([]*models.Food, error)
+	FetchOneFoodById(ctx context.Context, id *uuid.UUID) (*models.Food, error)
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/food"
+	"sync"
+
+	"github.com/Pheethy/sqlx"
+	"github.com/gofrs/uuid"
+)
+
+type foodRepository struct {
+	psqlDB *sqlx.DB
+}
+
+func NewFoodRepository(psqlDB *sqlx.DB) food.IFoodRepository {
+	return &foodRepository{
+		psqlDB: psqlDB,
+	}
+}
+
+func (f *foodRepository) FetchAllFoods(ctx context.Context, args *sync.Map) ([]*models.Food, error) {
+	var conds []interface{}
+	where := ""
+	if searchWord, ok := args.Load("search_word"); ok {
+		conds = append(conds, fmt.Sprintf("%%%s%%", searchWord))
+		where = fmt.Sprintf(`WHERE "foods"."name" ILIKE $%d::text`, len(conds))
+	}
+	limit := ""
+	page, pageOK := args.Load("page")
+	perPage, perPageOK := args.Load("per_page")
+	if pageOK && perPageOK {
+		conds = append(conds, perPage, (page.(int)-1)*perPage.(int))
+		limit = fmt.Sprintf(`LIMIT $%d::int OFFSET $%d::int`, len(conds)-1, len(conds))
+	}
+
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        "foods"."id",
+        "foods"."name",
+        "foods"."serving_size",
+        "foods"."serving_unit",
+        "foods"."calories",
+        "foods"."protein",
+        "foods"."carbohydrate",
+        "foods"."fat",
+        to_char("foods"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+        to_char("foods"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at"
+      FROM
+        "foods"
+      %s
+      ORDER BY
+        "foods"."name" ASC
+      %s
+    ) AS "json_data"
+  `, where, limit)
+
+	stmt, err := f.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, conds...).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	foods := make([]*models.Food, 0)
+	if err := json.Unmarshal(jsonData, &foods); err != nil {
+		return nil, err
+	}
+
+	return foods, nil
+}
+
+func (f *foodRepository) FetchOneFoodById(ctx context.Context, id *uuid.UUID) (*models.Food, error) {
+	sql := `
+    SELECT
+      to_jsonb("json_data")
+    FROM (
+      SELECT
+        "foods"."id",
+        "foods"."name",
+        "foods"."serving_size",
+        "foods"."serving_unit",
+        "foods"."calories",
+        "foods"."protein",
+        "foods"."carbohydrate",
+        "foods"."fat",
+        to_char("foods"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+        to_char("foods"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at"
+      FROM
+        "foods"
+      WHERE
+        "foods"."id" = $1::uuid
+    ) AS "json_data"
+  `
+
+	stmt, err := f.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, id).Scan(&jsonData); err != nil {
+		return nil, apperror.FromDB(err, constants.ERROR_FOOD_NOT_FOUND)
+	}
+
+	food := new(models.Food)
+	if err := json.Unmarshal(jsonData, &food); err != nil {
+		return nil, err
+	}
+
+	return food, nil
+}
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/food"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type foodUsecase struct {
+	foodRepo food.IFoodRepository
+}
+
+func NewFoodUsecase(foodRepo food.IFoodRepository) food.IFoodUsecase {
+	return &foodUsecase{
+		foodRepo: foodRepo,
+	}
+}
+
+func (f *foodUsecase) FetchAllFoods(ctx context.Context, args *sync.Map) ([]*models.Food, error) {
+	return f.foodRepo.FetchAllFoods(ctx, args)
+}
+
+func (f *foodUsecase) FetchOneFoodById(ctx context.Context, id *uuid.UUID) (*models.Food, error) {
+	return f.foodRepo.FetchOneFoodById(ctx, id)
+}
@@ -0,0 +1,12 @@
+package grocery
+
+import "github.com/gofiber/fiber/v2"
+
+type IGroceryHandler interface {
+	FetchAllGroceryLists(c *fiber.Ctx) error
+	FetchOneGroceryListById(c *fiber.Ctx) error
+	CreateGroceryList(c *fiber.Ctx) error
+	CheckGroceryItem(c *fiber.Ctx) error
+	ExportGroceryList(c *fiber.Ctx) error
+	DeleteGroceryList(c *fiber.Ctx) error
+}
@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/grocery"
+	"net/http"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+type groceryHandler struct {
+	groceryUs grocery.IGroceryUsecase
+}
+
+func NewGroceryHandler(groceryUs grocery.IGroceryUsecase) grocery.IGroceryHandler {
+	return &groceryHandler{
+		groceryUs: groceryUs,
+	}
+}
+
+// @Summary     FetchAllGroceryLists
+// @Description Get grocery lists of user grouped by aisle category, newest first
+// @Tags        grocery-list
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id      path  string true  "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       meal_plan_id query string false "only lists of this meal plan"
+// @Success     200          {object}     map[string]interface{}
+// @Failure     400          {object}     constants.ErrorResponse
+// @Failure     500          {object}     constants.ErrorResponse
+// @Router      /v1/grocery-list/{user_id} [get]
+func (g *groceryHandler) FetchAllGroceryLists(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	args := new(sync.Map)
+	args.Store("user_id", &userId)
+	if mealPlanId := c.Query("meal_plan_id"); mealPlanId != "" {
+		id, err := uuid.FromString(mealPlanId)
+		if err != nil {
+			return fiber.NewError(http.StatusBadRequest, fmt.Sprintf("meal_plan_id: %s", err.Error()))
+		}
+		args.Store("meal_plan_id", &id)
+	}
+
+	lists, err := g.groceryUs.FetchAllGroceryLists(ctx, args)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"grocery_lists": lists,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     FetchOneGroceryListById
+// @Description Get a grocery list with items grouped by aisle category
+// @Tags        grocery-list
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id path string true "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       list_id path string true "grocery list id"
+// @Success     200     {object}     map[string]interface{}
+// @Failure     400     {object}     constants.ErrorResponse
+// @Failure     404     {object}     constants.ErrorResponse "grocery list not found"
+// @Failure     500     {object}     constants.ErrorResponse
+// @Router      /v1/grocery-list/{user_id}/{list_id} [get]
+func (g *groceryHandler) FetchOneGroceryListById(c *fiber.Ctx) error {
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	listId := uuid.FromStringOrNil(c.Params("list_id"))
+
+	list, err := g.fetchOwnGroceryList(c, &userId, &listId)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"grocery_list": list,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     CreateGroceryList
+// @Description Aggregate ingredients of a meal plan in the date range, merge duplicates with unit normalisation and group by aisle category. Without dates the whole plan is used
+// @Tags        grocery-list
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id      path     string true  "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       meal_plan_id formData string true  "meal plan id"
+// @Param       start_date   formData string false "example:2025-01-01"
+// @Param       end_date     formData string false "example:2025-01-07"
+// @Success     201          {object}     map[string]interface{}
+// @Failure     400          {object}     constants.ErrorResponse
+// @Failure     404          {object}     constants.ErrorResponse "meal plan not found"
+// @Failure     422          {object}     constants.ErrorResponse "no meal in the selected date range"
+// @Failure     500          {object}     constants.ErrorResponse
+// @Router      /v1/grocery-list/{user_id} [post]
+func (g *groceryHandler) CreateGroceryList(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	params := c.Locals("params").(map[string]interface{})
+	option := models.NewGroceryListOptionWithParams(params)
+
+	list, err := g.groceryUs.CreateGroceryList(ctx, &userId, option)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"grocery_list": list,
+	}
+
+	return c.Status(http.StatusCreated).JSON(resp)
+}
+
+// @Summary     CheckGroceryItem
+// @Description Tick an item of a grocery list as bought, or untick it
+// @Tags        grocery-list
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id    path     string true "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       list_id    path     string true "grocery list id"
+// @Param       item_id    path     string true "grocery item id"
+// @Param       is_checked formData bool   true "bought or not"
+// @Success     200        {object}     map[string]interface{}
+// @Failure     400        {object}     constants.ErrorResponse
+// @Failure     404        {object}     constants.ErrorResponse "grocery list or item not found"
+// @Failure     500        {object}     constants.ErrorResponse
+// @Router      /v1/grocery-list/{user_id}/{list_id}/items/{item_id} [put]
+func (g *groceryHandler) CheckGroceryItem(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	listId := uuid.FromStringOrNil(c.Params("list_id"))
+	itemId := uuid.FromStringOrNil(c.Params("item_id"))
+	params := c.Locals("params").(map[string]interface{})
+
+	if _, err := g.fetchOwnGroceryList(c, &userId, &listId); err != nil {
+		return err
+	}
+	if err := g.groceryUs.CheckGroceryItem(ctx, &listId, &itemId, cast.ToBool(params["is_checked"])); err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message": "successful",
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     ExportGroceryList
+// @Description Export a grocery list for sharing as plain text (default) or as a JSON file
+// @Tags        grocery-list
+// @Produce     plain,application/problem+json
+// @Produce     json,application/problem+json
+// @Param       user_id path  string true  "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       list_id path  string true  "grocery list id"
+// @Param       format  query string false "text or json"
+// @Success     200     {string}     string
+// @Failure     400     {object}     constants.ErrorResponse
+// @Failure     404     {object}     constants.ErrorResponse "grocery list not found"
+// @Failure     500     {object}     constants.ErrorResponse
+// @Router      /v1/grocery-list/{user_id}/{list_id}/export [get]
+func (g *groceryHandler) ExportGroceryList(c *fiber.Ctx) error {
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	listId := uuid.FromStringOrNil(c.Params("list_id"))
+
+	list, err := g.fetchOwnGroceryList(c, &userId, &listId)
+	if err != nil {
+		return err
+	}
+	if c.Query("format") == "json" {
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="grocery-list-%s.json"`, listId.String()))
+		return c.Status(http.StatusOK).JSON(map[string]interface{}{
+			"grocery_list": list,
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+	return c.Status(http.StatusOK).SendString(list.Text())
+}
+
+// @Summary     DeleteGroceryList
+// @Description Delete a grocery list with its items
+// @Tags        grocery-list
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id path string true "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       list_id path string true "grocery list id"
+// @Success     200     {object}     map[string]interface{}
+// @Failure     404     {object}     constants.ErrorResponse "grocery list not found"
+// @Failure     500     {object}     constants.ErrorResponse
+// @Router      /v1/grocery-list/{user_id}/{list_id} [delete]
+func (g *groceryHandler) DeleteGroceryList(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	listId := uuid.FromStringOrNil(c.Params("list_id"))
+
+	if _, err := g.fetchOwnGroceryList(c, &userId, &listId); err != nil {
+		return err
+	}
+	if err := g.groceryUs.DeleteGroceryList(ctx, &listId); err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message": "successful",
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+/* fetchOwnGroceryList ดึงรายการและตรวจว่าเป็นของผู้ใช้ตาม path ไม่เช่นนั้นถือว่าไม่พบ */
+func (g *groceryHandler) fetchOwnGroceryList(c *fiber.Ctx, userId *uuid.UUID, listId *uuid.UUID) (*models.GroceryList, error) {
+	list, err := g.groceryUs.FetchOneGroceryListById(c.UserContext(), listId)
+	if err != nil {
+		return nil, err
+	}
+	if list.UserId == nil || *list.UserId != *userId {
+		return nil, apperror.NotFound(constants.ERROR_GROCERY_LIST_NOT_FOUND)
+	}
+	return list, nil
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"encoding/json"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/middleware"
+	"healthmatefood-api/models"
+	grocery_mocks "healthmatefood-api/service/grocery/mocks"
+	grocery_usecase "healthmatefood-api/service/grocery/usecase"
+	mealplan_mocks "healthmatefood-api/service/mealplan/mocks"
+	recipe_mocks "healthmatefood-api/service/recipe/mocks"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateGroceryList(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	otherUserId := uuid.FromStringOrNil("0c3b6f8e-1d2a-4e5f-8a9b-7c6d5e4f3a21")
+	planId := uuid.FromStringOrNil("5b0e8f3a-2f4c-4d8e-9a31-6c1f0d7e2a10")
+	newDay := func(date string) *models.MealPlanDay {
+		day := helper.NewDateFromString(date)
+		return &models.MealPlanDay{Date: &day, Meals: []*models.MealPlanMeal{
+			{MealType: models.MealTypeLunch, Items: []*models.MealPlanItem{
+				{Name: "ข้าวผัดไก่", Portion: "1 จาน"},
+				{Name: "นมจืด", Portion: "1 กล่อง (250 ml)"},
+			}},
+		}}
+	}
+	startDate, endDate := helper.NewDateFromString("2025-01-01"), helper.NewDateFromString("2025-01-03")
+	plan := &models.MealPlan{Id: &planId, UserId: &userId, StartDate: &startDate, EndDate: &endDate, Days: []*models.MealPlanDay{
+		newDay("2025-01-01"), newDay("2025-01-02"), newDay("2025-01-03"),
+	}}
+	friedRice := &models.Recipe{Name: "ข้าวผัดไก่", Servings: 1, Ingredients: []*models.RecipeIngredient{
+		{Quantity: 150, Unit: "g", Grams: 150, Food: &models.Food{Name: "ข้าวสวย"}},
+		{Quantity: 0.1, Unit: "kg", Grams: 100, Food: &models.Food{Name: "อกไก่"}},
+	}}
+	newApp := func(groceryRepo *grocery_mocks.IGroceryRepository, mealPlanRepo *mealplan_mocks.IMealPlanRepository, recipeRepo *recipe_mocks.IRecipeRepository) *fiber.App {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		groceryHandler := NewGroceryHandler(grocery_usecase.NewGroceryUsecase(groceryRepo, mealPlanRepo, recipeRepo))
+		app.Post("/v1/grocery-list/:user_id", func(c *fiber.Ctx) error {
+			params := map[string]interface{}{}
+			if err := json.Unmarshal(c.Body(), &params); err != nil {
+				return err
+			}
+			c.Locals("params", params)
+			return groceryHandler.CreateGroceryList(c)
+		})
+		return app
+	}
+	t.Run("success_merge_recipe_ingredients_in_date_range", func(t *testing.T) {
+		groceryRepo := new(grocery_mocks.IGroceryRepository)
+		mealPlanRepo := new(mealplan_mocks.IMealPlanRepository)
+		recipeRepo := new(recipe_mocks.IRecipeRepository)
+		mealPlanRepo.On("FetchOneMealPlanById", mock.Anything, &planId).Return(plan, nil)
+		recipeRepo.On("FetchAllRecipes", mock.Anything, mock.MatchedBy(func(args *sync.Map) bool {
+			names, _ := args.Load("names")
+			return assert.ElementsMatch(t, []string{"ข้าวผัดไก่", "นมจืด"}, names)
+		})).Return([]*models.Recipe{friedRice}, nil)
+		groceryRepo.On("InsertGroceryList", mock.Anything, mock.AnythingOfType("*models.GroceryList")).Return(nil).Run(func(args mock.Arguments) {
+			list := args.Get(1).(*models.GroceryList)
+			assert.NotNil(t, list.Id)
+			assert.Equal(t, "2025-01-02", list.EndDate.String())
+			units := make(map[string]string)
+			for _, item := range list.Items {
+				assert.NotNil(t, item.Id)
+				units[item.Name] = item.Unit
+				switch item.Name {
+				case "ข้าวสวย":
+					assert.Equal(t, 300.0, item.Quantity)
+				case "อกไก่":
+					assert.Equal(t, 200.0, item.Quantity)
+					assert.Equal(t, models.GroceryCategoryMeat, item.Category)
+				case "นมจืด":
+					assert.Equal(t, 500.0, item.Quantity)
+				}
+			}
+			assert.Equal(t, map[string]string{"ข้าวสวย": models.UNIT_GRAM, "อกไก่": models.UNIT_GRAM, "นมจืด": models.UNIT_MILLILITER}, units)
+		})
+		app := newApp(groceryRepo, mealPlanRepo, recipeRepo)
+
+		body := `{"meal_plan_id":"` + planId.String() + `","start_date":"2025-01-01","end_date":"2025-01-02"}`
+		req := httptest.NewRequest(http.MethodPost, "/v1/grocery-list/"+userId.String(), strings.NewReader(body))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		groceryRepo.AssertExpectations(t)
+	})
+	t.Run("error_no_meal_in_date_range", func(t *testing.T) {
+		groceryRepo := new(grocery_mocks.IGroceryRepository)
+		mealPlanRepo := new(mealplan_mocks.IMealPlanRepository)
+		recipeRepo := new(recipe_mocks.IRecipeRepository)
+		mealPlanRepo.On("FetchOneMealPlanById", mock.Anything, &planId).Return(plan, nil)
+		app := newApp(groceryRepo, mealPlanRepo, recipeRepo)
+
+		body := `{"meal_plan_id":"` + planId.String() + `","start_date":"2025-02-01","end_date":"2025-02-02"}`
+		req := httptest.NewRequest(http.MethodPost, "/v1/grocery-list/"+userId.String(), strings.NewReader(body))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		groceryRepo.AssertNotCalled(t, "InsertGroceryList", mock.Anything, mock.Anything)
+	})
+	t.Run("error_meal_plan_of_other_user", func(t *testing.T) {
+		groceryRepo := new(grocery_mocks.IGroceryRepository)
+		mealPlanRepo := new(mealplan_mocks.IMealPlanRepository)
+		recipeRepo := new(recipe_mocks.IRecipeRepository)
+		mealPlanRepo.On("FetchOneMealPlanById", mock.Anything, &planId).Return(plan, nil)
+		app := newApp(groceryRepo, mealPlanRepo, recipeRepo)
+
+		body := `{"meal_plan_id":"` + planId.String() + `"}`
+		req := httptest.NewRequest(http.MethodPost, "/v1/grocery-list/"+otherUserId.String(), strings.NewReader(body))
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		recipeRepo.AssertNotCalled(t, "FetchAllRecipes", mock.Anything, mock.Anything)
+	})
+}
+
+func TestExportGroceryList(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	listId := uuid.FromStringOrNil("3e7a1c90-4b2d-4f8e-b6a5-9d0c1e2f3a47")
+	startDate, endDate := helper.NewDateFromString("2025-01-01"), helper.NewDateFromString("2025-01-02")
+	newList := func() *models.GroceryList {
+		return &models.GroceryList{Id: &listId, UserId: &userId, StartDate: &startDate, EndDate: &endDate, Items: []*models.GroceryItem{
+			{Name: "อกไก่", Quantity: 200, Unit: models.UNIT_GRAM, Category: models.GroceryCategoryMeat, IsChecked: true},
+			{Name: "ข้าวสวย", Quantity: 300, Unit: models.UNIT_GRAM, Category: models.GroceryCategoryGrains},
+		}}
+	}
+	t.Run("success_text", func(t *testing.T) {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		groceryUs := new(grocery_mocks.IGroceryUsecase)
+		groceryUs.On("FetchOneGroceryListById", mock.Anything, &listId).Return(newList(), nil)
+		groceryHandler := NewGroceryHandler(groceryUs)
+		app.Get("/v1/grocery-list/:user_id/:list_id/export", groceryHandler.ExportGroceryList)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/grocery-list/"+userId.String()+"/"+listId.String()+"/export", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get(fiber.HeaderContentType), fiber.MIMETextPlain)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Contains(t, string(body), "[x] อกไก่ 200 g")
+		assert.Contains(t, string(body), "[ ] ข้าวสวย 300 g")
+	})
+	t.Run("success_json", func(t *testing.T) {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		groceryUs := new(grocery_mocks.IGroceryUsecase)
+		groceryUs.On("FetchOneGroceryListById", mock.Anything, &listId).Return(newList(), nil)
+		groceryHandler := NewGroceryHandler(groceryUs)
+		app.Get("/v1/grocery-list/:user_id/:list_id/export", groceryHandler.ExportGroceryList)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/grocery-list/"+userId.String()+"/"+listId.String()+"/export?format=json", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get(fiber.HeaderContentDisposition), "attachment")
+	})
+	t.Run("error_list_not_found", func(t *testing.T) {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		groceryUs := new(grocery_mocks.IGroceryUsecase)
+		groceryUs.On("FetchOneGroceryListById", mock.Anything, &listId).Return(nil, apperror.NotFound(constants.ERROR_GROCERY_LIST_NOT_FOUND))
+		groceryHandler := NewGroceryHandler(groceryUs)
+		app.Get("/v1/grocery-list/:user_id/:list_id/export", groceryHandler.ExportGroceryList)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/grocery-list/"+userId.String()+"/"+listId.String()+"/export", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,148 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "healthmatefood-api/models"
+
+	sync "sync"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IGroceryRepository is an autogenerated mock type for the IGroceryRepository type
+type IGroceryRepository struct {
+	mock.Mock
+}
+
+// DeleteGroceryList provides a mock function with given fields: ctx, id
+func (_m *IGroceryRepository) DeleteGroceryList(ctx context.Context, id *uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGroceryList")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchAllGroceryLists provides a mock function with given fields: ctx, args
+func (_m *IGroceryRepository) FetchAllGroceryLists(ctx context.Context, args *sync.Map) ([]*models.GroceryList, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllGroceryLists")
+	}
+
+	var r0 []*models.GroceryList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.GroceryList, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.GroceryList); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.GroceryList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchOneGroceryListById provides a mock function with given fields: ctx, id
+func (_m *IGroceryRepository) FetchOneGroceryListById(ctx context.Context, id *uuid.UUID) (*models.GroceryList, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOneGroceryListById")
+	}
+
+	var r0 *models.GroceryList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.GroceryList, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.GroceryList); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GroceryList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertGroceryList provides a mock function with given fields: ctx, list
+func (_m *IGroceryRepository) InsertGroceryList(ctx context.Context, list *models.GroceryList) error {
+	ret := _m.Called(ctx, list)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertGroceryList")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.GroceryList) error); ok {
+		r0 = rf(ctx, list)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateGroceryItemChecked provides a mock function with given fields: ctx, listId, itemId, isChecked
+func (_m *IGroceryRepository) UpdateGroceryItemChecked(ctx context.Context, listId *uuid.UUID, itemId *uuid.UUID, isChecked bool) error {
+	ret := _m.Called(ctx, listId, itemId, isChecked)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateGroceryItemChecked")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *uuid.UUID, bool) error); ok {
+		r0 = rf(ctx, listId, itemId, isChecked)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIGroceryRepository creates a new instance of IGroceryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIGroceryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IGroceryRepository {
+	mock := &IGroceryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
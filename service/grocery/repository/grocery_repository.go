@@ -0,0 +1,284 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/grocery"
+	"strings"
+	"sync"
+
+	"github.com/Pheethy/sqlx"
+	"github.com/gofrs/uuid"
+)
+
+type groceryRepository struct {
+	psqlDB *sqlx.DB
+}
+
+func NewGroceryRepository(psqlDB *sqlx.DB) grocery.IGroceryRepository {
+	return &groceryRepository{
+		psqlDB: psqlDB,
+	}
+}
+
+const selectGroceryList = `
+        "grocery_lists"."id",
+        "grocery_lists"."user_id",
+        "grocery_lists"."meal_plan_id",
+        to_char("grocery_lists"."start_date", 'yyyy-MM-dd') "start_date",
+        to_char("grocery_lists"."end_date", 'yyyy-MM-dd') "end_date",
+        to_char("grocery_lists"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+        to_char("grocery_lists"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at",
+        (
+          SELECT
+            COALESCE(array_to_json(array_agg("ITEM")), '[]'::json)
+          FROM (
+            SELECT
+              "grocery_list_items"."id",
+              "grocery_list_items"."grocery_list_id",
+              "grocery_list_items"."name",
+              "grocery_list_items"."quantity",
+              "grocery_list_items"."unit",
+              "grocery_list_items"."category",
+              "grocery_list_items"."is_checked"
+            FROM
+              "grocery_list_items"
+            WHERE
+              "grocery_list_items"."grocery_list_id" = "grocery_lists"."id"
+            ORDER BY
+              "grocery_list_items"."item_no" ASC
+          ) AS "ITEM"
+        ) AS "items"`
+
+func (r *groceryRepository) FetchAllGroceryLists(ctx context.Context, args *sync.Map) ([]*models.GroceryList, error) {
+	var conds []interface{}
+	var wheres []string
+	if userId, ok := args.Load("user_id"); ok {
+		conds = append(conds, userId)
+		wheres = append(wheres, fmt.Sprintf(`"grocery_lists"."user_id" = $%d::uuid`, len(conds)))
+	}
+	if mealPlanId, ok := args.Load("meal_plan_id"); ok {
+		conds = append(conds, mealPlanId)
+		wheres = append(wheres, fmt.Sprintf(`"grocery_lists"."meal_plan_id" = $%d::uuid`, len(conds)))
+	}
+	where := ""
+	if len(wheres) > 0 {
+		where = "WHERE " + strings.Join(wheres, " AND ")
+	}
+
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        %s
+      FROM
+        "grocery_lists"
+      %s
+      ORDER BY
+        "grocery_lists"."created_at" DESC
+    ) AS "json_data"
+  `, selectGroceryList, where)
+
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, conds...).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	lists := make([]*models.GroceryList, 0)
+	if err := json.Unmarshal(jsonData, &lists); err != nil {
+		return nil, err
+	}
+
+	return lists, nil
+}
+
+func (r *groceryRepository) FetchOneGroceryListById(ctx context.Context, id *uuid.UUID) (*models.GroceryList, error) {
+	sql := fmt.Sprintf(`
+    SELECT
+      to_jsonb("json_data")
+    FROM (
+      SELECT
+        %s
+      FROM
+        "grocery_lists"
+      WHERE
+        "grocery_lists"."id" = $1::uuid
+    ) AS "json_data"
+  `, selectGroceryList)
+
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, id).Scan(&jsonData); err != nil {
+		return nil, apperror.FromDB(err, constants.ERROR_GROCERY_LIST_NOT_FOUND)
+	}
+
+	list := new(models.GroceryList)
+	if err := json.Unmarshal(jsonData, &list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+/* InsertGroceryList บันทึกหัวรายการพร้อมวัตถุดิบทั้งหมดภายใน transaction เดียว ลำดับวัตถุดิบตามที่เรียงไว้ใน list.Items */
+func (r *groceryRepository) InsertGroceryList(ctx context.Context, list *models.GroceryList) error {
+	tx, err := r.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+	sql := `
+    INSERT INTO "grocery_lists" (
+      "id",
+      "user_id",
+      "meal_plan_id",
+      "start_date",
+      "end_date",
+      "created_at",
+      "updated_at"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::uuid,
+      $4::date,
+      $5::date,
+      $6::timestamp,
+      $7::timestamp
+    )
+  `
+	if _, err := tx.ExecContext(ctx, sql,
+		list.Id,
+		list.UserId,
+		list.MealPlanId,
+		list.StartDate.String(),
+		list.EndDate.String(),
+		list.CreatedAt,
+		list.UpdatedAt,
+	); err != nil {
+		tx.Rollback()
+		return apperror.FromDB(err, "")
+	}
+
+	itemStmt, err := tx.PreparexContext(ctx, `
+    INSERT INTO "grocery_list_items" (
+      "id",
+      "grocery_list_id",
+      "item_no",
+      "name",
+      "quantity",
+      "unit",
+      "category",
+      "is_checked"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::int,
+      $4::text,
+      $5::float,
+      $6::text,
+      $7::grocery_category,
+      $8::bool
+    )
+  `)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer itemStmt.Close()
+
+	for index, item := range list.Items {
+		if _, err := itemStmt.ExecContext(ctx,
+			item.Id,
+			list.Id,
+			index+1,
+			item.Name,
+			item.Quantity,
+			item.Unit,
+			item.Category,
+			item.IsChecked,
+		); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("exec item failed: %v", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *groceryRepository) UpdateGroceryItemChecked(ctx context.Context, listId *uuid.UUID, itemId *uuid.UUID, isChecked bool) error {
+	tx, err := r.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+	sql := `
+    UPDATE
+      "grocery_list_items"
+    SET
+      "is_checked" = $1::bool
+    WHERE
+      "grocery_list_items"."id" = $2::uuid
+    AND
+      "grocery_list_items"."grocery_list_id" = $3::uuid
+  `
+	result, err := tx.ExecContext(ctx, sql, isChecked, itemId, listId)
+	if err != nil {
+		tx.Rollback()
+		return apperror.FromDB(err, "")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		tx.Rollback()
+		return apperror.NotFound(constants.ERROR_GROCERY_ITEM_NOT_FOUND)
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE "grocery_lists" SET "updated_at" = now() WHERE "id" = $1::uuid`, listId); err != nil {
+		tx.Rollback()
+		return apperror.FromDB(err, "")
+	}
+
+	return tx.Commit()
+}
+
+func (r *groceryRepository) DeleteGroceryList(ctx context.Context, id *uuid.UUID) error {
+	tx, err := r.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+	sql := `
+    DELETE FROM
+      "grocery_lists"
+    WHERE
+      "grocery_lists"."id" = $1::uuid
+  `
+	stmt, err := tx.PreparexContext(ctx, sql)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		tx.Rollback()
+		return apperror.FromDB(err, "")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		tx.Rollback()
+		return apperror.NotFound(constants.ERROR_GROCERY_LIST_NOT_FOUND)
+	}
+
+	return tx.Commit()
+}
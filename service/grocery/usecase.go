@@ -0,0 +1,17 @@
+package grocery
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type IGroceryUsecase interface {
+	FetchAllGroceryLists(ctx context.Context, args *sync.Map) ([]*models.GroceryList, error)
+	FetchOneGroceryListById(ctx context.Context, id *uuid.UUID) (*models.GroceryList, error)
+	CreateGroceryList(ctx context.Context, userId *uuid.UUID, option *models.GroceryListOption) (*models.GroceryList, error)
+	CheckGroceryItem(ctx context.Context, listId *uuid.UUID, itemId *uuid.UUID, isChecked bool) error
+	DeleteGroceryList(ctx context.Context, id *uuid.UUID) error
+}
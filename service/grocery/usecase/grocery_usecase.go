@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/grocery"
+	"healthmatefood-api/service/mealplan"
+	"healthmatefood-api/service/recipe"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type groceryUsecase struct {
+	groceryRepo  grocery.IGroceryRepository
+	mealPlanRepo mealplan.IMealPlanRepository
+	recipeRepo   recipe.IRecipeRepository
+}
+
+func NewGroceryUsecase(groceryRepo grocery.IGroceryRepository, mealPlanRepo mealplan.IMealPlanRepository, recipeRepo recipe.IRecipeRepository) grocery.IGroceryUsecase {
+	return &groceryUsecase{
+		groceryRepo:  groceryRepo,
+		mealPlanRepo: mealPlanRepo,
+		recipeRepo:   recipeRepo,
+	}
+}
+
+func (u *groceryUsecase) FetchAllGroceryLists(ctx context.Context, args *sync.Map) ([]*models.GroceryList, error) {
+	lists, err := u.groceryRepo.FetchAllGroceryLists(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	for index := range lists {
+		lists[index].Group()
+	}
+	return lists, nil
+}
+
+func (u *groceryUsecase) FetchOneGroceryListById(ctx context.Context, id *uuid.UUID) (*models.GroceryList, error) {
+	list, err := u.groceryRepo.FetchOneGroceryListById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	list.Group()
+	return list, nil
+}
+
+/* CreateGroceryList รวมวัตถุดิบจากแผนอาหารของผู้ใช้ในช่วงวันที่ ไม่ระบุวันใช้ทั้งแผน รายการที่ชื่อตรงกับสูตรอาหารจะแตกเป็นวัตถุดิบของสูตร */
+func (u *groceryUsecase) CreateGroceryList(ctx context.Context, userId *uuid.UUID, option *models.GroceryListOption) (*models.GroceryList, error) {
+	plan, err := u.mealPlanRepo.FetchOneMealPlanById(ctx, option.MealPlanId)
+	if err != nil {
+		return nil, err
+	}
+	if plan.UserId == nil || *plan.UserId != *userId {
+		return nil, apperror.NotFound(constants.ERROR_MEAL_PLAN_NOT_FOUND)
+	}
+	startDate, endDate := plan.StartDate, plan.EndDate
+	if option.StartDate != nil {
+		startDate = option.StartDate
+	}
+	if option.EndDate != nil {
+		endDate = option.EndDate
+	}
+
+	names := make([]string, 0)
+	for _, day := range plan.Days {
+		if day.Date == nil || day.Date.String() < startDate.String() || day.Date.String() > endDate.String() {
+			continue
+		}
+		for _, meal := range day.Meals {
+			for _, item := range meal.Items {
+				if name := strings.ToLower(strings.TrimSpace(item.Name)); !slices.Contains(names, name) {
+					names = append(names, name)
+				}
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil, apperror.Unprocessable(constants.ERROR_GROCERY_LIST_IS_EMPTY)
+	}
+
+	recipes, err := u.fetchRecipesByNames(ctx, userId, names)
+	if err != nil {
+		return nil, err
+	}
+	list := models.NewGroceryList(plan, startDate, endDate, recipes)
+	list.NewID()
+	list.SetCreatedAt()
+	list.SetUpdatedAt()
+	if err := u.groceryRepo.InsertGroceryList(ctx, list); err != nil {
+		return nil, err
+	}
+	list.Group()
+
+	return list, nil
+}
+
+/* fetchRecipesByNames สูตรที่ชื่อตรงกับรายการในแผน ชื่อซ้ำกันใช้สูตรของผู้ใช้ก่อน */
+func (u *groceryUsecase) fetchRecipesByNames(ctx context.Context, userId *uuid.UUID, names []string) (map[string]*models.Recipe, error) {
+	args := new(sync.Map)
+	args.Store("names", names)
+	recipes, err := u.recipeRepo.FetchAllRecipes(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*models.Recipe)
+	for _, recipe := range recipes {
+		name := strings.ToLower(strings.TrimSpace(recipe.Name))
+		if found, ok := byName[name]; ok && found.UserId != nil && *found.UserId == *userId {
+			continue
+		}
+		byName[name] = recipe
+	}
+	return byName, nil
+}
+
+func (u *groceryUsecase) CheckGroceryItem(ctx context.Context, listId *uuid.UUID, itemId *uuid.UUID, isChecked bool) error {
+	return u.groceryRepo.UpdateGroceryItemChecked(ctx, listId, itemId, isChecked)
+}
+
+func (u *groceryUsecase) DeleteGroceryList(ctx context.Context, id *uuid.UUID) error {
+	return u.groceryRepo.DeleteGroceryList(ctx, id)
+}
@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	diary_validator "healthmatefood-api/service/diary/validator"
+
+	"github.com/Pheethy/psql/helper"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofiber/fiber/v2"
+	"github.com/spf13/cast"
+)
+
+type Validation struct{}
+
+func (v Validation) ValidateCreateGroceryList() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		if params == nil {
+			return apperror.Validation(constants.ERROR_BODY_WAS_MISSING)
+		}
+		var key string
+		fields := apperror.Fields{}
+
+		/* key params */
+		key = "meal_plan_id"
+		if mealPlanId, ok := params[key]; !ok {
+			fields.Missing(key)
+		} else {
+			fields.Check(key, validation.Validate(mealPlanId, validation.By(helper.ValidateTypeUUID)))
+		}
+		for _, key := range []string{"start_date", "end_date"} {
+			if date, ok := params[key]; ok {
+				fields.Check(key, validation.Validate(date, validation.By(diary_validator.ValidateDate)))
+			}
+		}
+		/* วันที่รูปแบบ yyyy-MM-dd เทียบแบบ string ได้ */
+		startDate, startOK := params["start_date"]
+		endDate, endOK := params["end_date"]
+		if startOK && endOK && cast.ToString(startDate) > cast.ToString(endDate) {
+			fields.Add("end_date", "must not be before start_date")
+		}
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateCheckGroceryItem() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		if params == nil {
+			return apperror.Validation(constants.ERROR_BODY_WAS_MISSING)
+		}
+		key := "is_checked"
+		isChecked, ok := params[key]
+		if !ok {
+			return apperror.Field(key, constants.ERROR_FIELD_WAS_MISSING)
+		}
+		if _, err := cast.ToBoolE(isChecked); err != nil {
+			return apperror.Field(key, "is not type boolean")
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateExportGroceryList() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := "format"
+		if format := c.Query(key); format != "" && format != "text" && format != "json" {
+			return apperror.Field(key, "must be text or json")
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateParams(key string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params := c.Params(key)
+		if err := validation.Validate(params, validation.By(helper.ValidateTypeUUID)); err != nil {
+			return apperror.Field(key, err.Error())
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,7 @@
+package guardrail
+
+import "github.com/gofiber/fiber/v2"
+
+type IGuardrailHandler interface {
+	FetchAllDiseaseRules(c *fiber.Ctx) error
+}
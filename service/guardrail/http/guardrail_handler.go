@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"healthmatefood-api/service/guardrail"
+	"net/http"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type guardrailHandler struct {
+	guardrailUs guardrail.IGuardrailUsecase
+}
+
+func NewGuardrailHandler(guardrailUs guardrail.IGuardrailUsecase) guardrail.IGuardrailHandler {
+	return &guardrailHandler{
+		guardrailUs: guardrailUs,
+	}
+}
+
+// @Summary     FetchAllDiseaseRules
+// @Description Nutrient caps per disease that every generated meal plan is checked against (sodium, potassium, purine in mg, sugar and protein in g), scope MEAL or DAY
+// @Tags        guardrail
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true  "Bearer access token"
+// @Param       disease_id    query  string false "only rules of this disease"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/guardrails/rules [get]
+func (h *guardrailHandler) FetchAllDiseaseRules(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	args := new(sync.Map)
+	if diseaseId := c.Query("disease_id"); diseaseId != "" {
+		args.Store("disease_ids", []string{diseaseId})
+	}
+	args.Store("is_active", true)
+
+	rules, err := h.guardrailUs.FetchAllDiseaseRules(ctx, args)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"rules": rules,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
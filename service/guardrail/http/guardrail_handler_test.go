@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"encoding/json"
+	"healthmatefood-api/middleware"
+	"healthmatefood-api/models"
+	guardrail_mocks "healthmatefood-api/service/guardrail/mocks"
+	guardrail_usecase "healthmatefood-api/service/guardrail/usecase"
+	"healthmatefood-api/service/guardrail/validator"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFetchAllDiseaseRules(t *testing.T) {
+	diseaseId := uuid.FromStringOrNil("262bf726-63e6-4932-8ddd-e06237740c90")
+	newApp := func(guardrailRepo *guardrail_mocks.IGuardrailRepository) *fiber.App {
+		handler := NewGuardrailHandler(guardrail_usecase.NewGuardrailUsecase(guardrailRepo))
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		app.Get("/v1/guardrails/rules", validator.Validation{}.ValidateFetchAllDiseaseRules(), handler.FetchAllDiseaseRules)
+		return app
+	}
+	t.Run("success", func(t *testing.T) {
+		guardrailRepo := new(guardrail_mocks.IGuardrailRepository)
+		guardrailRepo.On("FetchAllDiseaseRules", mock.Anything, mock.MatchedBy(func(args *sync.Map) bool {
+			diseaseIds, _ := args.Load("disease_ids")
+			isActive, _ := args.Load("is_active")
+			return assert.ObjectsAreEqual([]string{diseaseId.String()}, diseaseIds) && isActive == true
+		})).Return([]*models.DiseaseRule{
+			{DiseaseId: &diseaseId, DiseaseName: "โรคไต", Nutrient: models.NutrientProtein, Scope: models.RuleScopeDay, MaxValue: 0.8, PerKgBodyWeight: true, IsActive: true},
+		}, nil)
+		app := newApp(guardrailRepo)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/guardrails/rules?disease_id="+diseaseId.String(), nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		body := struct {
+			Rules []*models.DiseaseRule `json:"rules"`
+		}{}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Len(t, body.Rules, 1)
+		assert.Equal(t, models.NutrientProtein, body.Rules[0].Nutrient)
+		guardrailRepo.AssertExpectations(t)
+	})
+	t.Run("error_invalid_disease_id", func(t *testing.T) {
+		guardrailRepo := new(guardrail_mocks.IGuardrailRepository)
+		app := newApp(guardrailRepo)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/guardrails/rules?disease_id=kidney", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		guardrailRepo.AssertNotCalled(t, "FetchAllDiseaseRules", mock.Anything, mock.Anything)
+	})
+}
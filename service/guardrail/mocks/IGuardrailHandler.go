@@ -0,0 +1,46 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IGuardrailHandler is an autogenerated mock type for the IGuardrailHandler type
+type IGuardrailHandler struct {
+	mock.Mock
+}
+
+// FetchAllDiseaseRules provides a mock function with given fields: c
+func (_m *IGuardrailHandler) FetchAllDiseaseRules(c *fiber.Ctx) error {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllDiseaseRules")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*fiber.Ctx) error); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIGuardrailHandler creates a new instance of IGuardrailHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIGuardrailHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IGuardrailHandler {
+	mock := &IGuardrailHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
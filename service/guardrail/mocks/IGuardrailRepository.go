@@ -0,0 +1,62 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "healthmatefood-api/models"
+
+	sync "sync"
+)
+
+// IGuardrailRepository is an autogenerated mock type for the IGuardrailRepository type
+type IGuardrailRepository struct {
+	mock.Mock
+}
+
+// FetchAllDiseaseRules provides a mock function with given fields: ctx, args
+func (_m *IGuardrailRepository) FetchAllDiseaseRules(ctx context.Context, args *sync.Map) ([]*models.DiseaseRule, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllDiseaseRules")
+	}
+
+	var r0 []*models.DiseaseRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.DiseaseRule, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.DiseaseRule); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.DiseaseRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIGuardrailRepository creates a new instance of IGuardrailRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIGuardrailRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IGuardrailRepository {
+	mock := &IGuardrailRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,92 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "healthmatefood-api/models"
+
+	sync "sync"
+)
+
+// IGuardrailUsecase is an autogenerated mock type for the IGuardrailUsecase type
+type IGuardrailUsecase struct {
+	mock.Mock
+}
+
+// FetchAllDiseaseRules provides a mock function with given fields: ctx, args
+func (_m *IGuardrailUsecase) FetchAllDiseaseRules(ctx context.Context, args *sync.Map) ([]*models.DiseaseRule, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllDiseaseRules")
+	}
+
+	var r0 []*models.DiseaseRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.DiseaseRule, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.DiseaseRule); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.DiseaseRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchRulesByUserInfo provides a mock function with given fields: ctx, userInfo
+func (_m *IGuardrailUsecase) FetchRulesByUserInfo(ctx context.Context, userInfo *models.UserInfo) ([]*models.DiseaseRule, error) {
+	ret := _m.Called(ctx, userInfo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchRulesByUserInfo")
+	}
+
+	var r0 []*models.DiseaseRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserInfo) ([]*models.DiseaseRule, error)); ok {
+		return rf(ctx, userInfo)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserInfo) []*models.DiseaseRule); ok {
+		r0 = rf(ctx, userInfo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.DiseaseRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.UserInfo) error); ok {
+		r1 = rf(ctx, userInfo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIGuardrailUsecase creates a new instance of IGuardrailUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIGuardrailUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IGuardrailUsecase {
+	mock := &IGuardrailUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
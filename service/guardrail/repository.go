@@ -0,0 +1,11 @@
+package guardrail
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+)
+
+type IGuardrailRepository interface {
+	FetchAllDiseaseRules(ctx context.Context, args *sync.Map) ([]*models.DiseaseRule, error)
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/guardrail"
+	"strings"
+	"sync"
+
+	"github.com/Pheethy/sqlx"
+)
+
+type guardrailRepository struct {
+	psqlDB *sqlx.DB
+}
+
+func NewGuardrailRepository(psqlDB *sqlx.DB) guardrail.IGuardrailRepository {
+	return &guardrailRepository{
+		psqlDB: psqlDB,
+	}
+}
+
+const selectDiseaseRule = `
+        "disease_rules"."id",
+        "disease_rules"."disease_id",
+        "diseases"."name" "disease_name",
+        "disease_rules"."nutrient",
+        "disease_rules"."scope",
+        "disease_rules"."max_value",
+        "disease_rules"."per_kg_body_weight",
+        "disease_rules"."description",
+        "disease_rules"."is_active",
+        to_char("disease_rules"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+        to_char("disease_rules"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at"`
+
+/* FetchAllDiseaseRules args disease_ids ([]string) กรองเฉพาะโรคที่ระบุ */
+func (r *guardrailRepository) FetchAllDiseaseRules(ctx context.Context, args *sync.Map) ([]*models.DiseaseRule, error) {
+	var conds []interface{}
+	var wheres []string
+	if diseaseIds, ok := args.Load("disease_ids"); ok {
+		placeholders := make([]string, 0)
+		for _, diseaseId := range diseaseIds.([]string) {
+			conds = append(conds, diseaseId)
+			placeholders = append(placeholders, fmt.Sprintf("$%d::uuid", len(conds)))
+		}
+		wheres = append(wheres, fmt.Sprintf(`"disease_rules"."disease_id" IN (%s)`, strings.Join(placeholders, ", ")))
+	}
+	if isActive, ok := args.Load("is_active"); ok {
+		conds = append(conds, isActive)
+		wheres = append(wheres, fmt.Sprintf(`"disease_rules"."is_active" = $%d::bool`, len(conds)))
+	}
+	where := ""
+	if len(wheres) > 0 {
+		where = "WHERE " + strings.Join(wheres, " AND ")
+	}
+
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        %s
+      FROM
+        "disease_rules"
+      JOIN
+        "diseases" ON "diseases"."id" = "disease_rules"."disease_id"
+      %s
+      ORDER BY
+        "diseases"."name" ASC,
+        "disease_rules"."nutrient" ASC,
+        "disease_rules"."scope" ASC
+    ) AS "json_data"
+  `, selectDiseaseRule, where)
+
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, conds...).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	rules := make([]*models.DiseaseRule, 0)
+	if err := json.Unmarshal(jsonData, &rules); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
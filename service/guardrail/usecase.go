@@ -0,0 +1,12 @@
+package guardrail
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+)
+
+type IGuardrailUsecase interface {
+	FetchAllDiseaseRules(ctx context.Context, args *sync.Map) ([]*models.DiseaseRule, error)
+	FetchRulesByUserInfo(ctx context.Context, userInfo *models.UserInfo) ([]*models.DiseaseRule, error)
+}
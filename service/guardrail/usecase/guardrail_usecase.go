@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/guardrail"
+	"sync"
+)
+
+type guardrailUsecase struct {
+	guardrailRepo guardrail.IGuardrailRepository
+}
+
+func NewGuardrailUsecase(guardrailRepo guardrail.IGuardrailRepository) guardrail.IGuardrailUsecase {
+	return &guardrailUsecase{
+		guardrailRepo: guardrailRepo,
+	}
+}
+
+func (u *guardrailUsecase) FetchAllDiseaseRules(ctx context.Context, args *sync.Map) ([]*models.DiseaseRule, error) {
+	return u.guardrailRepo.FetchAllDiseaseRules(ctx, args)
+}
+
+/* FetchRulesByUserInfo กฎที่ active ของทุกโรคที่ผู้ใช้บันทึกไว้ ไม่มีโรคคืน slice ว่างโดยไม่ถาม database */
+func (u *guardrailUsecase) FetchRulesByUserInfo(ctx context.Context, userInfo *models.UserInfo) ([]*models.DiseaseRule, error) {
+	if userInfo == nil || len(userInfo.Diseases) == 0 {
+		return []*models.DiseaseRule{}, nil
+	}
+	diseaseIds := make([]string, 0, len(userInfo.Diseases))
+	for _, disease := range userInfo.Diseases {
+		if disease.Id != nil {
+			diseaseIds = append(diseaseIds, disease.Id.String())
+		}
+	}
+	if len(diseaseIds) == 0 {
+		return []*models.DiseaseRule{}, nil
+	}
+
+	args := new(sync.Map)
+	args.Store("disease_ids", diseaseIds)
+	args.Store("is_active", true)
+	return u.guardrailRepo.FetchAllDiseaseRules(ctx, args)
+}
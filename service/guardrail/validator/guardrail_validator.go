@@ -0,0 +1,23 @@
+package validator
+
+import (
+	"healthmatefood-api/apperror"
+
+	"github.com/Pheethy/psql/helper"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Validation struct{}
+
+/* ValidateFetchAllDiseaseRules disease_id ที่ส่งมาต้องเป็น uuid */
+func (v Validation) ValidateFetchAllDiseaseRules() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if diseaseId := c.Query("disease_id"); diseaseId != "" {
+			if err := validation.Validate(diseaseId, validation.By(helper.ValidateTypeUUID)); err != nil {
+				return apperror.Field("disease_id", err.Error())
+			}
+		}
+		return c.Next()
+	}
+}
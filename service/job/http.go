@@ -0,0 +1,11 @@
+package job
+
+import "github.com/gofiber/fiber/v2"
+
+type IJobHandler interface {
+	EnqueueMealPlanJob(c *fiber.Ctx) error
+	FetchAllJobs(c *fiber.Ctx) error
+	FetchOneJobById(c *fiber.Ctx) error
+	FetchJobResult(c *fiber.Ctx) error
+	RetryJob(c *fiber.Ctx) error
+}
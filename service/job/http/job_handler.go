@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/job"
+	"net/http"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+type jobHandler struct {
+	jobUs job.IJobUsecase
+}
+
+func NewJobHandler(jobUs job.IJobUsecase) job.IJobHandler {
+	return &jobHandler{
+		jobUs: jobUs,
+	}
+}
+
+// @Summary     EnqueueMealPlanJob
+// @Description Queue meal plan generation for the signed-in user and return right away, poll /v1/jobs/{job_id} until status is SUCCEEDED then read /v1/jobs/{job_id}/result
+// @Tags        job
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true "Bearer access token"
+// @Param       days    formData integer false "number of days (1-7)" default(3)
+// @Param       cuisine formData string  false "example: Thai, Japanese"
+// @Param       budget  formData number  false "food budget per day (THB)"
+// @Param       fresh   formData boolean false "skip the cached plan and generate a new one"
+// @Success     202 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     429 {object} constants.ErrorResponse "ai quota exceeded, problem has reset_at and quota members and Retry-After is set"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/jobs/meal-plans [post]
+func (h *jobHandler) EnqueueMealPlanJob(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	params, _ := c.Locals("params").(map[string]interface{})
+	userId, _ := c.Locals("user_id").(*uuid.UUID)
+	if userId == nil {
+		return fiber.NewError(http.StatusUnauthorized, "no permission to access")
+	}
+
+	job, err := h.jobUs.EnqueueMealPlanJob(ctx, userId, models.NewMealPlanOptionWithParams(params))
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"job_id": job.Id,
+		"job":    job,
+	}
+
+	return c.Status(http.StatusAccepted).JSON(resp)
+}
+
+// @Summary     FetchAllJobs
+// @Description Admin only, queued jobs newest first, status DEAD lists jobs that used up every attempt
+// @Tags        job
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true  "Bearer access token"
+// @Param       status        query  string false "PENDING, RUNNING, SUCCEEDED or DEAD"
+// @Param       user_id       query  string false "owner of the job"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     403 {object} constants.ErrorResponse
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/jobs [get]
+func (h *jobHandler) FetchAllJobs(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	args := new(sync.Map)
+	if status := c.Query("status"); status != "" {
+		args.Store("status", status)
+	}
+	if userId := c.Query("user_id"); userId != "" {
+		args.Store("user_id", userId)
+	}
+
+	jobs, err := h.jobUs.FetchAllJobs(ctx, args)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"jobs": jobs,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     FetchOneJobById
+// @Description Get status of a job, only the owner or an admin can see it
+// @Tags        job
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true "Bearer access token"
+// @Param       job_id        path   string true "job id"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     404 {object} constants.ErrorResponse "job not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/jobs/{job_id} [get]
+func (h *jobHandler) FetchOneJobById(c *fiber.Ctx) error {
+	job, err := h.fetchOwnJob(c)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"job": job,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     FetchJobResult
+// @Description Get the meal plan created by a job, answers 409 until the job has SUCCEEDED
+// @Tags        job
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true "Bearer access token"
+// @Param       job_id        path   string true "job id"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     404 {object} constants.ErrorResponse "job not found"
+// @Failure     409 {object} constants.ErrorResponse "job is not completed"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/jobs/{job_id}/result [get]
+func (h *jobHandler) FetchJobResult(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	job, err := h.fetchOwnJob(c)
+	if err != nil {
+		return err
+	}
+
+	plan, err := h.jobUs.FetchJobResult(ctx, job)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"job":  job,
+		"plan": plan,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     RetryJob
+// @Description Admin only, put a DEAD job back in the queue with a fresh set of attempts
+// @Tags        job
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true "Bearer access token"
+// @Param       job_id        path   string true "job id"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     403 {object} constants.ErrorResponse
+// @Failure     404 {object} constants.ErrorResponse "job not found"
+// @Failure     409 {object} constants.ErrorResponse "only dead job can be retried"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/jobs/{job_id}/retry [post]
+func (h *jobHandler) RetryJob(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	id := uuid.FromStringOrNil(c.Params("job_id"))
+
+	job, err := h.jobUs.RetryJob(ctx, &id)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"message": "successful",
+		"job":     job,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+/* fetchOwnJob งานของคนอื่นตอบเป็นไม่พบ ยกเว้น admin */
+func (h *jobHandler) fetchOwnJob(c *fiber.Ctx) (*models.Job, error) {
+	userId, _ := c.Locals("user_id").(*uuid.UUID)
+	if userId == nil {
+		return nil, fiber.NewError(http.StatusUnauthorized, "no permission to access")
+	}
+	id := uuid.FromStringOrNil(c.Params("job_id"))
+
+	job, err := h.jobUs.FetchOneJobById(c.UserContext(), &id)
+	if err != nil {
+		return nil, err
+	}
+	if cast.ToInt64(c.Locals("role_id")) != constants.USER_ROLE_ADMIN && (job.UserId == nil || *job.UserId != *userId) {
+		return nil, apperror.NotFound(constants.ERROR_JOB_NOT_FOUND)
+	}
+	return job, nil
+}
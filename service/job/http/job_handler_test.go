@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/middleware"
+	"healthmatefood-api/models"
+	agent_mocks "healthmatefood-api/service/agent-ai/mocks"
+	aiusage_mocks "healthmatefood-api/service/aiusage/mocks"
+	job_mocks "healthmatefood-api/service/job/mocks"
+	job_usecase "healthmatefood-api/service/job/usecase"
+	user_mocks "healthmatefood-api/service/user/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type jobConfig struct{}
+
+func (j jobConfig) Workers() int                { return 1 }
+func (j jobConfig) PollInterval() time.Duration { return time.Second }
+func (j jobConfig) MaxAttempts() int            { return 3 }
+func (j jobConfig) Timeout() time.Duration      { return time.Minute }
+
+func TestEnqueueMealPlanJob(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	jobRepo := new(job_mocks.IJobRepository)
+	jobRepo.On("InsertJob", mock.Anything, mock.MatchedBy(func(job *models.Job) bool {
+		option, err := job.MealPlanOption()
+		return err == nil && *job.UserId == userId && job.Status == models.JobStatusPending && job.MaxAttempts == 3 &&
+			option.Days == 5 && option.Cuisine == "Thai"
+	})).Return(nil)
+	handler := &jobHandler{jobUs: job_usecase.NewJobUsecase(jobConfig{}, jobRepo, nil, nil, nil, nil)}
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	app.Post("/v1/jobs/meal-plans", func(c *fiber.Ctx) error {
+		c.Locals("user_id", &userId)
+		c.Locals("params", map[string]interface{}{"days": "5", "cuisine": "Thai"})
+		return c.Next()
+	}, handler.EnqueueMealPlanJob)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/jobs/meal-plans", nil)
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	body := map[string]interface{}{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.NotEmpty(t, body["job_id"])
+	jobRepo.AssertExpectations(t)
+}
+
+func TestFetchJobResult(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	otherId := uuid.FromStringOrNil("4b1b0a43-4b8e-4bd4-a5a4-0e5e0b0b7c11")
+	jobId := uuid.FromStringOrNil("0d7ad0a8-4c5e-4b8f-9a5b-3b1d1c2a6f01")
+	newApp := func(jobRepo *job_mocks.IJobRepository, userId uuid.UUID) *fiber.App {
+		handler := &jobHandler{jobUs: job_usecase.NewJobUsecase(jobConfig{}, jobRepo, nil, nil, nil, nil)}
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		app.Get("/v1/jobs/:job_id/result", func(c *fiber.Ctx) error {
+			c.Locals("user_id", &userId)
+			c.Locals("role_id", int64(constants.USER_ROLE_CUSTOMER))
+			return c.Next()
+		}, handler.FetchJobResult)
+		return app
+	}
+	t.Run("error_not_completed", func(t *testing.T) {
+		jobRepo := new(job_mocks.IJobRepository)
+		jobRepo.On("FetchOneJobById", mock.Anything, &jobId).Return(&models.Job{Id: &jobId, UserId: &userId, Status: models.JobStatusRunning}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/jobs/"+jobId.String()+"/result", nil)
+		resp, err := newApp(jobRepo, userId).Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+	t.Run("error_other_user", func(t *testing.T) {
+		jobRepo := new(job_mocks.IJobRepository)
+		jobRepo.On("FetchOneJobById", mock.Anything, &jobId).Return(&models.Job{Id: &jobId, UserId: &userId, Status: models.JobStatusSucceeded}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/jobs/"+jobId.String()+"/result", nil)
+		resp, err := newApp(jobRepo, otherId).Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestProcessJob(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	planId := uuid.FromStringOrNil("6f1c3a52-2f1e-4f55-9f0a-5f0f5c1d2e33")
+	dob := helper.NewTimestampFromTime(time.Date(1995, 3, 1, 0, 0, 0, 0, time.Local))
+	user := &models.User{Id: &userId, UserInfo: &models.UserInfo{Gender: "MALE", DOB: &dob, Weight: 70, Height: 175, ActiveLevel: "MODERATE"}}
+	newJob := func(attempts int) *models.Job {
+		job, _ := models.NewMealPlanJob(&userId, &models.MealPlanOption{Days: 3}, 3)
+		job.Status = models.JobStatusRunning
+		job.Attempts = attempts
+		job.LockedBy = "worker-1"
+		return job
+	}
+	newAIUsageUs := func(dailyUsed int) *aiusage_mocks.IAIUsageUsecase {
+		dailyLimit := 1000
+		aiUsageUs := new(aiusage_mocks.IAIUsageUsecase)
+		aiUsageUs.On("CheckUserQuota", mock.Anything, &userId).
+			Return(models.NewAIQuotaStatus(&userId, &models.AIQuota{DailyTokenLimit: &dailyLimit}, dailyUsed, dailyUsed, time.Now()), nil)
+		return aiUsageUs
+	}
+	t.Run("success", func(t *testing.T) {
+		jobRepo := new(job_mocks.IJobRepository)
+		agentUs := new(agent_mocks.IAgentAIUsecase)
+		userUs := new(user_mocks.IUserUsecase)
+		aiUsageUs := newAIUsageUs(0)
+		userUs.On("FetchOneUserById", mock.Anything, &userId).Return(user, nil)
+		agentUs.On("GenerateMealsPlan", mock.Anything, user, mock.AnythingOfType("*models.MealPlanOption")).Return(&models.MealPlan{Id: &planId}, nil)
+		jobRepo.On("UpdateJob", mock.Anything, mock.MatchedBy(func(job *models.Job) bool {
+			return job.Status == models.JobStatusSucceeded && *job.ResultId == planId && job.CompletedAt != nil
+		}), "worker-1").Return(nil)
+		jobUs := job_usecase.NewJobUsecase(jobConfig{}, jobRepo, agentUs, userUs, nil, aiUsageUs)
+
+		assert.NoError(t, jobUs.ProcessJob(t.Context(), newJob(1)))
+		jobRepo.AssertExpectations(t)
+	})
+	t.Run("error_retry_later", func(t *testing.T) {
+		jobRepo := new(job_mocks.IJobRepository)
+		agentUs := new(agent_mocks.IAgentAIUsecase)
+		userUs := new(user_mocks.IUserUsecase)
+		userUs.On("FetchOneUserById", mock.Anything, &userId).Return(user, nil)
+		agentUs.On("GenerateMealsPlan", mock.Anything, user, mock.Anything).Return(nil, errors.New(constants.ERROR_AGENT_UNAVAILABLE))
+		jobRepo.On("UpdateJob", mock.Anything, mock.MatchedBy(func(job *models.Job) bool {
+			return job.Status == models.JobStatusPending && job.LastError == constants.ERROR_AGENT_UNAVAILABLE &&
+				job.RunAt.ToTime().After(time.Now().Add(30*time.Second))
+		}), "worker-1").Return(nil)
+		jobUs := job_usecase.NewJobUsecase(jobConfig{}, jobRepo, agentUs, userUs, nil, newAIUsageUs(0))
+
+		assert.Error(t, jobUs.ProcessJob(t.Context(), newJob(2)))
+		jobRepo.AssertExpectations(t)
+	})
+	t.Run("error_dead_letter", func(t *testing.T) {
+		jobRepo := new(job_mocks.IJobRepository)
+		agentUs := new(agent_mocks.IAgentAIUsecase)
+		userUs := new(user_mocks.IUserUsecase)
+		userUs.On("FetchOneUserById", mock.Anything, &userId).Return(user, nil)
+		agentUs.On("GenerateMealsPlan", mock.Anything, user, mock.Anything).Return(nil, apperror.Upstream(constants.ERROR_MEAL_PLAN_IS_INVALID))
+		jobRepo.On("UpdateJob", mock.Anything, mock.MatchedBy(func(job *models.Job) bool {
+			return job.Status == models.JobStatusDead && job.CompletedAt != nil && job.LockedBy == ""
+		}), "worker-1").Return(nil)
+		jobUs := job_usecase.NewJobUsecase(jobConfig{}, jobRepo, agentUs, userUs, nil, newAIUsageUs(0))
+
+		assert.Error(t, jobUs.ProcessJob(t.Context(), newJob(3)))
+		jobRepo.AssertExpectations(t)
+	})
+	t.Run("error_quota_exceeded", func(t *testing.T) {
+		jobRepo := new(job_mocks.IJobRepository)
+		agentUs := new(agent_mocks.IAgentAIUsecase)
+		userUs := new(user_mocks.IUserUsecase)
+		jobRepo.On("UpdateJob", mock.Anything, mock.MatchedBy(func(job *models.Job) bool {
+			return job.Status == models.JobStatusPending && job.Attempts == 1 && job.LockedBy == "" &&
+				job.RunAt.ToTime().After(time.Now())
+		}), "worker-1").Return(nil)
+		jobUs := job_usecase.NewJobUsecase(jobConfig{}, jobRepo, agentUs, userUs, nil, newAIUsageUs(1200))
+
+		err := jobUs.ProcessJob(t.Context(), newJob(2))
+		assert.Equal(t, apperror.KindRateLimited, apperror.KindOf(err))
+		userUs.AssertNotCalled(t, "FetchOneUserById", mock.Anything, mock.Anything)
+		agentUs.AssertNotCalled(t, "GenerateMealsPlan", mock.Anything, mock.Anything, mock.Anything)
+		jobRepo.AssertExpectations(t)
+	})
+	t.Run("error_lease_lost", func(t *testing.T) {
+		jobRepo := new(job_mocks.IJobRepository)
+		agentUs := new(agent_mocks.IAgentAIUsecase)
+		userUs := new(user_mocks.IUserUsecase)
+		userUs.On("FetchOneUserById", mock.Anything, &userId).Return(user, nil)
+		agentUs.On("GenerateMealsPlan", mock.Anything, user, mock.Anything).Return(&models.MealPlan{Id: &planId}, nil)
+		jobRepo.On("UpdateJob", mock.Anything, mock.Anything, "worker-1").Return(apperror.Conflict(constants.ERROR_JOB_LEASE_LOST))
+		jobUs := job_usecase.NewJobUsecase(jobConfig{}, jobRepo, agentUs, userUs, nil, newAIUsageUs(0))
+
+		err := jobUs.ProcessJob(t.Context(), newJob(1))
+		assert.Equal(t, apperror.KindConflict, apperror.KindOf(err))
+	})
+}
@@ -0,0 +1,162 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "healthmatefood-api/models"
+
+	sync "sync"
+
+	time "time"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IJobRepository is an autogenerated mock type for the IJobRepository type
+type IJobRepository struct {
+	mock.Mock
+}
+
+// ClaimJobs provides a mock function with given fields: ctx, workerId, limit, now, staleBefore
+func (_m *IJobRepository) ClaimJobs(ctx context.Context, workerId string, limit int, now time.Time, staleBefore time.Time) ([]*models.Job, error) {
+	ret := _m.Called(ctx, workerId, limit, now, staleBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimJobs")
+	}
+
+	var r0 []*models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Time, time.Time) ([]*models.Job, error)); ok {
+		return rf(ctx, workerId, limit, now, staleBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Time, time.Time) []*models.Job); ok {
+		r0 = rf(ctx, workerId, limit, now, staleBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, workerId, limit, now, staleBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchAllJobs provides a mock function with given fields: ctx, args
+func (_m *IJobRepository) FetchAllJobs(ctx context.Context, args *sync.Map) ([]*models.Job, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllJobs")
+	}
+
+	var r0 []*models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.Job, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.Job); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchOneJobById provides a mock function with given fields: ctx, id
+func (_m *IJobRepository) FetchOneJobById(ctx context.Context, id *uuid.UUID) (*models.Job, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOneJobById")
+	}
+
+	var r0 *models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.Job, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.Job); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertJob provides a mock function with given fields: ctx, _a1
+func (_m *IJobRepository) InsertJob(ctx context.Context, _a1 *models.Job) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Job) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateJob provides a mock function with given fields: ctx, _a1, lockedBy
+func (_m *IJobRepository) UpdateJob(ctx context.Context, _a1 *models.Job, lockedBy string) error {
+	ret := _m.Called(ctx, _a1, lockedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Job, string) error); ok {
+		r0 = rf(ctx, _a1, lockedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIJobRepository creates a new instance of IJobRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIJobRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IJobRepository {
+	mock := &IJobRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
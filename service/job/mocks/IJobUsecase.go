@@ -0,0 +1,232 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "healthmatefood-api/models"
+
+	sync "sync"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IJobUsecase is an autogenerated mock type for the IJobUsecase type
+type IJobUsecase struct {
+	mock.Mock
+}
+
+// ClaimJobs provides a mock function with given fields: ctx, workerId, limit
+func (_m *IJobUsecase) ClaimJobs(ctx context.Context, workerId string, limit int) ([]*models.Job, error) {
+	ret := _m.Called(ctx, workerId, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimJobs")
+	}
+
+	var r0 []*models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]*models.Job, error)); ok {
+		return rf(ctx, workerId, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []*models.Job); ok {
+		r0 = rf(ctx, workerId, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, workerId, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnqueueMealPlanJob provides a mock function with given fields: ctx, userId, option
+func (_m *IJobUsecase) EnqueueMealPlanJob(ctx context.Context, userId *uuid.UUID, option *models.MealPlanOption) (*models.Job, error) {
+	ret := _m.Called(ctx, userId, option)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueMealPlanJob")
+	}
+
+	var r0 *models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *models.MealPlanOption) (*models.Job, error)); ok {
+		return rf(ctx, userId, option)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *models.MealPlanOption) *models.Job); ok {
+		r0 = rf(ctx, userId, option)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, *models.MealPlanOption) error); ok {
+		r1 = rf(ctx, userId, option)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchAllJobs provides a mock function with given fields: ctx, args
+func (_m *IJobUsecase) FetchAllJobs(ctx context.Context, args *sync.Map) ([]*models.Job, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllJobs")
+	}
+
+	var r0 []*models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.Job, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.Job); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchJobResult provides a mock function with given fields: ctx, _a1
+func (_m *IJobUsecase) FetchJobResult(ctx context.Context, _a1 *models.Job) (*models.MealPlan, error) {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchJobResult")
+	}
+
+	var r0 *models.MealPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Job) (*models.MealPlan, error)); ok {
+		return rf(ctx, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Job) *models.MealPlan); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MealPlan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Job) error); ok {
+		r1 = rf(ctx, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchOneJobById provides a mock function with given fields: ctx, id
+func (_m *IJobUsecase) FetchOneJobById(ctx context.Context, id *uuid.UUID) (*models.Job, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOneJobById")
+	}
+
+	var r0 *models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.Job, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.Job); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProcessJob provides a mock function with given fields: ctx, _a1
+func (_m *IJobUsecase) ProcessJob(ctx context.Context, _a1 *models.Job) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Job) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RetryJob provides a mock function with given fields: ctx, id
+func (_m *IJobUsecase) RetryJob(ctx context.Context, id *uuid.UUID) (*models.Job, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryJob")
+	}
+
+	var r0 *models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.Job, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.Job); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIJobUsecase creates a new instance of IJobUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIJobUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IJobUsecase {
+	mock := &IJobUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
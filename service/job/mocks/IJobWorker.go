@@ -0,0 +1,53 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IJobWorker is an autogenerated mock type for the IJobWorker type
+type IJobWorker struct {
+	mock.Mock
+}
+
+// Shutdown provides a mock function with given fields: timeout
+func (_m *IJobWorker) Shutdown(timeout time.Duration) error {
+	ret := _m.Called(timeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Shutdown")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(time.Duration) error); ok {
+		r0 = rf(timeout)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Start provides a mock function with given fields: ctx
+func (_m *IJobWorker) Start(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// NewIJobWorker creates a new instance of IJobWorker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIJobWorker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IJobWorker {
+	mock := &IJobWorker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
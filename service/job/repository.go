@@ -0,0 +1,18 @@
+package job
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type IJobRepository interface {
+	FetchAllJobs(ctx context.Context, args *sync.Map) ([]*models.Job, error)
+	FetchOneJobById(ctx context.Context, id *uuid.UUID) (*models.Job, error)
+	InsertJob(ctx context.Context, job *models.Job) error
+	UpdateJob(ctx context.Context, job *models.Job, lockedBy string) error
+	ClaimJobs(ctx context.Context, workerId string, limit int, now time.Time, staleBefore time.Time) ([]*models.Job, error)
+}
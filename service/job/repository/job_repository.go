@@ -0,0 +1,307 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/job"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/Pheethy/sqlx"
+	"github.com/gofrs/uuid"
+)
+
+type jobRepository struct {
+	psqlDB *sqlx.DB
+}
+
+func NewJobRepository(psqlDB *sqlx.DB) job.IJobRepository {
+	return &jobRepository{
+		psqlDB: psqlDB,
+	}
+}
+
+const selectJob = `
+        "jobs"."id",
+        "jobs"."user_id",
+        "jobs"."type",
+        "jobs"."status",
+        "jobs"."payload",
+        "jobs"."attempts",
+        "jobs"."max_attempts",
+        COALESCE("jobs"."last_error", '') "last_error",
+        "jobs"."result_id",
+        to_char("jobs"."run_at", 'yyyy-MM-dd HH24:MI:SS') "run_at",
+        to_char("jobs"."locked_at", 'yyyy-MM-dd HH24:MI:SS') "locked_at",
+        COALESCE("jobs"."locked_by", '') "locked_by",
+        to_char("jobs"."completed_at", 'yyyy-MM-dd HH24:MI:SS') "completed_at",
+        to_char("jobs"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+        to_char("jobs"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at"`
+
+func (r *jobRepository) FetchAllJobs(ctx context.Context, args *sync.Map) ([]*models.Job, error) {
+	var conds []interface{}
+	var wheres []string
+	if userId, ok := args.Load("user_id"); ok {
+		conds = append(conds, userId)
+		wheres = append(wheres, fmt.Sprintf(`"jobs"."user_id" = $%d::uuid`, len(conds)))
+	}
+	if status, ok := args.Load("status"); ok {
+		conds = append(conds, status)
+		wheres = append(wheres, fmt.Sprintf(`"jobs"."status" = $%d::varchar`, len(conds)))
+	}
+	if jobType, ok := args.Load("type"); ok {
+		conds = append(conds, jobType)
+		wheres = append(wheres, fmt.Sprintf(`"jobs"."type" = $%d::varchar`, len(conds)))
+	}
+	where := ""
+	if len(wheres) > 0 {
+		where = "WHERE " + strings.Join(wheres, " AND ")
+	}
+
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        %s
+      FROM
+        "jobs"
+      %s
+      ORDER BY
+        "jobs"."created_at" DESC
+    ) AS "json_data"
+  `, selectJob, where)
+
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, conds...).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*models.Job, 0)
+	if err := json.Unmarshal(jsonData, &jobs); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+func (r *jobRepository) FetchOneJobById(ctx context.Context, id *uuid.UUID) (*models.Job, error) {
+	sql := fmt.Sprintf(`
+    SELECT
+      to_jsonb("json_data")
+    FROM (
+      SELECT
+        %s
+      FROM
+        "jobs"
+      WHERE
+        "jobs"."id" = $1::uuid
+    ) AS "json_data"
+  `, selectJob)
+
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, id).Scan(&jsonData); err != nil {
+		return nil, apperror.FromDB(err, constants.ERROR_JOB_NOT_FOUND)
+	}
+
+	job := new(models.Job)
+	if err := json.Unmarshal(jsonData, &job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (r *jobRepository) InsertJob(ctx context.Context, job *models.Job) error {
+	sql := `
+    INSERT INTO "jobs" (
+      "id",
+      "user_id",
+      "type",
+      "status",
+      "payload",
+      "max_attempts",
+      "run_at",
+      "created_at",
+      "updated_at"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::varchar,
+      $4::varchar,
+      $5::jsonb,
+      $6::int,
+      $7::timestamp,
+      $8::timestamp,
+      $9::timestamp
+    )
+  `
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx,
+		job.Id,
+		job.UserId,
+		job.Type,
+		job.Status,
+		string(job.Payload),
+		job.MaxAttempts,
+		job.RunAt,
+		job.CreatedAt,
+		job.UpdatedAt,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+/* UpdateJob บันทึกผลหลัง worker ทำงานเสร็จหรือเมื่อ admin สั่งลองใหม่ lockedBy คือผู้ถือ lease ตอนที่อ่านงานมา ถ้างานถูกจองใหม่ไปแล้ว (เช่นถูกหยิบเป็นงานค้าง) จะไม่เขียนทับและคืน Conflict */
+func (r *jobRepository) UpdateJob(ctx context.Context, job *models.Job, lockedBy string) error {
+	sql := `
+    UPDATE "jobs" SET
+      "status" = $2::varchar,
+      "attempts" = $3::int,
+      "last_error" = NULLIF($4::text, ''),
+      "result_id" = $5::uuid,
+      "run_at" = $6::timestamp,
+      "locked_at" = $7::timestamp,
+      "locked_by" = NULLIF($8::varchar, ''),
+      "completed_at" = $9::timestamp,
+      "updated_at" = $10::timestamp
+    WHERE
+      "id" = $1::uuid
+    AND
+      "locked_by" IS NOT DISTINCT FROM NULLIF($11::varchar, '')
+  `
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx,
+		job.Id,
+		job.Status,
+		job.Attempts,
+		job.LastError,
+		job.ResultId,
+		job.RunAt,
+		job.LockedAt,
+		job.LockedBy,
+		job.CompletedAt,
+		job.UpdatedAt,
+		lockedBy,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return apperror.Conflict(constants.ERROR_JOB_LEASE_LOST)
+	}
+
+	return nil
+}
+
+/* ClaimJobs จองงานที่ถึงเวลาแล้วด้วย FOR UPDATE SKIP LOCKED หลาย instance จึงไม่หยิบงานซ้ำกัน งานที่ RUNNING แต่ถูกจองก่อน staleBefore ถือว่า worker เดิมตายไปแล้ว หยิบมาทำใหม่เฉพาะที่ยังไม่ครบ max_attempts ที่ครบแล้วย้ายเป็น DEAD */
+func (r *jobRepository) ClaimJobs(ctx context.Context, workerId string, limit int, now time.Time, staleBefore time.Time) ([]*models.Job, error) {
+	sql := fmt.Sprintf(`
+    WITH "expired" AS (
+      UPDATE "jobs" SET
+        "status" = '%s',
+        "last_error" = '%s',
+        "locked_at" = NULL,
+        "locked_by" = NULL,
+        "completed_at" = $3::timestamp,
+        "updated_at" = $3::timestamp
+      WHERE
+        "status" = '%s'
+      AND
+        "locked_at" < $4::timestamp
+      AND
+        "attempts" >= "max_attempts"
+    ),
+    "claimed" AS (
+      UPDATE "jobs" SET
+        "status" = '%s',
+        "attempts" = "jobs"."attempts" + 1,
+        "locked_at" = $3::timestamp,
+        "locked_by" = $1::varchar,
+        "updated_at" = $3::timestamp
+      WHERE
+        "jobs"."id" IN (
+          SELECT
+            "id"
+          FROM
+            "jobs"
+          WHERE
+            ("status" = '%s' AND "run_at" <= $3::timestamp)
+          OR
+            ("status" = '%s' AND "locked_at" < $4::timestamp AND "attempts" < "max_attempts")
+          ORDER BY
+            "run_at" ASC
+          LIMIT $2::int
+          FOR UPDATE SKIP LOCKED
+        )
+      RETURNING *
+    )
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        %s
+      FROM
+        "claimed" AS "jobs"
+      ORDER BY
+        "jobs"."run_at" ASC
+    ) AS "json_data"
+  `, models.JobStatusDead, constants.ERROR_JOB_TIMED_OUT, models.JobStatusRunning, models.JobStatusRunning, models.JobStatusPending, models.JobStatusRunning, selectJob)
+
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx,
+		workerId,
+		limit,
+		helper.NewTimestampFromTime(now),
+		helper.NewTimestampFromTime(staleBefore),
+	).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*models.Job, 0)
+	if err := json.Unmarshal(jsonData, &jobs); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
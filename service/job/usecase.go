@@ -0,0 +1,19 @@
+package job
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type IJobUsecase interface {
+	FetchAllJobs(ctx context.Context, args *sync.Map) ([]*models.Job, error)
+	FetchOneJobById(ctx context.Context, id *uuid.UUID) (*models.Job, error)
+	FetchJobResult(ctx context.Context, job *models.Job) (*models.MealPlan, error)
+	EnqueueMealPlanJob(ctx context.Context, userId *uuid.UUID, option *models.MealPlanOption) (*models.Job, error)
+	RetryJob(ctx context.Context, id *uuid.UUID) (*models.Job, error)
+	ClaimJobs(ctx context.Context, workerId string, limit int) ([]*models.Job, error)
+	ProcessJob(ctx context.Context, job *models.Job) error
+}
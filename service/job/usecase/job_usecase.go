@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/config"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/agent-ai"
+	"healthmatefood-api/service/aiusage"
+	"healthmatefood-api/service/job"
+	"healthmatefood-api/service/mealplan"
+	"healthmatefood-api/service/user"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type jobUsecase struct {
+	cfg          config.IJobConfig
+	jobRepo      job.IJobRepository
+	agentUs      agent.IAgentAIUsecase
+	userUs       user.IUserUsecase
+	mealPlanRepo mealplan.IMealPlanRepository
+	aiUsageUs    aiusage.IAIUsageUsecase
+}
+
+func NewJobUsecase(cfg config.IJobConfig, jobRepo job.IJobRepository, agentUs agent.IAgentAIUsecase, userUs user.IUserUsecase, mealPlanRepo mealplan.IMealPlanRepository, aiUsageUs aiusage.IAIUsageUsecase) job.IJobUsecase {
+	return &jobUsecase{
+		cfg:          cfg,
+		jobRepo:      jobRepo,
+		agentUs:      agentUs,
+		userUs:       userUs,
+		mealPlanRepo: mealPlanRepo,
+		aiUsageUs:    aiUsageUs,
+	}
+}
+
+func (u *jobUsecase) FetchAllJobs(ctx context.Context, args *sync.Map) ([]*models.Job, error) {
+	return u.jobRepo.FetchAllJobs(ctx, args)
+}
+
+func (u *jobUsecase) FetchOneJobById(ctx context.Context, id *uuid.UUID) (*models.Job, error) {
+	return u.jobRepo.FetchOneJobById(ctx, id)
+}
+
+/* FetchJobResult แผนอาหารที่งานสร้างไว้ ได้เฉพาะงานที่ SUCCEEDED แล้ว */
+func (u *jobUsecase) FetchJobResult(ctx context.Context, job *models.Job) (*models.MealPlan, error) {
+	if job.Status != models.JobStatusSucceeded || job.ResultId == nil {
+		return nil, apperror.Conflict(constants.ERROR_JOB_NOT_COMPLETED)
+	}
+	return u.mealPlanRepo.FetchOneMealPlanById(ctx, job.ResultId)
+}
+
+func (u *jobUsecase) EnqueueMealPlanJob(ctx context.Context, userId *uuid.UUID, option *models.MealPlanOption) (*models.Job, error) {
+	job, err := models.NewMealPlanJob(userId, option, u.cfg.MaxAttempts())
+	if err != nil {
+		return nil, err
+	}
+	if err := u.jobRepo.InsertJob(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+/* RetryJob นำงานที่ DEAD กลับเข้าคิวใหม่ */
+func (u *jobUsecase) RetryJob(ctx context.Context, id *uuid.UUID) (*models.Job, error) {
+	job, err := u.jobRepo.FetchOneJobById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != models.JobStatusDead {
+		return nil, apperror.Conflict(constants.ERROR_JOB_CANNOT_RETRY)
+	}
+	lockedBy := job.LockedBy
+	job.Requeue()
+	if err := u.jobRepo.UpdateJob(ctx, job, lockedBy); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+/* ClaimJobs งานที่ถูกจองนานเกินสองเท่าของ timeout ถือว่าค้างและหยิบมาทำใหม่ได้ */
+func (u *jobUsecase) ClaimJobs(ctx context.Context, workerId string, limit int) ([]*models.Job, error) {
+	now := time.Now()
+	return u.jobRepo.ClaimJobs(ctx, workerId, limit, now, now.Add(-2*u.cfg.Timeout()))
+}
+
+/* ProcessJob ทำงานที่จองไว้แล้วบันทึกผล ถ้า ctx ถูกยกเลิกเพราะปิดโปรแกรมจะคืนงานเข้าคิวโดยไม่นับครั้ง ผู้ใช้ที่ใช้ AI เกินโควตาระหว่างที่งานรอคิว งานจะถูกเลื่อนไปทำหลังโควตารีเซ็ต */
+func (u *jobUsecase) ProcessJob(ctx context.Context, job *models.Job) error {
+	lockedBy := job.LockedBy
+	jobCtx, cancel := context.WithTimeout(ctx, u.cfg.Timeout())
+	defer cancel()
+
+	status, err := u.aiUsageUs.CheckUserQuota(jobCtx, job.UserId)
+	if err == nil && status.Exceeded {
+		job.Postpone(status.ResetAt)
+		if err := u.jobRepo.UpdateJob(context.Background(), job, lockedBy); err != nil {
+			return err
+		}
+		return apperror.RateLimited(constants.ERROR_AI_QUOTA_EXCEEDED).WithExtension("reset_at", status.ResetAt)
+	}
+
+	meter := models.NewAIUsageMeter(job.UserId, "job:"+string(job.Type), func(usages []*models.AIUsage) {
+		if err := u.aiUsageUs.RecordUsages(context.Background(), usages); err != nil {
+			logrus.Errorf("record ai usages of job %s failed: %v", job.Id, err)
+		}
+	})
+	var resultId *uuid.UUID
+	if err == nil {
+		resultId, err = u.runJob(models.ContextWithAIUsageMeter(jobCtx, meter), job)
+	}
+	meter.Done()
+
+	switch {
+	case err == nil:
+		job.Succeed(resultId)
+	case ctx.Err() != nil:
+		job.Release()
+	default:
+		job.Fail(err)
+	}
+	if updateErr := u.jobRepo.UpdateJob(context.Background(), job, lockedBy); updateErr != nil {
+		return updateErr
+	}
+	return err
+}
+
+func (u *jobUsecase) runJob(ctx context.Context, job *models.Job) (*uuid.UUID, error) {
+	switch job.Type {
+	case models.JobTypeMealPlan:
+		return u.generateMealPlan(ctx, job)
+	}
+	return nil, errors.New("unknown job type: " + string(job.Type))
+}
+
+/* generateMealPlan ใช้ข้อมูลผู้ใช้ล่าสุดตอนที่งานถูกทำ ไม่ใช่ตอนที่ส่งงาน */
+func (u *jobUsecase) generateMealPlan(ctx context.Context, job *models.Job) (*uuid.UUID, error) {
+	option, err := job.MealPlanOption()
+	if err != nil {
+		return nil, err
+	}
+	user, err := u.userUs.FetchOneUserById(ctx, job.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if user.UserInfo == nil {
+		return nil, apperror.NotFound(constants.ERROR_USER_INFO_NOT_FOUND)
+	}
+	user.UserInfo.GetBMR()
+	user.UserInfo.GetCaloriesLimit()
+	user.UserInfo.SetMedicalCondition()
+
+	plan, err := u.agentUs.GenerateMealsPlan(ctx, user, option)
+	if err != nil {
+		return nil, err
+	}
+	return plan.Id, nil
+}
@@ -0,0 +1,49 @@
+package validator
+
+import (
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/models"
+	agent_validator "healthmatefood-api/service/agent-ai/validator"
+
+	"github.com/Pheethy/psql/helper"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Validation struct{}
+
+/* ValidateMealPlanOption งานสร้างแผนอาหารรับ option ชุดเดียวกับ agent-ai */
+func (v Validation) ValidateMealPlanOption() fiber.Handler {
+	return agent_validator.Validation{}.ValidateMealPlanOption()
+}
+
+func (v Validation) ValidateFetchAllJobs() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		fields := apperror.Fields{}
+		if status := c.Query("status"); status != "" {
+			fields.Check("status", validation.Validate(models.JobStatus(status), validation.In(
+				models.JobStatusPending,
+				models.JobStatusRunning,
+				models.JobStatusSucceeded,
+				models.JobStatusDead,
+			)))
+		}
+		if userId := c.Query("user_id"); userId != "" {
+			fields.Check("user_id", validation.Validate(userId, validation.By(helper.ValidateTypeUUID)))
+		}
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateParams(key string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params := c.Params(key)
+		if err := validation.Validate(params, validation.By(helper.ValidateTypeUUID)); err != nil {
+			return apperror.Field(key, err.Error())
+		}
+		return c.Next()
+	}
+}
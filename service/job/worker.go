@@ -0,0 +1,11 @@
+package job
+
+import (
+	"context"
+	"time"
+)
+
+type IJobWorker interface {
+	Start(ctx context.Context)
+	Shutdown(timeout time.Duration) error
+}
@@ -0,0 +1,109 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"healthmatefood-api/config"
+	"healthmatefood-api/service/job"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type jobWorker struct {
+	cfg    config.IJobConfig
+	jobUs  job.IJobUsecase
+	name   string
+	wg     sync.WaitGroup
+	stop   chan struct{}
+	cancel context.CancelFunc
+	once   sync.Once
+}
+
+func NewJobWorker(cfg config.IJobConfig, jobUs job.IJobUsecase) job.IJobWorker {
+	hostname, _ := os.Hostname()
+	return &jobWorker{
+		cfg:   cfg,
+		jobUs: jobUs,
+		name:  fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		stop:  make(chan struct{}),
+	}
+}
+
+/* Start เปิด worker ตามจำนวนที่ตั้งไว้ แต่ละตัวจองงานทีละงานจนคิวว่างแล้วจึงรอรอบถัดไป */
+func (w *jobWorker) Start(ctx context.Context) {
+	ctx, w.cancel = context.WithCancel(ctx)
+	for i := range w.cfg.Workers() {
+		w.wg.Add(1)
+		go w.run(ctx, fmt.Sprintf("%s-%d", w.name, i+1))
+	}
+}
+
+func (w *jobWorker) run(ctx context.Context, workerId string) {
+	defer w.wg.Done()
+	ticker := time.NewTicker(w.cfg.PollInterval())
+	defer ticker.Stop()
+	for {
+		for w.processNext(ctx, workerId) {
+		}
+		select {
+		case <-w.stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+/* processNext คืนค่า true เมื่อทำงานไปหนึ่งงานและควรดึงงานต่อทันที */
+func (w *jobWorker) processNext(ctx context.Context, workerId string) bool {
+	select {
+	case <-w.stop:
+		return false
+	default:
+	}
+
+	jobs, err := w.jobUs.ClaimJobs(ctx, workerId, 1)
+	if err != nil {
+		if ctx.Err() == nil {
+			logrus.Errorf("claim jobs failed: %v", err)
+		}
+		return false
+	}
+	if len(jobs) == 0 {
+		return false
+	}
+	for _, job := range jobs {
+		if err := w.jobUs.ProcessJob(ctx, job); err != nil {
+			logrus.Errorf("job %s (%s) attempt %d failed: %v", job.Id, job.Type, job.Attempts, err)
+		}
+	}
+	return true
+}
+
+/* Shutdown หยุดจองงานใหม่และรองานที่กำลังทำให้เสร็จภายใน timeout เกินแล้วยกเลิกงานที่เหลือให้กลับเข้าคิว */
+func (w *jobWorker) Shutdown(timeout time.Duration) error {
+	w.once.Do(func() { close(w.stop) })
+	if w.cancel == nil {
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		w.cancel()
+		return nil
+	case <-time.After(timeout):
+		w.cancel()
+		<-done
+		return errors.New("job worker shutdown timed out, running jobs were released")
+	}
+}
@@ -0,0 +1,11 @@
+package knowledge
+
+import "github.com/gofiber/fiber/v2"
+
+type IKnowledgeHandler interface {
+	FetchAllKnowledgeDocuments(c *fiber.Ctx) error
+	FetchOneKnowledgeDocumentById(c *fiber.Ctx) error
+	CreateKnowledgeDocument(c *fiber.Ctx) error
+	DeleteKnowledgeDocument(c *fiber.Ctx) error
+	SearchKnowledge(c *fiber.Ctx) error
+}
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"errors"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/knowledge"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+)
+
+type knowledgeHandler struct {
+	knowledgeUs knowledge.IKnowledgeUsecase
+}
+
+func NewKnowledgeHandler(knowledgeUs knowledge.IKnowledgeUsecase) knowledge.IKnowledgeHandler {
+	return &knowledgeHandler{
+		knowledgeUs: knowledgeUs,
+	}
+}
+
+// @Summary     FetchAllKnowledgeDocuments
+// @Description Admin only, nutrition guideline documents in the knowledge base, newest first (without content)
+// @Tags        knowledge
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true  "Bearer access token"
+// @Param       search        query  string false "search in title or source"
+// @Success     200 {object} map[string]interface{}
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     403 {object} constants.ErrorResponse
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/knowledge/documents [get]
+func (h *knowledgeHandler) FetchAllKnowledgeDocuments(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	args := new(sync.Map)
+	if search := strings.TrimSpace(c.Query("search")); search != "" {
+		args.Store("search", search)
+	}
+
+	documents, err := h.knowledgeUs.FetchAllKnowledgeDocuments(ctx, args)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"documents": documents,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     FetchOneKnowledgeDocumentById
+// @Description Admin only, get a knowledge document with its content
+// @Tags        knowledge
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true "Bearer access token"
+// @Param       document_id   path   string true "document id"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     403 {object} constants.ErrorResponse
+// @Failure     404 {object} constants.ErrorResponse "knowledge document not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/knowledge/documents/{document_id} [get]
+func (h *knowledgeHandler) FetchOneKnowledgeDocumentById(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	id := uuid.FromStringOrNil(c.Params("document_id"))
+
+	document, err := h.knowledgeUs.FetchOneKnowledgeDocumentById(ctx, &id)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"document": document,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     CreateKnowledgeDocument
+// @Description Admin only, add a clinical nutrition guideline, the text is split into chunks and embedded for retrieval by chat and meal plans. Send content as text or upload one .txt/.md file (max 2 MB) in files
+// @Tags        knowledge
+// @Accept      multipart/form-data
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header   string true  "Bearer access token"
+// @Param       title         formData string true  "document title, shown in citations"
+// @Param       source        formData string false "where the guideline comes from, example: Thai Dietetic Association 2024"
+// @Param       content       formData string false "document text, required when no file is uploaded"
+// @Param       files         formData file   false "document file (.txt, .md)"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse "document is invalid or has no content"
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     403 {object} constants.ErrorResponse
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/knowledge/documents [post]
+func (h *knowledgeHandler) CreateKnowledgeDocument(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	params := c.Locals("params").(map[string]interface{})
+	files, _ := c.Locals("files").([]*multipart.FileHeader)
+	userId, _ := c.Locals("user_id").(*uuid.UUID)
+
+	document := models.NewKnowledgeDocumentWithParams(params, nil)
+	document.CreatedBy = userId
+	if len(files) > 0 {
+		content, err := readKnowledgeFile(files[0])
+		if err != nil {
+			return fiber.NewError(http.StatusBadRequest, err.Error())
+		}
+		document.Content = content
+		if document.Source == "" {
+			document.Source = filepath.Base(files[0].Filename)
+		}
+	}
+
+	if err := h.knowledgeUs.CreateKnowledgeDocument(ctx, document); err != nil {
+		return err
+	}
+	document.Content = ""
+	resp := map[string]interface{}{
+		"message":  "successful",
+		"document": document,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     DeleteKnowledgeDocument
+// @Description Admin only, remove a document and its chunks from the knowledge base
+// @Tags        knowledge
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true "Bearer access token"
+// @Param       document_id   path   string true "document id"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     403 {object} constants.ErrorResponse
+// @Failure     404 {object} constants.ErrorResponse "knowledge document not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/knowledge/documents/{document_id} [delete]
+func (h *knowledgeHandler) DeleteKnowledgeDocument(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	id := uuid.FromStringOrNil(c.Params("document_id"))
+
+	if err := h.knowledgeUs.DeleteKnowledgeDocument(ctx, &id); err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"message": "successful",
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     SearchKnowledge
+// @Description Admin only, preview which chunks the agent would retrieve for a question, with similarity scores
+// @Tags        knowledge
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true "Bearer access token"
+// @Param       q             query  string true "question, example: โซเดียมสำหรับผู้ป่วยความดันสูง"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse "q was missing"
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     403 {object} constants.ErrorResponse
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/knowledge/search [get]
+func (h *knowledgeHandler) SearchKnowledge(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return fiber.NewError(http.StatusBadRequest, "q: was missing on query")
+	}
+
+	chunks, err := h.knowledgeUs.SearchKnowledge(ctx, query)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"chunks":    chunks,
+		"citations": models.NewKnowledgeCitations(chunks),
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+func readKnowledgeFile(file *multipart.FileHeader) (string, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, models.MAX_KNOWLEDGE_DOCUMENT_SIZE+1))
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(data) {
+		return "", errors.New("files: must be UTF-8 text")
+	}
+	return string(data), nil
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"encoding/json"
+	"healthmatefood-api/config"
+	"healthmatefood-api/middleware"
+	"healthmatefood-api/models"
+	agent_repository "healthmatefood-api/service/agent-ai/repository"
+	knowledge_mocks "healthmatefood-api/service/knowledge/mocks"
+	knowledge_usecase "healthmatefood-api/service/knowledge/usecase"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type knowledgeConfig struct{}
+
+func (knowledgeConfig) EmbeddingModel() string { return "" }
+func (knowledgeConfig) VectorStore() string    { return config.KNOWLEDGE_VECTOR_STORE_GO }
+func (knowledgeConfig) TopK() int              { return 2 }
+func (knowledgeConfig) MinScore() float64      { return 0.1 }
+func (knowledgeConfig) ChunkSize() int         { return 100 }
+func (knowledgeConfig) ChunkOverlap() int      { return 10 }
+
+const kidneyGuideline = `ผู้ป่วยโรคไตเรื้อรังควรจำกัดโปรตีน 0.6-0.8 กรัมต่อน้ำหนักตัวหนึ่งกิโลกรัมต่อวัน
+
+ควรเลี่ยงอาหารโพแทสเซียมสูง เช่น กล้วย ส้ม มะเขือเทศ และจำกัดโซเดียมไม่เกิน 2000 มิลลิกรัมต่อวัน
+
+ผู้ป่วยโรคเกาต์ควรเลี่ยงเครื่องในสัตว์ ยอดผัก และเบียร์ ซึ่งมีพิวรีนสูง`
+
+func newKnowledgeHandler(knowledgeRepo *knowledge_mocks.IKnowledgeRepository) *knowledgeHandler {
+	embedder := agent_repository.NewHashEmbedder(256)
+	return &knowledgeHandler{knowledgeUs: knowledge_usecase.NewKnowledgeUsecase(knowledgeConfig{}, knowledgeRepo, embedder)}
+}
+
+func TestCreateKnowledgeDocument(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	newApp := func(knowledgeRepo *knowledge_mocks.IKnowledgeRepository, params map[string]interface{}) *fiber.App {
+		handler := newKnowledgeHandler(knowledgeRepo)
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		app.Post("/v1/knowledge/documents", func(c *fiber.Ctx) error {
+			c.Locals("user_id", &userId)
+			c.Locals("params", params)
+			return c.Next()
+		}, handler.CreateKnowledgeDocument)
+		return app
+	}
+	t.Run("success", func(t *testing.T) {
+		knowledgeRepo := new(knowledge_mocks.IKnowledgeRepository)
+		knowledgeRepo.On("InsertKnowledgeDocument", mock.Anything, mock.MatchedBy(func(document *models.KnowledgeDocument) bool {
+			return document.Title == "แนวทางโรคไต" && *document.CreatedBy == userId && document.ChunkCount == 3 && document.EmbeddingModel == "hash-256"
+		}), mock.MatchedBy(func(chunks []*models.KnowledgeChunk) bool {
+			return len(chunks) == 3 && chunks[2].ChunkIndex == 2 && len(chunks[0].Embedding) == 256 && strings.Contains(chunks[2].Content, "เกาต์")
+		})).Return(nil)
+		app := newApp(knowledgeRepo, map[string]interface{}{
+			"title":   "แนวทางโรคไต",
+			"content": kidneyGuideline,
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/knowledge/documents", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		knowledgeRepo.AssertExpectations(t)
+	})
+	t.Run("error_empty_content", func(t *testing.T) {
+		knowledgeRepo := new(knowledge_mocks.IKnowledgeRepository)
+		app := newApp(knowledgeRepo, map[string]interface{}{
+			"title":   "ว่าง",
+			"content": "\n\n  \n\n",
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/knowledge/documents", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		knowledgeRepo.AssertNotCalled(t, "InsertKnowledgeDocument", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSearchKnowledge(t *testing.T) {
+	embedder := agent_repository.NewHashEmbedder(256)
+	texts := models.ChunkKnowledgeText(kidneyGuideline, 100, 10)
+	embeddings, err := embedder.EmbedTexts(t.Context(), texts)
+	assert.NoError(t, err)
+	documentId := uuid.FromStringOrNil("5b0a7c6e-3c3f-4d7e-9a51-0d6f0b8f2a11")
+	newChunks := func() []*models.KnowledgeChunk {
+		chunks := make([]*models.KnowledgeChunk, 0, len(texts))
+		for index, text := range texts {
+			chunk := models.NewKnowledgeChunk(&documentId, index, text, embeddings[index], embedder.Model())
+			chunk.Title = "แนวทางโรคไต"
+			chunks = append(chunks, chunk)
+		}
+		return chunks
+	}
+	newApp := func(knowledgeRepo *knowledge_mocks.IKnowledgeRepository) *fiber.App {
+		handler := newKnowledgeHandler(knowledgeRepo)
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		app.Get("/v1/knowledge/search", handler.SearchKnowledge)
+		return app
+	}
+	t.Run("success", func(t *testing.T) {
+		knowledgeRepo := new(knowledge_mocks.IKnowledgeRepository)
+		knowledgeRepo.On("FetchAllKnowledgeChunks", mock.Anything, "hash-256").Return(newChunks(), nil)
+		app := newApp(knowledgeRepo)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/knowledge/search?q="+url.QueryEscape("โรคเกาต์ควรเลี่ยงเครื่องใน"), nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		body := struct {
+			Chunks    []*models.KnowledgeChunk    `json:"chunks"`
+			Citations []*models.KnowledgeCitation `json:"citations"`
+		}{}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.NotEmpty(t, body.Chunks)
+		assert.LessOrEqual(t, len(body.Chunks), 2)
+		assert.Equal(t, 2, body.Chunks[0].ChunkIndex)
+		assert.Empty(t, body.Chunks[0].Embedding)
+		assert.Equal(t, 1, body.Citations[0].Index)
+		assert.Equal(t, "แนวทางโรคไต", body.Citations[0].Title)
+	})
+	t.Run("error_missing_query", func(t *testing.T) {
+		knowledgeRepo := new(knowledge_mocks.IKnowledgeRepository)
+		app := newApp(knowledgeRepo)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/knowledge/search", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		knowledgeRepo.AssertNotCalled(t, "FetchAllKnowledgeChunks", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,118 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	fiber "github.com/gofiber/fiber/v2"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IKnowledgeHandler is an autogenerated mock type for the IKnowledgeHandler type
+type IKnowledgeHandler struct {
+	mock.Mock
+}
+
+// CreateKnowledgeDocument provides a mock function with given fields: c
+func (_m *IKnowledgeHandler) CreateKnowledgeDocument(c *fiber.Ctx) error {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateKnowledgeDocument")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*fiber.Ctx) error); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteKnowledgeDocument provides a mock function with given fields: c
+func (_m *IKnowledgeHandler) DeleteKnowledgeDocument(c *fiber.Ctx) error {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteKnowledgeDocument")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*fiber.Ctx) error); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchAllKnowledgeDocuments provides a mock function with given fields: c
+func (_m *IKnowledgeHandler) FetchAllKnowledgeDocuments(c *fiber.Ctx) error {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllKnowledgeDocuments")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*fiber.Ctx) error); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchOneKnowledgeDocumentById provides a mock function with given fields: c
+func (_m *IKnowledgeHandler) FetchOneKnowledgeDocumentById(c *fiber.Ctx) error {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOneKnowledgeDocumentById")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*fiber.Ctx) error); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchKnowledge provides a mock function with given fields: c
+func (_m *IKnowledgeHandler) SearchKnowledge(c *fiber.Ctx) error {
+	ret := _m.Called(c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchKnowledge")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*fiber.Ctx) error); ok {
+		r0 = rf(c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIKnowledgeHandler creates a new instance of IKnowledgeHandler. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIKnowledgeHandler(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IKnowledgeHandler {
+	mock := &IKnowledgeHandler{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,190 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "healthmatefood-api/models"
+
+	sync "sync"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IKnowledgeRepository is an autogenerated mock type for the IKnowledgeRepository type
+type IKnowledgeRepository struct {
+	mock.Mock
+}
+
+// DeleteKnowledgeDocument provides a mock function with given fields: ctx, id
+func (_m *IKnowledgeRepository) DeleteKnowledgeDocument(ctx context.Context, id *uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteKnowledgeDocument")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchAllKnowledgeChunks provides a mock function with given fields: ctx, embeddingModel
+func (_m *IKnowledgeRepository) FetchAllKnowledgeChunks(ctx context.Context, embeddingModel string) ([]*models.KnowledgeChunk, error) {
+	ret := _m.Called(ctx, embeddingModel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllKnowledgeChunks")
+	}
+
+	var r0 []*models.KnowledgeChunk
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.KnowledgeChunk, error)); ok {
+		return rf(ctx, embeddingModel)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.KnowledgeChunk); ok {
+		r0 = rf(ctx, embeddingModel)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.KnowledgeChunk)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, embeddingModel)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchAllKnowledgeDocuments provides a mock function with given fields: ctx, args
+func (_m *IKnowledgeRepository) FetchAllKnowledgeDocuments(ctx context.Context, args *sync.Map) ([]*models.KnowledgeDocument, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllKnowledgeDocuments")
+	}
+
+	var r0 []*models.KnowledgeDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.KnowledgeDocument, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.KnowledgeDocument); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.KnowledgeDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchOneKnowledgeDocumentById provides a mock function with given fields: ctx, id
+func (_m *IKnowledgeRepository) FetchOneKnowledgeDocumentById(ctx context.Context, id *uuid.UUID) (*models.KnowledgeDocument, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOneKnowledgeDocumentById")
+	}
+
+	var r0 *models.KnowledgeDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.KnowledgeDocument, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.KnowledgeDocument); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.KnowledgeDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertKnowledgeDocument provides a mock function with given fields: ctx, document, chunks
+func (_m *IKnowledgeRepository) InsertKnowledgeDocument(ctx context.Context, document *models.KnowledgeDocument, chunks []*models.KnowledgeChunk) error {
+	ret := _m.Called(ctx, document, chunks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertKnowledgeDocument")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.KnowledgeDocument, []*models.KnowledgeChunk) error); ok {
+		r0 = rf(ctx, document, chunks)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchKnowledgeChunks provides a mock function with given fields: ctx, embeddingModel, embedding, limit
+func (_m *IKnowledgeRepository) SearchKnowledgeChunks(ctx context.Context, embeddingModel string, embedding []float32, limit int) ([]*models.KnowledgeChunk, error) {
+	ret := _m.Called(ctx, embeddingModel, embedding, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchKnowledgeChunks")
+	}
+
+	var r0 []*models.KnowledgeChunk
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []float32, int) ([]*models.KnowledgeChunk, error)); ok {
+		return rf(ctx, embeddingModel, embedding, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []float32, int) []*models.KnowledgeChunk); ok {
+		r0 = rf(ctx, embeddingModel, embedding, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.KnowledgeChunk)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []float32, int) error); ok {
+		r1 = rf(ctx, embeddingModel, embedding, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIKnowledgeRepository creates a new instance of IKnowledgeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIKnowledgeRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IKnowledgeRepository {
+	mock := &IKnowledgeRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,160 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "healthmatefood-api/models"
+
+	sync "sync"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IKnowledgeUsecase is an autogenerated mock type for the IKnowledgeUsecase type
+type IKnowledgeUsecase struct {
+	mock.Mock
+}
+
+// CreateKnowledgeDocument provides a mock function with given fields: ctx, document
+func (_m *IKnowledgeUsecase) CreateKnowledgeDocument(ctx context.Context, document *models.KnowledgeDocument) error {
+	ret := _m.Called(ctx, document)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateKnowledgeDocument")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.KnowledgeDocument) error); ok {
+		r0 = rf(ctx, document)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteKnowledgeDocument provides a mock function with given fields: ctx, id
+func (_m *IKnowledgeUsecase) DeleteKnowledgeDocument(ctx context.Context, id *uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteKnowledgeDocument")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchAllKnowledgeDocuments provides a mock function with given fields: ctx, args
+func (_m *IKnowledgeUsecase) FetchAllKnowledgeDocuments(ctx context.Context, args *sync.Map) ([]*models.KnowledgeDocument, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllKnowledgeDocuments")
+	}
+
+	var r0 []*models.KnowledgeDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.KnowledgeDocument, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.KnowledgeDocument); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.KnowledgeDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchOneKnowledgeDocumentById provides a mock function with given fields: ctx, id
+func (_m *IKnowledgeUsecase) FetchOneKnowledgeDocumentById(ctx context.Context, id *uuid.UUID) (*models.KnowledgeDocument, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOneKnowledgeDocumentById")
+	}
+
+	var r0 *models.KnowledgeDocument
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.KnowledgeDocument, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.KnowledgeDocument); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.KnowledgeDocument)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchKnowledge provides a mock function with given fields: ctx, query
+func (_m *IKnowledgeUsecase) SearchKnowledge(ctx context.Context, query string) ([]*models.KnowledgeChunk, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchKnowledge")
+	}
+
+	var r0 []*models.KnowledgeChunk
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.KnowledgeChunk, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.KnowledgeChunk); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.KnowledgeChunk)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIKnowledgeUsecase creates a new instance of IKnowledgeUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIKnowledgeUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IKnowledgeUsecase {
+	mock := &IKnowledgeUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,18 @@
+package knowledge
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type IKnowledgeRepository interface {
+	FetchAllKnowledgeDocuments(ctx context.Context, args *sync.Map) ([]*models.KnowledgeDocument, error)
+	FetchOneKnowledgeDocumentById(ctx context.Context, id *uuid.UUID) (*models.KnowledgeDocument, error)
+	InsertKnowledgeDocument(ctx context.Context, document *models.KnowledgeDocument, chunks []*models.KnowledgeChunk) error
+	DeleteKnowledgeDocument(ctx context.Context, id *uuid.UUID) error
+	FetchAllKnowledgeChunks(ctx context.Context, embeddingModel string) ([]*models.KnowledgeChunk, error)
+	SearchKnowledgeChunks(ctx context.Context, embeddingModel string, embedding []float32, limit int) ([]*models.KnowledgeChunk, error)
+}
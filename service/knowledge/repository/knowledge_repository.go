@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/knowledge"
+	"strings"
+	"sync"
+
+	"github.com/Pheethy/sqlx"
+	"github.com/gofrs/uuid"
+)
+
+type knowledgeRepository struct {
+	psqlDB *sqlx.DB
+}
+
+func NewKnowledgeRepository(psqlDB *sqlx.DB) knowledge.IKnowledgeRepository {
+	return &knowledgeRepository{
+		psqlDB: psqlDB,
+	}
+}
+
+const selectKnowledgeDocument = `
+        "knowledge_documents"."id",
+        "knowledge_documents"."title",
+        "knowledge_documents"."source",
+        "knowledge_documents"."chunk_count",
+        "knowledge_documents"."embedding_model",
+        "knowledge_documents"."created_by",
+        to_char("knowledge_documents"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+        to_char("knowledge_documents"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at"`
+
+const selectKnowledgeChunk = `
+        "knowledge_chunks"."id",
+        "knowledge_chunks"."document_id",
+        "knowledge_chunks"."chunk_index",
+        "knowledge_chunks"."content",
+        "knowledge_documents"."title",
+        "knowledge_documents"."source"`
+
+func (r *knowledgeRepository) FetchAllKnowledgeDocuments(ctx context.Context, args *sync.Map) ([]*models.KnowledgeDocument, error) {
+	var conds []interface{}
+	var wheres []string
+	if search, ok := args.Load("search"); ok {
+		conds = append(conds, fmt.Sprintf("%%%v%%", search))
+		wheres = append(wheres, fmt.Sprintf(`("knowledge_documents"."title" ILIKE $%d::varchar OR "knowledge_documents"."source" ILIKE $%d::varchar)`, len(conds), len(conds)))
+	}
+	where := ""
+	if len(wheres) > 0 {
+		where = "WHERE " + strings.Join(wheres, " AND ")
+	}
+
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        %s
+      FROM
+        "knowledge_documents"
+      %s
+      ORDER BY
+        "knowledge_documents"."created_at" DESC
+    ) AS "json_data"
+  `, selectKnowledgeDocument, where)
+
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, conds...).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	documents := make([]*models.KnowledgeDocument, 0)
+	if err := json.Unmarshal(jsonData, &documents); err != nil {
+		return nil, err
+	}
+
+	return documents, nil
+}
+
+func (r *knowledgeRepository) FetchOneKnowledgeDocumentById(ctx context.Context, id *uuid.UUID) (*models.KnowledgeDocument, error) {
+	sql := fmt.Sprintf(`
+    SELECT
+      to_jsonb("json_data")
+    FROM (
+      SELECT
+        %s,
+        "knowledge_documents"."content"
+      FROM
+        "knowledge_documents"
+      WHERE
+        "knowledge_documents"."id" = $1::uuid
+    ) AS "json_data"
+  `, selectKnowledgeDocument)
+
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, id).Scan(&jsonData); err != nil {
+		return nil, apperror.FromDB(err, constants.ERROR_KNOWLEDGE_DOCUMENT_NOT_FOUND)
+	}
+
+	document := new(models.KnowledgeDocument)
+	if err := json.Unmarshal(jsonData, &document); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+/* InsertKnowledgeDocument บันทึกเอกสารพร้อม chunk ทั้งหมดใน transaction เดียว */
+func (r *knowledgeRepository) InsertKnowledgeDocument(ctx context.Context, document *models.KnowledgeDocument, chunks []*models.KnowledgeChunk) error {
+	tx, err := r.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+    INSERT INTO "knowledge_documents" (
+      "id",
+      "title",
+      "source",
+      "content",
+      "chunk_count",
+      "embedding_model",
+      "created_by",
+      "created_at",
+      "updated_at"
+    ) VALUES (
+      $1::uuid,
+      $2::varchar,
+      $3::varchar,
+      $4::text,
+      $5::int,
+      $6::varchar,
+      $7::uuid,
+      $8::timestamp,
+      $9::timestamp
+    )
+  `,
+		document.Id,
+		document.Title,
+		document.Source,
+		document.Content,
+		document.ChunkCount,
+		document.EmbeddingModel,
+		document.CreatedBy,
+		document.CreatedAt,
+		document.UpdatedAt,
+	); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	stmt, err := tx.PreparexContext(ctx, `
+    INSERT INTO "knowledge_chunks" (
+      "id",
+      "document_id",
+      "chunk_index",
+      "content",
+      "embedding",
+      "embedding_model",
+      "created_at"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::int,
+      $4::text,
+      $5::real[],
+      $6::varchar,
+      $7::timestamp
+    )
+  `)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for _, chunk := range chunks {
+		if _, err := stmt.ExecContext(ctx,
+			chunk.Id,
+			chunk.DocumentId,
+			chunk.ChunkIndex,
+			chunk.Content,
+			chunk.EmbeddingArray(),
+			chunk.EmbeddingModel,
+			document.CreatedAt,
+		); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+/* DeleteKnowledgeDocument chunk ของเอกสารถูกลบตามด้วย ON DELETE CASCADE */
+func (r *knowledgeRepository) DeleteKnowledgeDocument(ctx context.Context, id *uuid.UUID) error {
+	result, err := r.psqlDB.ExecContext(ctx, `
+    DELETE FROM "knowledge_documents"
+    WHERE
+      "id" = $1::uuid
+  `, id)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return apperror.NotFound(constants.ERROR_KNOWLEDGE_DOCUMENT_NOT_FOUND)
+	}
+
+	return nil
+}
+
+/* FetchAllKnowledgeChunks chunk ทั้งหมดที่ embed ด้วยโมเดลเดียวกัน สำหรับจัดอันดับใน Go */
+func (r *knowledgeRepository) FetchAllKnowledgeChunks(ctx context.Context, embeddingModel string) ([]*models.KnowledgeChunk, error) {
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        %s,
+        "knowledge_chunks"."embedding"
+      FROM
+        "knowledge_chunks"
+      JOIN
+        "knowledge_documents" ON "knowledge_documents"."id" = "knowledge_chunks"."document_id"
+      WHERE
+        "knowledge_chunks"."embedding_model" = $1::varchar
+    ) AS "json_data"
+  `, selectKnowledgeChunk)
+
+	return r.fetchKnowledgeChunks(ctx, sql, embeddingModel)
+}
+
+/* SearchKnowledgeChunks ค้นด้วย cosine distance ของ pgvector คะแนนคือ 1 - distance */
+func (r *knowledgeRepository) SearchKnowledgeChunks(ctx context.Context, embeddingModel string, embedding []float32, limit int) ([]*models.KnowledgeChunk, error) {
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        %s,
+        1 - ("knowledge_chunks"."embedding"::vector <=> $2::vector) "score"
+      FROM
+        "knowledge_chunks"
+      JOIN
+        "knowledge_documents" ON "knowledge_documents"."id" = "knowledge_chunks"."document_id"
+      WHERE
+        "knowledge_chunks"."embedding_model" = $1::varchar
+      ORDER BY
+        "knowledge_chunks"."embedding"::vector <=> $2::vector ASC
+      LIMIT $3::int
+    ) AS "json_data"
+  `, selectKnowledgeChunk)
+
+	return r.fetchKnowledgeChunks(ctx, sql, embeddingModel, models.VectorLiteral(embedding), limit)
+}
+
+func (r *knowledgeRepository) fetchKnowledgeChunks(ctx context.Context, sql string, args ...interface{}) ([]*models.KnowledgeChunk, error) {
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, args...).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	chunks := make([]*models.KnowledgeChunk, 0)
+	if err := json.Unmarshal(jsonData, &chunks); err != nil {
+		return nil, err
+	}
+
+	return chunks, nil
+}
@@ -0,0 +1,17 @@
+package knowledge
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type IKnowledgeUsecase interface {
+	FetchAllKnowledgeDocuments(ctx context.Context, args *sync.Map) ([]*models.KnowledgeDocument, error)
+	FetchOneKnowledgeDocumentById(ctx context.Context, id *uuid.UUID) (*models.KnowledgeDocument, error)
+	CreateKnowledgeDocument(ctx context.Context, document *models.KnowledgeDocument) error
+	DeleteKnowledgeDocument(ctx context.Context, id *uuid.UUID) error
+	SearchKnowledge(ctx context.Context, query string) ([]*models.KnowledgeChunk, error)
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/config"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/agent-ai"
+	"healthmatefood-api/service/knowledge"
+	"strings"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type knowledgeUsecase struct {
+	cfg           config.IKnowledgeConfig
+	knowledgeRepo knowledge.IKnowledgeRepository
+	embedder      agent.IEmbedder
+}
+
+func NewKnowledgeUsecase(cfg config.IKnowledgeConfig, knowledgeRepo knowledge.IKnowledgeRepository, embedder agent.IEmbedder) knowledge.IKnowledgeUsecase {
+	return &knowledgeUsecase{
+		cfg:           cfg,
+		knowledgeRepo: knowledgeRepo,
+		embedder:      embedder,
+	}
+}
+
+func (u *knowledgeUsecase) FetchAllKnowledgeDocuments(ctx context.Context, args *sync.Map) ([]*models.KnowledgeDocument, error) {
+	return u.knowledgeRepo.FetchAllKnowledgeDocuments(ctx, args)
+}
+
+func (u *knowledgeUsecase) FetchOneKnowledgeDocumentById(ctx context.Context, id *uuid.UUID) (*models.KnowledgeDocument, error) {
+	return u.knowledgeRepo.FetchOneKnowledgeDocumentById(ctx, id)
+}
+
+/* CreateKnowledgeDocument แบ่งเอกสารเป็น chunk แล้ว embed ทุก chunk ก่อนบันทึก */
+func (u *knowledgeUsecase) CreateKnowledgeDocument(ctx context.Context, document *models.KnowledgeDocument) error {
+	texts := models.ChunkKnowledgeText(document.Content, u.cfg.ChunkSize(), u.cfg.ChunkOverlap())
+	if len(texts) == 0 {
+		return apperror.Validation(constants.ERROR_KNOWLEDGE_DOCUMENT_IS_EMPTY)
+	}
+
+	embeddings, err := u.embedder.EmbedTexts(ctx, texts)
+	if err != nil {
+		return fmt.Errorf("embed knowledge document: %w", err)
+	}
+	if len(embeddings) != len(texts) {
+		return fmt.Errorf("embed knowledge document: expected %d embeddings, got %d", len(texts), len(embeddings))
+	}
+
+	document.NewID()
+	document.SetCreatedAt()
+	document.SetUpdatedAt()
+	document.ChunkCount = len(texts)
+	document.EmbeddingModel = u.embedder.Model()
+	chunks := make([]*models.KnowledgeChunk, 0, len(texts))
+	for index, text := range texts {
+		chunks = append(chunks, models.NewKnowledgeChunk(document.Id, index, text, embeddings[index], document.EmbeddingModel))
+	}
+
+	return u.knowledgeRepo.InsertKnowledgeDocument(ctx, document, chunks)
+}
+
+func (u *knowledgeUsecase) DeleteKnowledgeDocument(ctx context.Context, id *uuid.UUID) error {
+	return u.knowledgeRepo.DeleteKnowledgeDocument(ctx, id)
+}
+
+/* SearchKnowledge ค้น chunk ที่ใกล้เคียงคำถามที่สุดไม่เกิน KNOWLEDGE_TOP_K และคะแนนไม่ต่ำกว่า KNOWLEDGE_MIN_SCORE */
+func (u *knowledgeUsecase) SearchKnowledge(ctx context.Context, query string) ([]*models.KnowledgeChunk, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []*models.KnowledgeChunk{}, nil
+	}
+
+	embeddings, err := u.embedder.EmbedTexts(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("embed knowledge query: %w", err)
+	}
+	if len(embeddings) != 1 {
+		return nil, fmt.Errorf("embed knowledge query: expected 1 embedding, got %d", len(embeddings))
+	}
+
+	if u.cfg.VectorStore() == config.KNOWLEDGE_VECTOR_STORE_PGVECTOR {
+		chunks, err := u.knowledgeRepo.SearchKnowledgeChunks(ctx, u.embedder.Model(), embeddings[0], u.cfg.TopK())
+		if err != nil {
+			return nil, err
+		}
+		results := make([]*models.KnowledgeChunk, 0, len(chunks))
+		for _, chunk := range chunks {
+			if chunk.Score >= u.cfg.MinScore() {
+				results = append(results, chunk)
+			}
+		}
+		return results, nil
+	}
+
+	chunks, err := u.knowledgeRepo.FetchAllKnowledgeChunks(ctx, u.embedder.Model())
+	if err != nil {
+		return nil, err
+	}
+	results := models.RankKnowledgeChunks(chunks, embeddings[0], u.cfg.TopK(), u.cfg.MinScore())
+	for _, chunk := range results {
+		chunk.Embedding = nil
+	}
+	return results, nil
+}
@@ -0,0 +1,84 @@
+package validator
+
+import (
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"mime/multipart"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Pheethy/psql/helper"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Validation struct{}
+
+/* ValidateCreateKnowledgeDocument ต้องมี title และเนื้อหาจาก content หรือไฟล์ใน files อย่างใดอย่างหนึ่ง */
+func (v Validation) ValidateCreateKnowledgeDocument() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		if params == nil {
+			return apperror.Validation(constants.ERROR_BODY_WAS_MISSING)
+		}
+
+		fields := apperror.Fields{}
+		key := "title"
+		if title, ok := params[key]; !ok {
+			fields.Missing(key)
+		} else if err := validation.Validate(title, validation.By(helper.ValidateTypeString)); err != nil {
+			fields.Add(key, err.Error())
+		} else if strings.TrimSpace(title.(string)) == "" {
+			fields.Add(key, "must not be empty")
+		}
+		key = "source"
+		if source, ok := params[key]; ok {
+			fields.Check(key, validation.Validate(source, validation.By(helper.ValidateTypeString)))
+		}
+
+		key = "content"
+		if files, _ := c.Locals("files").([]*multipart.FileHeader); len(files) > 0 {
+			validateKnowledgeFile(files, &fields)
+		} else if content, ok := params[key]; !ok {
+			fields.Add(key, "was missing on body, or upload a file in files")
+		} else if err := validation.Validate(content, validation.By(helper.ValidateTypeString)); err != nil {
+			fields.Add(key, err.Error())
+		} else if strings.TrimSpace(content.(string)) == "" {
+			fields.Add(key, "must not be empty")
+		} else if len(content.(string)) > models.MAX_KNOWLEDGE_DOCUMENT_SIZE {
+			fields.Add(key, fmt.Sprintf("must not be larger than %d MB", models.MAX_KNOWLEDGE_DOCUMENT_SIZE/(1024*1024)))
+		}
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func validateKnowledgeFile(files []*multipart.FileHeader, fields *apperror.Fields) {
+	key := "files"
+	if len(files) > 1 {
+		fields.Add(key, "send only one document")
+		return
+	}
+	if files[0].Size > models.MAX_KNOWLEDGE_DOCUMENT_SIZE {
+		fields.Add(key, fmt.Sprintf("must not be larger than %d MB", models.MAX_KNOWLEDGE_DOCUMENT_SIZE/(1024*1024)))
+	}
+	ext := strings.ToLower(filepath.Ext(files[0].Filename))
+	if !slices.Contains(models.KnowledgeDocumentExtensions, ext) {
+		fields.Add(key, fmt.Sprintf("type %s is not supported, use %s", ext, strings.Join(models.KnowledgeDocumentExtensions, ", ")))
+	}
+}
+
+func (v Validation) ValidateParams(key string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params := c.Params(key)
+		if err := validation.Validate(params, validation.By(helper.ValidateTypeUUID)); err != nil {
+			return apperror.Field(key, err.Error())
+		}
+		return c.Next()
+	}
+}
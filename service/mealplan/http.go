@@ -0,0 +1,11 @@
+package mealplan
+
+import "github.com/gofiber/fiber/v2"
+
+type IMealPlanHandler interface {
+	FetchAllMealPlans(c *fiber.Ctx) error
+	FetchOneMealPlanById(c *fiber.Ctx) error
+	ActivateMealPlan(c *fiber.Ctx) error
+	DeleteMealPlan(c *fiber.Ctx) error
+	FetchAllMealPlanSwaps(c *fiber.Ctx) error
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/mealplan"
+	"net/http"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+type mealPlanHandler struct {
+	mealPlanUs mealplan.IMealPlanUsecase
+}
+
+func NewMealPlanHandler(mealPlanUs mealplan.IMealPlanUsecase) mealplan.IMealPlanHandler {
+	return &mealPlanHandler{
+		mealPlanUs: mealPlanUs,
+	}
+}
+
+// @Summary     FetchAllMealPlans
+// @Description Get saved meal plans of user, newest first
+// @Tags        meal-plan
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id   path  string true  "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       is_active query bool   false "only the active plan"
+// @Success     200       {object}     map[string]interface{}
+// @Failure     400       {object}     constants.ErrorResponse
+// @Failure     500       {object}     constants.ErrorResponse
+// @Router      /v1/meal-plan/{user_id} [get]
+func (m *mealPlanHandler) FetchAllMealPlans(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	args := new(sync.Map)
+	args.Store("user_id", &userId)
+	if isActive := c.Query("is_active"); isActive != "" {
+		args.Store("is_active", cast.ToBool(isActive))
+	}
+
+	plans, err := m.mealPlanUs.FetchAllMealPlans(ctx, args)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"meal_plans": plans,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     FetchOneMealPlanById
+// @Description Get a saved meal plan with days, meals and items
+// @Tags        meal-plan
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id path string true "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       plan_id path string true "meal plan id"
+// @Success     200     {object}     map[string]interface{}
+// @Failure     400     {object}     constants.ErrorResponse
+// @Failure     404     {object}     constants.ErrorResponse "meal plan not found"
+// @Failure     500     {object}     constants.ErrorResponse
+// @Router      /v1/meal-plan/{user_id}/{plan_id} [get]
+func (m *mealPlanHandler) FetchOneMealPlanById(c *fiber.Ctx) error {
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	planId := uuid.FromStringOrNil(c.Params("plan_id"))
+
+	plan, err := m.fetchOwnMealPlan(c, &userId, &planId)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"meal_plan": plan,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     ActivateMealPlan
+// @Description Mark a meal plan as the active one, the previous active plan of user is deactivated
+// @Tags        meal-plan
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id path string true "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       plan_id path string true "meal plan id"
+// @Success     200     {object}     map[string]interface{}
+// @Failure     400     {object}     constants.ErrorResponse
+// @Failure     404     {object}     constants.ErrorResponse "meal plan not found"
+// @Failure     500     {object}     constants.ErrorResponse
+// @Router      /v1/meal-plan/{user_id}/{plan_id}/active [put]
+func (m *mealPlanHandler) ActivateMealPlan(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	planId := uuid.FromStringOrNil(c.Params("plan_id"))
+
+	if _, err := m.fetchOwnMealPlan(c, &userId, &planId); err != nil {
+		return err
+	}
+	if err := m.mealPlanUs.ActivateMealPlan(ctx, &userId, &planId); err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message": "successful",
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     DeleteMealPlan
+// @Description Delete a meal plan with its days, meals and items
+// @Tags        meal-plan
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id path string true "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       plan_id path string true "meal plan id"
+// @Success     200     {object}     map[string]interface{}
+// @Failure     404     {object}     constants.ErrorResponse "meal plan not found"
+// @Failure     500     {object}     constants.ErrorResponse
+// @Router      /v1/meal-plan/{user_id}/{plan_id} [delete]
+func (m *mealPlanHandler) DeleteMealPlan(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	planId := uuid.FromStringOrNil(c.Params("plan_id"))
+
+	if _, err := m.fetchOwnMealPlan(c, &userId, &planId); err != nil {
+		return err
+	}
+	if err := m.mealPlanUs.DeleteMealPlan(ctx, &planId); err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message": "successful",
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     FetchAllMealPlanSwaps
+// @Description Get the swap history of a meal plan with the meal before and after each swap, newest first
+// @Tags        meal-plan
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id path  string true  "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       plan_id path  string true  "meal plan id"
+// @Param       meal_id query string false "only swaps of this meal"
+// @Success     200     {object}     map[string]interface{}
+// @Failure     400     {object}     constants.ErrorResponse
+// @Failure     404     {object}     constants.ErrorResponse "meal plan not found"
+// @Failure     500     {object}     constants.ErrorResponse
+// @Router      /v1/meal-plan/{user_id}/{plan_id}/swaps [get]
+func (m *mealPlanHandler) FetchAllMealPlanSwaps(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	planId := uuid.FromStringOrNil(c.Params("plan_id"))
+
+	if _, err := m.fetchOwnMealPlan(c, &userId, &planId); err != nil {
+		return err
+	}
+	args := new(sync.Map)
+	args.Store("meal_plan_id", &planId)
+	if mealId := c.Query("meal_id"); mealId != "" {
+		id, err := uuid.FromString(mealId)
+		if err != nil {
+			return fiber.NewError(http.StatusBadRequest, fmt.Sprintf("meal_id: %s", err.Error()))
+		}
+		args.Store("meal_plan_meal_id", &id)
+	}
+
+	swaps, err := m.mealPlanUs.FetchAllMealPlanSwaps(ctx, args)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"swaps": swaps,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+/* fetchOwnMealPlan ดึงแผนและตรวจว่าเป็นของผู้ใช้ตาม path ไม่เช่นนั้นถือว่าไม่พบ */
+func (m *mealPlanHandler) fetchOwnMealPlan(c *fiber.Ctx, userId *uuid.UUID, planId *uuid.UUID) (*models.MealPlan, error) {
+	plan, err := m.mealPlanUs.FetchOneMealPlanById(c.UserContext(), planId)
+	if err != nil {
+		return nil, err
+	}
+	if plan.UserId == nil || *plan.UserId != *userId {
+		return nil, apperror.NotFound(constants.ERROR_MEAL_PLAN_NOT_FOUND)
+	}
+	return plan, nil
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/middleware"
+	"healthmatefood-api/models"
+	mealplan_mocks "healthmatefood-api/service/mealplan/mocks"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestActivateMealPlan(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	otherUserId := uuid.FromStringOrNil("0c3b6f8e-1d2a-4e5f-8a9b-7c6d5e4f3a21")
+	planId := uuid.FromStringOrNil("5b0e8f3a-2f4c-4d8e-9a31-6c1f0d7e2a10")
+	t.Run("success", func(t *testing.T) {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		mealPlanUs := new(mealplan_mocks.IMealPlanUsecase)
+		mealPlanUs.On("FetchOneMealPlanById", mock.Anything, &planId).Return(&models.MealPlan{Id: &planId, UserId: &userId}, nil)
+		mealPlanUs.On("ActivateMealPlan", mock.Anything, &userId, &planId).Return(nil)
+		mealPlanHandler := NewMealPlanHandler(mealPlanUs)
+		app.Put("/v1/meal-plan/:user_id/:plan_id/active", mealPlanHandler.ActivateMealPlan)
+
+		req := httptest.NewRequest(http.MethodPut, "/v1/meal-plan/"+userId.String()+"/"+planId.String()+"/active", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		mealPlanUs.AssertExpectations(t)
+	})
+	t.Run("error_meal_plan_of_other_user", func(t *testing.T) {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		mealPlanUs := new(mealplan_mocks.IMealPlanUsecase)
+		mealPlanUs.On("FetchOneMealPlanById", mock.Anything, &planId).Return(&models.MealPlan{Id: &planId, UserId: &otherUserId}, nil)
+		mealPlanHandler := NewMealPlanHandler(mealPlanUs)
+		app.Put("/v1/meal-plan/:user_id/:plan_id/active", mealPlanHandler.ActivateMealPlan)
+
+		req := httptest.NewRequest(http.MethodPut, "/v1/meal-plan/"+userId.String()+"/"+planId.String()+"/active", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		mealPlanUs.AssertNotCalled(t, "ActivateMealPlan", mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("error_message_localized", func(t *testing.T) {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		mealPlanUs := new(mealplan_mocks.IMealPlanUsecase)
+		mealPlanUs.On("FetchOneMealPlanById", mock.Anything, &planId).Return(nil, apperror.NotFound(constants.ERROR_MEAL_PLAN_NOT_FOUND))
+		mealPlanHandler := NewMealPlanHandler(mealPlanUs)
+		app.Put("/v1/meal-plan/:user_id/:plan_id/active", middleware.InitMiddleware(nil, nil).Localize(), mealPlanHandler.ActivateMealPlan)
+
+		for acceptLanguage, message := range map[string]string{
+			"th-TH,th;q=0.9,en;q=0.8": "ไม่พบแผนอาหาร",
+			"en-US":                   constants.ERROR_MEAL_PLAN_NOT_FOUND,
+			"":                        constants.ERROR_MEAL_PLAN_NOT_FOUND,
+		} {
+			req := httptest.NewRequest(http.MethodPut, "/v1/meal-plan/"+userId.String()+"/"+planId.String()+"/active", nil)
+			req.Header.Set(fiber.HeaderAcceptLanguage, acceptLanguage)
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+			assert.Equal(t, middleware.MIMEApplicationProblemJSON, resp.Header.Get(fiber.HeaderContentType))
+			body := constants.ErrorResponse{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, constants.ErrorResponse{
+				Type:     "/problems/not-found",
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   message,
+				Instance: "/v1/meal-plan/" + userId.String() + "/" + planId.String() + "/active",
+			}, body)
+		}
+	})
+	t.Run("error_internal_hidden", func(t *testing.T) {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		mealPlanUs := new(mealplan_mocks.IMealPlanUsecase)
+		mealPlanUs.On("FetchOneMealPlanById", mock.Anything, &planId).Return(nil, errors.New("connection refused"))
+		mealPlanHandler := NewMealPlanHandler(mealPlanUs)
+		app.Put("/v1/meal-plan/:user_id/:plan_id/active", mealPlanHandler.ActivateMealPlan)
+
+		req := httptest.NewRequest(http.MethodPut, "/v1/meal-plan/"+userId.String()+"/"+planId.String()+"/active", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		body := constants.ErrorResponse{}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, "about:blank", body.Type)
+		assert.Equal(t, http.StatusInternalServerError, body.Status)
+		assert.Equal(t, constants.ERROR_INTERNAL_SERVER, body.Detail)
+	})
+}
+
+func TestFetchAllMealPlanSwaps(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	otherUserId := uuid.FromStringOrNil("0c3b6f8e-1d2a-4e5f-8a9b-7c6d5e4f3a21")
+	planId := uuid.FromStringOrNil("5b0e8f3a-2f4c-4d8e-9a31-6c1f0d7e2a10")
+	mealId := uuid.FromStringOrNil("7d2c9a41-8b3e-4f60-a5d1-2e9f8c7b6a54")
+	t.Run("success", func(t *testing.T) {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		mealPlanUs := new(mealplan_mocks.IMealPlanUsecase)
+		mealPlanUs.On("FetchOneMealPlanById", mock.Anything, &planId).Return(&models.MealPlan{Id: &planId, UserId: &userId}, nil)
+		mealPlanUs.On("FetchAllMealPlanSwaps", mock.Anything, mock.MatchedBy(func(args *sync.Map) bool {
+			planArg, _ := args.Load("meal_plan_id")
+			mealArg, _ := args.Load("meal_plan_meal_id")
+			return *planArg.(*uuid.UUID) == planId && *mealArg.(*uuid.UUID) == mealId
+		})).Return([]*models.MealPlanSwap{{MealPlanId: &planId, MealPlanMealId: &mealId}}, nil)
+		mealPlanHandler := NewMealPlanHandler(mealPlanUs)
+		app.Get("/v1/meal-plan/:user_id/:plan_id/swaps", mealPlanHandler.FetchAllMealPlanSwaps)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/meal-plan/"+userId.String()+"/"+planId.String()+"/swaps?meal_id="+mealId.String(), nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		mealPlanUs.AssertExpectations(t)
+	})
+	t.Run("error_meal_plan_of_other_user", func(t *testing.T) {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		mealPlanUs := new(mealplan_mocks.IMealPlanUsecase)
+		mealPlanUs.On("FetchOneMealPlanById", mock.Anything, &planId).Return(&models.MealPlan{Id: &planId, UserId: &otherUserId}, nil)
+		mealPlanHandler := NewMealPlanHandler(mealPlanUs)
+		app.Get("/v1/meal-plan/:user_id/:plan_id/swaps", mealPlanHandler.FetchAllMealPlanSwaps)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/meal-plan/"+userId.String()+"/"+planId.String()+"/swaps", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		mealPlanUs.AssertNotCalled(t, "FetchAllMealPlanSwaps", mock.Anything, mock.Anything)
+	})
+}
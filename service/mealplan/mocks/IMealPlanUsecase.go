@@ -0,0 +1,160 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "healthmatefood-api/models"
+
+	sync "sync"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IMealPlanUsecase is an autogenerated mock type for the IMealPlanUsecase type
+type IMealPlanUsecase struct {
+	mock.Mock
+}
+
+// ActivateMealPlan provides a mock function with given fields: ctx, userId, id
+func (_m *IMealPlanUsecase) ActivateMealPlan(ctx context.Context, userId *uuid.UUID, id *uuid.UUID) error {
+	ret := _m.Called(ctx, userId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActivateMealPlan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *uuid.UUID) error); ok {
+		r0 = rf(ctx, userId, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMealPlan provides a mock function with given fields: ctx, id
+func (_m *IMealPlanUsecase) DeleteMealPlan(ctx context.Context, id *uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMealPlan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchAllMealPlanSwaps provides a mock function with given fields: ctx, args
+func (_m *IMealPlanUsecase) FetchAllMealPlanSwaps(ctx context.Context, args *sync.Map) ([]*models.MealPlanSwap, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllMealPlanSwaps")
+	}
+
+	var r0 []*models.MealPlanSwap
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.MealPlanSwap, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.MealPlanSwap); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.MealPlanSwap)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchAllMealPlans provides a mock function with given fields: ctx, args
+func (_m *IMealPlanUsecase) FetchAllMealPlans(ctx context.Context, args *sync.Map) ([]*models.MealPlan, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllMealPlans")
+	}
+
+	var r0 []*models.MealPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.MealPlan, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.MealPlan); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.MealPlan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchOneMealPlanById provides a mock function with given fields: ctx, id
+func (_m *IMealPlanUsecase) FetchOneMealPlanById(ctx context.Context, id *uuid.UUID) (*models.MealPlan, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOneMealPlanById")
+	}
+
+	var r0 *models.MealPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.MealPlan, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.MealPlan); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MealPlan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIMealPlanUsecase creates a new instance of IMealPlanUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIMealPlanUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IMealPlanUsecase {
+	mock := &IMealPlanUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
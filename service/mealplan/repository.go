@@ -0,0 +1,19 @@
+package mealplan
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type IMealPlanRepository interface {
+	FetchAllMealPlans(ctx context.Context, args *sync.Map) ([]*models.MealPlan, error)
+	FetchOneMealPlanById(ctx context.Context, id *uuid.UUID) (*models.MealPlan, error)
+	UpsertMealPlan(ctx context.Context, plan *models.MealPlan) error
+	ActivateMealPlan(ctx context.Context, userId *uuid.UUID, id *uuid.UUID) error
+	DeleteMealPlan(ctx context.Context, id *uuid.UUID) error
+	SwapMealPlanMeal(ctx context.Context, swap *models.MealPlanSwap) error
+	FetchAllMealPlanSwaps(ctx context.Context, args *sync.Map) ([]*models.MealPlanSwap, error)
+}
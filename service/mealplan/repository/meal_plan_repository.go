@@ -0,0 +1,686 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/mealplan"
+	"strings"
+	"sync"
+
+	"github.com/Pheethy/sqlx"
+	"github.com/gofrs/uuid"
+)
+
+type mealPlanRepository struct {
+	psqlDB *sqlx.DB
+}
+
+func NewMealPlanRepository(psqlDB *sqlx.DB) mealplan.IMealPlanRepository {
+	return &mealPlanRepository{
+		psqlDB: psqlDB,
+	}
+}
+
+const selectMealPlan = `
+        "meal_plans"."id",
+        "meal_plans"."user_id",
+        to_char("meal_plans"."start_date", 'yyyy-MM-dd') "start_date",
+        to_char("meal_plans"."end_date", 'yyyy-MM-dd') "end_date",
+        "meal_plans"."calories_target",
+        "meal_plans"."model",
+        "meal_plans"."prompt_version",
+        "meal_plans"."is_active",
+        COALESCE("meal_plans"."note", '') "note",
+        "meal_plans"."citations",
+        "meal_plans"."safety",
+        to_char("meal_plans"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+        to_char("meal_plans"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at",
+        (
+          SELECT
+            COALESCE(array_to_json(array_agg("DAY")), '[]'::json)
+          FROM (
+            SELECT
+              "meal_plan_days"."id",
+              "meal_plan_days"."meal_plan_id",
+              "meal_plan_days"."day",
+              to_char("meal_plan_days"."date", 'yyyy-MM-dd') "date",
+              (
+                SELECT
+                  COALESCE(array_to_json(array_agg("MEAL")), '[]'::json)
+                FROM (
+                  SELECT
+                    "meal_plan_meals"."id",
+                    "meal_plan_meals"."meal_plan_day_id",
+                    "meal_plan_meals"."meal_type",
+                    "meal_plan_meals"."time",
+                    "meal_plan_meals"."name",
+                    (
+                      SELECT
+                        COALESCE(array_to_json(array_agg("ITEM")), '[]'::json)
+                      FROM (
+                        SELECT
+                          "meal_plan_items"."id",
+                          "meal_plan_items"."meal_plan_meal_id",
+                          "meal_plan_items"."name",
+                          "meal_plan_items"."portion",
+                          "meal_plan_items"."calories",
+                          "meal_plan_items"."protein",
+                          "meal_plan_items"."carbohydrate",
+                          "meal_plan_items"."fat",
+                          "meal_plan_items"."sodium",
+                          "meal_plan_items"."potassium",
+                          "meal_plan_items"."purine",
+                          "meal_plan_items"."sugar"
+                        FROM
+                          "meal_plan_items"
+                        WHERE
+                          "meal_plan_items"."meal_plan_meal_id" = "meal_plan_meals"."id"
+                        ORDER BY
+                          "meal_plan_items"."item_no" ASC
+                      ) AS "ITEM"
+                    ) AS "items"
+                  FROM
+                    "meal_plan_meals"
+                  WHERE
+                    "meal_plan_meals"."meal_plan_day_id" = "meal_plan_days"."id"
+                  ORDER BY
+                    "meal_plan_meals"."meal_no" ASC
+                ) AS "MEAL"
+              ) AS "meals"
+            FROM
+              "meal_plan_days"
+            WHERE
+              "meal_plan_days"."meal_plan_id" = "meal_plans"."id"
+            ORDER BY
+              "meal_plan_days"."day" ASC
+          ) AS "DAY"
+        ) AS "days"`
+
+func (m *mealPlanRepository) FetchAllMealPlans(ctx context.Context, args *sync.Map) ([]*models.MealPlan, error) {
+	var conds []interface{}
+	var wheres []string
+	if userId, ok := args.Load("user_id"); ok {
+		conds = append(conds, userId)
+		wheres = append(wheres, fmt.Sprintf(`"meal_plans"."user_id" = $%d::uuid`, len(conds)))
+	}
+	if isActive, ok := args.Load("is_active"); ok {
+		conds = append(conds, isActive)
+		wheres = append(wheres, fmt.Sprintf(`"meal_plans"."is_active" = $%d::bool`, len(conds)))
+	}
+	if date, ok := args.Load("date"); ok {
+		conds = append(conds, date)
+		wheres = append(wheres, fmt.Sprintf(`$%d::date BETWEEN "meal_plans"."start_date" AND "meal_plans"."end_date"`, len(conds)))
+	}
+	where := ""
+	if len(wheres) > 0 {
+		where = "WHERE " + strings.Join(wheres, " AND ")
+	}
+
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        %s
+      FROM
+        "meal_plans"
+      %s
+      ORDER BY
+        "meal_plans"."created_at" DESC
+    ) AS "json_data"
+  `, selectMealPlan, where)
+
+	stmt, err := m.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, conds...).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	plans := make([]*models.MealPlan, 0)
+	if err := json.Unmarshal(jsonData, &plans); err != nil {
+		return nil, err
+	}
+
+	return plans, nil
+}
+
+func (m *mealPlanRepository) FetchOneMealPlanById(ctx context.Context, id *uuid.UUID) (*models.MealPlan, error) {
+	sql := fmt.Sprintf(`
+    SELECT
+      to_jsonb("json_data")
+    FROM (
+      SELECT
+        %s
+      FROM
+        "meal_plans"
+      WHERE
+        "meal_plans"."id" = $1::uuid
+    ) AS "json_data"
+  `, selectMealPlan)
+
+	stmt, err := m.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, id).Scan(&jsonData); err != nil {
+		return nil, apperror.FromDB(err, constants.ERROR_MEAL_PLAN_NOT_FOUND)
+	}
+
+	plan := new(models.MealPlan)
+	if err := json.Unmarshal(jsonData, &plan); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+/* UpsertMealPlan บันทึกหัวแผนแล้วแทนที่วัน มื้อ และรายการอาหารทั้งหมดภายใน transaction เดียว สถานะ active เปลี่ยนผ่าน ActivateMealPlan เท่านั้น */
+func (m *mealPlanRepository) UpsertMealPlan(ctx context.Context, plan *models.MealPlan) error {
+	tx, err := m.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+	sql := `
+    INSERT INTO "meal_plans" (
+      "id",
+      "user_id",
+      "start_date",
+      "end_date",
+      "calories_target",
+      "model",
+      "prompt_version",
+      "is_active",
+      "note",
+      "citations",
+      "safety",
+      "created_at",
+      "updated_at"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::date,
+      $4::date,
+      $5::float,
+      $6::text,
+      $7::text,
+      $8::bool,
+      $9::text,
+      $10::jsonb,
+      $11::jsonb,
+      $12::timestamp,
+      $13::timestamp
+    )
+    ON CONFLICT (id)
+    DO UPDATE SET
+      start_date=$14::date,
+      end_date=$15::date,
+      calories_target=$16::float,
+      note=$17::text,
+      updated_at=$18::timestamp
+  `
+	stmt, err := tx.PreparexContext(ctx, sql)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx,
+		/* Create */
+		plan.Id,
+		plan.UserId,
+		plan.StartDate.String(),
+		plan.EndDate.String(),
+		plan.CaloriesTarget,
+		plan.Model,
+		plan.PromptVersion,
+		plan.IsActive,
+		plan.Note,
+		models.CitationsJSON(plan.Citations),
+		models.SafetyJSON(plan.Safety),
+		plan.CreatedAt,
+		plan.UpdatedAt,
+		/* Update */
+		plan.StartDate.String(),
+		plan.EndDate.String(),
+		plan.CaloriesTarget,
+		plan.Note,
+		plan.UpdatedAt,
+	); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	/* Replace Days (มื้อและรายการอาหารถูกลบตาม ON DELETE CASCADE) */
+	if _, err := tx.ExecContext(ctx, `DELETE FROM "meal_plan_days" WHERE "meal_plan_id" = $1::uuid`, plan.Id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	dayStmt, err := tx.PreparexContext(ctx, `
+    INSERT INTO "meal_plan_days" (
+      "id",
+      "meal_plan_id",
+      "day",
+      "date"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::int,
+      $4::date
+    )
+  `)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer dayStmt.Close()
+
+	mealStmt, err := tx.PreparexContext(ctx, `
+    INSERT INTO "meal_plan_meals" (
+      "id",
+      "meal_plan_day_id",
+      "meal_no",
+      "meal_type",
+      "time",
+      "name"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::int,
+      $4::meal_type,
+      $5::text,
+      $6::text
+    )
+  `)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer mealStmt.Close()
+
+	itemStmt, err := tx.PreparexContext(ctx, `
+    INSERT INTO "meal_plan_items" (
+      "id",
+      "meal_plan_meal_id",
+      "item_no",
+      "name",
+      "portion",
+      "calories",
+      "protein",
+      "carbohydrate",
+      "fat",
+      "sodium",
+      "potassium",
+      "purine",
+      "sugar"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::int,
+      $4::text,
+      $5::text,
+      $6::float,
+      $7::float,
+      $8::float,
+      $9::float,
+      $10::float,
+      $11::float,
+      $12::float,
+      $13::float
+    )
+  `)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer itemStmt.Close()
+
+	for _, day := range plan.Days {
+		if _, err := dayStmt.ExecContext(ctx,
+			day.Id,
+			plan.Id,
+			day.Day,
+			day.Date.String(),
+		); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("exec day failed: %v", err)
+		}
+		for mealIndex, meal := range day.Meals {
+			if _, err := mealStmt.ExecContext(ctx,
+				meal.Id,
+				day.Id,
+				mealIndex+1,
+				meal.MealType,
+				meal.Time,
+				meal.Name,
+			); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("exec meal failed: %v", err)
+			}
+			for itemIndex, item := range meal.Items {
+				if _, err := itemStmt.ExecContext(ctx,
+					item.Id,
+					meal.Id,
+					itemIndex+1,
+					item.Name,
+					item.Portion,
+					item.Calories,
+					item.Protein,
+					item.Carbohydrate,
+					item.Fat,
+					item.Sodium,
+					item.Potassium,
+					item.Purine,
+					item.Sugar,
+				); err != nil {
+					tx.Rollback()
+					return fmt.Errorf("exec item failed: %v", err)
+				}
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+/* ActivateMealPlan ปิดแผนที่ active อยู่ของผู้ใช้ก่อนแล้วจึงเปิดแผนที่เลือก ผู้ใช้หนึ่งคนมีแผน active ได้เพียงแผนเดียว */
+func (m *mealPlanRepository) ActivateMealPlan(ctx context.Context, userId *uuid.UUID, id *uuid.UUID) error {
+	tx, err := m.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+	deactivate := `
+    UPDATE
+      "meal_plans"
+    SET
+      "is_active" = false,
+      "updated_at" = now()
+    WHERE
+      "meal_plans"."user_id" = $1::uuid
+    AND
+      "meal_plans"."is_active"
+    AND
+      "meal_plans"."id" <> $2::uuid
+  `
+	if _, err := tx.ExecContext(ctx, deactivate, userId, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	activate := `
+    UPDATE
+      "meal_plans"
+    SET
+      "is_active" = true,
+      "updated_at" = now()
+    WHERE
+      "meal_plans"."id" = $1::uuid
+    AND
+      "meal_plans"."user_id" = $2::uuid
+  `
+	result, err := tx.ExecContext(ctx, activate, id, userId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		tx.Rollback()
+		return apperror.NotFound(constants.ERROR_MEAL_PLAN_NOT_FOUND)
+	}
+
+	return tx.Commit()
+}
+
+func (m *mealPlanRepository) DeleteMealPlan(ctx context.Context, id *uuid.UUID) error {
+	tx, err := m.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+	sql := `
+    DELETE FROM
+      "meal_plans"
+    WHERE
+      "meal_plans"."id" = $1::uuid
+  `
+	stmt, err := tx.PreparexContext(ctx, sql)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		tx.Rollback()
+		return apperror.NotFound(constants.ERROR_MEAL_PLAN_NOT_FOUND)
+	}
+
+	return tx.Commit()
+}
+
+/* SwapMealPlanMeal แทนชื่อและรายการอาหารของมื้อด้วยมื้อใหม่ พร้อมบันทึกประวัติการเปลี่ยนภายใน transaction เดียว id ของมื้อคงเดิม */
+func (m *mealPlanRepository) SwapMealPlanMeal(ctx context.Context, swap *models.MealPlanSwap) error {
+	tx, err := m.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+	meal := `
+    UPDATE
+      "meal_plan_meals"
+    SET
+      "name" = $1::text
+    WHERE
+      "meal_plan_meals"."id" = $2::uuid
+  `
+	result, err := tx.ExecContext(ctx, meal, swap.After.Name, swap.MealPlanMealId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		tx.Rollback()
+		return apperror.NotFound(constants.ERROR_MEAL_PLAN_MEAL_NOT_FOUND)
+	}
+
+	/* Replace Items */
+	if _, err := tx.ExecContext(ctx, `DELETE FROM "meal_plan_items" WHERE "meal_plan_meal_id" = $1::uuid`, swap.MealPlanMealId); err != nil {
+		tx.Rollback()
+		return err
+	}
+	itemStmt, err := tx.PreparexContext(ctx, `
+    INSERT INTO "meal_plan_items" (
+      "id",
+      "meal_plan_meal_id",
+      "item_no",
+      "name",
+      "portion",
+      "calories",
+      "protein",
+      "carbohydrate",
+      "fat",
+      "sodium",
+      "potassium",
+      "purine",
+      "sugar"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::int,
+      $4::text,
+      $5::text,
+      $6::float,
+      $7::float,
+      $8::float,
+      $9::float,
+      $10::float,
+      $11::float,
+      $12::float,
+      $13::float
+    )
+  `)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer itemStmt.Close()
+
+	for itemIndex, item := range swap.After.Items {
+		if _, err := itemStmt.ExecContext(ctx,
+			item.Id,
+			swap.MealPlanMealId,
+			itemIndex+1,
+			item.Name,
+			item.Portion,
+			item.Calories,
+			item.Protein,
+			item.Carbohydrate,
+			item.Fat,
+			item.Sodium,
+			item.Potassium,
+			item.Purine,
+			item.Sugar,
+		); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("exec item failed: %v", err)
+		}
+	}
+
+	before, err := json.Marshal(swap.Before)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	after, err := json.Marshal(swap.After)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	history := `
+    INSERT INTO "meal_plan_swaps" (
+      "id",
+      "meal_plan_id",
+      "meal_plan_meal_id",
+      "meal_plan_item_id",
+      "day",
+      "meal_type",
+      "reason",
+      "before",
+      "after",
+      "model",
+      "prompt_version",
+      "created_at"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::uuid,
+      $4::uuid,
+      $5::int,
+      $6::meal_type,
+      $7::text,
+      $8::jsonb,
+      $9::jsonb,
+      $10::text,
+      $11::text,
+      $12::timestamp
+    )
+  `
+	if _, err := tx.ExecContext(ctx, history,
+		swap.Id,
+		swap.MealPlanId,
+		swap.MealPlanMealId,
+		swap.MealPlanItemId,
+		swap.Day,
+		swap.MealType,
+		swap.Reason,
+		string(before),
+		string(after),
+		swap.Model,
+		swap.PromptVersion,
+		swap.CreatedAt,
+	); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE "meal_plans" SET "updated_at" = now() WHERE "id" = $1::uuid`, swap.MealPlanId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m *mealPlanRepository) FetchAllMealPlanSwaps(ctx context.Context, args *sync.Map) ([]*models.MealPlanSwap, error) {
+	var conds []interface{}
+	var wheres []string
+	if mealPlanId, ok := args.Load("meal_plan_id"); ok {
+		conds = append(conds, mealPlanId)
+		wheres = append(wheres, fmt.Sprintf(`"meal_plan_swaps"."meal_plan_id" = $%d::uuid`, len(conds)))
+	}
+	if mealPlanMealId, ok := args.Load("meal_plan_meal_id"); ok {
+		conds = append(conds, mealPlanMealId)
+		wheres = append(wheres, fmt.Sprintf(`"meal_plan_swaps"."meal_plan_meal_id" = $%d::uuid`, len(conds)))
+	}
+	where := ""
+	if len(wheres) > 0 {
+		where = "WHERE " + strings.Join(wheres, " AND ")
+	}
+
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        "meal_plan_swaps"."id",
+        "meal_plan_swaps"."meal_plan_id",
+        "meal_plan_swaps"."meal_plan_meal_id",
+        "meal_plan_swaps"."meal_plan_item_id",
+        "meal_plan_swaps"."day",
+        "meal_plan_swaps"."meal_type",
+        "meal_plan_swaps"."reason",
+        "meal_plan_swaps"."before",
+        "meal_plan_swaps"."after",
+        "meal_plan_swaps"."model",
+        "meal_plan_swaps"."prompt_version",
+        to_char("meal_plan_swaps"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at"
+      FROM
+        "meal_plan_swaps"
+      %s
+      ORDER BY
+        "meal_plan_swaps"."created_at" DESC
+    ) AS "json_data"
+  `, where)
+
+	stmt, err := m.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, conds...).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	swaps := make([]*models.MealPlanSwap, 0)
+	if err := json.Unmarshal(jsonData, &swaps); err != nil {
+		return nil, err
+	}
+
+	return swaps, nil
+}
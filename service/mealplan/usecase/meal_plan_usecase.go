@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/mealplan"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type mealPlanUsecase struct {
+	mealPlanRepo mealplan.IMealPlanRepository
+}
+
+func NewMealPlanUsecase(mealPlanRepo mealplan.IMealPlanRepository) mealplan.IMealPlanUsecase {
+	return &mealPlanUsecase{
+		mealPlanRepo: mealPlanRepo,
+	}
+}
+
+/* ยอดรวมโภชนาการไม่ได้เก็บในฐานข้อมูล จึงคำนวณจากรายการอาหารทุกครั้งที่ดึง */
+func (m *mealPlanUsecase) FetchAllMealPlans(ctx context.Context, args *sync.Map) ([]*models.MealPlan, error) {
+	plans, err := m.mealPlanRepo.FetchAllMealPlans(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	for index := range plans {
+		plans[index].CalculateTotals()
+	}
+	return plans, nil
+}
+
+func (m *mealPlanUsecase) FetchOneMealPlanById(ctx context.Context, id *uuid.UUID) (*models.MealPlan, error) {
+	plan, err := m.mealPlanRepo.FetchOneMealPlanById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	plan.CalculateTotals()
+	return plan, nil
+}
+
+func (m *mealPlanUsecase) ActivateMealPlan(ctx context.Context, userId *uuid.UUID, id *uuid.UUID) error {
+	return m.mealPlanRepo.ActivateMealPlan(ctx, userId, id)
+}
+
+func (m *mealPlanUsecase) DeleteMealPlan(ctx context.Context, id *uuid.UUID) error {
+	return m.mealPlanRepo.DeleteMealPlan(ctx, id)
+}
+
+func (m *mealPlanUsecase) FetchAllMealPlanSwaps(ctx context.Context, args *sync.Map) ([]*models.MealPlanSwap, error) {
+	return m.mealPlanRepo.FetchAllMealPlanSwaps(ctx, args)
+}
@@ -0,0 +1,11 @@
+package prompt
+
+import "github.com/gofiber/fiber/v2"
+
+type IPromptHandler interface {
+	FetchAllPrompts(c *fiber.Ctx) error
+	FetchAllDefaultPrompts(c *fiber.Ctx) error
+	FetchOnePromptById(c *fiber.Ctx) error
+	CreatePrompt(c *fiber.Ctx) error
+	ActivatePrompt(c *fiber.Ctx) error
+}
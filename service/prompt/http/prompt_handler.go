@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/prompt"
+	"net/http"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+type promptHandler struct {
+	promptUs prompt.IPromptUsecase
+}
+
+func NewPromptHandler(promptUs prompt.IPromptUsecase) prompt.IPromptHandler {
+	return &promptHandler{
+		promptUs: promptUs,
+	}
+}
+
+// @Summary     FetchAllPrompts
+// @Description Admin only, every stored prompt version, latest version first for each name
+// @Tags        prompt
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true  "Bearer access token"
+// @Param       name          query  string false "prompt name, example: user_info"
+// @Param       is_active     query  bool   false "only active versions"
+// @Success     200 {object} map[string]interface{}
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     403 {object} constants.ErrorResponse
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/prompts [get]
+func (h *promptHandler) FetchAllPrompts(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	args := new(sync.Map)
+	if name := c.Query("name"); name != "" {
+		args.Store("name", name)
+	}
+	if isActive := c.Query("is_active"); isActive != "" {
+		args.Store("is_active", cast.ToBool(isActive))
+	}
+
+	prompts, err := h.promptUs.FetchAllPrompts(ctx, args)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"prompts": prompts,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     FetchAllDefaultPrompts
+// @Description Admin only, prompts embedded in the binary, used when a name has no active version
+// @Tags        prompt
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true "Bearer access token"
+// @Success     200 {object} map[string]interface{}
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     403 {object} constants.ErrorResponse
+// @Router      /v1/prompts/defaults [get]
+func (h *promptHandler) FetchAllDefaultPrompts(c *fiber.Ctx) error {
+	resp := map[string]interface{}{
+		"prompts": h.promptUs.FetchAllDefaultPrompts(),
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     FetchOnePromptById
+// @Description Admin only, get a prompt version
+// @Tags        prompt
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true "Bearer access token"
+// @Param       prompt_id     path   string true "prompt id"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     403 {object} constants.ErrorResponse
+// @Failure     404 {object} constants.ErrorResponse "prompt not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/prompts/{prompt_id} [get]
+func (h *promptHandler) FetchOnePromptById(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	id := uuid.FromStringOrNil(c.Params("prompt_id"))
+
+	prompt, err := h.promptUs.FetchOnePromptById(ctx, &id)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"prompt": prompt,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     CreatePrompt
+// @Description Admin only, save a new version of a prompt, the template must render against user info (text/template with join)
+// @Tags        prompt
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true  "Bearer access token"
+// @Param       name          body   string true  "prompt name, see /v1/prompts/defaults"
+// @Param       content       body   string true  "template, example: อายุ {{.Age}} ปี"
+// @Param       is_active     body   bool   false "activate this version right away"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse "prompt name or template is invalid"
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     403 {object} constants.ErrorResponse
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/prompts [post]
+func (h *promptHandler) CreatePrompt(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	params := c.Locals("params").(map[string]interface{})
+	userId, _ := c.Locals("user_id").(*uuid.UUID)
+
+	prompt := models.NewPromptWithParams(params, nil)
+	prompt.NewID()
+	prompt.CreatedBy = userId
+	prompt.SetCreatedAt()
+	prompt.SetUpdatedAt()
+
+	if err := h.promptUs.CreatePrompt(ctx, prompt); err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"message": "successful",
+		"prompt":  prompt,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     ActivatePrompt
+// @Description Admin only, make this version the one used by the agent, the previous active version of the same name is deactivated
+// @Tags        prompt
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       Authorization header string true "Bearer access token"
+// @Param       prompt_id     path   string true "prompt id"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     401 {object} constants.ErrorResponse
+// @Failure     403 {object} constants.ErrorResponse
+// @Failure     404 {object} constants.ErrorResponse "prompt not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/prompts/{prompt_id}/activate [put]
+func (h *promptHandler) ActivatePrompt(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	id := uuid.FromStringOrNil(c.Params("prompt_id"))
+
+	prompt, err := h.promptUs.FetchOnePromptById(ctx, &id)
+	if err != nil {
+		return err
+	}
+	if err := h.promptUs.ActivatePrompt(ctx, prompt); err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"message": "successful",
+		"prompt":  prompt,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"encoding/json"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/middleware"
+	"healthmatefood-api/models"
+	prompt_mocks "healthmatefood-api/service/prompt/mocks"
+	prompt_usecase "healthmatefood-api/service/prompt/usecase"
+	"healthmatefood-api/service/prompt/validator"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreatePrompt(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	newApp := func(promptRepo *prompt_mocks.IPromptRepository, params map[string]interface{}) *fiber.App {
+		handler := &promptHandler{promptUs: prompt_usecase.NewPromptUsecase(promptRepo)}
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		app.Post("/v1/prompts", func(c *fiber.Ctx) error {
+			c.Locals("user_id", &userId)
+			c.Locals("params", params)
+			return c.Next()
+		}, handler.CreatePrompt)
+		return app
+	}
+	t.Run("success_activate", func(t *testing.T) {
+		promptRepo := new(prompt_mocks.IPromptRepository)
+		promptRepo.On("InsertPrompt", mock.Anything, mock.MatchedBy(func(prompt *models.Prompt) bool {
+			return prompt.Name == models.PromptUserInfo && !prompt.IsActive && *prompt.CreatedBy == userId
+		})).Return(nil)
+		promptRepo.On("ActivatePrompt", mock.Anything, mock.AnythingOfType("*models.Prompt")).Return(nil)
+		app := newApp(promptRepo, map[string]interface{}{
+			"name":      models.PromptUserInfo,
+			"content":   `อายุ {{.Age}} ปี แพ้ {{join (.GetFoodPreferences "ALLERGY") ", "}}`,
+			"is_active": true,
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/prompts", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		promptRepo.AssertExpectations(t)
+	})
+	t.Run("error_all_fields_reported", func(t *testing.T) {
+		promptRepo := new(prompt_mocks.IPromptRepository)
+		handler := &promptHandler{promptUs: prompt_usecase.NewPromptUsecase(promptRepo)}
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		app.Use(middleware.InitMiddleware(nil, nil).RequestId())
+		app.Post("/v1/prompts", func(c *fiber.Ctx) error {
+			c.Locals("params", map[string]interface{}{"content": " "})
+			return c.Next()
+		}, validator.Validation{}.ValidateCreatePrompt(), handler.CreatePrompt)
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/prompts", nil)
+		req.Header.Set(fiber.HeaderXRequestID, "request-1")
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, middleware.MIMEApplicationProblemJSON, resp.Header.Get(fiber.HeaderContentType))
+		body := constants.ErrorResponse{}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, constants.ErrorResponse{
+			Type:      "/problems/validation",
+			Title:     "Bad Request",
+			Status:    http.StatusBadRequest,
+			Detail:    constants.ERROR_REQUEST_IS_INVALID,
+			Instance:  "/v1/prompts",
+			RequestId: "request-1",
+			Errors: []constants.FieldError{
+				{Field: "name", Message: constants.ERROR_FIELD_WAS_MISSING},
+				{Field: "content", Message: "must not be empty"},
+			},
+		}, body)
+		promptRepo.AssertNotCalled(t, "InsertPrompt", mock.Anything, mock.Anything)
+	})
+	t.Run("error_unknown_field", func(t *testing.T) {
+		promptRepo := new(prompt_mocks.IPromptRepository)
+		app := newApp(promptRepo, map[string]interface{}{
+			"name":    models.PromptUserInfo,
+			"content": "น้ำหนัก {{.Wieght}} kg",
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/prompts", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		promptRepo.AssertNotCalled(t, "InsertPrompt", mock.Anything, mock.Anything)
+	})
+	t.Run("error_nil_user_info", func(t *testing.T) {
+		promptRepo := new(prompt_mocks.IPromptRepository)
+		app := newApp(promptRepo, map[string]interface{}{
+			"name":    models.PromptChatSystem,
+			"content": "ผู้ใช้อายุ {{.Age}} ปี",
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/prompts", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		promptRepo.AssertNotCalled(t, "InsertPrompt", mock.Anything, mock.Anything)
+	})
+	t.Run("error_unknown_name", func(t *testing.T) {
+		promptRepo := new(prompt_mocks.IPromptRepository)
+		app := newApp(promptRepo, map[string]interface{}{
+			"name":    "unknown",
+			"content": "สวัสดี",
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/prompts", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,179 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "healthmatefood-api/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sync "sync"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IPromptUsecase is an autogenerated mock type for the IPromptUsecase type
+type IPromptUsecase struct {
+	mock.Mock
+}
+
+// ActivatePrompt provides a mock function with given fields: ctx, _a1
+func (_m *IPromptUsecase) ActivatePrompt(ctx context.Context, _a1 *models.Prompt) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActivatePrompt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Prompt) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreatePrompt provides a mock function with given fields: ctx, _a1
+func (_m *IPromptUsecase) CreatePrompt(ctx context.Context, _a1 *models.Prompt) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePrompt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Prompt) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchAllDefaultPrompts provides a mock function with no fields
+func (_m *IPromptUsecase) FetchAllDefaultPrompts() []*models.Prompt {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllDefaultPrompts")
+	}
+
+	var r0 []*models.Prompt
+	if rf, ok := ret.Get(0).(func() []*models.Prompt); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Prompt)
+		}
+	}
+
+	return r0
+}
+
+// FetchAllPrompts provides a mock function with given fields: ctx, args
+func (_m *IPromptUsecase) FetchAllPrompts(ctx context.Context, args *sync.Map) ([]*models.Prompt, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllPrompts")
+	}
+
+	var r0 []*models.Prompt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.Prompt, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.Prompt); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Prompt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchOnePromptById provides a mock function with given fields: ctx, id
+func (_m *IPromptUsecase) FetchOnePromptById(ctx context.Context, id *uuid.UUID) (*models.Prompt, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOnePromptById")
+	}
+
+	var r0 *models.Prompt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.Prompt, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.Prompt); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Prompt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RenderPrompt provides a mock function with given fields: ctx, name, userInfo, language
+func (_m *IPromptUsecase) RenderPrompt(ctx context.Context, name string, userInfo *models.UserInfo, language models.Language) (*models.RenderedPrompt, error) {
+	ret := _m.Called(ctx, name, userInfo, language)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenderPrompt")
+	}
+
+	var r0 *models.RenderedPrompt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.UserInfo, models.Language) (*models.RenderedPrompt, error)); ok {
+		return rf(ctx, name, userInfo, language)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.UserInfo, models.Language) *models.RenderedPrompt); ok {
+		r0 = rf(ctx, name, userInfo, language)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RenderedPrompt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.UserInfo, models.Language) error); ok {
+		r1 = rf(ctx, name, userInfo, language)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIPromptUsecase creates a new instance of IPromptUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIPromptUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IPromptUsecase {
+	mock := &IPromptUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,17 @@
+package prompt
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type IPromptRepository interface {
+	FetchAllPrompts(ctx context.Context, args *sync.Map) ([]*models.Prompt, error)
+	FetchOnePromptById(ctx context.Context, id *uuid.UUID) (*models.Prompt, error)
+	FetchActivePromptByName(ctx context.Context, name string) (*models.Prompt, error)
+	InsertPrompt(ctx context.Context, prompt *models.Prompt) error
+	ActivatePrompt(ctx context.Context, prompt *models.Prompt) error
+}
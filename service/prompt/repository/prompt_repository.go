@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/prompt"
+	"strings"
+	"sync"
+
+	"github.com/Pheethy/sqlx"
+	"github.com/gofrs/uuid"
+)
+
+type promptRepository struct {
+	psqlDB *sqlx.DB
+}
+
+func NewPromptRepository(psqlDB *sqlx.DB) prompt.IPromptRepository {
+	return &promptRepository{
+		psqlDB: psqlDB,
+	}
+}
+
+const selectPrompt = `
+        "prompts"."id",
+        "prompts"."name",
+        "prompts"."version",
+        "prompts"."content",
+        "prompts"."is_active",
+        "prompts"."created_by",
+        to_char("prompts"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+        to_char("prompts"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at"`
+
+func (r *promptRepository) FetchAllPrompts(ctx context.Context, args *sync.Map) ([]*models.Prompt, error) {
+	var conds []interface{}
+	var wheres []string
+	if name, ok := args.Load("name"); ok {
+		conds = append(conds, name)
+		wheres = append(wheres, fmt.Sprintf(`"prompts"."name" = $%d::varchar`, len(conds)))
+	}
+	if isActive, ok := args.Load("is_active"); ok {
+		conds = append(conds, isActive)
+		wheres = append(wheres, fmt.Sprintf(`"prompts"."is_active" = $%d::bool`, len(conds)))
+	}
+	where := ""
+	if len(wheres) > 0 {
+		where = "WHERE " + strings.Join(wheres, " AND ")
+	}
+
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        %s
+      FROM
+        "prompts"
+      %s
+      ORDER BY
+        "prompts"."name" ASC,
+        "prompts"."version" DESC
+    ) AS "json_data"
+  `, selectPrompt, where)
+
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, conds...).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	prompts := make([]*models.Prompt, 0)
+	if err := json.Unmarshal(jsonData, &prompts); err != nil {
+		return nil, err
+	}
+
+	return prompts, nil
+}
+
+func (r *promptRepository) FetchOnePromptById(ctx context.Context, id *uuid.UUID) (*models.Prompt, error) {
+	return r.fetchOnePrompt(ctx, `"prompts"."id" = $1::uuid`, id)
+}
+
+func (r *promptRepository) FetchActivePromptByName(ctx context.Context, name string) (*models.Prompt, error) {
+	return r.fetchOnePrompt(ctx, `"prompts"."name" = $1::varchar AND "prompts"."is_active"`, name)
+}
+
+func (r *promptRepository) fetchOnePrompt(ctx context.Context, where string, arg interface{}) (*models.Prompt, error) {
+	sql := fmt.Sprintf(`
+    SELECT
+      to_jsonb("json_data")
+    FROM (
+      SELECT
+        %s
+      FROM
+        "prompts"
+      WHERE
+        %s
+    ) AS "json_data"
+  `, selectPrompt, where)
+
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, arg).Scan(&jsonData); err != nil {
+		return nil, apperror.FromDB(err, constants.ERROR_PROMPT_NOT_FOUND)
+	}
+
+	prompt := new(models.Prompt)
+	if err := json.Unmarshal(jsonData, &prompt); err != nil {
+		return nil, err
+	}
+
+	return prompt, nil
+}
+
+/* InsertPrompt เวอร์ชันใหม่คือเวอร์ชันล่าสุดของชื่อเดียวกันบวกหนึ่ง และเขียนค่ากลับไปที่ prompt.Version */
+func (r *promptRepository) InsertPrompt(ctx context.Context, prompt *models.Prompt) error {
+	sql := `
+    INSERT INTO "prompts" (
+      "id",
+      "name",
+      "version",
+      "content",
+      "is_active",
+      "created_by",
+      "created_at",
+      "updated_at"
+    ) VALUES (
+      $1::uuid,
+      $2::varchar,
+      (SELECT COALESCE(MAX("version"), 0) + 1 FROM "prompts" WHERE "name" = $2::varchar),
+      $3::text,
+      FALSE,
+      $4::uuid,
+      $5::timestamp,
+      $6::timestamp
+    )
+    RETURNING "version"
+  `
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if err := stmt.QueryRowxContext(ctx,
+		prompt.Id,
+		prompt.Name,
+		prompt.Content,
+		prompt.CreatedBy,
+		prompt.CreatedAt,
+		prompt.UpdatedAt,
+	).Scan(&prompt.Version); err != nil {
+		return apperror.FromDB(err, "")
+	}
+
+	return nil
+}
+
+/* ActivatePrompt ปิดเวอร์ชันที่ active อยู่ของชื่อเดียวกันแล้วเปิดเวอร์ชันนี้ใน transaction เดียว */
+func (r *promptRepository) ActivatePrompt(ctx context.Context, prompt *models.Prompt) error {
+	tx, err := r.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+    UPDATE "prompts" SET
+      "is_active" = FALSE,
+      "updated_at" = $2::timestamp
+    WHERE
+      "name" = $1::varchar
+    AND
+      "is_active"
+  `, prompt.Name, prompt.UpdatedAt); err != nil {
+		tx.Rollback()
+		return apperror.FromDB(err, "")
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+    UPDATE "prompts" SET
+      "is_active" = TRUE,
+      "updated_at" = $2::timestamp
+    WHERE
+      "id" = $1::uuid
+  `, prompt.Id, prompt.UpdatedAt); err != nil {
+		tx.Rollback()
+		return apperror.FromDB(err, "")
+	}
+
+	return tx.Commit()
+}
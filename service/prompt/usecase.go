@@ -0,0 +1,18 @@
+package prompt
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type IPromptUsecase interface {
+	FetchAllPrompts(ctx context.Context, args *sync.Map) ([]*models.Prompt, error)
+	FetchOnePromptById(ctx context.Context, id *uuid.UUID) (*models.Prompt, error)
+	FetchAllDefaultPrompts() []*models.Prompt
+	CreatePrompt(ctx context.Context, prompt *models.Prompt) error
+	ActivatePrompt(ctx context.Context, prompt *models.Prompt) error
+	RenderPrompt(ctx context.Context, name string, userInfo *models.UserInfo, language models.Language) (*models.RenderedPrompt, error)
+}
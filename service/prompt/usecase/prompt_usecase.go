@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/prompt"
+	"healthmatefood-api/templates"
+	"log"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+/* ระยะเวลาที่เก็บ template ที่ active ไว้ในหน่วยความจำก่อนถาม database ใหม่ (instance อื่นจะเห็นเวอร์ชันใหม่ภายในเวลานี้) */
+const promptCacheTTL = time.Minute
+
+/* promptFuncs ฟังก์ชันที่ใช้ได้ใน template นอกจาก built-in ของ text/template language คืนชื่อภาษาที่ต้องตอบ ถูกแทนตอน render */
+var promptFuncs = template.FuncMap{
+	"join":     strings.Join,
+	"language": models.DefaultLanguage.Name,
+}
+
+/* prompt ที่ถูก render โดยไม่มีข้อมูลผู้ใช้ได้ ต้อง render กับ nil ผ่านด้วย (เช่นครอบด้วย {{with .}}) */
+var promptsWithoutUserInfo = map[string]bool{
+	models.PromptChatSystem:      true,
+	models.PromptChatSummary:     true,
+	models.PromptMealPhotoSystem: true,
+}
+
+type parsedPrompt struct {
+	tmpl      *template.Template
+	version   string
+	expiresAt time.Time
+}
+
+type promptUsecase struct {
+	promptRepo prompt.IPromptRepository
+	defaults   map[string]*parsedPrompt
+	mu         sync.RWMutex
+	cache      map[string]*parsedPrompt
+}
+
+func NewPromptUsecase(promptRepo prompt.IPromptRepository) prompt.IPromptUsecase {
+	defaults := make(map[string]*parsedPrompt)
+	for _, name := range templates.Names() {
+		content, _ := templates.Default(name)
+		tmpl, err := parsePrompt(name, content)
+		if err != nil {
+			log.Fatalf("default prompt %s is invalid: %v", name, err)
+		}
+		defaults[name] = &parsedPrompt{tmpl: tmpl, version: models.PromptVersionLabel(name, 0)}
+	}
+	return &promptUsecase{
+		promptRepo: promptRepo,
+		defaults:   defaults,
+		cache:      make(map[string]*parsedPrompt),
+	}
+}
+
+func (u *promptUsecase) FetchAllPrompts(ctx context.Context, args *sync.Map) ([]*models.Prompt, error) {
+	return u.promptRepo.FetchAllPrompts(ctx, args)
+}
+
+func (u *promptUsecase) FetchOnePromptById(ctx context.Context, id *uuid.UUID) (*models.Prompt, error) {
+	return u.promptRepo.FetchOnePromptById(ctx, id)
+}
+
+func (u *promptUsecase) FetchAllDefaultPrompts() []*models.Prompt {
+	prompts := make([]*models.Prompt, 0, len(u.defaults))
+	for _, name := range templates.Names() {
+		content, _ := templates.Default(name)
+		prompts = append(prompts, &models.Prompt{Name: name, Content: content})
+	}
+	return prompts
+}
+
+/* CreatePrompt บันทึกเป็นเวอร์ชันใหม่หลังจากตรวจว่า template render กับ UserInfo ได้ ถ้า IsActive จะเปิดใช้ทันที */
+func (u *promptUsecase) CreatePrompt(ctx context.Context, prompt *models.Prompt) error {
+	if _, ok := u.defaults[prompt.Name]; !ok {
+		return apperror.Validation(constants.ERROR_PROMPT_NAME_IS_INVALID).WithDetail(prompt.Name)
+	}
+	tmpl, err := parsePrompt(prompt.Name, prompt.Content)
+	if err != nil {
+		return apperror.Validation(constants.ERROR_PROMPT_TEMPLATE_IS_INVALID).Wrap(err)
+	}
+	if err := tmpl.Execute(new(bytes.Buffer), sampleUserInfo()); err != nil {
+		return apperror.Validation(constants.ERROR_PROMPT_TEMPLATE_IS_INVALID).Wrap(err)
+	}
+	if promptsWithoutUserInfo[prompt.Name] {
+		if err := tmpl.Execute(new(bytes.Buffer), (*models.UserInfo)(nil)); err != nil {
+			return apperror.Validation(constants.ERROR_PROMPT_TEMPLATE_IS_INVALID).Wrap(err)
+		}
+	}
+
+	activate := prompt.IsActive
+	prompt.IsActive = false
+	if err := u.promptRepo.InsertPrompt(ctx, prompt); err != nil {
+		return err
+	}
+	if activate {
+		return u.ActivatePrompt(ctx, prompt)
+	}
+	return nil
+}
+
+func (u *promptUsecase) ActivatePrompt(ctx context.Context, prompt *models.Prompt) error {
+	prompt.SetUpdatedAt()
+	if err := u.promptRepo.ActivatePrompt(ctx, prompt); err != nil {
+		return err
+	}
+	prompt.IsActive = true
+
+	u.mu.Lock()
+	delete(u.cache, prompt.Name)
+	u.mu.Unlock()
+	return nil
+}
+
+/* RenderPrompt render เวอร์ชันที่ active ของ prompt ด้วยข้อมูลผู้ใช้และภาษาที่ต้องตอบ ถ้าไม่มีใน database หรืออ่านไม่ได้จะใช้ค่าตั้งต้น */
+func (u *promptUsecase) RenderPrompt(ctx context.Context, name string, userInfo *models.UserInfo, language models.Language) (*models.RenderedPrompt, error) {
+	parsed, err := u.activePrompt(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	/* template ใน cache ใช้ร่วมกันหลาย request จึง clone ก่อนเปลี่ยนภาษา */
+	tmpl, err := parsed.tmpl.Clone()
+	if err != nil {
+		return nil, fmt.Errorf("render prompt %s: %v", parsed.version, err)
+	}
+	tmpl.Funcs(template.FuncMap{"language": language.Name})
+	var text bytes.Buffer
+	if err := tmpl.Execute(&text, userInfo); err != nil {
+		return nil, fmt.Errorf("render prompt %s: %v", parsed.version, err)
+	}
+	return &models.RenderedPrompt{Name: name, Version: parsed.version, Text: text.String()}, nil
+}
+
+func (u *promptUsecase) activePrompt(ctx context.Context, name string) (*parsedPrompt, error) {
+	defaultPrompt, ok := u.defaults[name]
+	if !ok {
+		return nil, apperror.Validation(constants.ERROR_PROMPT_NAME_IS_INVALID).WithDetail(name)
+	}
+
+	now := time.Now()
+	u.mu.RLock()
+	cached, ok := u.cache[name]
+	u.mu.RUnlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached, nil
+	}
+
+	parsed := &parsedPrompt{tmpl: defaultPrompt.tmpl, version: defaultPrompt.version}
+	active, err := u.promptRepo.FetchActivePromptByName(ctx, name)
+	switch {
+	case err != nil && !errors.Is(err, apperror.ErrNotFound):
+		/* database มีปัญหาให้ใช้ค่าตั้งต้นไปก่อนโดยไม่ cache เพื่อให้ลองใหม่ในครั้งถัดไป */
+		log.Printf("fetch active prompt %s failed, use default: %v", name, err)
+		return parsed, nil
+	case err == nil:
+		tmpl, err := parsePrompt(name, active.Content)
+		if err != nil {
+			log.Printf("active prompt %s is invalid, use default: %v", models.PromptVersionLabel(name, active.Version), err)
+			break
+		}
+		parsed.tmpl = tmpl
+		parsed.version = models.PromptVersionLabel(name, active.Version)
+	}
+
+	parsed.expiresAt = now.Add(promptCacheTTL)
+	u.mu.Lock()
+	u.cache[name] = parsed
+	u.mu.Unlock()
+	return parsed, nil
+}
+
+func parsePrompt(name string, content string) (*template.Template, error) {
+	return template.New(name).Funcs(promptFuncs).Option("missingkey=error").Parse(content)
+}
+
+/* sampleUserInfo ข้อมูลตัวอย่างที่มีทุก field ใช้ตรวจ template ก่อนบันทึก */
+func sampleUserInfo() *models.UserInfo {
+	return &models.UserInfo{
+		Gender:            "FEMALE",
+		Height:            160,
+		Weight:            60,
+		Target:            "LOSE_WEIGHT",
+		TargetWeight:      55,
+		ActiveLevel:       "MODERATE",
+		Age:               35,
+		BMR:               1300,
+		CaloriesLimit:     1800,
+		WaterTarget:       2100,
+		MedicalCondition:  "เบาหวาน",
+		FoodOrIngredients: []string{"อกไก่", "ข้าวกล้อง"},
+		Diseases:          []*models.Disease{{Name: "เบาหวาน"}},
+		FoodPreferences: []*models.FoodPreference{
+			{Name: "ปลา", PreferenceType: models.FoodPreferenceLike},
+			{Name: "ผักชี", PreferenceType: models.FoodPreferenceDislike},
+			{Name: "กุ้ง", PreferenceType: models.FoodPreferenceAllergy},
+		},
+	}
+}
@@ -0,0 +1,47 @@
+package validator
+
+import (
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"strings"
+
+	"github.com/Pheethy/psql/helper"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Validation struct{}
+
+func (v Validation) ValidateCreatePrompt() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		if params == nil {
+			return apperror.Validation(constants.ERROR_BODY_WAS_MISSING)
+		}
+
+		fields := apperror.Fields{}
+		for _, key := range []string{"name", "content"} {
+			if val, ok := params[key]; !ok {
+				fields.Missing(key)
+			} else if err := validation.Validate(val, validation.By(helper.ValidateTypeString)); err != nil {
+				fields.Add(key, err.Error())
+			} else if strings.TrimSpace(val.(string)) == "" {
+				fields.Add(key, "must not be empty")
+			}
+		}
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateParams(key string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params := c.Params(key)
+		if err := validation.Validate(params, validation.By(helper.ValidateTypeUUID)); err != nil {
+			return apperror.Field(key, err.Error())
+		}
+		return c.Next()
+	}
+}
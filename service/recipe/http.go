@@ -0,0 +1,11 @@
+package recipe
+
+import "github.com/gofiber/fiber/v2"
+
+type IRecipeHandler interface {
+	FetchAllRecipes(c *fiber.Ctx) error
+	FetchOneRecipeById(c *fiber.Ctx) error
+	CreateRecipe(c *fiber.Ctx) error
+	UpdateRecipe(c *fiber.Ctx) error
+	DeleteRecipe(c *fiber.Ctx) error
+}
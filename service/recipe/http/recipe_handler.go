@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/recipe"
+	"mime/multipart"
+	"net/http"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+)
+
+type recipeHandler struct {
+	recipeUs recipe.IRecipeUsecase
+}
+
+func NewRecipeHandler(recipeUs recipe.IRecipeUsecase) recipe.IRecipeHandler {
+	return &recipeHandler{
+		recipeUs: recipeUs,
+	}
+}
+
+// @Summary     FetchAllRecipes
+// @Description Get list recipes with nutrition per serving
+// @Tags        recipes
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id     query string false "example: 98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       search_word query string false "example: กะเพรา"
+// @Success     200         {object}     map[string]interface{}
+// @Failure     500         {object}     constants.ErrorResponse
+// @Router      /v1/recipe/list [get]
+func (r *recipeHandler) FetchAllRecipes(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	args := new(sync.Map)
+	if userId := uuid.FromStringOrNil(c.Query("user_id")); !userId.IsNil() {
+		args.Store("user_id", &userId)
+	}
+	if searchWord := c.Query("search_word"); searchWord != "" {
+		args.Store("search_word", searchWord)
+	}
+
+	recipes, err := r.recipeUs.FetchAllRecipes(ctx, args)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"recipes": recipes,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     FetchOneRecipeById
+// @Description Get one recipe with ingredients, steps and images
+// @Tags        recipes
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       recipe_id path string true "recipe id"
+// @Success     200       {object}     map[string]interface{}
+// @Failure     404       {object}     constants.ErrorResponse "recipe not found"
+// @Failure     500       {object}     constants.ErrorResponse
+// @Router      /v1/recipe/{recipe_id} [get]
+func (r *recipeHandler) FetchOneRecipeById(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	id := uuid.FromStringOrNil(c.Params("recipe_id"))
+
+	recipe, err := r.recipeUs.FetchOneRecipeById(ctx, &id)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"recipe": recipe,
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     CreateRecipe
+// @Description Create a recipe; nutrition per serving is computed from ingredients (units: g, ml, tbsp, cup, piece)
+// @Tags        recipes
+// @Accept      multipart/form-data
+// @Produce     json,application/problem+json
+// @Param       user_id                  formData string true  "owner user id"
+// @Param       name                     formData string true  "recipe name"
+// @Param       description              formData string false "recipe description"
+// @Param       servings                 formData int    false "number of servings" default(1)
+// @Param       ingredients[0][food_id]  formData string true  "food id"
+// @Param       ingredients[0][quantity] formData number true  "quantity of unit"
+// @Param       ingredients[0][unit]     formData string false "g, ml, tbsp, cup or piece"
+// @Param       steps[0]                 formData string false "cooking step"
+// @Param       images                   formData file   false "recipe images"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse "unit is invalid, recipe has no ingredient, file type is invalid"
+// @Failure     404 {object} constants.ErrorResponse "food not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/recipe [post]
+func (r *recipeHandler) CreateRecipe(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	params := c.Locals("params").(map[string]interface{})
+	files, _ := c.Locals("images").([]*multipart.FileHeader)
+	recipe := models.NewRecipeWithParams(params, nil)
+	recipe.NewID()
+	recipe.SetCreatedAt()
+	recipe.SetUpdatedAt()
+
+	if err := r.recipeUs.UpsertRecipe(ctx, recipe, files); err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message": "successful",
+		"recipe":  recipe,
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     UpdateRecipe
+// @Description Update a recipe; sending ingredients or steps replaces the whole list
+// @Tags        recipes
+// @Accept      multipart/form-data
+// @Produce     json,application/problem+json
+// @Param       recipe_id path     string true  "recipe id"
+// @Param       images    formData file   false "more recipe images"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     404 {object} constants.ErrorResponse "recipe not found, food not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/recipe/{recipe_id} [put]
+func (r *recipeHandler) UpdateRecipe(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	params := c.Locals("params").(map[string]interface{})
+	files, _ := c.Locals("images").([]*multipart.FileHeader)
+	id := uuid.FromStringOrNil(c.Params("recipe_id"))
+
+	existRecipe, err := r.recipeUs.FetchOneRecipeById(ctx, &id)
+	if err != nil {
+		return err
+	}
+	/* เจ้าของสูตรเปลี่ยนไม่ได้ */
+	delete(params, "user_id")
+	newRecipe := models.NewRecipeWithParams(params, existRecipe)
+	newRecipe.SetUpdatedAt()
+
+	if err := r.recipeUs.UpsertRecipe(ctx, newRecipe, files); err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message": "successful",
+		"recipe":  newRecipe,
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     DeleteRecipe
+// @Description Delete a recipe with its ingredients, steps and images
+// @Tags        recipes
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       recipe_id path string true "recipe id"
+// @Success     200 {object} map[string]interface{}
+// @Failure     404 {object} constants.ErrorResponse "recipe not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/recipe/{recipe_id} [delete]
+func (r *recipeHandler) DeleteRecipe(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	id := uuid.FromStringOrNil(c.Params("recipe_id"))
+
+	if err := r.recipeUs.DeleteRecipe(ctx, &id); err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message": "successful",
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
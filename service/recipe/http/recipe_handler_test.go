@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"encoding/json"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/middleware"
+	"healthmatefood-api/models"
+	recipe_mocks "healthmatefood-api/service/recipe/mocks"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateRecipe(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	foodId := uuid.FromStringOrNil("0b3f1c55-2a5e-4f0b-9a43-6f1f5b7b6a01")
+	body := `{"user_id":"` + userId.String() + `","name":"ข้าวผัดไข่","servings":2,"ingredients":[{"food_id":"` + foodId.String() + `","quantity":2,"unit":"cup"}],"steps":["ตั้งกระทะ","ใส่ข้าวผัดกับไข่"]}`
+	newApp := func(recipeUs *recipe_mocks.IRecipeUsecase) *fiber.App {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		recipeHandler := NewRecipeHandler(recipeUs)
+		app.Post("/v1/recipe", func(c *fiber.Ctx) error {
+			params := map[string]interface{}{}
+			if err := json.Unmarshal(c.Body(), &params); err != nil {
+				return err
+			}
+			c.Locals("params", params)
+			return recipeHandler.CreateRecipe(c)
+		})
+		return app
+	}
+	t.Run("success", func(t *testing.T) {
+		recipeUs := new(recipe_mocks.IRecipeUsecase)
+		recipeUs.On("UpsertRecipe", mock.Anything, mock.AnythingOfType("*models.Recipe"), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			recipe := args.Get(1).(*models.Recipe)
+			assert.NotNil(t, recipe.Id)
+			assert.Equal(t, userId, *recipe.UserId)
+			assert.Equal(t, 2, recipe.Servings)
+			assert.Len(t, recipe.Ingredients, 1)
+			assert.Equal(t, foodId, *recipe.Ingredients[0].FoodId)
+			assert.Equal(t, models.UNIT_CUP, recipe.Ingredients[0].Unit)
+			assert.Len(t, recipe.Steps, 2)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/recipe", strings.NewReader(body))
+		req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+		resp, err := newApp(recipeUs).Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+	t.Run("error_food_not_found", func(t *testing.T) {
+		recipeUs := new(recipe_mocks.IRecipeUsecase)
+		recipeUs.On("UpsertRecipe", mock.Anything, mock.AnythingOfType("*models.Recipe"), mock.Anything).Return(apperror.NotFound(constants.ERROR_FOOD_NOT_FOUND))
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/recipe", strings.NewReader(body))
+		req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+		resp, err := newApp(recipeUs).Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
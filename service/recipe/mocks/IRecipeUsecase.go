@@ -0,0 +1,131 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "healthmatefood-api/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	multipart "mime/multipart"
+
+	sync "sync"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IRecipeUsecase is an autogenerated mock type for the IRecipeUsecase type
+type IRecipeUsecase struct {
+	mock.Mock
+}
+
+// DeleteRecipe provides a mock function with given fields: ctx, id
+func (_m *IRecipeUsecase) DeleteRecipe(ctx context.Context, id *uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRecipe")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchAllRecipes provides a mock function with given fields: ctx, args
+func (_m *IRecipeUsecase) FetchAllRecipes(ctx context.Context, args *sync.Map) ([]*models.Recipe, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllRecipes")
+	}
+
+	var r0 []*models.Recipe
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.Recipe, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.Recipe); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Recipe)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchOneRecipeById provides a mock function with given fields: ctx, id
+func (_m *IRecipeUsecase) FetchOneRecipeById(ctx context.Context, id *uuid.UUID) (*models.Recipe, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOneRecipeById")
+	}
+
+	var r0 *models.Recipe
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.Recipe, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.Recipe); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Recipe)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertRecipe provides a mock function with given fields: ctx, _a1, files
+func (_m *IRecipeUsecase) UpsertRecipe(ctx context.Context, _a1 *models.Recipe, files []*multipart.FileHeader) error {
+	ret := _m.Called(ctx, _a1, files)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertRecipe")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Recipe, []*multipart.FileHeader) error); ok {
+		r0 = rf(ctx, _a1, files)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIRecipeUsecase creates a new instance of IRecipeUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIRecipeUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IRecipeUsecase {
+	mock := &IRecipeUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
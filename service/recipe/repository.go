@@ -0,0 +1,17 @@
+package recipe
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type IRecipeRepository interface {
+	FetchAllRecipes(ctx context.Context, args *sync.Map) ([]*models.Recipe, error)
+	FetchOneRecipeById(ctx context.Context, id *uuid.UUID) (*models.Recipe, error)
+	UpsertRecipe(ctx context.Context, recipe *models.Recipe) error
+	UpsertImages(ctx context.Context, recipe *models.Recipe) error
+	DeleteRecipe(ctx context.Context, id *uuid.UUID) error
+}
@@ -0,0 +1,472 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/recipe"
+	"strings"
+	"sync"
+
+	"github.com/Pheethy/sqlx"
+	"github.com/gofrs/uuid"
+)
+
+type recipeRepository struct {
+	psqlDB *sqlx.DB
+}
+
+func NewRecipeRepository(psqlDB *sqlx.DB) recipe.IRecipeRepository {
+	return &recipeRepository{
+		psqlDB: psqlDB,
+	}
+}
+
+const selectRecipe = `
+        "recipes"."id",
+        "recipes"."user_id",
+        "recipes"."name",
+        COALESCE("recipes"."description", '') "description",
+        "recipes"."servings",
+        "recipes"."calories",
+        "recipes"."protein",
+        "recipes"."carbohydrate",
+        "recipes"."fat",
+        to_char("recipes"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+        to_char("recipes"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at",
+        (
+          SELECT
+            COALESCE(array_to_json(array_agg("ING")), '[]'::json)
+          FROM (
+            SELECT
+              "recipe_ingredients"."id",
+              "recipe_ingredients"."recipe_id",
+              "recipe_ingredients"."food_id",
+              "recipe_ingredients"."quantity",
+              "recipe_ingredients"."unit",
+              "recipe_ingredients"."grams",
+              COALESCE("recipe_ingredients"."note", '') "note",
+              to_char("recipe_ingredients"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+              to_char("recipe_ingredients"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at",
+              (
+                SELECT
+                  to_jsonb("FOOD")
+                FROM (
+                  SELECT
+                    "foods"."id",
+                    "foods"."name",
+                    "foods"."serving_size",
+                    "foods"."serving_unit",
+                    "foods"."calories",
+                    "foods"."protein",
+                    "foods"."carbohydrate",
+                    "foods"."fat"
+                  FROM
+                    "foods"
+                  WHERE
+                    "foods"."id" = "recipe_ingredients"."food_id"
+                ) AS "FOOD"
+              ) AS "food"
+            FROM
+              "recipe_ingredients"
+            WHERE
+              "recipe_ingredients"."recipe_id" = "recipes"."id"
+            ORDER BY
+              "recipe_ingredients"."created_at" ASC
+          ) AS "ING"
+        ) AS "ingredients",
+        (
+          SELECT
+            COALESCE(array_to_json(array_agg("STEP")), '[]'::json)
+          FROM (
+            SELECT
+              "recipe_steps"."id",
+              "recipe_steps"."recipe_id",
+              "recipe_steps"."step_no",
+              "recipe_steps"."description",
+              to_char("recipe_steps"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+              to_char("recipe_steps"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at"
+            FROM
+              "recipe_steps"
+            WHERE
+              "recipe_steps"."recipe_id" = "recipes"."id"
+            ORDER BY
+              "recipe_steps"."step_no" ASC
+          ) AS "STEP"
+        ) AS "steps",
+        (
+          SELECT
+            COALESCE(array_to_json(array_agg("IM")), '[]'::json)
+          FROM (
+            SELECT
+              "images"."id",
+              "images"."filename",
+              "images"."url",
+              "images"."ref_id",
+              "images"."ref_type",
+              to_char("images"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+              to_char("images"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at"
+            FROM
+              "images"
+            WHERE
+              "images"."ref_id" = "recipes"."id"
+            AND
+              "images"."ref_type" = 'RECIPE'
+          ) AS "IM"
+        ) AS "images"`
+
+func (r *recipeRepository) FetchAllRecipes(ctx context.Context, args *sync.Map) ([]*models.Recipe, error) {
+	var conds []interface{}
+	var wheres []string
+	if userId, ok := args.Load("user_id"); ok {
+		conds = append(conds, userId)
+		wheres = append(wheres, fmt.Sprintf(`"recipes"."user_id" = $%d::uuid`, len(conds)))
+	}
+	if searchWord, ok := args.Load("search_word"); ok {
+		conds = append(conds, fmt.Sprintf("%%%s%%", searchWord))
+		wheres = append(wheres, fmt.Sprintf(`"recipes"."name" ILIKE $%d::text`, len(conds)))
+	}
+	/* names ([]string) ชื่อสูตรแบบตรงตัว ไม่สนตัวพิมพ์เล็กใหญ่ */
+	if names, ok := args.Load("names"); ok {
+		placeholders := make([]string, 0)
+		for _, name := range names.([]string) {
+			conds = append(conds, strings.ToLower(name))
+			placeholders = append(placeholders, fmt.Sprintf("$%d::text", len(conds)))
+		}
+		wheres = append(wheres, fmt.Sprintf(`LOWER("recipes"."name") IN (%s)`, strings.Join(placeholders, ", ")))
+	}
+	where := ""
+	if len(wheres) > 0 {
+		where = "WHERE " + strings.Join(wheres, " AND ")
+	}
+
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        %s
+      FROM
+        "recipes"
+      %s
+      ORDER BY
+        "recipes"."created_at" DESC
+    ) AS "json_data"
+  `, selectRecipe, where)
+
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, conds...).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	recipes := make([]*models.Recipe, 0)
+	if err := json.Unmarshal(jsonData, &recipes); err != nil {
+		return nil, err
+	}
+
+	return recipes, nil
+}
+
+func (r *recipeRepository) FetchOneRecipeById(ctx context.Context, id *uuid.UUID) (*models.Recipe, error) {
+	sql := fmt.Sprintf(`
+    SELECT
+      to_jsonb("json_data")
+    FROM (
+      SELECT
+        %s
+      FROM
+        "recipes"
+      WHERE
+        "recipes"."id" = $1::uuid
+    ) AS "json_data"
+  `, selectRecipe)
+
+	stmt, err := r.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, id).Scan(&jsonData); err != nil {
+		return nil, apperror.FromDB(err, constants.ERROR_RECIPE_NOT_FOUND)
+	}
+
+	recipe := new(models.Recipe)
+	if err := json.Unmarshal(jsonData, &recipe); err != nil {
+		return nil, err
+	}
+
+	return recipe, nil
+}
+
+/* UpsertRecipe บันทึกสูตรอาหาร แล้วแทนที่ส่วนผสมและขั้นตอนทั้งหมดภายใน transaction เดียว */
+func (r *recipeRepository) UpsertRecipe(ctx context.Context, recipe *models.Recipe) error {
+	tx, err := r.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+	sql := `
+    INSERT INTO "recipes" (
+      "id",
+      "user_id",
+      "name",
+      "description",
+      "servings",
+      "calories",
+      "protein",
+      "carbohydrate",
+      "fat",
+      "created_at",
+      "updated_at"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::text,
+      $4::text,
+      $5::int,
+      $6::float,
+      $7::float,
+      $8::float,
+      $9::float,
+      $10::timestamp,
+      $11::timestamp
+    )
+    ON CONFLICT (id)
+    DO UPDATE SET
+      name=$12::text,
+      description=$13::text,
+      servings=$14::int,
+      calories=$15::float,
+      protein=$16::float,
+      carbohydrate=$17::float,
+      fat=$18::float,
+      updated_at=$19::timestamp
+  `
+	stmt, err := tx.PreparexContext(ctx, sql)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx,
+		/* Create */
+		recipe.Id,
+		recipe.UserId,
+		recipe.Name,
+		recipe.Description,
+		recipe.Servings,
+		recipe.Calories,
+		recipe.Protein,
+		recipe.Carbohydrate,
+		recipe.Fat,
+		recipe.CreatedAt,
+		recipe.UpdatedAt,
+		/* Update */
+		recipe.Name,
+		recipe.Description,
+		recipe.Servings,
+		recipe.Calories,
+		recipe.Protein,
+		recipe.Carbohydrate,
+		recipe.Fat,
+		recipe.UpdatedAt,
+	); err != nil {
+		tx.Rollback()
+		return apperror.FromDB(err, "")
+	}
+
+	/* Replace Ingredients */
+	if _, err := tx.ExecContext(ctx, `DELETE FROM "recipe_ingredients" WHERE "recipe_id" = $1::uuid`, recipe.Id); err != nil {
+		tx.Rollback()
+		return apperror.FromDB(err, "")
+	}
+	ingredientStmt, err := tx.PreparexContext(ctx, `
+    INSERT INTO "recipe_ingredients" (
+      "id",
+      "recipe_id",
+      "food_id",
+      "quantity",
+      "unit",
+      "grams",
+      "note",
+      "created_at",
+      "updated_at"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::uuid,
+      $4::float,
+      $5::text,
+      $6::float,
+      $7::text,
+      $8::timestamp,
+      $9::timestamp
+    )
+  `)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer ingredientStmt.Close()
+
+	for index := range recipe.Ingredients {
+		ingredient := recipe.Ingredients[index]
+		if _, err := ingredientStmt.ExecContext(ctx,
+			ingredient.Id,
+			recipe.Id,
+			ingredient.FoodId,
+			ingredient.Quantity,
+			ingredient.Unit,
+			ingredient.Grams,
+			ingredient.Note,
+			ingredient.CreatedAt,
+			ingredient.UpdatedAt,
+		); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("exec ingredient failed: %v", err)
+		}
+	}
+
+	/* Replace Steps */
+	if _, err := tx.ExecContext(ctx, `DELETE FROM "recipe_steps" WHERE "recipe_id" = $1::uuid`, recipe.Id); err != nil {
+		tx.Rollback()
+		return apperror.FromDB(err, "")
+	}
+	stepStmt, err := tx.PreparexContext(ctx, `
+    INSERT INTO "recipe_steps" (
+      "id",
+      "recipe_id",
+      "step_no",
+      "description",
+      "created_at",
+      "updated_at"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::int,
+      $4::text,
+      $5::timestamp,
+      $6::timestamp
+    )
+  `)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stepStmt.Close()
+
+	for index := range recipe.Steps {
+		step := recipe.Steps[index]
+		if _, err := stepStmt.ExecContext(ctx,
+			step.Id,
+			recipe.Id,
+			step.StepNo,
+			step.Description,
+			step.CreatedAt,
+			step.UpdatedAt,
+		); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("exec step failed: %v", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *recipeRepository) UpsertImages(ctx context.Context, recipe *models.Recipe) error {
+	tx, err := r.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+	sql := `
+		INSERT INTO images (
+	        id,
+	        filename,
+	        url,
+	        ref_id,
+	        ref_type,
+	        created_at,
+	        updated_at
+		) VALUES (
+	        $1::uuid,
+	        $2::text,
+	        $3::text,
+	        $4::uuid,
+	        $5::image_ref_type,
+	        $6::timestamp,
+	        $7::timestamp
+		)
+		ON CONFLICT (id)
+		DO UPDATE SET
+	        filename=$8::text,
+	        url=$9::text,
+	        ref_id=$10::uuid,
+	        ref_type=$11::image_ref_type,
+	        updated_at=$12::timestamp
+	`
+	stmt, err := tx.PreparexContext(ctx, sql)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("prepare failed: %v", err.Error())
+	}
+	defer stmt.Close()
+
+	for index := range recipe.Images {
+		if _, err := stmt.ExecContext(ctx,
+			// create
+			recipe.Images[index].Id,
+			recipe.Images[index].FileName,
+			recipe.Images[index].URL,
+			recipe.Id,
+			recipe.Images[index].RefType,
+			recipe.Images[index].CreatedAt,
+			recipe.Images[index].UpdatedAt,
+			// update
+			recipe.Images[index].FileName,
+			recipe.Images[index].URL,
+			recipe.Id,
+			recipe.Images[index].RefType,
+			recipe.Images[index].UpdatedAt,
+		); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("exec failed: %v", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *recipeRepository) DeleteRecipe(ctx context.Context, id *uuid.UUID) error {
+	tx, err := r.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+
+	/* ส่วนผสมและขั้นตอนถูกลบตาม ON DELETE CASCADE ส่วนรูปภาพอ้างอิงแบบ ref_id จึงต้องลบเอง */
+	if _, err := tx.ExecContext(ctx, `DELETE FROM "images" WHERE "ref_id" = $1::uuid AND "ref_type" = 'RECIPE'`, id); err != nil {
+		tx.Rollback()
+		return apperror.FromDB(err, "")
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM "recipes" WHERE "id" = $1::uuid`, id)
+	if err != nil {
+		tx.Rollback()
+		return apperror.FromDB(err, "")
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		tx.Rollback()
+		return apperror.NotFound(constants.ERROR_RECIPE_NOT_FOUND)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,17 @@
+package recipe
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"mime/multipart"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type IRecipeUsecase interface {
+	FetchAllRecipes(ctx context.Context, args *sync.Map) ([]*models.Recipe, error)
+	FetchOneRecipeById(ctx context.Context, id *uuid.UUID) (*models.Recipe, error)
+	UpsertRecipe(ctx context.Context, recipe *models.Recipe, files []*multipart.FileHeader) error
+	DeleteRecipe(ctx context.Context, id *uuid.UUID) error
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/config"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/file"
+	"healthmatefood-api/service/food"
+	"healthmatefood-api/service/recipe"
+	"healthmatefood-api/utils"
+	"math"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type recipeUsecase struct {
+	cfg        config.Iconfig
+	recipeRepo recipe.IRecipeRepository
+	foodRepo   food.IFoodRepository
+	fileUs     file.IFileUsecase
+}
+
+func NewRecipeUsecase(cfg config.Iconfig, recipeRepo recipe.IRecipeRepository, foodRepo food.IFoodRepository, fileUs file.IFileUsecase) recipe.IRecipeUsecase {
+	return &recipeUsecase{
+		cfg:        cfg,
+		recipeRepo: recipeRepo,
+		foodRepo:   foodRepo,
+		fileUs:     fileUs,
+	}
+}
+
+func (r *recipeUsecase) FetchAllRecipes(ctx context.Context, args *sync.Map) ([]*models.Recipe, error) {
+	return r.recipeRepo.FetchAllRecipes(ctx, args)
+}
+
+func (r *recipeUsecase) FetchOneRecipeById(ctx context.Context, id *uuid.UUID) (*models.Recipe, error) {
+	return r.recipeRepo.FetchOneRecipeById(ctx, id)
+}
+
+func (r *recipeUsecase) UpsertRecipe(ctx context.Context, recipe *models.Recipe, files []*multipart.FileHeader) error {
+	if len(recipe.Ingredients) == 0 {
+		return apperror.Validation(constants.ERROR_RECIPE_HAS_NO_INGREDIENT)
+	}
+	for index := range recipe.Ingredients {
+		ingredient := recipe.Ingredients[index]
+		if ingredient.Unit != "" && !models.IsUnit(ingredient.Unit) {
+			return apperror.Validation(constants.ERROR_UNIT_IS_INVALID).WithDetail(ingredient.Unit)
+		}
+		food, err := r.foodRepo.FetchOneFoodById(ctx, ingredient.FoodId)
+		if err != nil {
+			return err
+		}
+		ingredient.Food = food
+	}
+	recipe.PrepareChildren()
+	recipe.CalculateNutrition()
+
+	if len(files) > 0 {
+		if err := r.prepareImage(ctx, recipe, files); err != nil {
+			return err
+		}
+	}
+	if err := r.recipeRepo.UpsertRecipe(ctx, recipe); err != nil {
+		return err
+	}
+	if err := r.recipeRepo.UpsertImages(ctx, recipe); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *recipeUsecase) DeleteRecipe(ctx context.Context, id *uuid.UUID) error {
+	return r.recipeRepo.DeleteRecipe(ctx, id)
+}
+
+func (r *recipeUsecase) prepareImage(ctx context.Context, recipe *models.Recipe, files []*multipart.FileHeader) error {
+	reqFile := make([]*models.FileReq, 0)
+	for _, file := range files {
+		ext := strings.TrimPrefix(filepath.Ext(file.Filename), ".")
+		if ok := r.validateFileType(ext); !ok {
+			return apperror.Validation(constants.ERROR_FILE_TYPE_IS_INVALID)
+		}
+
+		if file.Size > int64(r.cfg.App().FileLimit()) {
+			return fmt.Errorf("file size must less than %d MiB", int(math.Ceil(float64(r.cfg.App().FileLimit())/math.Pow(1024, 2))))
+		}
+
+		filename := utils.RandFileName(ext)
+		reqFile = append(reqFile, &models.FileReq{
+			File:        file,
+			Destination: constants.RECIPE_IMAGE_DESTINETION + "/" + filename,
+			Extension:   ext,
+			FileName:    file.Filename,
+		})
+	}
+
+	/* upload images to google cloud platfrom */
+	filesResp, err := r.fileUs.UploadToGCP(ctx, reqFile)
+	if err != nil {
+		return fmt.Errorf("upload recipe image failed: %v", err.Error())
+	}
+	recipe.Images = append(recipe.Images, models.FilesResp(filesResp).GetImagesWithRef(recipe.Id, constants.REF_TYPE_RECIPE)...)
+
+	return nil
+}
+
+func (r *recipeUsecase) validateFileType(ext string) bool {
+	if ext == "" {
+		return false
+	}
+
+	expMap := []string{"png", "jpg", "jpeg"}
+	for index := range expMap {
+		if expMap[index] == ext {
+			return true
+		}
+	}
+	return false
+}
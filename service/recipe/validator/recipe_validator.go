@@ -0,0 +1,132 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"reflect"
+
+	"github.com/Pheethy/psql/helper"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofiber/fiber/v2"
+	"github.com/spf13/cast"
+)
+
+type Validation struct{}
+
+func (v Validation) ValidateCreateRecipe() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		if params == nil {
+			return apperror.Validation(constants.ERROR_BODY_WAS_MISSING)
+		}
+		var key string
+		fields := apperror.Fields{}
+
+		/* key params */
+		key = "user_id"
+		if userId, ok := params[key]; !ok {
+			fields.Missing(key)
+		} else {
+			fields.Check(key, validation.Validate(userId, validation.By(helper.ValidateTypeUUID)))
+		}
+
+		key = "name"
+		if name, ok := params[key]; !ok {
+			fields.Missing(key)
+		} else {
+			fields.Check(key, validation.Validate(name, validation.By(helper.ValidateTypeString)))
+		}
+
+		key = "ingredients"
+		if _, ingredientsOK := params[key]; !ingredientsOK {
+			fields.Missing(key)
+		}
+		validateRecipeParams(params, &fields)
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateUpdateRecipe() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		if params == nil {
+			return apperror.Validation(constants.ERROR_BODY_WAS_MISSING)
+		}
+		fields := apperror.Fields{}
+		validateRecipeParams(params, &fields)
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateParams(key string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params := c.Params(key)
+		if err := validation.Validate(params, validation.By(helper.ValidateTypeUUID)); err != nil {
+			return apperror.Field(key, err.Error())
+		}
+		return c.Next()
+	}
+}
+
+func validateRecipeParams(params map[string]interface{}, fields *apperror.Fields) {
+	key := "servings"
+	if servings, ok := params[key]; ok {
+		if number, err := cast.ToIntE(servings); err != nil || number <= 0 {
+			fields.Add(key, "must be integer greater than 0")
+		}
+	}
+
+	key = "ingredients"
+	if ingredients, ok := params[key]; ok {
+		items, err := ingredientItems(ingredients)
+		if err != nil {
+			fields.Add(key, err.Error())
+			return
+		}
+		for index, item := range items {
+			fields.Check(fmt.Sprintf("%s[%d].food_id", key, index), validation.Validate(item["food_id"], validation.By(helper.ValidateTypeUUID)))
+			if quantity, err := cast.ToFloat64E(item["quantity"]); err != nil || quantity <= 0 {
+				fields.Add(fmt.Sprintf("%s[%d].quantity", key, index), "must be number greater than 0")
+			}
+		}
+	}
+}
+
+func ingredientItems(val interface{}) ([]map[string]interface{}, error) {
+	items := make([]map[string]interface{}, 0)
+	if val == nil {
+		return nil, errors.New("is empty")
+	}
+	switch reflect.TypeOf(val).Kind() {
+	case reflect.Slice:
+		for _, item := range cast.ToSlice(val) {
+			m, err := cast.ToStringMapE(item)
+			if err != nil {
+				return nil, errors.New("is not type array of object")
+			}
+			items = append(items, m)
+		}
+	case reflect.Map:
+		for _, item := range cast.ToStringMap(val) {
+			m, err := cast.ToStringMapE(item)
+			if err != nil {
+				return nil, errors.New("is not type array of object")
+			}
+			items = append(items, m)
+		}
+	default:
+		return nil, errors.New("is not type array")
+	}
+	if len(items) == 0 {
+		return nil, errors.New("is empty")
+	}
+	return items, nil
+}
@@ -11,5 +11,6 @@ type IUserHandler interface {
 	SignUpAdmin(c *fiber.Ctx) error
 	CreateUserInfo(c *fiber.Ctx) error
 	UpdateUserInfo(c *fiber.Ctx) error
+	UpdateFoodPreferences(c *fiber.Ctx) error
 	RefreshUserPassport(c *fiber.Ctx) error
 }
@@ -7,7 +7,6 @@ import (
 	"mime/multipart"
 	"net/http"
 	"strconv"
-	"strings"
 	"sync"
 
 	"github.com/gofiber/fiber/v2"
@@ -28,7 +27,7 @@ func NewUserHandler(userUs user.IUserUsecase) user.IUserHandler {
 // @Description Get list users
 // @Tags        users
 // @Accept      json
-// @Produce     json
+// @Produce     json,application/problem+json
 // @Param       search_word query string false "example: john doe"
 // @Param       page        query int    false "example: 1"
 // @Param       per_page    query int    false "example: 10"
@@ -51,7 +50,7 @@ func (u *userHandler) FetchAllUsers(c *fiber.Ctx) error {
 
 	users, err := u.userUs.FetchAllUsers(ctx, args)
 	if err != nil {
-		return fiber.NewError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 	resp := map[string]interface{}{
 		"users": users,
@@ -64,7 +63,7 @@ func (u *userHandler) FetchAllUsers(c *fiber.Ctx) error {
 // @Description Get One users
 // @Tags        users
 // @Accept      json
-// @Produce     json
+// @Produce     json,application/problem+json
 // @Param       user_id path string true "example:257d3552-c186-4c23-aa5d-1ea53f453e2a"
 // @Success     200         {object}     map[string]interface{}
 // @Failure     500         {object}     constants.ErrorResponse
@@ -75,7 +74,7 @@ func (u *userHandler) FetchOneUserById(c *fiber.Ctx) error {
 
 	user, err := u.userUs.FetchOneUserById(ctx, &id)
 	if err != nil {
-		return fiber.NewError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 	resp := map[string]interface{}{
 		"user": user,
@@ -87,13 +86,14 @@ func (u *userHandler) FetchOneUserById(c *fiber.Ctx) error {
 // @Description Sign-up to system with email and password
 // @Tags        users
 // @Accept      multipart/form-data
-// @Produce     json
+// @Produce     json,application/problem+json
 // @Param       username formData string true "username user" default:"john_doe"
 // @Param       email    formData string true "email user" example:"customer001@odor.com"
 // @Param       password formData string true "password user" example:"strongpassword123"
 // @Param       files    formData file   false "user profile image"
 // @Success     200 {object} map[string]interface{} "Successful response" example({"message":"successful","user_id":"uuid-123","username":"john_doe"})
-// @Failure     400 {object} constants.ErrorResponse "Invalid email format, duplicate username, or duplicate email"
+// @Failure     400 {object} constants.ErrorResponse "Invalid email format"
+// @Failure     409 {object} constants.ErrorResponse "Duplicate username or duplicate email"
 // @Failure     422 {object} constants.ErrorResponse "Password hashing error"
 // @Failure     500 {object} constants.ErrorResponse "Internal server error"
 // @Router      /v1/user/sign-up [post]
@@ -115,13 +115,7 @@ func (u *userHandler) SignUp(c *fiber.Ctx) error {
 	}
 
 	if err := u.userUs.UpsertUser(ctx, user, false, files); err != nil {
-		if ok := strings.Contains(err.Error(), constants.ERROR_USERNAME_WAS_DUPLICATED); ok {
-			return fiber.NewError(http.StatusBadRequest, err.Error())
-		}
-		if ok := strings.Contains(err.Error(), constants.ERROR_EMAIL_WAS_DUPLICATED); ok {
-			return fiber.NewError(http.StatusBadRequest, err.Error())
-		}
-		return fiber.NewError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	resp := map[string]interface{}{
@@ -139,7 +133,7 @@ func (u *userHandler) FetchOneUserInfoByUserId(c *fiber.Ctx) error {
 
 	userInfo, err := u.userUs.FetchOneUserInfoByUserId(ctx, &userId)
 	if err != nil {
-		return fiber.NewError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 	resp := map[string]interface{}{
 		"user_info": userInfo,
@@ -151,13 +145,13 @@ func (u *userHandler) FetchOneUserInfoByUserId(c *fiber.Ctx) error {
 // @Description Sign-in to system with email and password
 // @Tags        users
 // @Accept      multipart/form-data
-// @Produce     json
+// @Produce     json,application/problem+json
 // @Param       email formData string true "Email user"
 // @Param       password formData string true "Password user"
 // @Success     200 {object} map[string]interface{}
 // @Failure     400 {object} constants.ErrorResponse "email pattern is invalid"
 // @Failure     404 {object} constants.ErrorResponse "user not found"
-// @Failure     400 {object} constants.ErrorResponse "password is invalid"
+// @Failure     401 {object} constants.ErrorResponse "password is invalid"
 // @Failure     500 {object} constants.ErrorResponse  "Internal server error"
 // @Router      /v1/user/sign-in [post]
 func (u *userHandler) SignIn(c *fiber.Ctx) error {
@@ -171,13 +165,7 @@ func (u *userHandler) SignIn(c *fiber.Ctx) error {
 
 	userPassport, err := u.userUs.FetchUserPassport(ctx, user)
 	if err != nil {
-		if ok := strings.Contains(err.Error(), constants.ERROR_USER_NOT_FOUND); ok {
-			return fiber.NewError(http.StatusNotFound, err.Error())
-		}
-		if ok := strings.Contains(err.Error(), constants.ERROR_PASSWORD_IS_INVALID); ok {
-			return fiber.NewError(http.StatusBadRequest, err.Error())
-		}
-		return fiber.NewError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	resp := map[string]interface{}{
@@ -191,7 +179,7 @@ func (u *userHandler) SignIn(c *fiber.Ctx) error {
 // @Description create user info data
 // @Tags        users
 // @Accept      multipart/form-data
-// @Produce     json
+// @Produce     json,application/problem+json
 // @Param       user_id formData string true "username user" default:"d5fff3c1-b647-42c1-a177-07e8802df2c3"
 // @Param       age formData integer true "age user" default(25)
 // @Param       gender formData string true "gender user" default("male")
@@ -199,6 +187,7 @@ func (u *userHandler) SignIn(c *fiber.Ctx) error {
 // @Param       weight formData number true "weight user" default(80.0)
 // @Param       target_weight formData number true "target weight user" default(80.0)
 // @Param       active_level formData string true "active level user" default("active")
+// @Param       language formData string false "th or en, default is the request Accept-Language" default("th")
 // @Success     200 {object} map[string]interface{} "Successful response" example({"message":"successful","user_id":"uuid-123","username":"john_doe"})
 // @Failure     400 {object} constants.ErrorResponse "Invalid email format, duplicate username, or duplicate email"
 // @Failure     422 {object} constants.ErrorResponse "Password hashing error"
@@ -211,9 +200,13 @@ func (u *userHandler) CreateUserInfo(c *fiber.Ctx) error {
 	userInfo.NewID()
 	userInfo.SetCreatedAt()
 	userInfo.SetUpdatedAt()
+	if !userInfo.Language.IsValid() {
+		/* ไม่ได้ระบุภาษาใช้ภาษาที่ request ขอมา */
+		userInfo.Language = models.ResolveLanguage(ctx, nil)
+	}
 
 	if err := u.userUs.UpsertUserInfo(ctx, userInfo); err != nil {
-		return fiber.NewError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	resp := map[string]interface{}{
@@ -229,12 +222,12 @@ func (u *userHandler) UpdateUserInfo(c *fiber.Ctx) error {
 
 	existUserInfo, err := u.userUs.FetchOneUserInfoByUserId(ctx, &userId)
 	if err != nil {
-		return fiber.NewError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 	newUserInfo := models.NewUserInfoWithParams(params, existUserInfo)
 
 	if err := u.userUs.UpsertUserInfo(ctx, newUserInfo); err != nil {
-		return fiber.NewError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	resp := map[string]interface{}{
@@ -243,17 +236,54 @@ func (u *userHandler) UpdateUserInfo(c *fiber.Ctx) error {
 	return c.Status(http.StatusOK).JSON(resp)
 }
 
+// @Summary     UpdateFoodPreferences
+// @Description Replace liked, disliked and allergic foods of user, used when generating meal plans
+// @Tags        users
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id   path     string   true  "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       likes     formData []string false "liked foods"
+// @Param       dislikes  formData []string false "disliked foods"
+// @Param       allergies formData []string false "allergic foods"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     404 {object} constants.ErrorResponse "user info not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/user/info/{user_id}/preferences [put]
+func (u *userHandler) UpdateFoodPreferences(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	params := c.Locals("params").(map[string]interface{})
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+
+	userInfo, err := u.userUs.FetchOneUserInfoByUserId(ctx, &userId)
+	if err != nil {
+		return err
+	}
+	userInfo.FoodPreferences = models.NewFoodPreferencesWithParams(params, userInfo.Id)
+
+	if err := u.userUs.UpsertFoodPreferences(ctx, userInfo); err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message":          "successful",
+		"food_preferences": userInfo.FoodPreferences,
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
 // @Summary     SignUpAdmin
 // @Description Sign-up admin to system with email and password
 // @Tags        users
 // @Accept      multipart/form-data
-// @Produce     json
+// @Produce     json,application/problem+json
 // @Param       username formData string true "Username user"
 // @Param       email    formData string true "Email user" example:"example@odor.com"
 // @Param       password formData string true "Password user" example:"strongpassword123"
 // @Param       files    formData file   false "User profile image"
 // @Success     200 {object} map[string]interface{} "Successful response" example({"message":"successful","user_id":"uuid-123","username":"john_doe"})
-// @Failure     400 {object} constants.ErrorResponse "Invalid email format, duplicate username, or duplicate email"
+// @Failure     400 {object} constants.ErrorResponse "Invalid email format"
+// @Failure     409 {object} constants.ErrorResponse "Duplicate username or duplicate email"
 // @Failure     422 {object} constants.ErrorResponse "Password hashing error"
 // @Failure     500 {object} constants.ErrorResponse "Internal server error"
 // @Router      /v1/user/admin [post]
@@ -275,13 +305,7 @@ func (u *userHandler) SignUpAdmin(c *fiber.Ctx) error {
 	}
 
 	if err := u.userUs.UpsertUser(ctx, user, true, files); err != nil {
-		if ok := strings.Contains(err.Error(), constants.ERROR_USERNAME_WAS_DUPLICATED); ok {
-			return fiber.NewError(http.StatusBadRequest, err.Error())
-		}
-		if ok := strings.Contains(err.Error(), constants.ERROR_EMAIL_WAS_DUPLICATED); ok {
-			return fiber.NewError(http.StatusBadRequest, err.Error())
-		}
-		return fiber.NewError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	resp := map[string]interface{}{
@@ -297,7 +321,7 @@ func (u *userHandler) SignUpAdmin(c *fiber.Ctx) error {
 // @Description Refresh user passport
 // @Tags        users
 // @Accept      json
-// @Produce     json
+// @Produce     json,application/problem+json
 // @Param       refresh_token query string true "refresh_token"
 // @Success     200 {object} map[string]interface{}
 // @Failure     500 {object} constants.ErrorResponse
@@ -308,7 +332,7 @@ func (u *userHandler) RefreshUserPassport(c *fiber.Ctx) error {
 
 	passport, err := u.userUs.RefreshUserPassport(ctx, refreshToken)
 	if err != nil {
-		return fiber.NewError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 	resp := map[string]interface{}{
 		"passport": passport,
@@ -3,6 +3,7 @@ package handler
 import (
 	"errors"
 	"fmt"
+	"healthmatefood-api/middleware"
 	"healthmatefood-api/models"
 	user_mocks "healthmatefood-api/service/user/mocks"
 	"net/http"
@@ -39,7 +40,7 @@ func TestFetchAllUsers(t *testing.T) {
 		},
 	}
 	t.Run("success", func(t *testing.T) {
-		app := fiber.New()
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
 		userUs := new(user_mocks.IUserUsecase)
 		userUs.On("FetchAllUsers", mock.Anything, mock.AnythingOfType("*sync.Map")).Return(mockUsers, nil).Run(func(args mock.Arguments) {
 			epCtx := args.Get(0)
@@ -65,7 +66,7 @@ func TestFetchAllUsers(t *testing.T) {
 		assert.Equal(t, http.StatusOK, resp.StatusCode)
 	})
 	t.Run("error_internal_server", func(t *testing.T) {
-		app := fiber.New()
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
 		userUs := new(user_mocks.IUserUsecase)
 		userUs.On("FetchAllUsers", mock.Anything, mock.AnythingOfType("*sync.Map")).Return(nil, errors.New("unexpected")).Run(func(args mock.Arguments) {
 			epCtx := args.Get(0)
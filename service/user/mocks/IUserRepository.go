@@ -79,23 +79,23 @@ func (_m *IUserRepository) FetchOneOAuthByRefreshToken(ctx context.Context, refr
 }
 
 // FetchOneUserByEmail provides a mock function with given fields: ctx, email
-func (_m *IUserRepository) FetchOneUserByEmail(ctx context.Context, email string) (*models.User, error) {
+func (_m *IUserRepository) FetchOneUserByEmail(ctx context.Context, email string) (*models.UserSign, error) {
 	ret := _m.Called(ctx, email)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FetchOneUserByEmail")
 	}
 
-	var r0 *models.User
+	var r0 *models.UserSign
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.User, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.UserSign, error)); ok {
 		return rf(ctx, email)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.UserSign); ok {
 		r0 = rf(ctx, email)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.User)
+			r0 = ret.Get(0).(*models.UserSign)
 		}
 	}
 
@@ -109,23 +109,23 @@ func (_m *IUserRepository) FetchOneUserByEmail(ctx context.Context, email string
 }
 
 // FetchOneUserById provides a mock function with given fields: ctx, id
-func (_m *IUserRepository) FetchOneUserById(ctx context.Context, id *uuid.UUID) (*models.User, error) {
+func (_m *IUserRepository) FetchOneUserById(ctx context.Context, id *uuid.UUID) (*models.UserSign, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FetchOneUserById")
 	}
 
-	var r0 *models.User
+	var r0 *models.UserSign
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.User, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.UserSign, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.User); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.UserSign); ok {
 		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.User)
+			r0 = ret.Get(0).(*models.UserSign)
 		}
 	}
 
@@ -168,6 +168,24 @@ func (_m *IUserRepository) FetchOneUserInfoByUserId(ctx context.Context, userId
 	return r0, r1
 }
 
+// UpsertFoodPreferences provides a mock function with given fields: ctx, userInfo
+func (_m *IUserRepository) UpsertFoodPreferences(ctx context.Context, userInfo *models.UserInfo) error {
+	ret := _m.Called(ctx, userInfo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertFoodPreferences")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserInfo) error); ok {
+		r0 = rf(ctx, userInfo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpsertImages provides a mock function with given fields: ctx, _a1
 func (_m *IUserRepository) UpsertImages(ctx context.Context, _a1 *models.User) error {
 	ret := _m.Called(ctx, _a1)
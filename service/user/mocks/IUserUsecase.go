@@ -170,6 +170,24 @@ func (_m *IUserUsecase) RefreshUserPassport(ctx context.Context, refreshToken st
 	return r0, r1
 }
 
+// UpsertFoodPreferences provides a mock function with given fields: ctx, userInfo
+func (_m *IUserUsecase) UpsertFoodPreferences(ctx context.Context, userInfo *models.UserInfo) error {
+	ret := _m.Called(ctx, userInfo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertFoodPreferences")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserInfo) error); ok {
+		r0 = rf(ctx, userInfo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpsertUser provides a mock function with given fields: ctx, _a1, isAdmin, files
 func (_m *IUserUsecase) UpsertUser(ctx context.Context, _a1 *models.User, isAdmin bool, files []*multipart.FileHeader) error {
 	ret := _m.Called(ctx, _a1, isAdmin, files)
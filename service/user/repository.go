@@ -18,4 +18,5 @@ type IUserRepository interface {
 	UpsertImages(ctx context.Context, user *models.User) error
 	UpsertOAuth(ctx context.Context, oauth *models.OAuth) error
 	UpsertUserInfo(ctx context.Context, userInfo *models.UserInfo) error
+	UpsertFoodPreferences(ctx context.Context, userInfo *models.UserInfo) error
 }
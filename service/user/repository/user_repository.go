@@ -3,12 +3,11 @@ package repository
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"healthmatefood-api/apperror"
 	"healthmatefood-api/constants"
 	"healthmatefood-api/models"
 	"healthmatefood-api/service/user"
-	"strings"
 	"sync"
 
 	"github.com/Pheethy/psql/orm"
@@ -74,15 +73,53 @@ func (u *userRepository) FetchOneUserByEmail(ctx context.Context, email string)
               "user_info"."target",
               "user_info"."target_weight",
               "user_info"."active_level",
-              to_char("user_info"."dob", 'yyyy-MM-dd HH:mm:ss') "dob",
-              to_char("user_info"."created_at", 'yyyy-MM-dd HH:mm:ss') "created_at",
-              to_char("user_info"."updated_at", 'yyyy-MM-dd HH:mm:ss') "updated_at"
+              "user_info"."language",
+              to_char("user_info"."dob", 'yyyy-MM-dd HH24:MI:SS') "dob",
+              to_char("user_info"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+              to_char("user_info"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at",
+              (
+                SELECT
+                  COALESCE(array_to_json(array_agg("DIS")), '[]'::json)
+                FROM (
+                  SELECT
+                    "diseases"."id",
+                    "diseases"."name",
+                    COALESCE("diseases"."description", '') "description"
+                  FROM
+                    "user_diseases"
+                  INNER JOIN
+                    "diseases"
+                  ON
+                    "diseases"."id" = "user_diseases"."disease_id"
+                  WHERE
+                    "user_diseases"."user_info_id" = "user_info"."id"
+                  ORDER BY
+                    "diseases"."name" ASC
+                ) AS "DIS"
+              ) AS "diseases",
+              (
+                SELECT
+                  COALESCE(array_to_json(array_agg("PREF")), '[]'::json)
+                FROM (
+                  SELECT
+                    "user_food_preferences"."id",
+                    "user_food_preferences"."user_info_id",
+                    "user_food_preferences"."name",
+                    "user_food_preferences"."preference_type",
+                    to_char("user_food_preferences"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+                    to_char("user_food_preferences"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at"
+                  FROM
+                    "user_food_preferences"
+                  WHERE
+                    "user_food_preferences"."user_info_id" = "user_info"."id"
+                  ORDER BY
+                    "user_food_preferences"."created_at" ASC
+                ) AS "PREF"
+              ) AS "food_preferences"
             FROM
               "user_info"
-            INNER JOIN
-              "users"
-            ON
-              "users"."id" = "user_info"."user_id"
+            WHERE
+              "user_info"."user_id" = "users"."id"
           ) AS "INFO"
         ) AS "user_info"
       FROM
@@ -104,7 +141,7 @@ func (u *userRepository) FetchOneUserByEmail(ctx context.Context, email string)
 	var jsonData []byte
 	err = stmt.QueryRowxContext(ctx, email).Scan(&jsonData)
 	if err != nil {
-		return nil, err
+		return nil, apperror.FromDB(err, constants.ERROR_USER_NOT_FOUND)
 	}
 
 	user := new(models.UserSign)
@@ -162,15 +199,53 @@ func (u *userRepository) FetchOneUserById(ctx context.Context, id *uuid.UUID) (*
               "user_info"."target",
               "user_info"."target_weight",
               "user_info"."active_level",
-              to_char("user_info"."dob", 'yyyy-MM-dd HH:mm:ss') "dob",
-              to_char("user_info"."created_at", 'yyyy-MM-dd HH:mm:ss') "created_at",
-              to_char("user_info"."updated_at", 'yyyy-MM-dd HH:mm:ss') "updated_at"
+              "user_info"."language",
+              to_char("user_info"."dob", 'yyyy-MM-dd HH24:MI:SS') "dob",
+              to_char("user_info"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+              to_char("user_info"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at",
+              (
+                SELECT
+                  COALESCE(array_to_json(array_agg("DIS")), '[]'::json)
+                FROM (
+                  SELECT
+                    "diseases"."id",
+                    "diseases"."name",
+                    COALESCE("diseases"."description", '') "description"
+                  FROM
+                    "user_diseases"
+                  INNER JOIN
+                    "diseases"
+                  ON
+                    "diseases"."id" = "user_diseases"."disease_id"
+                  WHERE
+                    "user_diseases"."user_info_id" = "user_info"."id"
+                  ORDER BY
+                    "diseases"."name" ASC
+                ) AS "DIS"
+              ) AS "diseases",
+              (
+                SELECT
+                  COALESCE(array_to_json(array_agg("PREF")), '[]'::json)
+                FROM (
+                  SELECT
+                    "user_food_preferences"."id",
+                    "user_food_preferences"."user_info_id",
+                    "user_food_preferences"."name",
+                    "user_food_preferences"."preference_type",
+                    to_char("user_food_preferences"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+                    to_char("user_food_preferences"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at"
+                  FROM
+                    "user_food_preferences"
+                  WHERE
+                    "user_food_preferences"."user_info_id" = "user_info"."id"
+                  ORDER BY
+                    "user_food_preferences"."created_at" ASC
+                ) AS "PREF"
+              ) AS "food_preferences"
             FROM
               "user_info"
-            INNER JOIN
-              "users"
-            ON
-              "users"."id" = "user_info"."user_id"
+            WHERE
+              "user_info"."user_id" = "users"."id"
           ) AS "INFO"
         ) AS "user_info"
       FROM
@@ -192,7 +267,7 @@ func (u *userRepository) FetchOneUserById(ctx context.Context, id *uuid.UUID) (*
 	var jsonData []byte
 	err = stmt.QueryRowxContext(ctx, id).Scan(&jsonData)
 	if err != nil {
-		return nil, err
+		return nil, apperror.FromDB(err, constants.ERROR_USER_NOT_FOUND)
 	}
 
 	user := new(models.UserSign)
@@ -251,6 +326,7 @@ func (u *userRepository) FetchAllUsers(ctx context.Context, args *sync.Map) ([]*
               "user_info"."target",
               "user_info"."target_weight",
               "user_info"."active_level",
+              "user_info"."language",
               to_char("user_info"."dob", 'yyyy-MM-dd HH:mm:ss') "dob",
               to_char("user_info"."created_at", 'yyyy-MM-dd HH:mm:ss') "created_at",
               to_char("user_info"."updated_at", 'yyyy-MM-dd HH:mm:ss') "updated_at"
@@ -316,7 +392,7 @@ func (u *userRepository) FetchOneOAuthByRefreshToken(ctx context.Context, refres
 
 	jsonData := make([]byte, 0)
 	if err = stmt.QueryRowxContext(ctx, refreshToken).Scan(&jsonData); err != nil {
-		return nil, err
+		return nil, apperror.FromDB(err, constants.ERROR_OAUTH_NOT_FOUND)
 	}
 
 	oauth := new(models.OAuth)
@@ -335,14 +411,18 @@ func (u *userRepository) FetchOneUserInfoByUserId(ctx context.Context, userId *u
       SELECT
         "user_info"."id",
         "user_info"."user_id",
-        "user_info"."age",
+        "user_info"."firstname",
+        "user_info"."lastname",
         "user_info"."gender",
         "user_info"."height",
         "user_info"."weight",
+        "user_info"."target",
         "user_info"."target_weight",
         "user_info"."active_level",
-        "user_info"."created_at",
-        "user_info"."updated_at"
+        "user_info"."language",
+        to_char("user_info"."dob", 'yyyy-MM-dd HH24:MI:SS') "dob",
+        to_char("user_info"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+        to_char("user_info"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at"
       FROM
         "user_info"
       WHERE
@@ -359,7 +439,7 @@ func (u *userRepository) FetchOneUserInfoByUserId(ctx context.Context, userId *u
 	var jsonData []byte
 	err = stmt.QueryRowxContext(ctx, userId).Scan(&jsonData)
 	if err != nil {
-		return nil, err
+		return nil, apperror.FromDB(err, constants.ERROR_USER_INFO_NOT_FOUND)
 	}
 
 	userInfo := new(models.UserInfo)
@@ -484,13 +564,7 @@ func (u *userRepository) UpsertUser(ctx context.Context, user *models.User) erro
 	)
 	if err != nil {
 		tx.Rollback()
-		if ok := strings.Contains(err.Error(), constants.POSTGRES_ERROR_USERNAME_WAS_DUPLICATED); ok {
-			return errors.New(constants.ERROR_USERNAME_WAS_DUPLICATED)
-		}
-		if ok := strings.Contains(err.Error(), constants.POSTGRES_ERROR_EMAIL_WAS_DUPLICATED); ok {
-			return errors.New(constants.ERROR_EMAIL_WAS_DUPLICATED)
-		}
-		return err
+		return apperror.FromDB(err, "")
 	}
 	return tx.Commit()
 }
@@ -512,6 +586,7 @@ func (u *userRepository) UpsertUserInfo(ctx context.Context, userInfo *models.Us
       "target",
       "target_weight",
       "active_level",
+      "language",
       "dob",
       "created_at",
       "updated_at"
@@ -526,21 +601,24 @@ func (u *userRepository) UpsertUserInfo(ctx context.Context, userInfo *models.Us
       $8::target_type,
       $9::float,
       $10::active_level_type,
-      $11::timestamp,
+      $11::text,
       $12::timestamp,
-      $13::timestamp
+      $13::timestamp,
+      $14::timestamp
     )
 	ON CONFLICT (id)
 	DO UPDATE SET
-      firstname=$14::text,
-      lastname=$15::text,
-      gender=$16::gender_type,
-      height=$17::float,
-      weight=$18::float,
-      target=$19::target_type,
-      target_weight=$20::float,
-      dob=$21::timestamp,
-      updated_at=$22::timestamp
+      firstname=$15::text,
+      lastname=$16::text,
+      gender=$17::gender_type,
+      height=$18::float,
+      weight=$19::float,
+      target=$20::target_type,
+      target_weight=$21::float,
+      active_level=$22::active_level_type,
+      language=$23::text,
+      dob=$24::timestamp,
+      updated_at=$25::timestamp
   `
 	stmt, err := tx.PreparexContext(ctx, sql)
 	if err != nil {
@@ -557,10 +635,10 @@ func (u *userRepository) UpsertUserInfo(ctx context.Context, userInfo *models.Us
 		userInfo.Gender,
 		userInfo.Height,
 		userInfo.Weight,
-		userInfo.Weight,
 		userInfo.Target,
 		userInfo.TargetWeight,
 		userInfo.ActiveLevel,
+		userInfo.Language,
 		userInfo.DOB,
 		userInfo.CreatedAt,
 		userInfo.UpdatedAt,
@@ -570,17 +648,69 @@ func (u *userRepository) UpsertUserInfo(ctx context.Context, userInfo *models.Us
 		userInfo.Gender,
 		userInfo.Height,
 		userInfo.Weight,
-		userInfo.Weight,
 		userInfo.Target,
 		userInfo.TargetWeight,
 		userInfo.ActiveLevel,
+		userInfo.Language,
 		userInfo.DOB,
 		userInfo.UpdatedAt,
 	)
+	if err != nil {
+		tx.Rollback()
+		return apperror.FromDB(err, "")
+	}
+
+	return tx.Commit()
+}
+
+/* UpsertFoodPreferences แทนที่ความชอบด้านอาหารทั้งหมดของ user info ภายใน transaction เดียว */
+func (u *userRepository) UpsertFoodPreferences(ctx context.Context, userInfo *models.UserInfo) error {
+	tx, err := u.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM "user_food_preferences" WHERE "user_info_id" = $1::uuid`, userInfo.Id); err != nil {
+		tx.Rollback()
+		return apperror.FromDB(err, "")
+	}
+	sql := `
+    INSERT INTO "user_food_preferences" (
+      "id",
+      "user_info_id",
+      "name",
+      "preference_type",
+      "created_at",
+      "updated_at"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::text,
+      $4::food_preference_type,
+      $5::timestamp,
+      $6::timestamp
+    )
+  `
+	stmt, err := tx.PreparexContext(ctx, sql)
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
+	defer stmt.Close()
+
+	for index := range userInfo.FoodPreferences {
+		preference := userInfo.FoodPreferences[index]
+		if _, err := stmt.ExecContext(ctx,
+			preference.Id,
+			userInfo.Id,
+			preference.Name,
+			preference.PreferenceType,
+			preference.CreatedAt,
+			preference.UpdatedAt,
+		); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("exec food preference failed: %v", err)
+		}
+	}
 
 	return tx.Commit()
 }
@@ -633,7 +763,7 @@ func (u *userRepository) UpsertOAuth(ctx context.Context, oauth *models.OAuth) e
 	)
 	if err != nil {
 		tx.Rollback()
-		return err
+		return apperror.FromDB(err, "")
 	}
 	return tx.Commit()
 }
@@ -645,7 +775,7 @@ func (u *userRepository) ormOneUser(ctx context.Context, rows *sqlx.Rows) (*mode
 	}
 	users := mapping.GetData().([]*models.User)
 	if len(users) == 0 {
-		return nil, errors.New(constants.ERROR_USER_NOT_FOUND)
+		return nil, apperror.NotFound(constants.ERROR_USER_NOT_FOUND)
 	}
 	return users[0], nil
 }
@@ -657,7 +787,7 @@ func (u *userRepository) ormAllUser(ctx context.Context, rows *sqlx.Rows) ([]*mo
 	}
 	users := mapping.GetData().([]*models.User)
 	if len(users) == 0 {
-		return nil, errors.New(constants.ERROR_USER_NOT_FOUND)
+		return nil, apperror.NotFound(constants.ERROR_USER_NOT_FOUND)
 	}
 	return users, nil
 }
@@ -669,7 +799,7 @@ func (u *userRepository) ormOneOAuth(ctx context.Context, rows *sqlx.Rows) (*mod
 	}
 	oauths := mapping.GetData().([]*models.OAuth)
 	if len(oauths) == 0 {
-		return nil, errors.New(constants.ERROR_OAUTH_NOT_FOUND)
+		return nil, apperror.NotFound(constants.ERROR_OAUTH_NOT_FOUND)
 	}
 	return oauths[0], nil
 }
@@ -681,7 +811,7 @@ func (u *userRepository) ormOneUserInfo(ctx context.Context, rows *sqlx.Rows) (*
 	}
 	userInfo := mapping.GetData().([]*models.UserInfo)
 	if len(userInfo) == 0 {
-		return nil, errors.New(constants.ERROR_OAUTH_NOT_FOUND)
+		return nil, apperror.NotFound(constants.ERROR_OAUTH_NOT_FOUND)
 	}
 	return userInfo[0], nil
 }
@@ -16,5 +16,6 @@ type IUserUsecase interface {
 	FetchOneUserInfoByUserId(ctx context.Context, userId *uuid.UUID) (*models.UserInfo, error)
 	UpsertUser(ctx context.Context, user *models.User, isAdmin bool, files []*multipart.FileHeader) error
 	UpsertUserInfo(ctx context.Context, userInfo *models.UserInfo) error
+	UpsertFoodPreferences(ctx context.Context, userInfo *models.UserInfo) error
 	RefreshUserPassport(ctx context.Context, refreshToken string) (*models.UserPassport, error)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"healthmatefood-api/apperror"
 	"healthmatefood-api/config"
 	"healthmatefood-api/constants"
 	"healthmatefood-api/models"
@@ -48,7 +49,7 @@ func (u *userUsecase) FetchUserPassport(ctx context.Context, req *models.User) (
 
 	/* Compare password */
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		return nil, errors.New(constants.ERROR_PASSWORD_IS_INVALID)
+		return nil, apperror.Unauthorized(constants.ERROR_PASSWORD_IS_INVALID)
 	}
 
 	/* New Auth With Access Token */
@@ -136,6 +137,10 @@ func (u *userUsecase) UpsertUserInfo(ctx context.Context, userInfo *models.UserI
 	return u.userRepo.UpsertUserInfo(ctx, userInfo)
 }
 
+func (u *userUsecase) UpsertFoodPreferences(ctx context.Context, userInfo *models.UserInfo) error {
+	return u.userRepo.UpsertFoodPreferences(ctx, userInfo)
+}
+
 func (u *userUsecase) RefreshUserPassport(ctx context.Context, refreshToken string) (*models.UserPassport, error) {
 	passport := new(models.UserPassport)
 	token, err := u.authRepo.ParseToken(refreshToken)
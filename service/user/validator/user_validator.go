@@ -1,12 +1,14 @@
 package validator
 
 import (
-	"fmt"
-	"net/http"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
 
 	"github.com/Pheethy/psql/helper"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/gofiber/fiber/v2"
+	"github.com/spf13/cast"
 )
 
 type Validation struct{}
@@ -15,33 +17,31 @@ func (v Validation) ValidateSignUp() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		params := c.Locals("params").(map[string]interface{})
 		var key string
+		fields := apperror.Fields{}
 
 		/* key params */
 		key = "email"
-		email, emailOK := params[key]
-		if !emailOK {
-			return fiber.NewError(http.StatusBadRequest, fmt.Sprintf("%s: was missing on body", key))
-		}
-		if err := validation.Validate(email, validation.By(helper.ValidateTypeString)); err != nil {
-			return fiber.NewError(http.StatusBadRequest, fmt.Sprintf("%s: %s", key, err.Error()))
+		if email, ok := params[key]; !ok {
+			fields.Missing(key)
+		} else {
+			fields.Check(key, validation.Validate(email, validation.By(helper.ValidateTypeString)))
 		}
 
 		key = "username"
-		username, usernameOK := params[key]
-		if !usernameOK {
-			return fiber.NewError(http.StatusBadRequest, fmt.Sprintf("%s: was missing on body", key))
-		}
-		if err := validation.Validate(username, validation.By(helper.ValidateTypeString)); err != nil {
-			return fiber.NewError(http.StatusBadRequest, fmt.Sprintf("%s: %s", key, err.Error()))
+		if username, ok := params[key]; !ok {
+			fields.Missing(key)
+		} else {
+			fields.Check(key, validation.Validate(username, validation.By(helper.ValidateTypeString)))
 		}
 
 		key = "password"
-		password, passwordOK := params[key]
-		if !passwordOK {
-			return fiber.NewError(http.StatusBadRequest, fmt.Sprintf("%s: was missing on body", key))
+		if password, ok := params[key]; !ok {
+			fields.Missing(key)
+		} else {
+			fields.Check(key, validation.Validate(password, validation.By(helper.ValidateTypeString)))
 		}
-		if err := validation.Validate(password, validation.By(helper.ValidateTypeString)); err != nil {
-			return fiber.NewError(http.StatusBadRequest, fmt.Sprintf("%s: %s", key, err.Error()))
+		if err := fields.Err(); err != nil {
+			return err
 		}
 		return c.Next()
 	}
@@ -51,24 +51,24 @@ func (v Validation) ValidateCreateUser() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		params := c.Locals("params").(map[string]interface{})
 		var key string
+		fields := apperror.Fields{}
 
 		/* key params */
 		key = "username"
-		username, usernameOK := params[key]
-		if !usernameOK {
-			return fiber.NewError(http.StatusBadRequest, fmt.Sprintf("%s: was missing on body", key))
-		}
-		if err := validation.Validate(username, validation.By(helper.ValidateTypeString)); err != nil {
-			return fiber.NewError(http.StatusBadRequest, fmt.Sprintf("%s: %s", key, err.Error()))
+		if username, ok := params[key]; !ok {
+			fields.Missing(key)
+		} else {
+			fields.Check(key, validation.Validate(username, validation.By(helper.ValidateTypeString)))
 		}
 
 		key = "password"
-		password, passwordOK := params[key]
-		if !passwordOK {
-			return fiber.NewError(http.StatusBadRequest, fmt.Sprintf("%s: was missing on body", key))
+		if password, ok := params[key]; !ok {
+			fields.Missing(key)
+		} else {
+			fields.Check(key, validation.Validate(password, validation.By(helper.ValidateTypeString)))
 		}
-		if err := validation.Validate(password, validation.By(helper.ValidateTypeString)); err != nil {
-			return fiber.NewError(http.StatusBadRequest, fmt.Sprintf("%s: %s", key, err.Error()))
+		if err := fields.Err(); err != nil {
+			return err
 		}
 		return c.Next()
 	}
@@ -78,24 +78,61 @@ func (v Validation) ValidateSignIn() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		params := c.Locals("params").(map[string]interface{})
 		var key string
+		fields := apperror.Fields{}
 
 		/* key params */
 		key = "email"
-		email, emailOK := params[key]
-		if !emailOK {
-			return fiber.NewError(http.StatusBadRequest, fmt.Sprintf("%s: was missing on body", key))
-		}
-		if err := validation.Validate(email, validation.By(helper.ValidateTypeString)); err != nil {
-			return fiber.NewError(http.StatusBadRequest, fmt.Sprintf("%s: %s", key, err.Error()))
+		if email, ok := params[key]; !ok {
+			fields.Missing(key)
+		} else {
+			fields.Check(key, validation.Validate(email, validation.By(helper.ValidateTypeString)))
 		}
 
 		key = "password"
-		password, passwordOK := params[key]
-		if !passwordOK {
-			return fiber.NewError(http.StatusBadRequest, fmt.Sprintf("%s: was missing on body", key))
+		if password, ok := params[key]; !ok {
+			fields.Missing(key)
+		} else {
+			fields.Check(key, validation.Validate(password, validation.By(helper.ValidateTypeString)))
+		}
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateUpdateFoodPreferences() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		if params == nil {
+			return apperror.Validation(constants.ERROR_BODY_WAS_MISSING)
+		}
+		fields := apperror.Fields{}
+		for key := range models.FoodPreferenceParams {
+			names, ok := params[key]
+			if !ok {
+				continue
+			}
+			if _, err := cast.ToStringSliceE(names); err != nil {
+				fields.Add(key, "is not type array of string")
+			}
 		}
-		if err := validation.Validate(password, validation.By(helper.ValidateTypeString)); err != nil {
-			return fiber.NewError(http.StatusBadRequest, fmt.Sprintf("%s: %s", key, err.Error()))
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+/* ValidateUserInfoLanguage ตรวจเฉพาะภาษาที่ผู้ใช้เลือก ถ้าส่งมาต้องเป็นภาษาที่รองรับ */
+func (v Validation) ValidateUserInfoLanguage() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		key := "language"
+		if language, ok := params[key]; ok {
+			if _, ok := models.ParseLanguage(cast.ToString(language)); !ok {
+				return apperror.Field(key, constants.ERROR_LANGUAGE_IS_INVALID)
+			}
 		}
 		return c.Next()
 	}
@@ -105,7 +142,7 @@ func (v Validation) ValidateParams(key string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		params := c.Params(key)
 		if err := validation.Validate(params, validation.By(helper.ValidateTypeUUID)); err != nil {
-			return fiber.NewError(http.StatusBadRequest, fmt.Sprintf("%s: %s", key, err.Error()))
+			return apperror.Field(key, err.Error())
 		}
 		return c.Next()
 	}
@@ -0,0 +1,12 @@
+package water
+
+import "github.com/gofiber/fiber/v2"
+
+type IWaterHandler interface {
+	FetchAllWaterLogs(c *fiber.Ctx) error
+	FetchDailyWaterSummary(c *fiber.Ctx) error
+	FetchWeeklyWaterSummary(c *fiber.Ctx) error
+	CreateWaterLog(c *fiber.Ctx) error
+	UpdateWaterLog(c *fiber.Ctx) error
+	DeleteWaterLog(c *fiber.Ctx) error
+}
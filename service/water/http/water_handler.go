@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/water"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+)
+
+type waterHandler struct {
+	waterUs water.IWaterUsecase
+}
+
+func NewWaterHandler(waterUs water.IWaterUsecase) water.IWaterHandler {
+	return &waterHandler{
+		waterUs: waterUs,
+	}
+}
+
+// @Summary     FetchAllWaterLogs
+// @Description Get water logs of user on a date
+// @Tags        water
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id path  string true  "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       date    query string false "example: 2025-03-01 (default today)"
+// @Success     200     {object}     map[string]interface{}
+// @Failure     400     {object}     constants.ErrorResponse
+// @Failure     500     {object}     constants.ErrorResponse
+// @Router      /v1/water/{user_id} [get]
+func (w *waterHandler) FetchAllWaterLogs(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	args := new(sync.Map)
+	args.Store("user_id", &userId)
+	args.Store("drank_at", queryDate(c).String())
+
+	waterLogs, err := w.waterUs.FetchAllWaterLogs(ctx, args)
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"water_logs": waterLogs,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     FetchDailyWaterSummary
+// @Description Compare water drunk on a date with the target from user weight and activity level
+// @Tags        water
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id path  string true  "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       date    query string false "example: 2025-03-01 (default today)"
+// @Success     200     {object}     map[string]interface{}
+// @Failure     400     {object}     constants.ErrorResponse
+// @Failure     404     {object}     constants.ErrorResponse "user info not found"
+// @Failure     500     {object}     constants.ErrorResponse
+// @Router      /v1/water/{user_id}/summary [get]
+func (w *waterHandler) FetchDailyWaterSummary(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+
+	summary, err := w.waterUs.FetchDailyWaterSummary(ctx, &userId, queryDate(c))
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"summary": summary,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     FetchWeeklyWaterSummary
+// @Description Get water progress of the 7 days ending on a date
+// @Tags        water
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id path  string true  "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       date    query string false "last day of the week, example: 2025-03-07 (default today)"
+// @Success     200     {object}     map[string]interface{}
+// @Failure     400     {object}     constants.ErrorResponse
+// @Failure     404     {object}     constants.ErrorResponse "user info not found"
+// @Failure     500     {object}     constants.ErrorResponse
+// @Router      /v1/water/{user_id}/summary/weekly [get]
+func (w *waterHandler) FetchWeeklyWaterSummary(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+
+	summary, err := w.waterUs.FetchWeeklyWaterSummary(ctx, &userId, queryDate(c))
+	if err != nil {
+		return err
+	}
+	resp := map[string]interface{}{
+		"summary": summary,
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     CreateWaterLog
+// @Description Record a drink in ml
+// @Tags        water
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id    path     string true  "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       amount_ml  formData number true  "amount in ml"
+// @Param       drink_type formData string false "example: WATER, TEA, MILK" default(WATER)
+// @Param       drank_at   formData string false "example: 2025-03-01 (default today)"
+// @Param       note       formData string false "note"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/water/{user_id} [post]
+func (w *waterHandler) CreateWaterLog(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	params := c.Locals("params").(map[string]interface{})
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	waterLog := models.NewWaterLogWithParams(params, nil)
+	waterLog.NewID()
+	waterLog.UserId = &userId
+	waterLog.SetCreatedAt()
+	waterLog.SetUpdatedAt()
+
+	if err := w.waterUs.UpsertWaterLog(ctx, waterLog); err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message":   "successful",
+		"water_log": waterLog,
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     UpdateWaterLog
+// @Description Edit a water log
+// @Tags        water
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id path string true "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       log_id  path string true "water log id"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} constants.ErrorResponse
+// @Failure     404 {object} constants.ErrorResponse "water log not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/water/{user_id}/{log_id} [put]
+func (w *waterHandler) UpdateWaterLog(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	params := c.Locals("params").(map[string]interface{})
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	logId := uuid.FromStringOrNil(c.Params("log_id"))
+
+	existLog, err := w.fetchOwnWaterLog(c, &userId, &logId)
+	if err != nil {
+		return err
+	}
+	newLog := models.NewWaterLogWithParams(params, existLog)
+	newLog.SetUpdatedAt()
+
+	if err := w.waterUs.UpsertWaterLog(ctx, newLog); err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message":   "successful",
+		"water_log": newLog,
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// @Summary     DeleteWaterLog
+// @Description Delete a water log
+// @Tags        water
+// @Accept      json
+// @Produce     json,application/problem+json
+// @Param       user_id path string true "example:98ba2fe1-95c9-420b-80bd-8e86b3a29a6f"
+// @Param       log_id  path string true "water log id"
+// @Success     200 {object} map[string]interface{}
+// @Failure     404 {object} constants.ErrorResponse "water log not found"
+// @Failure     500 {object} constants.ErrorResponse
+// @Router      /v1/water/{user_id}/{log_id} [delete]
+func (w *waterHandler) DeleteWaterLog(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	userId := uuid.FromStringOrNil(c.Params("user_id"))
+	logId := uuid.FromStringOrNil(c.Params("log_id"))
+
+	if _, err := w.fetchOwnWaterLog(c, &userId, &logId); err != nil {
+		return err
+	}
+	if err := w.waterUs.DeleteWaterLog(ctx, &logId); err != nil {
+		return err
+	}
+
+	resp := map[string]interface{}{
+		"message": "successful",
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+/* fetchOwnWaterLog ดึงรายการและตรวจว่าเป็นของผู้ใช้ตาม path ไม่เช่นนั้นถือว่าไม่พบ */
+func (w *waterHandler) fetchOwnWaterLog(c *fiber.Ctx, userId *uuid.UUID, logId *uuid.UUID) (*models.WaterLog, error) {
+	waterLog, err := w.waterUs.FetchOneWaterLogById(c.UserContext(), logId)
+	if err != nil {
+		return nil, err
+	}
+	if waterLog.UserId == nil || *waterLog.UserId != *userId {
+		return nil, apperror.NotFound(constants.ERROR_WATER_LOG_NOT_FOUND)
+	}
+	return waterLog, nil
+}
+
+func queryDate(c *fiber.Ctx) *helper.Date {
+	var date helper.Date
+	if dateStr := c.Query("date"); dateStr != "" {
+		date = helper.NewDateFromString(dateStr)
+	} else {
+		date = helper.NewDateFromTime(time.Now())
+	}
+	return &date
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"encoding/json"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/middleware"
+	"healthmatefood-api/models"
+	water_mocks "healthmatefood-api/service/water/mocks"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFetchWeeklyWaterSummary(t *testing.T) {
+	userId := uuid.FromStringOrNil("98ba2fe1-95c9-420b-80bd-8e86b3a29a6f")
+	date := helper.NewDateFromString("2025-03-07")
+	day1 := helper.NewDateFromString("2025-03-01")
+	day7 := helper.NewDateFromString("2025-03-07")
+	totals := []*models.WaterDailyTotal{
+		{UserId: &userId, Date: &day1, TotalMl: 2000, LogCount: 8},
+		{UserId: &userId, Date: &day7, TotalMl: 1000, LogCount: 4},
+	}
+	t.Run("success", func(t *testing.T) {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		waterUs := new(water_mocks.IWaterUsecase)
+		waterUs.On("FetchWeeklyWaterSummary", mock.Anything, &userId, &date).Return(models.NewWaterWeeklySummary(&userId, &date, 2000, totals), nil)
+		waterHandler := NewWaterHandler(waterUs)
+		app.Get("/v1/water/:user_id/summary/weekly", waterHandler.FetchWeeklyWaterSummary)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/water/"+userId.String()+"/summary/weekly?date=2025-03-07", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		body, _ := io.ReadAll(resp.Body)
+		result := map[string]*models.WaterWeeklySummary{}
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, "2025-03-01", result["summary"].StartDate.String())
+		assert.Len(t, result["summary"].Days, 7)
+		assert.Equal(t, float64(3000), result["summary"].Consumed)
+		assert.Equal(t, 1, result["summary"].DaysReached)
+		assert.Equal(t, float64(50), result["summary"].Days[6].Progress)
+		assert.Equal(t, float64(0), result["summary"].Days[3].Consumed)
+	})
+	t.Run("error_user_info_not_found", func(t *testing.T) {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		waterUs := new(water_mocks.IWaterUsecase)
+		waterUs.On("FetchWeeklyWaterSummary", mock.Anything, &userId, &date).Return(nil, apperror.NotFound(constants.ERROR_USER_INFO_NOT_FOUND))
+		waterHandler := NewWaterHandler(waterUs)
+		app.Get("/v1/water/:user_id/summary/weekly", waterHandler.FetchWeeklyWaterSummary)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/water/"+userId.String()+"/summary/weekly?date=2025-03-07", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,159 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	models "healthmatefood-api/models"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sync "sync"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IWaterRepository is an autogenerated mock type for the IWaterRepository type
+type IWaterRepository struct {
+	mock.Mock
+}
+
+// DeleteWaterLog provides a mock function with given fields: ctx, id
+func (_m *IWaterRepository) DeleteWaterLog(ctx context.Context, id *uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWaterLog")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchAllWaterDailyTotals provides a mock function with given fields: ctx, args
+func (_m *IWaterRepository) FetchAllWaterDailyTotals(ctx context.Context, args *sync.Map) ([]*models.WaterDailyTotal, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllWaterDailyTotals")
+	}
+
+	var r0 []*models.WaterDailyTotal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.WaterDailyTotal, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.WaterDailyTotal); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.WaterDailyTotal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchAllWaterLogs provides a mock function with given fields: ctx, args
+func (_m *IWaterRepository) FetchAllWaterLogs(ctx context.Context, args *sync.Map) ([]*models.WaterLog, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllWaterLogs")
+	}
+
+	var r0 []*models.WaterLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.WaterLog, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.WaterLog); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.WaterLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchOneWaterLogById provides a mock function with given fields: ctx, id
+func (_m *IWaterRepository) FetchOneWaterLogById(ctx context.Context, id *uuid.UUID) (*models.WaterLog, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOneWaterLogById")
+	}
+
+	var r0 *models.WaterLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.WaterLog, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.WaterLog); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WaterLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertWaterLog provides a mock function with given fields: ctx, waterLog
+func (_m *IWaterRepository) UpsertWaterLog(ctx context.Context, waterLog *models.WaterLog) error {
+	ret := _m.Called(ctx, waterLog)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertWaterLog")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WaterLog) error); ok {
+		r0 = rf(ctx, waterLog)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIWaterRepository creates a new instance of IWaterRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIWaterRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IWaterRepository {
+	mock := &IWaterRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
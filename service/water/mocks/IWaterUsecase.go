@@ -0,0 +1,191 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	helper "github.com/Pheethy/psql/helper"
+	mock "github.com/stretchr/testify/mock"
+
+	models "healthmatefood-api/models"
+
+	sync "sync"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// IWaterUsecase is an autogenerated mock type for the IWaterUsecase type
+type IWaterUsecase struct {
+	mock.Mock
+}
+
+// DeleteWaterLog provides a mock function with given fields: ctx, id
+func (_m *IWaterUsecase) DeleteWaterLog(ctx context.Context, id *uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWaterLog")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchAllWaterLogs provides a mock function with given fields: ctx, args
+func (_m *IWaterUsecase) FetchAllWaterLogs(ctx context.Context, args *sync.Map) ([]*models.WaterLog, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchAllWaterLogs")
+	}
+
+	var r0 []*models.WaterLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) ([]*models.WaterLog, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *sync.Map) []*models.WaterLog); ok {
+		r0 = rf(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.WaterLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *sync.Map) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchDailyWaterSummary provides a mock function with given fields: ctx, userId, date
+func (_m *IWaterUsecase) FetchDailyWaterSummary(ctx context.Context, userId *uuid.UUID, date *helper.Date) (*models.WaterSummary, error) {
+	ret := _m.Called(ctx, userId, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchDailyWaterSummary")
+	}
+
+	var r0 *models.WaterSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *helper.Date) (*models.WaterSummary, error)); ok {
+		return rf(ctx, userId, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *helper.Date) *models.WaterSummary); ok {
+		r0 = rf(ctx, userId, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WaterSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, *helper.Date) error); ok {
+		r1 = rf(ctx, userId, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchOneWaterLogById provides a mock function with given fields: ctx, id
+func (_m *IWaterUsecase) FetchOneWaterLogById(ctx context.Context, id *uuid.UUID) (*models.WaterLog, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchOneWaterLogById")
+	}
+
+	var r0 *models.WaterLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*models.WaterLog, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.WaterLog); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WaterLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchWeeklyWaterSummary provides a mock function with given fields: ctx, userId, endDate
+func (_m *IWaterUsecase) FetchWeeklyWaterSummary(ctx context.Context, userId *uuid.UUID, endDate *helper.Date) (*models.WaterWeeklySummary, error) {
+	ret := _m.Called(ctx, userId, endDate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FetchWeeklyWaterSummary")
+	}
+
+	var r0 *models.WaterWeeklySummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *helper.Date) (*models.WaterWeeklySummary, error)); ok {
+		return rf(ctx, userId, endDate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *helper.Date) *models.WaterWeeklySummary); ok {
+		r0 = rf(ctx, userId, endDate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WaterWeeklySummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, *helper.Date) error); ok {
+		r1 = rf(ctx, userId, endDate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertWaterLog provides a mock function with given fields: ctx, waterLog
+func (_m *IWaterUsecase) UpsertWaterLog(ctx context.Context, waterLog *models.WaterLog) error {
+	ret := _m.Called(ctx, waterLog)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertWaterLog")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WaterLog) error); ok {
+		r0 = rf(ctx, waterLog)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIWaterUsecase creates a new instance of IWaterUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIWaterUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IWaterUsecase {
+	mock := &IWaterUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,17 @@
+package water
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+
+	"github.com/gofrs/uuid"
+)
+
+type IWaterRepository interface {
+	FetchAllWaterLogs(ctx context.Context, args *sync.Map) ([]*models.WaterLog, error)
+	FetchOneWaterLogById(ctx context.Context, id *uuid.UUID) (*models.WaterLog, error)
+	FetchAllWaterDailyTotals(ctx context.Context, args *sync.Map) ([]*models.WaterDailyTotal, error)
+	UpsertWaterLog(ctx context.Context, waterLog *models.WaterLog) error
+	DeleteWaterLog(ctx context.Context, id *uuid.UUID) error
+}
@@ -0,0 +1,328 @@
+package repository
+
+import (
+	"context"
+	stdsql "database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/water"
+	"strings"
+	"sync"
+
+	"github.com/Pheethy/sqlx"
+	"github.com/gofrs/uuid"
+)
+
+type waterRepository struct {
+	psqlDB *sqlx.DB
+}
+
+func NewWaterRepository(psqlDB *sqlx.DB) water.IWaterRepository {
+	return &waterRepository{
+		psqlDB: psqlDB,
+	}
+}
+
+const selectWaterLog = `
+        "water_logs"."id",
+        "water_logs"."user_id",
+        "water_logs"."amount_ml",
+        "water_logs"."drink_type",
+        COALESCE("water_logs"."note", '') "note",
+        to_char("water_logs"."drank_at", 'yyyy-MM-dd') "drank_at",
+        to_char("water_logs"."created_at", 'yyyy-MM-dd HH24:MI:SS') "created_at",
+        to_char("water_logs"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at"`
+
+func (w *waterRepository) FetchAllWaterLogs(ctx context.Context, args *sync.Map) ([]*models.WaterLog, error) {
+	var conds []interface{}
+	var wheres []string
+	if userId, ok := args.Load("user_id"); ok {
+		conds = append(conds, userId)
+		wheres = append(wheres, fmt.Sprintf(`"water_logs"."user_id" = $%d::uuid`, len(conds)))
+	}
+	if drankAt, ok := args.Load("drank_at"); ok {
+		conds = append(conds, drankAt)
+		wheres = append(wheres, fmt.Sprintf(`"water_logs"."drank_at" = $%d::date`, len(conds)))
+	}
+	where := ""
+	if len(wheres) > 0 {
+		where = "WHERE " + strings.Join(wheres, " AND ")
+	}
+
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        %s
+      FROM
+        "water_logs"
+      %s
+      ORDER BY
+        "water_logs"."drank_at" ASC,
+        "water_logs"."created_at" ASC
+    ) AS "json_data"
+  `, selectWaterLog, where)
+
+	stmt, err := w.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, conds...).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	waterLogs := make([]*models.WaterLog, 0)
+	if err := json.Unmarshal(jsonData, &waterLogs); err != nil {
+		return nil, err
+	}
+
+	return waterLogs, nil
+}
+
+func (w *waterRepository) FetchOneWaterLogById(ctx context.Context, id *uuid.UUID) (*models.WaterLog, error) {
+	sql := fmt.Sprintf(`
+    SELECT
+      to_jsonb("json_data")
+    FROM (
+      SELECT
+        %s
+      FROM
+        "water_logs"
+      WHERE
+        "water_logs"."id" = $1::uuid
+    ) AS "json_data"
+  `, selectWaterLog)
+
+	stmt, err := w.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, id).Scan(&jsonData); err != nil {
+		return nil, apperror.FromDB(err, constants.ERROR_WATER_LOG_NOT_FOUND)
+	}
+
+	waterLog := new(models.WaterLog)
+	if err := json.Unmarshal(jsonData, &waterLog); err != nil {
+		return nil, err
+	}
+
+	return waterLog, nil
+}
+
+func (w *waterRepository) FetchAllWaterDailyTotals(ctx context.Context, args *sync.Map) ([]*models.WaterDailyTotal, error) {
+	var conds []interface{}
+	var wheres []string
+	if userId, ok := args.Load("user_id"); ok {
+		conds = append(conds, userId)
+		wheres = append(wheres, fmt.Sprintf(`"water_daily_totals"."user_id" = $%d::uuid`, len(conds)))
+	}
+	if startDate, ok := args.Load("start_date"); ok {
+		conds = append(conds, startDate)
+		wheres = append(wheres, fmt.Sprintf(`"water_daily_totals"."date" >= $%d::date`, len(conds)))
+	}
+	if endDate, ok := args.Load("end_date"); ok {
+		conds = append(conds, endDate)
+		wheres = append(wheres, fmt.Sprintf(`"water_daily_totals"."date" <= $%d::date`, len(conds)))
+	}
+	where := ""
+	if len(wheres) > 0 {
+		where = "WHERE " + strings.Join(wheres, " AND ")
+	}
+
+	sql := fmt.Sprintf(`
+    SELECT
+      COALESCE(array_to_json(array_agg("json_data")), '[]'::json)
+    FROM (
+      SELECT
+        "water_daily_totals"."user_id",
+        to_char("water_daily_totals"."date", 'yyyy-MM-dd') "date",
+        "water_daily_totals"."total_ml",
+        "water_daily_totals"."log_count",
+        to_char("water_daily_totals"."updated_at", 'yyyy-MM-dd HH24:MI:SS') "updated_at"
+      FROM
+        "water_daily_totals"
+      %s
+      ORDER BY
+        "water_daily_totals"."date" ASC
+    ) AS "json_data"
+  `, where)
+
+	stmt, err := w.psqlDB.PreparexContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	var jsonData []byte
+	if err := stmt.QueryRowxContext(ctx, conds...).Scan(&jsonData); err != nil {
+		return nil, err
+	}
+
+	totals := make([]*models.WaterDailyTotal, 0)
+	if err := json.Unmarshal(jsonData, &totals); err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
+
+func (w *waterRepository) UpsertWaterLog(ctx context.Context, waterLog *models.WaterLog) error {
+	tx, err := w.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+
+	/* วันที่เดิมของรายการ ถ้าย้ายวันต้องคำนวณยอดรวมของวันเดิมใหม่ด้วย */
+	var prevDate string
+	if err := tx.QueryRowxContext(ctx, `SELECT to_char("drank_at", 'yyyy-MM-dd') FROM "water_logs" WHERE "id" = $1::uuid`, waterLog.Id).Scan(&prevDate); err != nil && !errors.Is(err, stdsql.ErrNoRows) {
+		tx.Rollback()
+		return err
+	}
+
+	sql := `
+    INSERT INTO "water_logs" (
+      "id",
+      "user_id",
+      "amount_ml",
+      "drink_type",
+      "note",
+      "drank_at",
+      "created_at",
+      "updated_at"
+    ) VALUES (
+      $1::uuid,
+      $2::uuid,
+      $3::float,
+      $4::text,
+      $5::text,
+      $6::date,
+      $7::timestamp,
+      $8::timestamp
+    )
+    ON CONFLICT (id)
+    DO UPDATE SET
+      amount_ml=$9::float,
+      drink_type=$10::text,
+      note=$11::text,
+      drank_at=$12::date,
+      updated_at=$13::timestamp
+  `
+	stmt, err := tx.PreparexContext(ctx, sql)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.ExecContext(ctx,
+		/* Create */
+		waterLog.Id,
+		waterLog.UserId,
+		waterLog.AmountMl,
+		waterLog.DrinkType,
+		waterLog.Note,
+		waterLog.DrankAt.String(),
+		waterLog.CreatedAt,
+		waterLog.UpdatedAt,
+		/* Update */
+		waterLog.AmountMl,
+		waterLog.DrinkType,
+		waterLog.Note,
+		waterLog.DrankAt.String(),
+		waterLog.UpdatedAt,
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := w.refreshWaterDailyTotal(ctx, tx, waterLog.UserId, waterLog.DrankAt.String()); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if prevDate != "" && prevDate != waterLog.DrankAt.String() {
+		if err := w.refreshWaterDailyTotal(ctx, tx, waterLog.UserId, prevDate); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (w *waterRepository) DeleteWaterLog(ctx context.Context, id *uuid.UUID) error {
+	tx, err := w.psqlDB.Beginx()
+	if err != nil {
+		return err
+	}
+	sql := `
+    DELETE FROM
+      "water_logs"
+    WHERE
+      "water_logs"."id" = $1::uuid
+    RETURNING
+      "water_logs"."user_id",
+      to_char("water_logs"."drank_at", 'yyyy-MM-dd')
+  `
+	stmt, err := tx.PreparexContext(ctx, sql)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	var userId uuid.UUID
+	var drankAt string
+	if err := stmt.QueryRowxContext(ctx, id).Scan(&userId, &drankAt); err != nil {
+		tx.Rollback()
+		return apperror.FromDB(err, constants.ERROR_WATER_LOG_NOT_FOUND)
+	}
+	if err := w.refreshWaterDailyTotal(ctx, tx, &userId, drankAt); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+/* refreshWaterDailyTotal รวมยอดของวันนั้นจาก water_logs ใหม่แล้วเขียนทับลง water_daily_totals */
+func (w *waterRepository) refreshWaterDailyTotal(ctx context.Context, tx *sqlx.Tx, userId *uuid.UUID, date string) error {
+	sql := `
+    INSERT INTO "water_daily_totals" (
+      "user_id",
+      "date",
+      "total_ml",
+      "log_count",
+      "updated_at"
+    )
+    SELECT
+      $1::uuid,
+      $2::date,
+      COALESCE(SUM("water_logs"."amount_ml"), 0),
+      COUNT("water_logs"."id"),
+      now()
+    FROM
+      "water_logs"
+    WHERE
+      "water_logs"."user_id" = $1::uuid
+      AND "water_logs"."drank_at" = $2::date
+    ON CONFLICT ("user_id", "date")
+    DO UPDATE SET
+      total_ml=EXCLUDED.total_ml,
+      log_count=EXCLUDED.log_count,
+      updated_at=EXCLUDED.updated_at
+  `
+	_, err := tx.ExecContext(ctx, sql, userId, date)
+	return err
+}
@@ -0,0 +1,19 @@
+package water
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"sync"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+)
+
+type IWaterUsecase interface {
+	FetchAllWaterLogs(ctx context.Context, args *sync.Map) ([]*models.WaterLog, error)
+	FetchOneWaterLogById(ctx context.Context, id *uuid.UUID) (*models.WaterLog, error)
+	FetchDailyWaterSummary(ctx context.Context, userId *uuid.UUID, date *helper.Date) (*models.WaterSummary, error)
+	FetchWeeklyWaterSummary(ctx context.Context, userId *uuid.UUID, endDate *helper.Date) (*models.WaterWeeklySummary, error)
+	UpsertWaterLog(ctx context.Context, waterLog *models.WaterLog) error
+	DeleteWaterLog(ctx context.Context, id *uuid.UUID) error
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"healthmatefood-api/models"
+	"healthmatefood-api/service/user"
+	"healthmatefood-api/service/water"
+	"sync"
+	"time"
+
+	"github.com/Pheethy/psql/helper"
+	"github.com/gofrs/uuid"
+)
+
+type waterUsecase struct {
+	waterRepo water.IWaterRepository
+	userUs    user.IUserUsecase
+}
+
+func NewWaterUsecase(waterRepo water.IWaterRepository, userUs user.IUserUsecase) water.IWaterUsecase {
+	return &waterUsecase{
+		waterRepo: waterRepo,
+		userUs:    userUs,
+	}
+}
+
+func (w *waterUsecase) FetchAllWaterLogs(ctx context.Context, args *sync.Map) ([]*models.WaterLog, error) {
+	return w.waterRepo.FetchAllWaterLogs(ctx, args)
+}
+
+func (w *waterUsecase) FetchOneWaterLogById(ctx context.Context, id *uuid.UUID) (*models.WaterLog, error) {
+	return w.waterRepo.FetchOneWaterLogById(ctx, id)
+}
+
+func (w *waterUsecase) FetchDailyWaterSummary(ctx context.Context, userId *uuid.UUID, date *helper.Date) (*models.WaterSummary, error) {
+	userInfo, err := w.userUs.FetchOneUserInfoByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	userInfo.GetWaterTarget()
+
+	args := new(sync.Map)
+	args.Store("user_id", userId)
+	args.Store("drank_at", date.String())
+	logs, err := w.waterRepo.FetchAllWaterLogs(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	var consumed float64
+	for index := range logs {
+		consumed += logs[index].AmountMl
+	}
+
+	return models.NewWaterSummary(userId, date, userInfo.WaterTarget, consumed, logs), nil
+}
+
+func (w *waterUsecase) FetchWeeklyWaterSummary(ctx context.Context, userId *uuid.UUID, endDate *helper.Date) (*models.WaterWeeklySummary, error) {
+	userInfo, err := w.userUs.FetchOneUserInfoByUserId(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	userInfo.GetWaterTarget()
+
+	args := new(sync.Map)
+	args.Store("user_id", userId)
+	args.Store("start_date", time.Time(*endDate).AddDate(0, 0, -6).Format(helper.DateLayout))
+	args.Store("end_date", endDate.String())
+	totals, err := w.waterRepo.FetchAllWaterDailyTotals(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewWaterWeeklySummary(userId, endDate, userInfo.WaterTarget, totals), nil
+}
+
+func (w *waterUsecase) UpsertWaterLog(ctx context.Context, waterLog *models.WaterLog) error {
+	waterLog.SetDefault()
+	return w.waterRepo.UpsertWaterLog(ctx, waterLog)
+}
+
+func (w *waterUsecase) DeleteWaterLog(ctx context.Context, id *uuid.UUID) error {
+	return w.waterRepo.DeleteWaterLog(ctx, id)
+}
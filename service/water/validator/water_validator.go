@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"errors"
+	"healthmatefood-api/apperror"
+	"healthmatefood-api/constants"
+	diary_validator "healthmatefood-api/service/diary/validator"
+
+	"github.com/Pheethy/psql/helper"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofiber/fiber/v2"
+	"github.com/spf13/cast"
+)
+
+type Validation struct{}
+
+func (v Validation) ValidateCreateWaterLog() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		if params == nil {
+			return apperror.Validation(constants.ERROR_BODY_WAS_MISSING)
+		}
+
+		/* key params */
+		fields := apperror.Fields{}
+		key := "amount_ml"
+		if _, ok := params[key]; !ok {
+			fields.Missing(key)
+		}
+
+		validateWaterLogParams(params, &fields)
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateUpdateWaterLog() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params, _ := c.Locals("params").(map[string]interface{})
+		if params == nil {
+			return apperror.Validation(constants.ERROR_BODY_WAS_MISSING)
+		}
+		fields := apperror.Fields{}
+		validateWaterLogParams(params, &fields)
+		if err := fields.Err(); err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateParams(key string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params := c.Params(key)
+		if err := validation.Validate(params, validation.By(helper.ValidateTypeUUID)); err != nil {
+			return apperror.Field(key, err.Error())
+		}
+		return c.Next()
+	}
+}
+
+func (v Validation) ValidateQueryDate(key string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		date := c.Query(key)
+		if date == "" {
+			return c.Next()
+		}
+		if err := validation.Validate(date, validation.By(diary_validator.ValidateDate)); err != nil {
+			return apperror.Field(key, err.Error())
+		}
+		return c.Next()
+	}
+}
+
+func validateWaterLogParams(params map[string]interface{}, fields *apperror.Fields) {
+	key := "amount_ml"
+	if amount, ok := params[key]; ok {
+		fields.Check(key, validation.Validate(amount, validation.By(validatePositiveNumber)))
+	}
+	key = "drink_type"
+	if drinkType, ok := params[key]; ok {
+		fields.Check(key, validation.Validate(drinkType, validation.By(helper.ValidateTypeString)))
+	}
+	key = "drank_at"
+	if drankAt, ok := params[key]; ok {
+		fields.Check(key, validation.Validate(drankAt, validation.By(diary_validator.ValidateDate)))
+	}
+}
+
+func validatePositiveNumber(val interface{}) error {
+	number, err := cast.ToFloat64E(val)
+	if err != nil {
+		return errors.New("is not type number")
+	}
+	if number <= 0 {
+		return errors.New("must be greater than 0")
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package templates
+
+import (
+	"embed"
+	"path"
+	"sort"
+	"strings"
+)
+
+/* prompt ตั้งต้นของ agent ฝังไว้ใน binary ชื่อ prompt คือชื่อไฟล์ไม่รวม .txt ใช้เมื่อยังไม่มีเวอร์ชันที่ active ใน database */
+//go:embed *.txt
+var files embed.FS
+
+/* Default คืนเนื้อหา prompt ตั้งต้นตามชื่อ */
+func Default(name string) (string, bool) {
+	content, err := files.ReadFile(name + ".txt")
+	if err != nil {
+		return "", false
+	}
+	return string(content), true
+}
+
+/* Names ชื่อ prompt ทั้งหมดที่มีค่าตั้งต้น เรียงตามตัวอักษร */
+func Names() []string {
+	entries, _ := files.ReadDir(".")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"healthmatefood-api/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+/* SSESendFunc เขียน event หนึ่งรายการไปหา client แล้ว flush ทันที */
+type SSESendFunc func(event string, data interface{}) error
+
+/* StreamSSE ตอบแบบ Server-Sent Events ctx ที่ส่งให้ fn จะถูก cancel เมื่อเขียนหา client ไม่ได้ (client ปิดการเชื่อมต่อ) ถ้ามี AIUsageMeter ใน ctx จะเลื่อนการบันทึก token ไปจนกว่า stream จบ */
+func StreamSSE(c *fiber.Ctx, fn func(ctx context.Context, send SSESendFunc)) error {
+	parent := c.UserContext()
+	meter := models.AIUsageMeterFromContext(parent)
+	if meter != nil {
+		meter.Defer()
+	}
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ctx, cancel := context.WithCancel(parent)
+		defer cancel()
+		if meter != nil {
+			defer meter.Done()
+		}
+
+		send := func(event string, data interface{}) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			bt, err := json.Marshal(data)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, bt)
+			if err := w.Flush(); err != nil {
+				cancel()
+				return err
+			}
+			return nil
+		}
+		fn(ctx, send)
+	})
+	return nil
+}